@@ -0,0 +1,86 @@
+// Command bootstrap-admin creates the first admin of a fresh database.
+//
+// Usage:
+//
+//	go run ./cmd/bootstrap-admin -username root -email admin@example.com -age 30
+//
+// The password is read from stdin when -password isn't set, so it stays out
+// of the shell history. The command refuses to run once an admin exists,
+// later admins are promoted with PUT /users/{userID}/role.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	auth "github.com/dieg0code/player-profile/src/auth/impl"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	repo "github.com/dieg0code/player-profile/src/repository/impl"
+	services "github.com/dieg0code/player-profile/src/services/impl"
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	userName := flag.String("username", "", "User name of the admin")
+	email := flag.String("email", "", "Email of the admin")
+	password := flag.String("password", "", "Password of the admin, read from stdin when empty")
+	age := flag.Int("age", 18, "Age of the admin")
+	flag.Parse()
+
+	// The variables can also come from the environment
+	err := godotenv.Load()
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			log.Fatalf("Error reading the password: %v", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	db := config.DatabaseConnection()
+	authConfig := config.LoadAuthConfig()
+	validate := validator.New()
+
+	err = config.MigrateDatabase(db)
+	if err != nil {
+		log.Fatalf("Error migrating the database: %v", err)
+	}
+
+	userRepo := repo.NewUserRepositoryImpl(db)
+	roleRepo := repo.NewRoleRepositoryImpl(db)
+	revocationStore := auth.NewRevocationStoreImpl(repo.NewRevokedTokenRepositoryImpl(db), authConfig.RevocationSyncInterval)
+	permissionStore := auth.NewPermissionStoreImpl(roleRepo, authConfig.PermissionSyncInterval)
+
+	// The admin role must exist before anyone gets it
+	roleService := services.NewRoleServiceImpl(roleRepo, userRepo, permissionStore, revocationStore, validate)
+	err = roleService.SeedRoles()
+	if err != nil {
+		log.Fatalf("Error creating the roles: %v", err)
+	}
+
+	// No verification email is sent, the admin is created verified
+	userService := services.NewUserServiceImpl(userRepo, validate, services.NewPassWordHasher(config.LoadPasswordHashConfig()), nil)
+
+	admin, err := userService.BootstrapAdmin(request.CreateUserRequest{
+		UserName: *userName,
+		Email:    *email,
+		Password: *password,
+		Age:      *age,
+	})
+	if err != nil {
+		log.Fatalf("Error creating the admin: %v", err)
+	}
+
+	fmt.Printf("Admin %s created with ID %d\n", admin.Email, admin.ID)
+}
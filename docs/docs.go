@@ -22,7 +22,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all achievements with pagination, default page is 1 and default pageSize is 10",
+                "description": "Get all achievements with pagination, default page is 1 and default pageSize is 10. Filter with parameters like name~=first (case-insensitive prefix search) on id and name. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before",
                 "consumes": [
                     "application/json"
                 ],
@@ -45,6 +45,30 @@ const docTemplate = `{
                         "description": "Page size",
                         "name": "pageSize",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, descending with a minus, like -id or name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filters",
+                        "name": "total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -304,7 +328,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an achievement with players by ID",
+                "description": "Get an achievement with the players that unlocked it, paginated and sorted by unlock date, default page is 1, default pageSize is 10 and default order is desc",
                 "consumes": [
                     "application/json"
                 ],
@@ -322,13 +346,47 @@ const docTemplate = `{
                         "name": "achievementID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order by unlock date",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.AchievementWithPlayers"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -337,6 +395,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -346,39 +410,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Login to the application with the input payload",
-                "consumes": [
-                    "application/json"
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "All the API keys, revoked ones included, only admins can see them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
-                ],
-                "summary": "Login to the application",
-                "parameters": [
-                    {
-                        "description": "Login Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
-                        }
-                    }
+                    "API Keys"
                 ],
+                "summary": "List the API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.APIKeyResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.BaseResponse"
                         }
@@ -390,16 +460,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/players": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all players with pagination, by default page is 1 and pageSize is 10",
+                "description": "Create an API key for a game server or backend integration, only admins can do it. The key is only shown in this response",
                 "consumes": [
                     "application/json"
                 ],
@@ -407,26 +475,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
+                    "API Keys"
                 ],
-                "summary": "Get all players",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Create API Key Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAPIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -436,10 +501,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/response.PlayerProfileResponse"
-                                            }
+                                            "$ref": "#/definitions/response.CreatedAPIKeyResponse"
                                         }
                                     }
                                 }
@@ -452,6 +514,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -459,33 +527,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api-keys/{apiKeyID}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new player profile with the input payload",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "The key stops working right away, only admins can do it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
+                    "API Keys"
                 ],
-                "summary": "Create a new player profile",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
-                        "description": "Create Player Profile Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreatePlayerProfileRequest"
-                        }
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "apiKeyID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -501,6 +566,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -510,14 +587,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{playerID}": {
-            "get": {
+        "/auth/2fa/confirm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get player by ID",
+                "description": "Enable two factor authentication with a code of the authenticator app. The response has the recovery codes, they are only shown once. Wrong codes count as failed logins",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,16 +602,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
+                    "Auth"
                 ],
-                "summary": "Get player by ID",
+                "summary": "Confirm the two factor enrollment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
+                        "description": "Two Factor Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -549,7 +628,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PlayerProfileResponse"
+                                            "$ref": "#/definitions/response.RecoveryCodesResponse"
                                         }
                                     }
                                 }
@@ -562,6 +641,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -569,14 +660,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/2fa/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update player by ID",
+                "description": "Disable two factor authentication with the current password and a TOTP or recovery code, the recovery codes are deleted. Wrong passwords and codes count as failed logins",
                 "consumes": [
                     "application/json"
                 ],
@@ -584,24 +677,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
+                    "Auth"
                 ],
-                "summary": "Update player by ID",
+                "summary": "Disable two factor authentication",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Player Profile Request",
+                        "description": "Disable Two Factor Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdatePlayerProfileRequest"
+                            "$ref": "#/definitions/request.DisableTwoFactorRequest"
                         }
                     }
                 ],
@@ -618,6 +704,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -625,38 +723,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/2fa/enroll": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete player by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Create a TOTP secret for the authenticated user, it isn't enforced until it is confirmed with a code",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
-                ],
-                "summary": "Delete player by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Start the two factor enrollment",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.TwoFactorEnrollmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -665,6 +765,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -674,14 +780,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{playerID}/achievements": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get player with achievements by ID",
+        "/auth/2fa/verify": {
+            "post": {
+                "description": "Exchange the challenge token returned by the login and a TOTP or recovery code for the tokens. The challenge can only be tried once",
                 "consumes": [
                     "application/json"
                 ],
@@ -689,23 +790,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Player"
+                    "Auth"
                 ],
-                "summary": "Get player with achievements by ID",
+                "summary": "Finish a two factor login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "playerID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
+                        "description": "Two Factor Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -714,6 +829,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -723,14 +850,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all users, can be paginated, default page is 1 and default pageSize is 10",
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Send an email with a single use link to reset the password, the response is the same whether the email is registered or not",
                 "consumes": [
                     "application/json"
                 ],
@@ -738,21 +860,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Get all users",
+                "summary": "Request a password reset",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Forgot Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -774,37 +893,50 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     }
-                },
-                "x-order": 1
-            },
+                }
+            }
+        },
+        "/auth/impersonate/{userID}": {
             "post": {
-                "description": "Create a new user with the input payload",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Issue a short-lived token to see the API as the user, only admins can do it. The token can't be refreshed, destructive operations are refused and every request made with it is logged",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Create a new user",
+                "summary": "Impersonate a user",
                 "parameters": [
                     {
-                        "description": "Create User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ImpersonationResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -813,24 +945,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     }
-                },
-                "x-order": 0
+                }
             }
         },
-        "/users/{userID}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user by ID",
+                "description": "End the session of the access token used in the request, the refresh token is revoked too when it is sent in the body",
                 "consumes": [
                     "application/json"
                 ],
@@ -838,16 +987,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Logout from the application",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
+                        "description": "Logout Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.LogoutRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -863,6 +1013,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -870,47 +1026,93 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update user by ID",
-                "consumes": [
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "Names of the identity providers players can sign in with",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List the social login providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Redirect URL of the providers. Exchange the code for the identity and sign the user in, registering new players",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Update user by ID",
+                "summary": "Finish a social login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State of the login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -919,6 +1121,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -926,29 +1152,28 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/oidc/{provider}/link": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete user by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Start a login with the provider that links its identity to the authenticated user. The client sends the user to the returned URL",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Delete user by ID",
+                "summary": "Link an identity provider to the account",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     }
@@ -956,12 +1181,30 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.OIDCAuthorizationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.BaseResponse"
                         }
@@ -974,367 +1217,5224 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.CreateAchievementRequest": {
-            "description": "Create achievement request structure",
-            "type": "object",
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirect the browser to the provider, which sends the user back to the callback",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign in with an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token, the presented refresh token can't be used again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh the access token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using the token received by email, every session of the user is logged out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset the password",
+                "parameters": [
+                    {
+                        "description": "Reset Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify": {
+            "get": {
+                "description": "Mark the email of the user as verified using the token received by email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify an email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify/resend": {
+            "post": {
+                "description": "Send a new verification link, at most once per configured interval. The response is the same whether the email is registered or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Resend the verification email",
+                "parameters": [
+                    {
+                        "description": "Resend Verification Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{metric}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rank the players by points, level or experience, best first. Competitive ranking skips the ranks after a tie (1, 2, 2, 4), dense ranking doesn't (1, 2, 2, 3). The top of each board can be a few seconds old",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Get a leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "points, season_points, level or experience",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "competitive (default) or dense",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LeaderboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboards/{metric}/players/{playerID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rank of the player in a leaderboard with the players ranked right above and below",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Get the rank of a player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "points, season_points, level or experience",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "competitive (default) or dense",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Players above and below, 5 by default",
+                        "name": "neighbours",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LeaderboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login to the application with the input payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login to the application",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Save the result of a match and update the ratings of its players with the configured rating system (Elo or Glicko-2). Every player is rated against each opposing team, the better placement wins and equal placements draw",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Match"
+                ],
+                "summary": "Submit a match result",
+                "parameters": [
+                    {
+                        "description": "Submit Match Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SubmitMatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.MatchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Catalog of the permissions that can be granted to roles and API keys",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "List the permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PermissionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all players with pagination, by default page is 1 and pageSize is 10. Filter with parameters like level\u003e=10 or nickname~=noob (case-insensitive prefix search) on id, nickname, level, experience, points and user_id. Numeric fields take =, !=, \u003e, \u003e=, \u003c and \u003c=, nickname takes =, != and ~=. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before. Players that blocked the caller are left out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Get all players",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, descending with a minus, like -points,nickname",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filters",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PlayerProfileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new player profile for the authenticated user, player moderators can create it for another user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Create a new player profile",
+                "parameters": [
+                    {
+                        "description": "Create Player Profile Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreatePlayerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get player by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Get player by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PlayerProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update player by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Update player by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Player Profile Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePlayerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete player by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Delete player by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get player with the unlocked achievements, paginated and sorted by unlock date, default page is 1, default pageSize is 10 and default order is desc",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Get player with achievements by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order by unlock date",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PlayerWithAchievements"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/achievements/{achievementID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Award an achievement to a player, awarding an achievement the player already has keeps the original unlock date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Award an achievement to a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "achievementID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PlayerAchievementResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke an achievement previously awarded to a player",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Revoke an achievement from a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "achievementID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/blocks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Players blocked by the player sorted by id, paged like the friends of a player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Get the players blocked by a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the blocked players",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PlayerProfileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/blocks/{blockedID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Block a player, ending the friendship and the pending requests between both players. Blocked players can't send friend requests to the player nor find the player in the player list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Block a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Player to block",
+                        "name": "blockedID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Unblock a player blocked by the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Unblock a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Player to unblock",
+                        "name": "blockedID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/experience": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add experience to a player, the level goes up as many times as the XP curve allows. Reserved to game servers and admins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Grant experience to a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grant Experience Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GrantExperienceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ExperienceGrantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friend-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pending friend requests received or sent by the player sorted by id, paged like the friends of a player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Get the friend requests of a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "incoming (default) or outgoing",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the friend requests",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.FriendRequestResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a friend request from the player to another player. Players that blocked each other, friends and players with a pending request either way can't send one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Send a friend request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player to befriend",
+                        "name": "friendRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SendFriendRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.FriendRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friend-requests/{requestID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel a friend request sent by the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Cancel a friend request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Friend request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friend-requests/{requestID}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accept a friend request received by the player, both players become friends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Accept a friend request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Friend request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friend-requests/{requestID}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Decline a friend request received by the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Decline a friend request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Friend request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friends": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Friends of the player sorted by id, paged by page and pageSize or with the next_cursor and prev_cursor of the pagination as after and before",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Get the friends of a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the friends",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PlayerProfileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/friends/{friendID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a player from the friends of the player, for both of them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Unfriend a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Friend ID",
+                        "name": "friendID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rated matches of the player with the rating won or lost in each one, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Match"
+                ],
+                "summary": "Get the matches of a player",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PlayerMatchResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/mutual-friends/{otherPlayerID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Players that are friends of both players sorted by id, paged like the friends of a player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friend"
+                ],
+                "summary": "Get the mutual friends of two players",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Other player ID",
+                        "name": "otherPlayerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the mutual friends",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PlayerProfileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{playerID}/progress": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the experience and points of a player, the level follows from the experience on the XP curve. Reserved to game servers and admins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Player"
+                ],
+                "summary": "Update player progression by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Player Progress Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePlayerProgressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "All the roles with their permissions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "List the roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a role with a set of permissions, users can then be given the role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create a role",
+                "parameters": [
+                    {
+                        "description": "Create Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{roleID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a role and its permissions by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get a role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the description and permissions of a role, the change applies to every user with the role. Admin keeps every permission and the default role of new accounts can't get any",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update a role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a role no user has, built-in roles can't be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete a role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all the seasons, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Get all seasons",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SeasonResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "End the running season archiving the final season points standings, reset the season points of every player and start a new season. Lifetime points, level and experience are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Start a new season",
+                "parameters": [
+                    {
+                        "description": "Start Season Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.StartSeasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SeasonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the running season, its board is the season_points leaderboard",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Get the current season",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SeasonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{seasonID}/standings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Final season points standings of an ended season, best first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leaderboard"
+                ],
+                "summary": "Get the standings of a season",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SeasonStandingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all users, can be paginated, default page is 1 and default pageSize is 10. Filter with parameters like age\u003e=18 or role=admin on id, user_name, age and role. Numeric fields take =, !=, \u003e, \u003e=, \u003c and \u003c=, the others take = and !=. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, descending with a minus, like -age,user_name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items after the cursor, the next_cursor of a previous response",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Items before the cursor, the prev_cursor of a previous response",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filters",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                },
+                "x-order": 1
+            },
+            "post": {
+                "description": "Create a new user with the input payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "Create User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                },
+                "x-order": 0
+            }
+        },
+        "/users/{userID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete user by ID, the last admin can't be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/identities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Identity providers linked to the account, only the user or an admin can see them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List the linked identities of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.UserIdentityResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/identities/{provider}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the link between the account and the provider, only the user or an admin can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlink an identity provider",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke every access and refresh token issued to the user, only the user or an admin can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout all the sessions of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the password checking the current one, admins can set the password of another user without it. Every session of the user is logged out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change the password of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a user another role, their access tokens are revoked so the change applies on the next refresh. The last admin can't be demoted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Change the role of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devices signed in to the account, only the user or an admin can see them. The session making the request is marked as current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List the active sessions of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SessionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/sessions/{sessionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sign out a device remotely, its refresh token stops working and its access tokens are rejected right away. Only the user or an admin can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Sign out a session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear the failed login attempts of a user, lifting the lockout. Only admins can do it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Unlock a user account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.AssignRoleRequest": {
+            "description": "Assign role request structure",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "Name of the new role",
+                    "type": "string",
+                    "maxLength": 50,
+                    "x-order": "0",
+                    "example": "moderator"
+                }
+            }
+        },
+        "request.ChangePasswordRequest": {
+            "description": "Change password request structure, the current password can only be omitted by an admin changing another user's password",
+            "type": "object",
+            "required": [
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "Current password",
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "0",
+                    "example": "012345678"
+                },
+                "new_password": {
+                    "description": "New password",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8,
+                    "x-order": "1",
+                    "example": "876543210"
+                }
+            }
+        },
+        "request.CreateAPIKeyRequest": {
+            "description": "Create API key request structure",
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name to recognize the key",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "eu-west game server"
+                },
+                "scopes": {
+                    "description": "Permissions granted to the key",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        "achievements:award"
+                    ]
+                },
+                "expires_at": {
+                    "description": "Optional expiration date",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "request.CreateAchievementRequest": {
+            "description": "Create achievement request structure",
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Achievement description",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5,
+                    "example": "Kill the first enemy"
+                },
+                "name": {
+                    "description": "Achievement name",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5,
+                    "example": "First blood"
+                }
+            }
+        },
+        "request.CreatePlayerProfileRequest": {
+            "description": "Create player profile request structure, the progression starts from the configured defaults",
+            "type": "object",
+            "required": [
+                "avatar",
+                "nickname",
+                "user_id"
+            ],
+            "properties": {
+                "nickname": {
+                    "description": "Player nickname",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "NoobMaster69"
+                },
+                "avatar": {
+                    "description": "Player avatar URL",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "https://example.com/avatar.png"
+                },
+                "user_id": {
+                    "description": "Owner user ID, the authenticated user by default. Only player moderators can set another user",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1
+                }
+            }
+        },
+        "request.CreateRoleRequest": {
+            "description": "Create role request structure",
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Role name, lowercase letters, digits, - and _",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "moderator"
+                },
+                "description": {
+                    "description": "Role description",
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "1",
+                    "example": "Community moderator"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "2",
+                    "example": [
+                        "players:moderate"
+                    ]
+                }
+            }
+        },
+        "request.CreateUserRequest": {
+            "description": "Create user request structure",
+            "type": "object",
+            "required": [
+                "age",
+                "email",
+                "password",
+                "user_name"
+            ],
+            "properties": {
+                "user_name": {
+                    "description": "User name",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "Pepe"
+                },
+                "email": {
+                    "description": "User email",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "example@example.com"
+                },
+                "password": {
+                    "description": "User password",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8,
+                    "x-order": "2",
+                    "example": "012345678"
+                },
+                "age": {
+                    "description": "User age",
+                    "type": "integer",
+                    "minimum": 18,
+                    "x-order": "3",
+                    "example": 25
+                }
+            }
+        },
+        "request.DisableTwoFactorRequest": {
+            "description": "Disable two factor request structure, the password and a code are both required",
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "description": "Current password",
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "0",
+                    "example": "012345678"
+                },
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 32,
+                    "x-order": "1",
+                    "example": "123456"
+                }
+            }
+        },
+        "request.ForgotPasswordRequest": {
+            "description": "Forgot password request structure",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "User email",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "example@example.com"
+                }
+            }
+        },
+        "request.GrantExperienceRequest": {
+            "description": "Grant experience request structure, the level follows the configured XP curve",
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Experience earned",
+                    "type": "integer",
+                    "maximum": 2147483647,
+                    "x-order": "0",
+                    "example": 250
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "description": "Login request structure",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "User email",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "example@example.com"
+                },
+                "password": {
+                    "description": "User password",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "012345678"
+                },
+                "device_name": {
+                    "description": "Name shown in the session list, optional",
+                    "type": "string",
+                    "maxLength": 100,
+                    "x-order": "2",
+                    "example": "Living room PS5"
+                }
+            }
+        },
+        "request.LogoutRequest": {
+            "description": "Logout request structure",
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "Optional refresh token to revoke along with the access token",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "3q2-7wEAAAB..."
+                }
+            }
+        },
+        "request.MatchParticipantRequest": {
+            "description": "Match participant structure, placement 1 won and players of the same team share the placement",
+            "type": "object",
+            "required": [
+                "placement",
+                "player_id",
+                "team"
+            ],
+            "properties": {
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "team": {
+                    "description": "Team number, one per player in free-for-all matches",
+                    "type": "integer",
+                    "minimum": 1,
+                    "x-order": "1",
+                    "example": 1
+                },
+                "placement": {
+                    "description": "Final placement of the team, ties share it",
+                    "type": "integer",
+                    "minimum": 1,
+                    "x-order": "2",
+                    "example": 1
+                }
+            }
+        },
+        "request.RefreshTokenRequest": {
+            "description": "Refresh token request structure",
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token issued at login or by a previous refresh",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "3q2-7wEAAAB..."
+                }
+            }
+        },
+        "request.ResendVerificationRequest": {
+            "description": "Resend verification email request structure",
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "User email",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "example@example.com"
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "description": "Reset password request structure",
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "Token received by email",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "3q2-7wEAAAB..."
+                },
+                "password": {
+                    "description": "New password",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8,
+                    "x-order": "1",
+                    "example": "012345678"
+                }
+            }
+        },
+        "request.SendFriendRequestRequest": {
+            "description": "Send friend request structure",
+            "type": "object",
+            "required": [
+                "player_id"
+            ],
+            "properties": {
+                "player_id": {
+                    "description": "Player ID of the receiver",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 2
+                }
+            }
+        },
+        "request.StartSeasonRequest": {
+            "description": "Start season request structure, the running season ends and the season points of every player are reset",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Season name, must be unique",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "Season 2"
+                }
+            }
+        },
+        "request.SubmitMatchRequest": {
+            "description": "Submit match request structure, the ratings of the players are updated with the configured rating system",
+            "type": "object",
+            "required": [
+                "participants"
+            ],
+            "properties": {
+                "played_at": {
+                    "description": "When the match ended, now by default",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "2024-08-01T12:00:00Z"
+                },
+                "participants": {
+                    "description": "Players of the match",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/request.MatchParticipantRequest"
+                    },
+                    "x-order": "1"
+                }
+            }
+        },
+        "request.TwoFactorCodeRequest": {
+            "description": "Two factor code request structure",
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 32,
+                    "x-order": "0",
+                    "example": "123456"
+                }
+            }
+        },
+        "request.TwoFactorLoginRequest": {
+            "description": "Two factor login request structure",
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "description": "Challenge token returned by the login",
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "3q2-7wEAAAB..."
+                },
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 32,
+                    "x-order": "1",
+                    "example": "123456"
+                },
+                "device_name": {
+                    "description": "Name shown in the session list, optional",
+                    "type": "string",
+                    "maxLength": 100,
+                    "x-order": "2",
+                    "example": "Living room PS5"
+                }
+            }
+        },
+        "request.UpdateAchievementRequest": {
+            "description": "Update achievement request structure",
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Achievement name",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5,
+                    "x-order": "0",
+                    "example": "First blood updated"
+                },
+                "description": {
+                    "description": "Achievement description",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5,
+                    "x-order": "1",
+                    "example": "Kill the first enemy"
+                }
+            }
+        },
+        "request.UpdatePlayerProfileRequest": {
+            "description": "Update player profile cosmetic data",
+            "type": "object",
+            "required": [
+                "avatar",
+                "nickname"
+            ],
+            "properties": {
+                "nickname": {
+                    "description": "Player nickname",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "NoobMaster69"
+                },
+                "avatar": {
+                    "description": "Player avatar URL",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "https://example.com/avatar-new.png"
+                }
+            }
+        },
+        "request.UpdatePlayerProgressRequest": {
+            "description": "Update player profile progression, only game servers and admins can send it. The level follows from the experience.",
+            "type": "object",
+            "properties": {
+                "experience": {
+                    "description": "Player experience",
+                    "type": "integer",
+                    "minimum": 0,
+                    "x-order": "0",
+                    "example": 200
+                },
+                "points": {
+                    "description": "Player points",
+                    "type": "integer",
+                    "minimum": 0,
+                    "x-order": "1",
+                    "example": 200
+                }
+            }
+        },
+        "request.UpdateRoleRequest": {
+            "description": "Update role request structure",
+            "type": "object",
+            "required": [
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Role description",
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "0",
+                    "example": "Community moderator"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        "players:moderate"
+                    ]
+                }
+            }
+        },
+        "request.UpdateUserRequest": {
+            "description": "Update user request structure",
+            "type": "object",
+            "required": [
+                "age",
+                "email",
+                "user_name"
+            ],
+            "properties": {
+                "user_name": {
+                    "description": "User name",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0",
+                    "example": "Pepe new"
+                },
+                "email": {
+                    "description": "User email",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "new@example.com"
+                },
+                "age": {
+                    "description": "User age",
+                    "type": "integer",
+                    "minimum": 18,
+                    "x-order": "2",
+                    "example": 25
+                }
+            }
+        },
+        "response.APIKeyResponse": {
+            "description": "API key response structure",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "API key ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name of the key",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "eu-west game server"
+                },
+                "prefix": {
+                    "description": "Public part of the key",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "pp_1a2b3c4d5e6f"
+                },
+                "scopes": {
+                    "description": "Permissions granted to the key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "3",
+                    "example": [
+                        "achievements:award"
+                    ]
+                },
+                "created_at": {
+                    "description": "Creation date",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2024-08-01T12:00:00Z"
+                },
+                "expires_at": {
+                    "description": "Expiration date, null if it doesn't expire",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "last_used_at": {
+                    "description": "Last time the key was used",
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2024-08-02T12:00:00Z"
+                },
+                "revoked_at": {
+                    "description": "Revocation date, null while the key is active",
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2024-08-03T12:00:00Z"
+                }
+            }
+        },
+        "response.AchievementResponse": {
+            "description": "Achievement response structure",
+            "type": "object",
             "required": [
                 "description",
+                "id",
                 "name"
             ],
             "properties": {
+                "id": {
+                    "description": "Achievement ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Achievement name",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "x-order": "1"
+                },
                 "description": {
                     "description": "Achievement description",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5,
-                    "example": "Kill the first enemy"
+                    "maxLength": 255,
+                    "minLength": 5,
+                    "x-order": "2",
+                    "example": "Kill the first enemy"
+                }
+            }
+        },
+        "response.AchievementWithPlayers": {
+            "description": "Achievement with players response structure",
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "description": "Achievement ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "achievement_name": {
+                    "description": "Achievement name",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "First blood"
+                },
+                "players": {
+                    "description": "List of players who have the achievement",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PlayerSumary"
+                    },
+                    "x-order": "2"
+                }
+            }
+        },
+        "response.AchievementsSumary": {
+            "description": "Achievements summary response structure",
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "description": "Achievement ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "achievement_name": {
+                    "description": "Achievement name",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "First blood"
+                },
+                "unlocked_at": {
+                    "description": "Date the player unlocked the achievement",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2024-08-01T12:00:00Z"
+                }
+            }
+        },
+        "response.BaseResponse": {
+            "description": "Base response structure",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code of the response",
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "status": {
+                    "description": "Status of the response",
+                    "type": "string",
+                    "x-order": "1"
                 },
-                "name": {
-                    "description": "Achievement name",
+                "message": {
+                    "description": "Message of the response",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5,
-                    "example": "First blood"
+                    "x-order": "2"
+                },
+                "data": {
+                    "description": "Data payload of the response",
+                    "x-order": "3"
+                },
+                "pagination": {
+                    "description": "Pagination of list responses",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.Pagination"
+                        }
+                    ],
+                    "x-order": "4"
                 }
             }
         },
-        "request.CreatePlayerProfileRequest": {
-            "description": "Create player profile request structure",
+        "response.CreatedAPIKeyResponse": {
+            "description": "Created API key response structure, the key is only shown once",
             "type": "object",
-            "required": [
-                "avatar",
-                "experience",
-                "level",
-                "nickname",
-                "point",
-                "user_id"
-            ],
             "properties": {
-                "nickname": {
-                    "description": "Player nickname",
+                "key": {
+                    "description": "Value of the X-API-Key header",
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
                     "x-order": "0",
-                    "example": "NoobMaster69"
+                    "example": "pp_1a2b3c4d5e6f.secret"
                 },
-                "avatar": {
-                    "description": "Player avatar URL",
-                    "type": "string",
+                "api_key": {
+                    "description": "Stored key",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.APIKeyResponse"
+                        }
+                    ],
+                    "x-order": "1"
+                }
+            }
+        },
+        "response.ExperienceGrantResponse": {
+            "description": "Experience grant response structure",
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "experience_gained": {
+                    "description": "Experience added, less than the amount at the max level",
+                    "type": "integer",
                     "x-order": "1",
-                    "example": "https://example.com/avatar.png"
+                    "example": 250
                 },
-                "level": {
-                    "description": "Player level",
+                "experience": {
+                    "description": "Total experience of the player",
                     "type": "integer",
                     "x-order": "2",
-                    "example": 1
+                    "example": 350
                 },
-                "experience": {
-                    "description": "Player experience",
+                "level": {
+                    "description": "Level after the grant",
                     "type": "integer",
                     "x-order": "3",
-                    "example": 100
+                    "example": 4
                 },
-                "point": {
-                    "description": "Player points",
+                "levels_gained": {
+                    "description": "Levels reached with the grant",
                     "type": "integer",
                     "x-order": "4",
+                    "example": 2
+                },
+                "experience_to_next_level": {
+                    "description": "Experience missing for the next level, 0 at the max level",
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 50
+                },
+                "max_level": {
+                    "description": "Highest level of the curve",
+                    "type": "integer",
+                    "x-order": "6",
                     "example": 100
+                }
+            }
+        },
+        "response.FriendRequestResponse": {
+            "description": "Friend request response structure",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Friend request ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
                 },
-                "user_id": {
-                    "description": "User ID (foreign key) in the database",
+                "sender_id": {
+                    "description": "Player ID of the sender",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "sender_nickname": {
+                    "description": "Nickname of the sender",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "elPepe123"
+                },
+                "receiver_id": {
+                    "description": "Player ID of the receiver",
                     "type": "integer",
+                    "x-order": "3",
+                    "example": 2
+                },
+                "receiver_nickname": {
+                    "description": "Nickname of the receiver",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "noobMaster"
+                },
+                "created_at": {
+                    "description": "When the request was sent",
+                    "type": "string",
                     "x-order": "5",
+                    "example": "2024-08-01T12:00:00Z"
+                }
+            }
+        },
+        "response.ImpersonationResponse": {
+            "description": "Impersonation response structure, the token can't be refreshed",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "JWT token of the impersonated user",
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "expires_in": {
+                    "description": "Token lifetime in seconds",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 600
+                },
+                "user_id": {
+                    "description": "Impersonated user",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 42
+                },
+                "impersonator_id": {
+                    "description": "Admin acting as the user",
+                    "type": "integer",
+                    "x-order": "3",
                     "example": 1
                 }
             }
         },
-        "request.CreateUserRequest": {
-            "description": "Create user request structure",
+        "response.LeaderboardEntryResponse": {
+            "description": "Leaderboard entry response structure",
             "type": "object",
-            "required": [
-                "age",
-                "email",
-                "password",
-                "user_name"
-            ],
             "properties": {
-                "user_name": {
-                    "description": "User name",
+                "rank": {
+                    "description": "Rank of the player, tied players share it",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "nickname": {
+                    "description": "Player nickname",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "elPepe123"
+                },
+                "value": {
+                    "description": "Value of the metric",
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 1500
+                }
+            }
+        },
+        "response.LeaderboardResponse": {
+            "description": "Leaderboard response structure",
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "description": "points, season_points, level or experience",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3,
                     "x-order": "0",
-                    "example": "Pepe"
+                    "example": "points"
                 },
-                "email": {
-                    "description": "User email",
+                "ranking": {
+                    "description": "competitive or dense",
                     "type": "string",
                     "x-order": "1",
-                    "example": "example@example.com"
+                    "example": "competitive"
                 },
-                "password": {
-                    "description": "User password",
+                "entries": {
+                    "description": "Ranked players, best first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LeaderboardEntryResponse"
+                    },
+                    "x-order": "2"
+                }
+            }
+        },
+        "response.LoginResponse": {
+            "description": "Login response structure. When two factor authentication is enabled the login only returns a challenge token to exchange with a code for the tokens",
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "description": "Token to send with the code to /auth/2fa/verify",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "Access token lifetime in seconds",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "Opaque refresh token, single use",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "JWT token",
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "description": "A TOTP code is needed to finish the login",
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.MatchParticipantResponse": {
+            "description": "Match participant response structure",
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "team": {
+                    "description": "Team number",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "placement": {
+                    "description": "Final placement of the team",
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1
+                },
+                "rating_before": {
+                    "description": "Rating before the match",
+                    "type": "number",
+                    "x-order": "3",
+                    "example": 1500
+                },
+                "rating_after": {
+                    "description": "Rating after the match",
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 1516
+                },
+                "rating_delta": {
+                    "description": "Rating won or lost",
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 16
+                },
+                "deviation": {
+                    "description": "Rating deviation after the match, Glicko-2 only",
+                    "type": "number",
+                    "x-order": "6",
+                    "example": 350
+                }
+            }
+        },
+        "response.MatchResponse": {
+            "description": "Match response structure",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Match ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "rating_system": {
+                    "description": "elo or glicko2",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "elo"
+                },
+                "played_at": {
+                    "description": "When the match ended",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 8,
                     "x-order": "2",
-                    "example": "012345678"
+                    "example": "2024-08-01T12:00:00Z"
                 },
-                "age": {
-                    "description": "User age",
+                "participants": {
+                    "description": "Players with their new ratings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MatchParticipantResponse"
+                    },
+                    "x-order": "3"
+                }
+            }
+        },
+        "response.OIDCAuthorizationResponse": {
+            "description": "Social login authorization response structure",
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "description": "Provider page to send the user to",
+                    "type": "string"
+                }
+            }
+        },
+        "response.Pagination": {
+            "description": "Pagination of a list response",
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Cursor to pass as after for the next page, for lists sorted by id",
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "prev_cursor": {
+                    "description": "Cursor to pass as before for the previous page, for lists sorted by id",
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "has_more": {
+                    "description": "Whether there are more items in the direction of the request",
+                    "type": "boolean",
+                    "x-order": "2"
+                },
+                "total": {
+                    "description": "Number of items matching the filters, when asked for",
                     "type": "integer",
-                    "minimum": 18,
-                    "x-order": "3",
-                    "example": 25
+                    "x-order": "3"
                 }
             }
         },
-        "request.LoginRequest": {
-            "description": "Login request structure",
+        "response.PermissionResponse": {
+            "description": "Permission response structure",
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "User email",
+                "name": {
+                    "description": "Permission name",
                     "type": "string",
                     "x-order": "0",
-                    "example": "example@example.com"
+                    "example": "players:moderate"
                 },
-                "password": {
-                    "description": "User password",
+                "description": {
+                    "description": "What the permission allows",
                     "type": "string",
                     "x-order": "1",
-                    "example": "012345678"
+                    "example": "Update and delete any player profile"
+                }
+            }
+        },
+        "response.PlayerAchievementResponse": {
+            "description": "Player achievement response structure",
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "achievement_id": {
+                    "description": "Achievement ID",
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "unlocked_at": {
+                    "description": "Date the achievement was unlocked",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2024-08-01T12:00:00Z"
+                },
+                "newly_unlocked": {
+                    "description": "False when the player already had the achievement",
+                    "type": "boolean",
+                    "x-order": "3",
+                    "example": true
                 }
             }
         },
-        "request.UpdateAchievementRequest": {
-            "description": "Update achievement request structure",
+        "response.PlayerMatchResponse": {
+            "description": "Player match response structure",
             "type": "object",
-            "required": [
-                "description",
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "description": "Achievement name",
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5,
+                "match_id": {
+                    "description": "Match ID",
+                    "type": "integer",
                     "x-order": "0",
-                    "example": "First blood updated"
+                    "example": 1
                 },
-                "description": {
-                    "description": "Achievement description",
+                "rating_system": {
+                    "description": "elo or glicko2",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5,
                     "x-order": "1",
-                    "example": "Kill the first enemy"
+                    "example": "elo"
+                },
+                "played_at": {
+                    "description": "When the match ended",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2024-08-01T12:00:00Z"
+                },
+                "team": {
+                    "description": "Team of the player",
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 1
+                },
+                "placement": {
+                    "description": "Final placement of the team",
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1
+                },
+                "rating_before": {
+                    "description": "Rating before the match",
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 1500
+                },
+                "rating_after": {
+                    "description": "Rating after the match",
+                    "type": "number",
+                    "x-order": "6",
+                    "example": 1516
+                },
+                "rating_delta": {
+                    "description": "Rating won or lost",
+                    "type": "number",
+                    "x-order": "7",
+                    "example": 16
+                },
+                "deviation": {
+                    "description": "Rating deviation after the match, Glicko-2 only",
+                    "type": "number",
+                    "x-order": "8",
+                    "example": 350
                 }
             }
         },
-        "request.UpdatePlayerProfileRequest": {
-            "description": "Update player profile data",
+        "response.PlayerProfileResponse": {
+            "description": "Player profile response structure",
             "type": "object",
             "required": [
                 "avatar",
                 "experience",
+                "id",
                 "level",
                 "nickname",
-                "points"
+                "points",
+                "user_id"
             ],
             "properties": {
+                "id": {
+                    "description": "Player ID (primary key) in the database",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
                 "nickname": {
                     "description": "Player nickname",
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "x-order": "0",
-                    "example": "NoobMaster69"
+                    "x-order": "1",
+                    "example": "elPepe123"
                 },
                 "avatar": {
                     "description": "Player avatar URL",
                     "type": "string",
-                    "x-order": "1",
-                    "example": "https://example.com/avatar-new.png"
+                    "x-order": "2",
+                    "example": "https://example.com/avatar.png"
                 },
                 "level": {
                     "description": "Player level",
                     "type": "integer",
-                    "x-order": "2",
-                    "example": 2
+                    "x-order": "3",
+                    "example": 1
                 },
                 "experience": {
                     "description": "Player experience",
                     "type": "integer",
-                    "x-order": "3",
-                    "example": 200
+                    "x-order": "4",
+                    "example": 100
                 },
                 "points": {
                     "description": "Player points",
                     "type": "integer",
-                    "x-order": "4",
-                    "example": 200
+                    "x-order": "5",
+                    "example": 100
+                },
+                "user_id": {
+                    "description": "User ID (foreign key) in the database",
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 1
                 }
             }
         },
-        "request.UpdateUserRequest": {
-            "description": "Update user request structure",
+        "response.PlayerSumary": {
+            "description": "Player summary response structure",
             "type": "object",
-            "required": [
-                "age",
-                "email",
-                "user_name"
-            ],
             "properties": {
-                "user_name": {
-                    "description": "User name",
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
+                "plyer_id": {
+                    "description": "Player ID (primary key) in the database",
+                    "type": "integer",
                     "x-order": "0",
-                    "example": "Pepe new"
+                    "example": 1
                 },
-                "email": {
-                    "description": "User email",
+                "player_nickname": {
+                    "description": "Player nickname",
                     "type": "string",
                     "x-order": "1",
-                    "example": "new@example.com"
+                    "example": "elPepe123"
                 },
-                "age": {
-                    "description": "User age",
-                    "type": "integer",
-                    "minimum": 18,
+                "unlocked_at": {
+                    "description": "Date the player unlocked the achievement",
+                    "type": "string",
                     "x-order": "2",
-                    "example": 25
+                    "example": "2024-08-01T12:00:00Z"
                 }
             }
         },
-        "response.AchievementResponse": {
-            "description": "Achievement response structure",
+        "response.PlayerWithAchievements": {
+            "description": "Player with achievements response structure",
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "description": "Player ID (primary key) in the database",
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "player_nickname": {
+                    "description": "Player nickname",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "elPepe123"
+                },
+                "achievements": {
+                    "description": "List of player achievements",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AchievementsSumary"
+                    },
+                    "x-order": "2"
+                }
+            }
+        },
+        "response.RecoveryCodesResponse": {
+            "description": "Recovery codes response structure, the codes are only shown once",
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "Single use codes accepted instead of a TOTP code",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.RoleResponse": {
+            "description": "Role response structure",
             "type": "object",
-            "required": [
-                "description",
-                "id",
-                "name"
-            ],
             "properties": {
                 "id": {
-                    "description": "Achievement ID",
+                    "description": "Role ID",
                     "type": "integer",
                     "x-order": "0",
                     "example": 1
                 },
                 "name": {
-                    "description": "Achievement name",
+                    "description": "Role name",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3,
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "moderator"
                 },
                 "description": {
-                    "description": "Achievement description",
+                    "description": "Role description",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5,
                     "x-order": "2",
-                    "example": "Kill the first enemy"
+                    "example": "Community moderator"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "3",
+                    "example": [
+                        "players:moderate"
+                    ]
                 }
             }
         },
-        "response.BaseResponse": {
-            "description": "Base response structure",
+        "response.SeasonResponse": {
+            "description": "Season response structure",
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "HTTP status code of the response",
+                "id": {
+                    "description": "Season ID",
                     "type": "integer",
-                    "x-order": "0"
+                    "x-order": "0",
+                    "example": 2
                 },
-                "status": {
-                    "description": "Status of the response",
+                "name": {
+                    "description": "Season name",
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "Season 2"
                 },
-                "message": {
-                    "description": "Message of the response",
+                "started_at": {
+                    "description": "Start date",
                     "type": "string",
-                    "x-order": "2"
+                    "x-order": "2",
+                    "example": "2024-08-01T12:00:00Z"
                 },
-                "data": {
-                    "description": "Data payload of the response",
-                    "x-order": "3"
+                "ended_at": {
+                    "description": "End date, missing while running",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2024-11-01T12:00:00Z"
                 }
             }
         },
-        "response.PlayerProfileResponse": {
-            "description": "Player profile response structure",
+        "response.SeasonStandingResponse": {
+            "description": "Season standing response structure",
             "type": "object",
-            "required": [
-                "avatar",
-                "experience",
-                "id",
-                "level",
-                "nickname",
-                "points",
-                "user_id"
-            ],
             "properties": {
-                "id": {
-                    "description": "Player ID (primary key) in the database",
+                "rank": {
+                    "description": "Competitive rank at the end of the season",
                     "type": "integer",
                     "x-order": "0",
                     "example": 1
                 },
-                "nickname": {
-                    "description": "Player nickname",
-                    "type": "string",
+                "player_id": {
+                    "description": "Player ID",
+                    "type": "integer",
                     "x-order": "1",
-                    "example": "elPepe123"
+                    "example": 1
                 },
-                "avatar": {
-                    "description": "Player avatar URL",
+                "nickname": {
+                    "description": "Nickname at the end of the season",
                     "type": "string",
                     "x-order": "2",
-                    "example": "https://example.com/avatar.png"
+                    "example": "elPepe123"
                 },
-                "level": {
-                    "description": "Player level",
+                "points": {
+                    "description": "Season points",
                     "type": "integer",
                     "x-order": "3",
-                    "example": 1
+                    "example": 1500
                 },
-                "experience": {
-                    "description": "Player experience",
+                "level": {
+                    "description": "Level at the end of the season",
                     "type": "integer",
                     "x-order": "4",
-                    "example": 100
+                    "example": 12
                 },
-                "points": {
-                    "description": "Player points",
+                "experience": {
+                    "description": "Experience at the end of the season",
                     "type": "integer",
                     "x-order": "5",
-                    "example": 100
-                },
-                "user_id": {
-                    "description": "User ID (foreign key) in the database",
+                    "example": 4500
+                }
+            }
+        },
+        "response.SessionResponse": {
+            "description": "Session response structure",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Session ID",
                     "type": "integer",
-                    "x-order": "6",
+                    "x-order": "0",
                     "example": 1
+                },
+                "device_name": {
+                    "description": "Name given on login, empty if none",
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Living room PS5"
+                },
+                "user_agent": {
+                    "description": "User agent of the login",
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Mozilla/5.0 (PlayStation; PlayStation 5/2.26)"
+                },
+                "ip": {
+                    "description": "Last IP address seen",
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "203.0.113.7"
+                },
+                "created_at": {
+                    "description": "Login date",
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2024-08-01T12:00:00Z"
+                },
+                "last_seen_at": {
+                    "description": "Last login or token refresh",
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2024-08-02T12:00:00Z"
+                },
+                "current": {
+                    "description": "Whether the session made the request",
+                    "type": "boolean",
+                    "x-order": "6",
+                    "example": true
+                }
+            }
+        },
+        "response.TwoFactorEnrollmentResponse": {
+            "description": "Two factor enrollment response structure",
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "Base32 secret, for manual entry in the authenticator app",
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "otpauth:// URI to show as a QR code",
+                    "type": "string"
+                }
+            }
+        },
+        "response.UserIdentityResponse": {
+            "description": "Linked identity response structure",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email reported by the provider",
+                    "type": "string"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Name of the identity provider",
+                    "type": "string"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "type": "apiKey",
             "name": "Authorization",
@@ -1353,6 +6453,12 @@ const docTemplate = `{
         },
         {
             "name": "Achievement"
+        },
+        {
+            "name": "API Keys"
+        },
+        {
+            "name": "Roles"
         }
     ]
 }`
@@ -3,12 +3,13 @@ package main
 import (
 	"log"
 	"net/http"
+	"time"
 
 	_ "github.com/dieg0code/player-profile/docs"
 	auth "github.com/dieg0code/player-profile/src/auth/impl"
 	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/controllers"
-	"github.com/dieg0code/player-profile/src/models"
+	mailer "github.com/dieg0code/player-profile/src/mailer/impl"
 	repo "github.com/dieg0code/player-profile/src/repository/impl"
 	"github.com/dieg0code/player-profile/src/routers"
 	services "github.com/dieg0code/player-profile/src/services/impl"
@@ -29,10 +30,16 @@ import (
 //	@tag.name	User
 //	@tag.name	Player
 //	@tag.name	Achievement
+//	@tag.name	API Keys
+//	@tag.name	Roles
 
 // @securityDefinitions.apikey	BearerAuth
 // @in							header
 // @name						Authorization
+
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
 func main() {
 	// Load .env file
 	err := godotenv.Load()
@@ -41,55 +48,193 @@ func main() {
 	}
 
 	db := config.DatabaseConnection()
+	authConfig := config.LoadAuthConfig()
 	validate := validator.New()
 
-	passWordHasher := services.NewPassWordHasher()
+	passWordHasher := services.NewPassWordHasher(config.LoadPasswordHashConfig())
 
-	err = db.AutoMigrate(&models.User{}, &models.PlayerProfile{}, &models.Achievement{})
+	err = config.MigrateDatabase(db)
 	if err != nil {
 		panic(err)
 	}
+
 	// User repo
 	userRepo := repo.NewUserRepositoryImpl(db)
 	//Player profile repo
 	playerProfileRepo := repo.NewPlayerProfileRepositoryImpl(db)
 	//Achievement repo
 	achievementRepo := repo.NewAchievementRepositoryImpl(db)
+	// Refresh token repo
+	refreshTokenRepo := repo.NewRefreshTokenRepositoryImpl(db)
+	// Revoked token repo
+	revokedTokenRepo := repo.NewRevokedTokenRepositoryImpl(db)
+	// User token repo
+	userTokenRepo := repo.NewUserTokenRepositoryImpl(db)
+	// Two factor repo
+	twoFactorRepo := repo.NewTwoFactorRepositoryImpl(db)
+	// Login attempt repo
+	loginAttemptRepo := repo.NewLoginAttemptRepositoryImpl(db)
+	// User identity repo
+	userIdentityRepo := repo.NewUserIdentityRepositoryImpl(db)
+	// OIDC state repo
+	oidcStateRepo := repo.NewOIDCStateRepositoryImpl(db)
+	// API key repo
+	apiKeyRepo := repo.NewAPIKeyRepositoryImpl(db)
+	// Role repo
+	roleRepo := repo.NewRoleRepositoryImpl(db)
+	// Session repo
+	sessionRepo := repo.NewSessionRepositoryImpl(db)
+	// Leaderboard repo
+	leaderboardRepo := repo.NewLeaderboardRepositoryImpl(db)
+	// Season repo
+	seasonRepo := repo.NewSeasonRepositoryImpl(db)
+	// Match repo
+	matchRepo := repo.NewMatchRepositoryImpl(db)
+	// Friendship repo
+	friendshipRepo := repo.NewFriendshipRepositoryImpl(db)
 
 	// auth
-	auth := auth.NewJWTAth()
+	keySet, err := auth.LoadKeySet(config.LoadJWTConfig())
+	if err != nil {
+		panic(err)
+	}
+	authUtils := auth.NewJWTAth(authConfig.AccessTokenTTL, keySet)
+	revocationStore := auth.NewRevocationStoreImpl(revokedTokenRepo, authConfig.RevocationSyncInterval)
+	sessionStore := auth.NewSessionStoreImpl(sessionRepo, authConfig.RevocationSyncInterval, authConfig.AccessTokenTTL)
+	permissionStore := auth.NewPermissionStoreImpl(roleRepo, authConfig.PermissionSyncInterval)
+	loginLimiter := auth.NewLoginLimiterImpl(loginAttemptRepo, config.LoadLoginLimitConfig())
+	oidcConfig := config.LoadOIDCConfig()
+	oidcClient := auth.NewOIDCClientImpl(oidcConfig, &http.Client{Timeout: 10 * time.Second})
+
+	// mailer
+	mailSender, err := mailer.NewMailer(config.LoadMailerConfig())
+	if err != nil {
+		panic(err)
+	}
 
 	// SERVICES
 
+	// Two factor service
+	twoFactorService := services.NewTwoFactorServiceImpl(userRepo, twoFactorRepo, passWordHasher, loginLimiter, validate, authConfig)
+
 	// Auth service
-	authService := services.NewAuthService(userRepo, passWordHasher, validate, auth)
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, userTokenRepo, sessionRepo, sessionStore, passWordHasher, validate, authUtils, revocationStore, loginLimiter, twoFactorService, authConfig)
+
+	// OIDC service
+	oidcService := services.NewOIDCServiceImpl(userRepo, userIdentityRepo, oidcStateRepo, oidcClient, authService, passWordHasher, oidcConfig)
+
+	// API key service
+	apiKeyService := services.NewAPIKeyServiceImpl(apiKeyRepo, validate)
+
+	// Session service
+	sessionService := services.NewSessionServiceImpl(sessionRepo, refreshTokenRepo, sessionStore, authConfig)
+
+	// Impersonation service
+	impersonationService := services.NewImpersonationServiceImpl(userRepo, authUtils, permissionStore, authConfig)
+
+	// Role service, the built-in roles must exist before serving requests
+	roleService := services.NewRoleServiceImpl(roleRepo, userRepo, permissionStore, revocationStore, validate)
+	err = roleService.SeedRoles()
+	if err != nil {
+		panic(err)
+	}
+
+	// Every signup gets the default role, refuse to start with a role granting permissions
+	err = roleService.CheckDefaultRole(config.DefaultRole())
+	if err != nil {
+		panic(err)
+	}
+
+	// Password service
+	passwordService := services.NewPasswordServiceImpl(userRepo, userTokenRepo, refreshTokenRepo, sessionRepo, revocationStore, passWordHasher, mailSender, validate, authConfig)
+
+	// Email verification service
+	emailVerificationService := services.NewEmailVerificationServiceImpl(userRepo, userTokenRepo, mailSender, validate, authConfig)
 
 	// User service
-	userService := services.NewUserServiceImpl(userRepo, validate, passWordHasher)
+	userService := services.NewUserServiceImpl(userRepo, validate, passWordHasher, emailVerificationService)
 
 	// Player profile service
-	playerProfileService := services.NewPlayerProfileServiceImpl(playerProfileRepo, validate)
+	xpCurve := services.NewXPCurve(config.LoadXPCurveConfig())
+	playerProfileConfig := config.LoadPlayerProfileConfig()
+	playerProfileService := services.NewPlayerProfileServiceImpl(playerProfileRepo, xpCurve, validate, playerProfileConfig)
+
+	// Leveling service
+	levelingService := services.NewLevelingServiceImpl(playerProfileRepo, xpCurve, validate)
+
+	// Leaderboard service
+	leaderboardConfig := config.LoadLeaderboardConfig()
+	leaderboardCache := services.NewLeaderboardCacheImpl(leaderboardRepo, leaderboardConfig)
+	leaderboardService := services.NewLeaderboardServiceImpl(leaderboardRepo, playerProfileRepo, leaderboardCache, leaderboardConfig)
+
+	// Season service
+	seasonService := services.NewSeasonServiceImpl(seasonRepo, leaderboardCache, validate)
+
+	// Match service
+	matchService := services.NewMatchServiceImpl(matchRepo, playerProfileRepo, services.NewRatingEngine(config.LoadRatingConfig()), validate)
 
 	// Achievement service
 	achievementService := services.NewAchievementServiceImpl(achievementRepo, validate)
 
+	// Friendship service
+	friendshipService := services.NewFriendshipServiceImpl(friendshipRepo, validate)
+
 	// CONTROLLERS
 
 	// Auth controller
 	authController := controllers.NewAuthController(authService)
 
+	// Password controller
+	passwordController := controllers.NewPasswordController(passwordService)
+
+	// Two factor controller
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+
+	// OIDC controller
+	oidcController := controllers.NewOIDCController(oidcService)
+
+	// API key controller
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
+
+	// Role controller
+	roleController := controllers.NewRoleController(roleService)
+
+	// Impersonation controller
+	impersonationController := controllers.NewImpersonationController(impersonationService)
+
+	// Session controller
+	sessionController := controllers.NewSessionController(sessionService)
+
+	// Email verification controller
+	emailVerificationController := controllers.NewEmailVerificationController(emailVerificationService)
+
 	// User controller
 	userController := controllers.NewUserController(userService)
 
 	// Player profile controller
 	playerController := controllers.NewPlayerProfileController(playerProfileService)
 
+	// Leveling controller
+	levelingController := controllers.NewLevelingController(levelingService)
+
+	// Leaderboard controller
+	leaderboardController := controllers.NewLeaderboardController(leaderboardService)
+
+	// Season controller
+	seasonController := controllers.NewSeasonController(seasonService)
+
+	// Match controller
+	matchController := controllers.NewMatchController(matchService)
+
 	// Achievement controller
 	achievementController := controllers.NewAchievementController(achievementService)
 
+	// Friendship controller
+	friendshipController := controllers.NewFriendshipController(friendshipService)
+
 	// ROUTER
 
-	routes := routers.NewRouter(authController, userController, playerController, achievementController)
+	routes := routers.NewRouter(config.LoadTrustedProxies(), authUtils, revocationStore, sessionStore, permissionStore, authController, passwordController, emailVerificationController, twoFactorController, oidcController, apiKeyController, roleController, impersonationController, sessionController, userController, playerController, levelingController, leaderboardController, seasonController, matchController, achievementController, friendshipController)
 
 	routes.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
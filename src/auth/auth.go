@@ -1,8 +1,16 @@
 package auth
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type AuthUtils interface {
-	GenerateToken(userID uint, role string) (string, error)
+	GenerateToken(userID uint, role string, sessionID uint) (string, error)
+	// GenerateImpersonationToken issues a token for the user with the admin
+	// acting as them in the act claim.
+	GenerateImpersonationToken(userID uint, role string, actorID uint, ttl time.Duration) (string, error)
 	ParseToken(tokenString string) (*jwt.Token, error)
+	JWKS() JWKS
 }
@@ -2,38 +2,103 @@ package impl
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+type AuthImpl struct {
+	AccessTokenTTL time.Duration
+	KeySet         *KeySet
+}
+
+func (j *AuthImpl) GenerateToken(userID uint, role string, sessionID uint) (string, error) {
+	tokenID, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"jti":    tokenID,
+		"userID": userID,
+		"role":   role,
+		"sid":    sessionID,
+		"iat":    issuedAt(now),
+		"exp":    now.Add(j.AccessTokenTTL).Unix(),
+	}
+
+	return j.signToken(claims)
+}
 
-type AuthImpl struct{}
+// GenerateImpersonationToken implements auth.AuthUtils.
+// The token has no session, it can't be refreshed and ends with its TTL or a logout.
+func (j *AuthImpl) GenerateImpersonationToken(userID uint, role string, actorID uint, ttl time.Duration) (string, error) {
+	tokenID, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
 
-func (j *AuthImpl) GenerateToken(userID uint, role string) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
+		"jti":    tokenID,
 		"userID": userID,
 		"role":   role,
-		"exp":    time.Now().Add(time.Hour * 72).Unix(),
+		"act":    map[string]interface{}{"userID": actorID},
+		"iat":    issuedAt(now),
+		"exp":    now.Add(ttl).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return j.signToken(claims)
+}
+
+// issuedAt is the iat claim of a token in seconds with millisecond precision,
+// user revocations compare it with the time of the revocation.
+func issuedAt(now time.Time) float64 {
+	return float64(now.UnixMilli()) / 1000
+}
+
+func (j *AuthImpl) signToken(claims jwt.MapClaims) (string, error) {
+	signingKey := j.KeySet.SigningKey
 
-	return token.SignedString(jwtSecret)
+	token := jwt.NewWithClaims(signingKey.Method, claims)
+	if signingKey.ID != "" {
+		token.Header["kid"] = signingKey.ID
+	}
+
+	return token.SignedString(signingKey.Key)
 }
 
+// ParseToken only accepts tokens signed by a key of the key set, using the
+// algorithm registered for that key, so a public key can never be used as an
+// HMAC secret.
 func (j *AuthImpl) ParseToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		keyID, _ := token.Header["kid"].(string)
+
+		verificationKey, ok := j.KeySet.VerificationKeys[keyID]
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+
+		if token.Method.Alg() != verificationKey.Method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return jwtSecret, nil
+
+		return verificationKey.Key, nil
 	})
 }
 
-func NewJWTAth() auth.AuthUtils {
-	return &AuthImpl{}
+// JWKS implements auth.AuthUtils.
+func (j *AuthImpl) JWKS() auth.JWKS {
+	return j.KeySet.JWKS()
+}
+
+func NewJWTAth(accessTokenTTL time.Duration, keySet *KeySet) auth.AuthUtils {
+	return &AuthImpl{
+		AccessTokenTTL: accessTokenTTL,
+		KeySet:         keySet,
+	}
 }
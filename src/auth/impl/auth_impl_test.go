@@ -2,16 +2,19 @@ package impl
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestAuthImpl(t *testing.T) {
 	t.Run("GenrateToken", func(t *testing.T) {
-		auth := AuthImpl{}
+		auth := NewJWTAth(time.Hour, NewHMACKeySet([]byte("secret")))
 		token, err := auth.GenerateToken(
 			1,
 			"admin",
+			5,
 		)
 
 		assert.Nil(t, err, "Expected no error generating token")
@@ -19,23 +22,60 @@ func TestAuthImpl(t *testing.T) {
 	})
 }
 
+func TestAuthImpl_GenerateImpersonationToken(t *testing.T) {
+	auth := NewJWTAth(time.Hour, NewHMACKeySet([]byte("secret")))
+	token, err := auth.GenerateImpersonationToken(42, "user", 1, 10*time.Minute)
+	assert.Nil(t, err, "Expected no error generating token")
+
+	parsedToken, err := auth.ParseToken(token)
+	assert.Nil(t, err, "Expected no error parsing token")
+
+	claims := parsedToken.Claims.(jwt.MapClaims)
+	assert.Equal(t, float64(42), claims["userID"], "Expected the impersonated user in the userID claim")
+	assert.Equal(t, map[string]interface{}{"userID": float64(1)}, claims["act"], "Expected the admin in the act claim")
+	assert.Nil(t, claims["sid"], "Expected no session")
+
+	expiresAt, err := claims.GetExpirationTime()
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), expiresAt.Time, 5*time.Second)
+}
+
 func TestAuthImpl_ParseToken(t *testing.T) {
 	t.Run("ValidToken", func(t *testing.T) {
-		auth := AuthImpl{}
+		auth := NewJWTAth(time.Hour, NewHMACKeySet([]byte("secret")))
 		token, err := auth.GenerateToken(
 			1,
 			"admin",
+			5,
 		)
 		assert.Nil(t, err, "Expected no error generating token")
 
-		_, err = auth.ParseToken(token)
+		parsedToken, err := auth.ParseToken(token)
 		assert.Nil(t, err, "Expected no error parsing token")
+
+		claims := parsedToken.Claims.(jwt.MapClaims)
+		assert.NotEmpty(t, claims["jti"], "Expected token to have a jti claim")
+		assert.NotNil(t, claims["iat"], "Expected token to have an iat claim")
+		assert.Equal(t, float64(5), claims["sid"], "Expected token to have the session ID")
 	})
 
 	t.Run("InvalidToken", func(t *testing.T) {
-		auth := AuthImpl{}
+		auth := NewJWTAth(time.Hour, NewHMACKeySet([]byte("secret")))
 		_, err := auth.ParseToken("invalidtoken")
 		assert.NotNil(t, err, "Expected error parsing token")
 	})
 
+	t.Run("ExpiredToken", func(t *testing.T) {
+		auth := NewJWTAth(-time.Minute, NewHMACKeySet([]byte("secret")))
+		token, err := auth.GenerateToken(
+			1,
+			"admin",
+			5,
+		)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		_, err = auth.ParseToken(token)
+		assert.NotNil(t, err, "Expected error parsing expired token")
+	})
+
 }
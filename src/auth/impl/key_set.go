@@ -0,0 +1,250 @@
+package impl
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is the key used to sign new access tokens.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Key    interface{}
+}
+
+// VerificationKey is a key accepted when parsing access tokens.
+type VerificationKey struct {
+	Method jwt.SigningMethod
+	Key    interface{}
+}
+
+// KeySet holds the current signing key and every key, indexed by kid, that
+// still verifies tokens. Keeping the previous public keys in the set lets
+// tokens signed before a rotation live until they expire.
+type KeySet struct {
+	SigningKey       SigningKey
+	VerificationKeys map[string]VerificationKey
+}
+
+// NewHMACKeySet returns a key set that signs and verifies with a shared HS256 secret.
+func NewHMACKeySet(secret []byte) *KeySet {
+	return &KeySet{
+		SigningKey: SigningKey{
+			Method: jwt.SigningMethodHS256,
+			Key:    secret,
+		},
+		VerificationKeys: map[string]VerificationKey{
+			"": {Method: jwt.SigningMethodHS256, Key: secret},
+		},
+	}
+}
+
+// NewAsymmetricKeySet returns a key set that signs with the private key, the
+// signing method is RS256 for RSA keys and EdDSA for Ed25519 keys. When keyID
+// is empty it's derived from the public key.
+func NewAsymmetricKeySet(privateKey crypto.Signer, keyID string) (*KeySet, error) {
+	method, err := signingMethodFor(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID == "" {
+		keyID, err = keyIDFor(privateKey.Public())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &KeySet{
+		SigningKey: SigningKey{
+			ID:     keyID,
+			Method: method,
+			Key:    privateKey,
+		},
+		VerificationKeys: map[string]VerificationKey{
+			keyID: {Method: method, Key: privateKey.Public()},
+		},
+	}, nil
+}
+
+// AddVerificationKey accepts tokens signed by the private part of publicKey.
+func (k *KeySet) AddVerificationKey(keyID string, publicKey crypto.PublicKey) error {
+	method, err := signingMethodFor(publicKey)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := k.VerificationKeys[keyID]; exists {
+		return fmt.Errorf("duplicated key id %q", keyID)
+	}
+
+	k.VerificationKeys[keyID] = VerificationKey{Method: method, Key: publicKey}
+	return nil
+}
+
+// LoadKeySet builds the key set described by the configuration, reading the
+// PEM files of the asymmetric keys.
+func LoadKeySet(jwtConfig config.JWTConfig) (*KeySet, error) {
+	var keySet *KeySet
+
+	switch jwtConfig.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if jwtConfig.Secret == "" {
+			return nil, errors.New("JWT_SECRET is required with HS256")
+		}
+		keySet = NewHMACKeySet([]byte(jwtConfig.Secret))
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		privateKey, err := readPrivateKey(jwtConfig.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keySet, err = NewAsymmetricKeySet(privateKey, jwtConfig.KeyID)
+		if err != nil {
+			return nil, err
+		}
+
+		if keySet.SigningKey.Method.Alg() != jwtConfig.Algorithm {
+			return nil, fmt.Errorf("private key doesn't match algorithm %s", jwtConfig.Algorithm)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", jwtConfig.Algorithm)
+	}
+
+	for keyID, path := range jwtConfig.VerificationKeyFiles {
+		publicKey, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = keySet.AddVerificationKey(keyID, publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keySet, nil
+}
+
+// JWKS returns the public verification keys, HMAC secrets are never published.
+func (k *KeySet) JWKS() auth.JWKS {
+	jwks := auth.JWKS{Keys: []auth.JWK{}}
+
+	for keyID, verificationKey := range k.VerificationKeys {
+		switch publicKey := verificationKey.Key.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, auth.JWK{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: verificationKey.Method.Alg(),
+				Kid: keyID,
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, auth.JWK{
+				Kty: "OKP",
+				Use: "sig",
+				Alg: verificationKey.Method.Alg(),
+				Kid: keyID,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+
+	return jwks
+}
+
+func signingMethodFor(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, errors.New("unsupported key type, expected RSA or Ed25519")
+	}
+}
+
+func keyIDFor(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(hash[:12]), nil
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	if path == "" {
+		return nil, errors.New("key file is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	return block, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return rsaKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key in %s", path)
+	}
+
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsaKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return rsaKey, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,191 @@
+package impl
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.NoError(t, err, "Error writing PEM file")
+	return path
+}
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	t.Run("RS256", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err, "Error generating RSA key")
+
+		keySet, err := NewAsymmetricKeySet(privateKey, "rsa-key")
+		require.NoError(t, err, "Error creating key set")
+
+		authUtils := NewJWTAth(time.Hour, keySet)
+		tokenString, err := authUtils.GenerateToken(1, "user", 1)
+		require.NoError(t, err, "Error generating token")
+
+		token, err := authUtils.ParseToken(tokenString)
+		require.NoError(t, err, "Error parsing token")
+		assert.Equal(t, "RS256", token.Method.Alg())
+		assert.Equal(t, "rsa-key", token.Header["kid"])
+	})
+
+	t.Run("EdDSA", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+
+		keySet, err := NewAsymmetricKeySet(privateKey, "")
+		require.NoError(t, err, "Error creating key set")
+		assert.NotEmpty(t, keySet.SigningKey.ID, "Expected key ID to be derived from the public key")
+
+		authUtils := NewJWTAth(time.Hour, keySet)
+		tokenString, err := authUtils.GenerateToken(1, "user", 1)
+		require.NoError(t, err, "Error generating token")
+
+		token, err := authUtils.ParseToken(tokenString)
+		require.NoError(t, err, "Error parsing token")
+		assert.Equal(t, "EdDSA", token.Method.Alg())
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+		_, newKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+
+		oldKeySet, err := NewAsymmetricKeySet(oldKey, "old")
+		require.NoError(t, err, "Error creating key set")
+		oldToken, err := NewJWTAth(time.Hour, oldKeySet).GenerateToken(1, "user", 1)
+		require.NoError(t, err, "Error generating token")
+
+		// The new key signs, the old one still verifies
+		newKeySet, err := NewAsymmetricKeySet(newKey, "new")
+		require.NoError(t, err, "Error creating key set")
+		authUtils := NewJWTAth(time.Hour, newKeySet)
+
+		_, err = authUtils.ParseToken(oldToken)
+		assert.Error(t, err, "Expected token signed with an unknown key to be rejected")
+
+		require.NoError(t, newKeySet.AddVerificationKey("old", oldKey.Public()), "Error adding verification key")
+
+		_, err = authUtils.ParseToken(oldToken)
+		assert.NoError(t, err, "Expected token signed with the previous key to be accepted")
+	})
+
+	t.Run("RejectsHMACWithPublicKey", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err, "Error generating RSA key")
+
+		keySet, err := NewAsymmetricKeySet(privateKey, "rsa-key")
+		require.NoError(t, err, "Error creating key set")
+
+		// Forge an HS256 token using the public key as the secret
+		publicDER := x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"userID": 1, "role": "admin"})
+		forged.Header["kid"] = "rsa-key"
+		forgedString, err := forged.SignedString(publicDER)
+		require.NoError(t, err, "Error signing forged token")
+
+		_, err = NewJWTAth(time.Hour, keySet).ParseToken(forgedString)
+		assert.Error(t, err, "Expected forged token to be rejected")
+	})
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Error generating RSA key")
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "Error generating Ed25519 key")
+
+	keySet, err := NewAsymmetricKeySet(rsaKey, "rsa-key")
+	require.NoError(t, err, "Error creating key set")
+	require.NoError(t, keySet.AddVerificationKey("ed-key", edPublicKey), "Error adding verification key")
+
+	jwks := keySet.JWKS()
+	require.Len(t, jwks.Keys, 2, "Expected both public keys")
+
+	for _, jwk := range jwks.Keys {
+		switch jwk.Kid {
+		case "rsa-key":
+			assert.Equal(t, "RSA", jwk.Kty)
+			assert.Equal(t, "RS256", jwk.Alg)
+			assert.Equal(t, "AQAB", jwk.E)
+			assert.NotEmpty(t, jwk.N)
+		case "ed-key":
+			assert.Equal(t, "OKP", jwk.Kty)
+			assert.Equal(t, "EdDSA", jwk.Alg)
+			assert.Equal(t, "Ed25519", jwk.Crv)
+			assert.NotEmpty(t, jwk.X)
+		default:
+			t.Errorf("Unexpected key %q", jwk.Kid)
+		}
+	}
+
+	assert.Empty(t, NewHMACKeySet([]byte("secret")).JWKS().Keys, "Expected HMAC secrets not to be published")
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Run("HS256", func(t *testing.T) {
+		keySet, err := LoadKeySet(config.JWTConfig{Algorithm: "HS256", Secret: "secret"})
+		require.NoError(t, err, "Error loading key set")
+		assert.Equal(t, "HS256", keySet.SigningKey.Method.Alg())
+	})
+
+	t.Run("HS256_MissingSecret", func(t *testing.T) {
+		_, err := LoadKeySet(config.JWTConfig{Algorithm: "HS256"})
+		assert.Error(t, err, "Expected error without secret")
+	})
+
+	t.Run("EdDSA_WithVerificationKey", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+		privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err, "Error encoding private key")
+
+		oldPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+		publicDER, err := x509.MarshalPKIXPublicKey(oldPublicKey)
+		require.NoError(t, err, "Error encoding public key")
+
+		keySet, err := LoadKeySet(config.JWTConfig{
+			Algorithm:            "EdDSA",
+			PrivateKeyFile:       writePEM(t, "PRIVATE KEY", privateDER),
+			KeyID:                "current",
+			VerificationKeyFiles: map[string]string{"previous": writePEM(t, "PUBLIC KEY", publicDER)},
+		})
+		require.NoError(t, err, "Error loading key set")
+		assert.Equal(t, "current", keySet.SigningKey.ID)
+		assert.Contains(t, keySet.VerificationKeys, "current")
+		assert.Contains(t, keySet.VerificationKeys, "previous")
+	})
+
+	t.Run("AlgorithmMismatch", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Error generating Ed25519 key")
+		privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err, "Error encoding private key")
+
+		_, err = LoadKeySet(config.JWTConfig{
+			Algorithm:      "RS256",
+			PrivateKeyFile: writePEM(t, "PRIVATE KEY", privateDER),
+		})
+		assert.Error(t, err, "Expected error when the key doesn't match the algorithm")
+	})
+
+	t.Run("UnsupportedAlgorithm", func(t *testing.T) {
+		_, err := LoadKeySet(config.JWTConfig{Algorithm: "none"})
+		assert.Error(t, err, "Expected error with unsupported algorithm")
+	})
+}
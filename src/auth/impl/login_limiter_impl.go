@@ -0,0 +1,139 @@
+package impl
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// LoginLimiterImpl keeps the failure counters in the database so every
+// instance of the API sees the same attempts. Accounts are keyed by the email
+// as typed, registered or not, so the throttling doesn't tell which emails exist.
+type LoginLimiterImpl struct {
+	LoginAttemptRepository repository.LoginAttemptRepository
+	Config                 config.LoginLimitConfig
+}
+
+// Check implements auth.LoginLimiter.
+func (l *LoginLimiterImpl) Check(email string, clientIP string) (time.Duration, error) {
+	now := time.Now()
+
+	accountWait, err := l.wait(accountKey(email), l.Config.AccountFreeAttempts, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if clientIP == "" {
+		return accountWait, nil
+	}
+
+	ipWait, err := l.wait(ipKey(clientIP), l.Config.IPFreeAttempts, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(accountWait, ipWait), nil
+}
+
+// RecordFailure implements auth.LoginLimiter.
+// The account is locked once it reaches the lockout threshold, client IPs are
+// only slowed down since many players can share one.
+func (l *LoginLimiterImpl) RecordFailure(email string, clientIP string) error {
+	now := time.Now()
+
+	loginAttempt, err := l.LoginAttemptRepository.RecordFailure(accountKey(email), now, l.Config.Window)
+	if err != nil {
+		logrus.WithError(err).Error("[LoginLimiterImpl.RecordFailure] Failed to record account failure")
+		return err
+	}
+
+	if loginAttempt.Failures >= l.Config.LockoutThreshold {
+		logrus.WithField("failures", loginAttempt.Failures).Warn("[LoginLimiterImpl.RecordFailure] Account locked after too many failed logins")
+
+		err = l.LoginAttemptRepository.LockUntil(loginAttempt.Key, now.Add(l.Config.LockoutDuration))
+		if err != nil {
+			logrus.WithError(err).Error("[LoginLimiterImpl.RecordFailure] Failed to lock account")
+			return err
+		}
+	}
+
+	if clientIP == "" {
+		return nil
+	}
+
+	_, err = l.LoginAttemptRepository.RecordFailure(ipKey(clientIP), now, l.Config.Window)
+	if err != nil {
+		logrus.WithError(err).Error("[LoginLimiterImpl.RecordFailure] Failed to record IP failure")
+		return err
+	}
+
+	return nil
+}
+
+// RecordSuccess implements auth.LoginLimiter.
+func (l *LoginLimiterImpl) RecordSuccess(email string) error {
+	return l.LoginAttemptRepository.DeleteLoginAttempt(accountKey(email))
+}
+
+// Unlock implements auth.LoginLimiter.
+func (l *LoginLimiterImpl) Unlock(email string) error {
+	return l.LoginAttemptRepository.DeleteLoginAttempt(accountKey(email))
+}
+
+func (l *LoginLimiterImpl) wait(key string, freeAttempts int, now time.Time) (time.Duration, error) {
+	loginAttempt, err := l.LoginAttemptRepository.GetLoginAttempt(key)
+	if errors.Is(err, helpers.ErrorLoginAttemptNotFound) {
+		return 0, nil
+	}
+
+	if err != nil {
+		logrus.WithError(err).Error("[LoginLimiterImpl.wait] Failed to get login attempt")
+		return 0, err
+	}
+
+	if loginAttempt.LockedUntil != nil && now.Before(*loginAttempt.LockedUntil) {
+		return loginAttempt.LockedUntil.Sub(now), nil
+	}
+
+	if now.Sub(loginAttempt.LastFailureAt) > l.Config.Window || loginAttempt.Failures < freeAttempts {
+		return 0, nil
+	}
+
+	retryAt := loginAttempt.LastFailureAt.Add(l.backoff(loginAttempt.Failures - freeAttempts))
+	if now.Before(retryAt) {
+		return retryAt.Sub(now), nil
+	}
+
+	return 0, nil
+}
+
+// backoff doubles the wait for every failure past the free attempts.
+func (l *LoginLimiterImpl) backoff(extraFailures int) time.Duration {
+	delay := l.Config.BackoffBase
+	for i := 0; i < extraFailures && delay < l.Config.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, l.Config.BackoffMax)
+}
+
+func accountKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+func NewLoginLimiterImpl(loginAttemptRepository repository.LoginAttemptRepository, loginLimitConfig config.LoginLimitConfig) auth.LoginLimiter {
+	return &LoginLimiterImpl{
+		LoginAttemptRepository: loginAttemptRepository,
+		Config:                 loginLimitConfig,
+	}
+}
@@ -0,0 +1,155 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testLoginLimitConfig = config.LoginLimitConfig{
+	AccountFreeAttempts: 3,
+	IPFreeAttempts:      10,
+	BackoffBase:         time.Second,
+	BackoffMax:          time.Minute,
+	LockoutThreshold:    6,
+	LockoutDuration:     30 * time.Minute,
+	Window:              time.Hour,
+}
+
+func TestLoginLimiterImpl_Check(t *testing.T) {
+	t.Run("NoFailures", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(nil, helpers.ErrorLoginAttemptNotFound)
+		loginAttemptRepo.On("GetLoginAttempt", "ip:10.0.0.1").Return(nil, helpers.ErrorLoginAttemptNotFound)
+
+		retryAfter, err := limiter.Check(" Test@Test.com ", "10.0.0.1")
+
+		assert.NoError(t, err)
+		assert.Zero(t, retryAfter)
+		loginAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("FreeAttempts", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 2, LastFailureAt: time.Now()}, nil)
+
+		retryAfter, err := limiter.Check("test@test.com", "")
+
+		assert.NoError(t, err)
+		assert.Zero(t, retryAfter, "Expected no wait under the free attempts")
+	})
+
+	t.Run("ExponentialBackoff", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		// Two failures past the free attempts wait base * 2^2
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 5, LastFailureAt: time.Now()}, nil)
+
+		retryAfter, err := limiter.Check("test@test.com", "")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, float64(4*time.Second), float64(retryAfter), float64(100*time.Millisecond))
+	})
+
+	t.Run("BackoffCapped", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(nil, helpers.ErrorLoginAttemptNotFound)
+		loginAttemptRepo.On("GetLoginAttempt", "ip:10.0.0.1").Return(&models.LoginAttempt{Key: "ip:10.0.0.1", Failures: 500, LastFailureAt: time.Now()}, nil)
+
+		retryAfter, err := limiter.Check("test@test.com", "10.0.0.1")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, float64(time.Minute), float64(retryAfter), float64(100*time.Millisecond))
+	})
+
+	t.Run("BackoffElapsed", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 4, LastFailureAt: time.Now().Add(-time.Minute)}, nil)
+
+		retryAfter, err := limiter.Check("test@test.com", "")
+
+		assert.NoError(t, err)
+		assert.Zero(t, retryAfter)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		lockedUntil := time.Now().Add(20 * time.Minute)
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 6, LastFailureAt: time.Now().Add(-10 * time.Minute), LockedUntil: &lockedUntil}, nil)
+
+		retryAfter, err := limiter.Check("test@test.com", "")
+
+		assert.NoError(t, err)
+		assert.InDelta(t, float64(20*time.Minute), float64(retryAfter), float64(time.Second))
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("GetLoginAttempt", "email:test@test.com").Return(nil, assert.AnError)
+
+		_, err := limiter.Check("test@test.com", "10.0.0.1")
+
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestLoginLimiterImpl_RecordFailure(t *testing.T) {
+	t.Run("CountsAccountAndIP", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("RecordFailure", "email:test@test.com", mock.Anything, time.Hour).Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 2}, nil)
+		loginAttemptRepo.On("RecordFailure", "ip:10.0.0.1", mock.Anything, time.Hour).Return(&models.LoginAttempt{Key: "ip:10.0.0.1", Failures: 2}, nil)
+
+		err := limiter.RecordFailure("test@test.com", "10.0.0.1")
+
+		assert.NoError(t, err)
+		loginAttemptRepo.AssertExpectations(t)
+		loginAttemptRepo.AssertNotCalled(t, "LockUntil", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LocksAccountAtThreshold", func(t *testing.T) {
+		loginAttemptRepo := new(mocks.LoginAttemptRepository)
+		limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+		loginAttemptRepo.On("RecordFailure", "email:test@test.com", mock.Anything, time.Hour).Return(&models.LoginAttempt{Key: "email:test@test.com", Failures: 6}, nil)
+		loginAttemptRepo.On("LockUntil", "email:test@test.com", mock.MatchedBy(func(lockedUntil time.Time) bool {
+			return lockedUntil.After(time.Now().Add(29 * time.Minute))
+		})).Return(nil)
+
+		err := limiter.RecordFailure("test@test.com", "")
+
+		assert.NoError(t, err)
+		loginAttemptRepo.AssertExpectations(t)
+	})
+}
+
+func TestLoginLimiterImpl_Reset(t *testing.T) {
+	loginAttemptRepo := new(mocks.LoginAttemptRepository)
+	limiter := NewLoginLimiterImpl(loginAttemptRepo, testLoginLimitConfig)
+
+	loginAttemptRepo.On("DeleteLoginAttempt", "email:test@test.com").Return(nil).Twice()
+
+	assert.NoError(t, limiter.RecordSuccess("Test@test.com"))
+	assert.NoError(t, limiter.Unlock("test@test.com"))
+	loginAttemptRepo.AssertExpectations(t)
+}
@@ -0,0 +1,328 @@
+package impl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// oidcDiscovery is the part of the provider metadata used by the client.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+}
+
+// OIDCClientImpl reads the discovery document and the signing keys of every
+// provider the first time they are needed and keeps them in memory. The keys
+// are fetched again when an ID token is signed with an unknown kid, so key
+// rotations at the provider don't need a restart.
+type OIDCClientImpl struct {
+	Config     config.OIDCConfig
+	HTTPClient *http.Client
+
+	mu        sync.Mutex
+	discovery map[string]*oidcDiscovery
+	keys      map[string]map[string]crypto.PublicKey
+}
+
+// Providers implements auth.OIDCClient.
+func (o *OIDCClientImpl) Providers() []string {
+	providers := make([]string, 0, len(o.Config.Providers))
+	for name := range o.Config.Providers {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+
+	return providers
+}
+
+// AuthCodeURL implements auth.OIDCClient.
+func (o *OIDCClientImpl) AuthCodeURL(provider string, state string, nonce string, codeChallenge string) (string, error) {
+	providerConfig, ok := o.Config.Providers[provider]
+	if !ok {
+		return "", helpers.ErrUnknownOIDCProvider
+	}
+
+	discovery, err := o.getDiscovery(providerConfig)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", providerConfig.ClientID)
+	query.Set("redirect_uri", providerConfig.RedirectURL)
+	query.Set("scope", strings.Join(providerConfig.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange implements auth.OIDCClient.
+func (o *OIDCClientImpl) Exchange(provider string, code string, codeVerifier string) (*auth.OIDCClaims, error) {
+	providerConfig, ok := o.Config.Providers[provider]
+	if !ok {
+		return nil, helpers.ErrUnknownOIDCProvider
+	}
+
+	discovery, err := o.getDiscovery(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", providerConfig.RedirectURL)
+	form.Set("client_id", providerConfig.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if providerConfig.ClientSecret != "" {
+		form.Set("client_secret", providerConfig.ClientSecret)
+	}
+
+	resp, err := o.HTTPClient.PostForm(discovery.TokenEndpoint, form)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCClientImpl.Exchange] Failed to call token endpoint")
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCClientImpl.Exchange] Failed to decode token response")
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	if resp.StatusCode != http.StatusOK || tokenResponse.IDToken == "" {
+		logrus.WithFields(logrus.Fields{
+			"provider":    provider,
+			"status":      resp.StatusCode,
+			"error":       tokenResponse.Error,
+			"description": tokenResponse.ErrorDescription,
+		}).Warn("[OIDCClientImpl.Exchange] Token request refused")
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	return o.verifyIDToken(providerConfig, discovery, tokenResponse.IDToken)
+}
+
+// verifyIDToken checks the signature, issuer, audience and expiration of the ID token.
+func (o *OIDCClientImpl) verifyIDToken(providerConfig config.OIDCProviderConfig, discovery *oidcDiscovery, idToken string) (*auth.OIDCClaims, error) {
+	claims := &oidcIDTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		return o.getKey(providerConfig, discovery, keyID)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(providerConfig.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		logrus.WithError(err).Warn("[OIDCClientImpl.verifyIDToken] Invalid ID token")
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	if claims.Subject == "" {
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	return &auth.OIDCClaims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+func (o *OIDCClientImpl) getDiscovery(providerConfig config.OIDCProviderConfig) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	discovery, ok := o.discovery[providerConfig.Name]
+	o.mu.Unlock()
+	if ok {
+		return discovery, nil
+	}
+
+	discovery = &oidcDiscovery{}
+	err := o.getJSON(providerConfig.Issuer+"/.well-known/openid-configuration", discovery)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCClientImpl.getDiscovery] Failed to read discovery document")
+		return nil, err
+	}
+
+	// The issuer in the metadata must be the configured one (OpenID Connect Discovery 4.3)
+	if strings.TrimSuffix(discovery.Issuer, "/") != providerConfig.Issuer {
+		return nil, fmt.Errorf("discovery issuer %q doesn't match %q", discovery.Issuer, providerConfig.Issuer)
+	}
+
+	o.mu.Lock()
+	o.discovery[providerConfig.Name] = discovery
+	o.mu.Unlock()
+
+	return discovery, nil
+}
+
+func (o *OIDCClientImpl) getKey(providerConfig config.OIDCProviderConfig, discovery *oidcDiscovery, keyID string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.keys[providerConfig.Name][keyID]
+	o.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	jwks := auth.JWKS{}
+	err := o.getJSON(discovery.JWKSURI, &jwks)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCClientImpl.getKey] Failed to read provider keys")
+		return nil, err
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := parseJWK(jwk)
+		if err != nil {
+			logrus.WithError(err).WithField("kid", jwk.Kid).Warn("[OIDCClientImpl.getKey] Skipping provider key")
+			continue
+		}
+		keys[jwk.Kid] = publicKey
+	}
+
+	o.mu.Lock()
+	o.keys[providerConfig.Name] = keys
+	o.mu.Unlock()
+
+	key, ok = keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", keyID)
+	}
+
+	return key, nil
+}
+
+func (o *OIDCClientImpl) getJSON(url string, target interface{}) error {
+	resp, err := o.HTTPClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// parseJWK returns the public key of an RSA, EC or Ed25519 JSON Web Key.
+func parseJWK(jwk auth.JWK) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func NewOIDCClientImpl(oidcConfig config.OIDCConfig, httpClient *http.Client) auth.OIDCClient {
+	return &OIDCClientImpl{
+		Config:     oidcConfig,
+		HTTPClient: httpClient,
+		discovery:  map[string]*oidcDiscovery{},
+		keys:       map[string]map[string]crypto.PublicKey{},
+	}
+}
@@ -0,0 +1,219 @@
+package impl
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCClientID = "player-profile"
+
+func newTestOIDCConfig(issuer string) config.OIDCConfig {
+	return config.OIDCConfig{
+		Providers: map[string]config.OIDCProviderConfig{
+			"test": {
+				Name:        "test",
+				Issuer:      issuer,
+				ClientID:    testOIDCClientID,
+				RedirectURL: "http://localhost:8080/api/v1/auth/oidc/test/callback",
+				Scopes:      []string{"openid", "email", "profile"},
+			},
+		},
+		StateTTL: time.Minute,
+	}
+}
+
+func TestOIDCClientImpl_AuthCodeURL(t *testing.T) {
+	provider := testutils.NewOIDCProvider(testOIDCClientID)
+	defer provider.Close()
+	client := NewOIDCClientImpl(newTestOIDCConfig(provider.Issuer()), http.DefaultClient)
+
+	authURL, err := client.AuthCodeURL("test", "state", "nonce", "challenge")
+	require.NoError(t, err)
+
+	parsedURL, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsedURL.Query()
+
+	assert.Equal(t, provider.Issuer()+"/authorize", parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testOIDCClientID, query.Get("client_id"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, "challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	_, err = client.AuthCodeURL("unknown", "state", "nonce", "challenge")
+	assert.Equal(t, helpers.ErrUnknownOIDCProvider, err)
+}
+
+func TestOIDCClientImpl_Exchange(t *testing.T) {
+	provider := testutils.NewOIDCProvider(testOIDCClientID)
+	defer provider.Close()
+	user := testutils.OIDCUser{Subject: "subject-1", Email: "player@test.com", EmailVerified: true, Name: "Player"}
+
+	t.Run("Success", func(t *testing.T) {
+		client := NewOIDCClientImpl(newTestOIDCConfig(provider.Issuer()), http.DefaultClient)
+		codeVerifier, _ := helpers.GenerateOpaqueToken()
+
+		authURL, err := client.AuthCodeURL("test", "state", "nonce", helpers.PKCEChallenge(codeVerifier))
+		require.NoError(t, err)
+		code, _ := provider.Authorize(authURL, user)
+
+		claims, err := client.Exchange("test", code, codeVerifier)
+
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", claims.Subject)
+		assert.Equal(t, "player@test.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "Player", claims.Name)
+		assert.Equal(t, "nonce", claims.Nonce)
+	})
+
+	t.Run("WrongCodeVerifier", func(t *testing.T) {
+		client := NewOIDCClientImpl(newTestOIDCConfig(provider.Issuer()), http.DefaultClient)
+		codeVerifier, _ := helpers.GenerateOpaqueToken()
+
+		authURL, err := client.AuthCodeURL("test", "state", "nonce", helpers.PKCEChallenge(codeVerifier))
+		require.NoError(t, err)
+		code, _ := provider.Authorize(authURL, user)
+
+		_, err = client.Exchange("test", code, "another-verifier")
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("CodeUsedTwice", func(t *testing.T) {
+		client := NewOIDCClientImpl(newTestOIDCConfig(provider.Issuer()), http.DefaultClient)
+		codeVerifier, _ := helpers.GenerateOpaqueToken()
+
+		authURL, err := client.AuthCodeURL("test", "state", "nonce", helpers.PKCEChallenge(codeVerifier))
+		require.NoError(t, err)
+		code, _ := provider.Authorize(authURL, user)
+
+		_, err = client.Exchange("test", code, codeVerifier)
+		require.NoError(t, err)
+		_, err = client.Exchange("test", code, codeVerifier)
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("WrongClientID", func(t *testing.T) {
+		// A client registered under another id gets tokens for another audience
+		oidcConfig := newTestOIDCConfig(provider.Issuer())
+		providerConfig := oidcConfig.Providers["test"]
+		providerConfig.ClientID = "other-client"
+		oidcConfig.Providers["test"] = providerConfig
+		client := NewOIDCClientImpl(oidcConfig, http.DefaultClient)
+		codeVerifier, _ := helpers.GenerateOpaqueToken()
+
+		authURL, err := client.AuthCodeURL("test", "state", "nonce", helpers.PKCEChallenge(codeVerifier))
+		require.NoError(t, err)
+		code, _ := provider.Authorize(authURL, user)
+
+		_, err = client.Exchange("test", code, codeVerifier)
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("IssuerMismatch", func(t *testing.T) {
+		oidcConfig := newTestOIDCConfig(provider.Issuer())
+		providerConfig := oidcConfig.Providers["test"]
+		providerConfig.Issuer = provider.Issuer() + "/other"
+		oidcConfig.Providers["test"] = providerConfig
+		client := NewOIDCClientImpl(oidcConfig, http.DefaultClient)
+
+		_, err := client.AuthCodeURL("test", "state", "nonce", "challenge")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCClientImpl_VerifyIDToken(t *testing.T) {
+	provider := testutils.NewOIDCProvider(testOIDCClientID)
+	defer provider.Close()
+	oidcConfig := newTestOIDCConfig(provider.Issuer())
+	client := NewOIDCClientImpl(oidcConfig, http.DefaultClient).(*OIDCClientImpl)
+
+	discovery, err := client.getDiscovery(oidcConfig.Providers["test"])
+	require.NoError(t, err)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": provider.Issuer(),
+			"sub": "subject-1",
+			"aud": testOIDCClientID,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		claims, err := client.verifyIDToken(oidcConfig.Providers["test"], discovery, provider.SignIDToken(validClaims()))
+
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", claims.Subject)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		_, err := client.verifyIDToken(oidcConfig.Providers["test"], discovery, provider.SignIDToken(claims))
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("OtherIssuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "https://evil.example.com"
+
+		_, err := client.verifyIDToken(oidcConfig.Providers["test"], discovery, provider.SignIDToken(claims))
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("SignedWithOtherKey", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims())
+		token.Header["kid"] = provider.KeyID
+		signed, err := token.SignedString(otherKey)
+		require.NoError(t, err)
+
+		_, err = client.verifyIDToken(oidcConfig.Providers["test"], discovery, signed)
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+
+	t.Run("NotSigned", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = client.verifyIDToken(oidcConfig.Providers["test"], discovery, signed)
+
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+	})
+}
+
+func TestOIDCClientImpl_Providers(t *testing.T) {
+	oidcConfig := config.OIDCConfig{Providers: map[string]config.OIDCProviderConfig{
+		"steam":   {Name: "steam"},
+		"discord": {Name: "discord"},
+		"google":  {Name: "google"},
+	}}
+	client := NewOIDCClientImpl(oidcConfig, http.DefaultClient)
+
+	assert.Equal(t, []string{"discord", "google", "steam"}, client.Providers())
+}
@@ -0,0 +1,102 @@
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PermissionStoreImpl answers permission lookups from an in-memory copy of the
+// roles, reloaded every SyncInterval so changes made through other instances
+// of the API are picked up.
+type PermissionStoreImpl struct {
+	RoleRepository repository.RoleRepository
+	SyncInterval   time.Duration
+
+	mutex       sync.RWMutex
+	roles       map[string][]string
+	lastSync    time.Time
+	syncing     bool
+	invalidated bool
+	loaded      bool
+	firstLoad   chan struct{}
+}
+
+// Permissions implements auth.PermissionStore.
+func (s *PermissionStoreImpl) Permissions(role string) ([]string, bool) {
+	s.syncIfStale()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	permissions, ok := s.roles[role]
+	return permissions, ok
+}
+
+// Invalidate implements auth.PermissionStore.
+// A sync already loading keeps the cache stale, its roles may predate the change.
+func (s *PermissionStoreImpl) Invalidate() {
+	s.mutex.Lock()
+	s.lastSync = time.Time{}
+	s.invalidated = true
+	s.mutex.Unlock()
+}
+
+// syncIfStale reloads the roles once the cache is older than SyncInterval or
+// invalidated. Only one request loads them, outside the lock so lookups keep
+// answering from the cache meanwhile. A failed load is retried after the next
+// SyncInterval instead of on every request.
+func (s *PermissionStoreImpl) syncIfStale() {
+	s.mutex.Lock()
+	if s.syncing || time.Since(s.lastSync) < s.SyncInterval {
+		// Until the first load ends the cache is empty, not just stale
+		firstLoad := s.syncing && !s.loaded
+		s.mutex.Unlock()
+		if firstLoad {
+			<-s.firstLoad
+		}
+		return
+	}
+	s.syncing = true
+	s.invalidated = false
+	s.mutex.Unlock()
+
+	now := time.Now()
+	roles, err := s.RoleRepository.GetAllRoles()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.loaded {
+		s.loaded = true
+		close(s.firstLoad)
+	}
+	s.syncing = false
+	if !s.invalidated {
+		s.lastSync = now
+	}
+
+	if err != nil {
+		logrus.WithError(err).Error("[PermissionStoreImpl.syncIfStale] Failed to load roles, keeping cached roles")
+		return
+	}
+
+	loadedRoles := make(map[string][]string, len(roles))
+	for i := range roles {
+		loadedRoles[roles[i].Name] = roles[i].PermissionNames()
+	}
+
+	s.roles = loadedRoles
+}
+
+func NewPermissionStoreImpl(roleRepository repository.RoleRepository, syncInterval time.Duration) auth.PermissionStore {
+	return &PermissionStoreImpl{
+		RoleRepository: roleRepository,
+		SyncInterval:   syncInterval,
+		roles:          make(map[string][]string),
+		firstLoad:      make(chan struct{}),
+	}
+}
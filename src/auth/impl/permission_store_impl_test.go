@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPermissionStoreImpl_Permissions(t *testing.T) {
+	roles := []models.Role{
+		{Name: "moderator", Permissions: []models.RolePermission{{Permission: models.PermissionPlayersModerate}}},
+		{Name: "user"},
+	}
+
+	t.Run("LoadsRolesFromRepository", func(t *testing.T) {
+		roleRepo := new(mocks.RoleRepository)
+		roleRepo.On("GetAllRoles").Return(roles, nil)
+		store := NewPermissionStoreImpl(roleRepo, time.Minute)
+
+		permissions, ok := store.Permissions("moderator")
+		assert.True(t, ok)
+		assert.Equal(t, []string{models.PermissionPlayersModerate}, permissions)
+
+		permissions, ok = store.Permissions("user")
+		assert.True(t, ok, "A role without permissions still exists")
+		assert.Empty(t, permissions)
+
+		_, ok = store.Permissions("owner")
+		assert.False(t, ok, "Expected unknown role")
+
+		// The repository is only queried once per sync interval
+		roleRepo.AssertNumberOfCalls(t, "GetAllRoles", 1)
+	})
+
+	t.Run("Invalidate_Reloads", func(t *testing.T) {
+		roleRepo := new(mocks.RoleRepository)
+		roleRepo.On("GetAllRoles").Return(roles, nil)
+		store := NewPermissionStoreImpl(roleRepo, time.Hour)
+
+		store.Permissions("user")
+		store.Invalidate()
+		store.Permissions("user")
+
+		roleRepo.AssertNumberOfCalls(t, "GetAllRoles", 2)
+	})
+
+	t.Run("SyncError_KeepsCache", func(t *testing.T) {
+		roleRepo := new(mocks.RoleRepository)
+		roleRepo.On("GetAllRoles").Return(roles, nil).Once()
+		roleRepo.On("GetAllRoles").Return(nil, assert.AnError)
+		store := NewPermissionStoreImpl(roleRepo, time.Hour)
+
+		store.Permissions("moderator")
+		store.Invalidate()
+
+		permissions, ok := store.Permissions("moderator")
+		assert.True(t, ok)
+		assert.Equal(t, []string{models.PermissionPlayersModerate}, permissions)
+	})
+
+	t.Run("SyncError_RetriesAfterSyncInterval", func(t *testing.T) {
+		roleRepo := new(mocks.RoleRepository)
+		roleRepo.On("GetAllRoles").Return(nil, assert.AnError)
+		store := NewPermissionStoreImpl(roleRepo, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			store.Permissions("user")
+		}
+
+		// A failing database isn't queried again on every request
+		roleRepo.AssertNumberOfCalls(t, "GetAllRoles", 1)
+	})
+
+	t.Run("InvalidateWhileSyncing_Reloads", func(t *testing.T) {
+		roleRepo := new(mocks.RoleRepository)
+		store := NewPermissionStoreImpl(roleRepo, time.Hour)
+		roleRepo.On("GetAllRoles").Run(func(mock.Arguments) { store.Invalidate() }).Return(roles, nil).Once()
+		roleRepo.On("GetAllRoles").Return(roles, nil)
+
+		store.Permissions("user")
+		store.Permissions("user")
+
+		// The roles loaded by the first sync may predate the role change
+		roleRepo.AssertNumberOfCalls(t, "GetAllRoles", 2)
+	})
+}
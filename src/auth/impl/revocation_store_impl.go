@@ -0,0 +1,170 @@
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// RevocationStoreImpl persists revocations in the database and answers lookups
+// from an in-memory copy, reloaded every SyncInterval so revocations made by
+// other instances of the API are picked up.
+type RevocationStoreImpl struct {
+	RevokedTokenRepository repository.RevokedTokenRepository
+	SyncInterval           time.Duration
+
+	mutex         sync.RWMutex
+	revokedTokens map[string]time.Time
+	revokedUsers  map[uint]time.Time
+	lastSync      time.Time
+	syncing       bool
+	firstLoad     chan struct{}
+}
+
+// RevokeToken implements auth.RevocationStore.
+func (s *RevocationStoreImpl) RevokeToken(tokenID string, userID uint, expiresAt time.Time) error {
+	err := s.RevokedTokenRepository.CreateRevokedToken(&models.RevokedToken{
+		JTI:       tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.revokedTokens[tokenID] = expiresAt
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// RevokeAllForUser implements auth.RevocationStore.
+// Token issue times have millisecond precision, so the revocation covers the
+// tokens issued up to the current millisecond and not the ones issued after it.
+func (s *RevocationStoreImpl) RevokeAllForUser(userID uint) error {
+	revokedAt := time.Now().Truncate(time.Millisecond)
+
+	err := s.RevokedTokenRepository.SaveUserRevocation(&models.UserRevocation{
+		UserID:    userID,
+		RevokedAt: revokedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.revokedUsers[userID] = revokedAt
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// IsRevoked implements auth.RevocationStore.
+func (s *RevocationStoreImpl) IsRevoked(tokenID string, userID uint, issuedAt time.Time) bool {
+	s.syncIfStale()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, ok := s.revokedTokens[tokenID]; ok {
+		return true
+	}
+
+	revokedAt, ok := s.revokedUsers[userID]
+	return ok && !issuedAt.After(revokedAt)
+}
+
+// syncIfStale reloads the revocations once the cache is older than
+// SyncInterval. Only one request loads them, outside the lock so lookups keep
+// answering from the cache meanwhile. A failed load is retried after the next
+// SyncInterval instead of on every request.
+func (s *RevocationStoreImpl) syncIfStale() {
+	s.mutex.Lock()
+	if s.syncing || time.Since(s.lastSync) < s.SyncInterval {
+		// Until the first load ends the cache is empty, not just stale
+		firstLoad := s.syncing && s.lastSync.IsZero()
+		s.mutex.Unlock()
+		if firstLoad {
+			<-s.firstLoad
+		}
+		return
+	}
+	s.syncing = true
+	s.mutex.Unlock()
+
+	now := time.Now()
+	revokedTokens, userRevocations, err := s.loadRevocations(now)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.lastSync.IsZero() {
+		close(s.firstLoad)
+	}
+	s.syncing = false
+	s.lastSync = now
+
+	if err != nil {
+		return
+	}
+
+	// Revocations are never lifted, so the ones made while loading are kept
+	loadedTokens := make(map[string]time.Time, len(revokedTokens))
+	for _, revokedToken := range revokedTokens {
+		loadedTokens[revokedToken.JTI] = revokedToken.ExpiresAt
+	}
+	for tokenID, expiresAt := range s.revokedTokens {
+		if expiresAt.After(now) {
+			loadedTokens[tokenID] = expiresAt
+		}
+	}
+
+	loadedUsers := make(map[uint]time.Time, len(userRevocations))
+	for _, userRevocation := range userRevocations {
+		loadedUsers[userRevocation.UserID] = userRevocation.RevokedAt
+	}
+	for userID, revokedAt := range s.revokedUsers {
+		if revokedAt.After(loadedUsers[userID]) {
+			loadedUsers[userID] = revokedAt
+		}
+	}
+
+	s.revokedTokens = loadedTokens
+	s.revokedUsers = loadedUsers
+}
+
+func (s *RevocationStoreImpl) loadRevocations(now time.Time) ([]models.RevokedToken, []models.UserRevocation, error) {
+	err := s.RevokedTokenRepository.DeleteExpiredRevokedTokens(now)
+	if err != nil {
+		logrus.WithError(err).Warn("[RevocationStoreImpl.loadRevocations] Failed to purge expired revoked tokens")
+	}
+
+	revokedTokens, err := s.RevokedTokenRepository.GetActiveRevokedTokens(now)
+	if err != nil {
+		logrus.WithError(err).Error("[RevocationStoreImpl.loadRevocations] Failed to load revoked tokens, keeping cached revocations")
+		return nil, nil, err
+	}
+
+	userRevocations, err := s.RevokedTokenRepository.GetAllUserRevocations()
+	if err != nil {
+		logrus.WithError(err).Error("[RevocationStoreImpl.loadRevocations] Failed to load user revocations, keeping cached revocations")
+		return nil, nil, err
+	}
+
+	return revokedTokens, userRevocations, nil
+}
+
+func NewRevocationStoreImpl(revokedTokenRepository repository.RevokedTokenRepository, syncInterval time.Duration) auth.RevocationStore {
+	return &RevocationStoreImpl{
+		RevokedTokenRepository: revokedTokenRepository,
+		SyncInterval:           syncInterval,
+		revokedTokens:          make(map[string]time.Time),
+		revokedUsers:           make(map[uint]time.Time),
+		firstLoad:              make(chan struct{}),
+	}
+}
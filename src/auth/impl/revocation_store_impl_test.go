@@ -0,0 +1,139 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSyncedRevokedTokenRepository(revokedTokens []models.RevokedToken, userRevocations []models.UserRevocation) *mocks.RevokedTokenRepository {
+	revokedTokenRepo := new(mocks.RevokedTokenRepository)
+	revokedTokenRepo.On("DeleteExpiredRevokedTokens", mock.Anything).Return(nil)
+	revokedTokenRepo.On("GetActiveRevokedTokens", mock.Anything).Return(revokedTokens, nil)
+	revokedTokenRepo.On("GetAllUserRevocations").Return(userRevocations, nil)
+	return revokedTokenRepo
+}
+
+func TestRevocationStoreImpl_IsRevoked(t *testing.T) {
+	t.Run("LoadsRevocationsFromRepository", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		revokedTokenRepo := newSyncedRevokedTokenRepository(
+			[]models.RevokedToken{{JTI: "revoked", UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}},
+			[]models.UserRevocation{{UserID: 2, RevokedAt: revokedAt}},
+		)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		assert.True(t, store.IsRevoked("revoked", 1, time.Now()), "Expected revoked token to be revoked")
+		assert.False(t, store.IsRevoked("other", 1, time.Now()), "Expected other token not to be revoked")
+		assert.True(t, store.IsRevoked("old", 2, revokedAt.Add(-time.Minute)), "Expected token issued before the user revocation to be revoked")
+		assert.False(t, store.IsRevoked("new", 2, revokedAt.Add(time.Minute)), "Expected token issued after the user revocation not to be revoked")
+
+		// The repository is only queried once per sync interval
+		revokedTokenRepo.AssertNumberOfCalls(t, "GetActiveRevokedTokens", 1)
+	})
+
+	t.Run("SyncError_KeepsCache", func(t *testing.T) {
+		revokedTokenRepo := new(mocks.RevokedTokenRepository)
+		revokedTokenRepo.On("DeleteExpiredRevokedTokens", mock.Anything).Return(nil)
+		revokedTokenRepo.On("GetActiveRevokedTokens", mock.Anything).Return(nil, assert.AnError)
+		revokedTokenRepo.On("CreateRevokedToken", mock.Anything).Return(nil)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		err := store.RevokeToken("revoked", 1, time.Now().Add(time.Hour))
+		assert.Nil(t, err, "Expected no error revoking token")
+
+		assert.True(t, store.IsRevoked("revoked", 1, time.Now()), "Expected revoked token to be revoked")
+	})
+
+	t.Run("SyncError_RetriesAfterSyncInterval", func(t *testing.T) {
+		revokedTokenRepo := new(mocks.RevokedTokenRepository)
+		revokedTokenRepo.On("DeleteExpiredRevokedTokens", mock.Anything).Return(nil)
+		revokedTokenRepo.On("GetActiveRevokedTokens", mock.Anything).Return(nil, assert.AnError)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			store.IsRevoked("token", 1, time.Now())
+		}
+
+		// A failing database isn't queried again on every request
+		revokedTokenRepo.AssertNumberOfCalls(t, "GetActiveRevokedTokens", 1)
+	})
+
+	t.Run("Sync_KeepsRevocationsMadeMeanwhile", func(t *testing.T) {
+		revokedTokenRepo := newSyncedRevokedTokenRepository(nil, nil)
+		revokedTokenRepo.On("CreateRevokedToken", mock.Anything).Return(nil)
+		store := NewRevocationStoreImpl(revokedTokenRepo, 0)
+
+		err := store.RevokeToken("revoked", 1, time.Now().Add(time.Hour))
+		assert.Nil(t, err, "Expected no error revoking token")
+
+		// The database copy loaded by the next sync predates the revocation
+		assert.True(t, store.IsRevoked("revoked", 1, time.Now()), "Expected revoked token to stay revoked")
+	})
+}
+
+func TestRevocationStoreImpl_RevokeToken(t *testing.T) {
+	revokedTokenRepo := newSyncedRevokedTokenRepository(nil, nil)
+	expiresAt := time.Now().Add(time.Hour)
+	revokedTokenRepo.On("CreateRevokedToken", mock.MatchedBy(func(revokedToken *models.RevokedToken) bool {
+		return revokedToken.JTI == "token" && revokedToken.UserID == 1 && revokedToken.ExpiresAt.Equal(expiresAt)
+	})).Return(nil)
+	store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+	assert.False(t, store.IsRevoked("token", 1, time.Now()), "Expected token not to be revoked yet")
+
+	err := store.RevokeToken("token", 1, expiresAt)
+	assert.Nil(t, err, "Expected no error revoking token")
+
+	assert.True(t, store.IsRevoked("token", 1, time.Now()), "Expected token to be revoked")
+	revokedTokenRepo.AssertExpectations(t)
+}
+
+func TestRevocationStoreImpl_RevokeAllForUser(t *testing.T) {
+	t.Run("RevokeAllForUser_Success", func(t *testing.T) {
+		revokedTokenRepo := newSyncedRevokedTokenRepository(nil, nil)
+		revokedTokenRepo.On("SaveUserRevocation", mock.MatchedBy(func(userRevocation *models.UserRevocation) bool {
+			return userRevocation.UserID == 1
+		})).Return(nil)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		issuedAt := time.Now().Truncate(time.Second)
+		assert.False(t, store.IsRevoked("token", 1, issuedAt), "Expected user token not to be revoked yet")
+
+		err := store.RevokeAllForUser(1)
+		assert.Nil(t, err, "Expected no error revoking user tokens")
+
+		assert.True(t, store.IsRevoked("token", 1, issuedAt), "Expected user token to be revoked")
+		assert.False(t, store.IsRevoked("token", 2, issuedAt), "Expected other user token not to be revoked")
+		revokedTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("RevokeAllForUser_LoginRightAfter", func(t *testing.T) {
+		revokedTokenRepo := newSyncedRevokedTokenRepository(nil, nil)
+		revokedTokenRepo.On("SaveUserRevocation", mock.Anything).Return(nil)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		err := store.RevokeAllForUser(1)
+		assert.Nil(t, err, "Expected no error revoking user tokens")
+
+		// Tokens carry the issue time in milliseconds, a token of the same second isn't revoked
+		time.Sleep(2 * time.Millisecond)
+		issuedAt := time.Now().Truncate(time.Millisecond)
+		assert.False(t, store.IsRevoked("token", 1, issuedAt), "Expected token issued after the revocation not to be revoked")
+	})
+
+	t.Run("RevokeAllForUser_RepositoryError", func(t *testing.T) {
+		revokedTokenRepo := newSyncedRevokedTokenRepository(nil, nil)
+		revokedTokenRepo.On("SaveUserRevocation", mock.Anything).Return(assert.AnError)
+		store := NewRevocationStoreImpl(revokedTokenRepo, time.Minute)
+
+		err := store.RevokeAllForUser(1)
+		assert.NotNil(t, err, "Expected error revoking user tokens")
+
+		assert.False(t, store.IsRevoked("token", 1, time.Now()), "Expected token not to be revoked")
+	})
+}
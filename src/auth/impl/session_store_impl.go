@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionStoreImpl ends sessions in the database and answers lookups from an
+// in-memory copy of the sessions ended in the last AccessTokenTTL, older ones
+// have no valid access token left. The copy is reloaded every SyncInterval so
+// sessions ended through other instances of the API are picked up.
+type SessionStoreImpl struct {
+	SessionRepository repository.SessionRepository
+	SyncInterval      time.Duration
+	AccessTokenTTL    time.Duration
+
+	mutex         sync.RWMutex
+	endedSessions map[uint]time.Time
+	lastSync      time.Time
+	syncing       bool
+	firstLoad     chan struct{}
+}
+
+// EndSession implements auth.SessionStore.
+func (s *SessionStoreImpl) EndSession(sessionID uint) error {
+	endedAt := time.Now()
+
+	_, err := s.SessionRepository.EndSession(sessionID, endedAt)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.endedSessions[sessionID] = endedAt
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// IsEnded implements auth.SessionStore.
+func (s *SessionStoreImpl) IsEnded(sessionID uint) bool {
+	s.syncIfStale()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, ok := s.endedSessions[sessionID]
+	return ok
+}
+
+// syncIfStale reloads the ended sessions once the cache is older than
+// SyncInterval, the same way RevocationStoreImpl reloads revocations.
+func (s *SessionStoreImpl) syncIfStale() {
+	s.mutex.Lock()
+	if s.syncing || time.Since(s.lastSync) < s.SyncInterval {
+		// Until the first load ends the cache is empty, not just stale
+		firstLoad := s.syncing && s.lastSync.IsZero()
+		s.mutex.Unlock()
+		if firstLoad {
+			<-s.firstLoad
+		}
+		return
+	}
+	s.syncing = true
+	s.mutex.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-s.AccessTokenTTL)
+	endedSessions, err := s.SessionRepository.GetEndedSessions(cutoff)
+	if err != nil {
+		logrus.WithError(err).Error("[SessionStoreImpl.syncIfStale] Failed to load ended sessions, keeping cached sessions")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.lastSync.IsZero() {
+		close(s.firstLoad)
+	}
+	s.syncing = false
+	s.lastSync = now
+
+	if err != nil {
+		return
+	}
+
+	// Ended sessions never start again, so the ones ended while loading are kept
+	loadedSessions := make(map[uint]time.Time, len(endedSessions))
+	for _, session := range endedSessions {
+		if session.EndedAt != nil {
+			loadedSessions[session.ID] = *session.EndedAt
+		}
+	}
+	for sessionID, endedAt := range s.endedSessions {
+		if endedAt.After(cutoff) {
+			loadedSessions[sessionID] = endedAt
+		}
+	}
+
+	s.endedSessions = loadedSessions
+}
+
+func NewSessionStoreImpl(sessionRepository repository.SessionRepository, syncInterval time.Duration, accessTokenTTL time.Duration) auth.SessionStore {
+	return &SessionStoreImpl{
+		SessionRepository: sessionRepository,
+		SyncInterval:      syncInterval,
+		AccessTokenTTL:    accessTokenTTL,
+		endedSessions:     make(map[uint]time.Time),
+		firstLoad:         make(chan struct{}),
+	}
+}
@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSessionStoreImpl(t *testing.T) {
+	t.Run("IsEnded_LoadsFromRepository", func(t *testing.T) {
+		endedAt := time.Now()
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("GetEndedSessions", mock.AnythingOfType("time.Time")).Return([]models.Session{{Model: gorm.Model{ID: 3}, EndedAt: &endedAt}}, nil)
+		store := NewSessionStoreImpl(sessionRepo, time.Minute, 15*time.Minute)
+
+		assert.True(t, store.IsEnded(3))
+		assert.False(t, store.IsEnded(4))
+
+		// The repository is only queried once per sync interval
+		sessionRepo.AssertNumberOfCalls(t, "GetEndedSessions", 1)
+	})
+
+	t.Run("EndSession_AppliesRightAway", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("GetEndedSessions", mock.AnythingOfType("time.Time")).Return([]models.Session{}, nil)
+		sessionRepo.On("EndSession", uint(5), mock.AnythingOfType("time.Time")).Return(true, nil)
+		store := NewSessionStoreImpl(sessionRepo, time.Hour, 15*time.Minute)
+
+		assert.False(t, store.IsEnded(5))
+		assert.NoError(t, store.EndSession(5))
+		assert.True(t, store.IsEnded(5))
+	})
+
+	t.Run("IsEnded_SyncErrorRetriesAfterSyncInterval", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("GetEndedSessions", mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
+		store := NewSessionStoreImpl(sessionRepo, time.Minute, 15*time.Minute)
+
+		assert.False(t, store.IsEnded(3))
+		assert.False(t, store.IsEnded(3))
+
+		sessionRepo.AssertNumberOfCalls(t, "GetEndedSessions", 1)
+	})
+
+	t.Run("EndSession_Error", func(t *testing.T) {
+		sessionRepo := new(mocks.SessionRepository)
+		sessionRepo.On("GetEndedSessions", mock.AnythingOfType("time.Time")).Return([]models.Session{}, nil)
+		sessionRepo.On("EndSession", uint(5), mock.AnythingOfType("time.Time")).Return(false, assert.AnError)
+		store := NewSessionStoreImpl(sessionRepo, time.Hour, 15*time.Minute)
+
+		assert.Error(t, store.EndSession(5))
+		assert.False(t, store.IsEnded(5))
+	})
+}
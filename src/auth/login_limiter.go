@@ -0,0 +1,13 @@
+package auth
+
+import "time"
+
+// LoginLimiter throttles failed logins per account and per client IP.
+type LoginLimiter interface {
+	// Check returns how long the client must wait before trying again, zero
+	// when the attempt is allowed.
+	Check(email string, clientIP string) (time.Duration, error)
+	RecordFailure(email string, clientIP string) error
+	RecordSuccess(email string) error
+	Unlock(email string) error
+}
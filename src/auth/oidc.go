@@ -0,0 +1,19 @@
+package auth
+
+// OIDCClaims is the identity read from a verified OpenID Connect ID token.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+// OIDCClient runs the authorization code flow with PKCE against the configured providers.
+type OIDCClient interface {
+	Providers() []string
+	// AuthCodeURL returns the provider page the user is sent to.
+	AuthCodeURL(provider string, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange trades the code for the ID token and returns its verified claims.
+	Exchange(provider string, code string, codeVerifier string) (*OIDCClaims, error)
+}
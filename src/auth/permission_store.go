@@ -0,0 +1,9 @@
+package auth
+
+// PermissionStore resolves the permissions of the roles stored in the database.
+type PermissionStore interface {
+	// Permissions returns the permissions of the role, ok is false when the role doesn't exist.
+	Permissions(role string) (permissions []string, ok bool)
+	// Invalidate drops the cached roles, the next lookup reloads them.
+	Invalidate()
+}
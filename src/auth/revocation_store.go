@@ -0,0 +1,10 @@
+package auth
+
+import "time"
+
+// RevocationStore keeps track of access tokens invalidated before their expiration.
+type RevocationStore interface {
+	RevokeToken(tokenID string, userID uint, expiresAt time.Time) error
+	RevokeAllForUser(userID uint) error
+	IsRevoked(tokenID string, userID uint, issuedAt time.Time) bool
+}
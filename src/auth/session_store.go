@@ -0,0 +1,7 @@
+package auth
+
+// SessionStore keeps track of the sessions ended while their access tokens are still valid.
+type SessionStore interface {
+	EndSession(sessionID uint) error
+	IsEnded(sessionID uint) bool
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultAccessTokenTTL = 15 * time.Minute
+const defaultRefreshTokenTTL = 30 * 24 * time.Hour
+const defaultRevocationSyncInterval = 30 * time.Second
+const defaultPermissionSyncInterval = 30 * time.Second
+const defaultPasswordResetTTL = time.Hour
+const defaultEmailVerificationTTL = 24 * time.Hour
+const defaultVerificationResendInterval = time.Minute
+const defaultTwoFactorChallengeTTL = 5 * time.Minute
+const defaultImpersonationTokenTTL = 10 * time.Minute
+const defaultTOTPIssuer = "Player Profile"
+
+// AuthConfig holds the token lifetimes used by the auth service.
+type AuthConfig struct {
+	AccessTokenTTL         time.Duration
+	RefreshTokenTTL        time.Duration
+	RevocationSyncInterval time.Duration
+	PermissionSyncInterval time.Duration // Time role changes take to reach the permission cache of other instances
+	PasswordResetTTL       time.Duration
+	PasswordResetURL       string // Page of the client that receives the reset token as ?token=
+
+	RequireEmailVerification   bool // Login refuses accounts whose email isn't verified
+	EmailVerificationTTL       time.Duration
+	EmailVerificationURL       string        // Page that receives the verification token as ?token=
+	VerificationResendInterval time.Duration // Minimum time between two verification emails
+
+	TwoFactorChallengeTTL time.Duration // Time to enter the TOTP code after the password
+	TOTPIssuer            string        // Name shown by authenticator apps
+
+	ImpersonationTokenTTL time.Duration // Lifetime of the tokens issued to admins impersonating a user
+}
+
+// LoadAuthConfig reads ACCESS_TOKEN_TTL, REFRESH_TOKEN_TTL,
+// REVOCATION_SYNC_INTERVAL, PERMISSION_SYNC_INTERVAL, PASSWORD_RESET_TTL, EMAIL_VERIFICATION_TTL and
+// VERIFICATION_RESEND_INTERVAL, TWO_FACTOR_CHALLENGE_TTL, IMPERSONATION_TOKEN_TTL (Go duration strings
+// like "15m" or "720h"), falling back to the defaults when unset,
+// PASSWORD_RESET_URL, EMAIL_VERIFICATION_URL, REQUIRE_EMAIL_VERIFICATION (false
+// by default) and TOTP_ISSUER.
+func LoadAuthConfig() AuthConfig {
+	return AuthConfig{
+		AccessTokenTTL:         durationFromEnv("ACCESS_TOKEN_TTL", defaultAccessTokenTTL),
+		RefreshTokenTTL:        durationFromEnv("REFRESH_TOKEN_TTL", defaultRefreshTokenTTL),
+		RevocationSyncInterval: durationFromEnv("REVOCATION_SYNC_INTERVAL", defaultRevocationSyncInterval),
+		PermissionSyncInterval: durationFromEnv("PERMISSION_SYNC_INTERVAL", defaultPermissionSyncInterval),
+		PasswordResetTTL:       durationFromEnv("PASSWORD_RESET_TTL", defaultPasswordResetTTL),
+		PasswordResetURL:       os.Getenv("PASSWORD_RESET_URL"),
+
+		RequireEmailVerification:   boolFromEnv("REQUIRE_EMAIL_VERIFICATION", false),
+		EmailVerificationTTL:       durationFromEnv("EMAIL_VERIFICATION_TTL", defaultEmailVerificationTTL),
+		EmailVerificationURL:       os.Getenv("EMAIL_VERIFICATION_URL"),
+		VerificationResendInterval: durationFromEnv("VERIFICATION_RESEND_INTERVAL", defaultVerificationResendInterval),
+
+		TwoFactorChallengeTTL: durationFromEnv("TWO_FACTOR_CHALLENGE_TTL", defaultTwoFactorChallengeTTL),
+		TOTPIssuer:            stringFromEnv("TOTP_ISSUER", defaultTOTPIssuer),
+
+		ImpersonationTokenTTL: durationFromEnv("IMPERSONATION_TOKEN_TTL", defaultImpersonationTokenTTL),
+	}
+}
+
+// DefaultRole reads DEFAULT_ROLE, the role of the accounts created by sign-up
+// or by a first social login.
+func DefaultRole() string {
+	return os.Getenv("DEFAULT_ROLE")
+}
+
+// JWTConfig describes how access tokens are signed and verified.
+type JWTConfig struct {
+	Algorithm            string            // HS256, RS256 or EdDSA
+	Secret               string            // HMAC secret, only used with HS256
+	PrivateKeyFile       string            // PEM private key used to sign, RS256 and EdDSA
+	KeyID                string            // kid of the signing key, derived from the public key when empty
+	VerificationKeyFiles map[string]string // kid to PEM public key of keys still accepted after a rotation
+}
+
+// LoadJWTConfig reads JWT_ALGORITHM (HS256 by default), JWT_SECRET,
+// JWT_PRIVATE_KEY_FILE, JWT_KEY_ID and JWT_VERIFICATION_KEY_FILES, a comma
+// separated list of kid=path pairs.
+func LoadJWTConfig() JWTConfig {
+	jwtConfig := JWTConfig{
+		Algorithm:            os.Getenv("JWT_ALGORITHM"),
+		Secret:               os.Getenv("JWT_SECRET"),
+		PrivateKeyFile:       os.Getenv("JWT_PRIVATE_KEY_FILE"),
+		KeyID:                os.Getenv("JWT_KEY_ID"),
+		VerificationKeyFiles: map[string]string{},
+	}
+
+	if jwtConfig.Algorithm == "" {
+		jwtConfig.Algorithm = "HS256"
+	}
+
+	verificationKeyFiles := os.Getenv("JWT_VERIFICATION_KEY_FILES")
+	if verificationKeyFiles == "" {
+		return jwtConfig
+	}
+
+	for _, entry := range strings.Split(verificationKeyFiles, ",") {
+		keyID, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || keyID == "" || path == "" {
+			panic(fmt.Sprintf("invalid JWT_VERIFICATION_KEY_FILES entry: %q", entry))
+		}
+		jwtConfig.VerificationKeyFiles[keyID] = path
+	}
+
+	return jwtConfig
+}
+
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		panic(fmt.Sprintf("invalid %s: %q", key, value))
+	}
+
+	return duration
+}
+
+func boolFromEnv(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %q", key, value))
+	}
+
+	return boolValue
+}
+
+func stringFromEnv(key string, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
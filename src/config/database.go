@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/dieg0code/player-profile/src/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -25,3 +26,19 @@ func DatabaseConnection() *gorm.DB {
 
 	return db
 }
+
+// MigrateDatabase creates or updates the tables of every model.
+func MigrateDatabase(db *gorm.DB) error {
+	// Custom join table for the player achievements, it stores the unlock date
+	err := db.SetupJoinTable(&models.PlayerProfile{}, "Achievements", &models.PlayerProfileAchievement{})
+	if err != nil {
+		return err
+	}
+
+	err = db.SetupJoinTable(&models.Achievement{}, "PlayerProfiles", &models.PlayerProfileAchievement{})
+	if err != nil {
+		return err
+	}
+
+	return db.AutoMigrate(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.UserRevocation{}, &models.UserToken{}, &models.TwoFactor{}, &models.RecoveryCode{}, &models.LoginAttempt{}, &models.UserIdentity{}, &models.OIDCState{}, &models.APIKey{}, &models.Role{}, &models.RolePermission{}, &models.Session{}, &models.Season{}, &models.SeasonStanding{}, &models.Match{}, &models.MatchParticipant{}, &models.PlayerRating{}, &models.FriendRequest{}, &models.Friendship{}, &models.PlayerBlock{})
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// LeaderboardConfig sets how many players of the top of each leaderboard are
+// kept in memory and for how long, and how many neighbours can be asked
+// around a player.
+type LeaderboardConfig struct {
+	CacheSize     int
+	CacheTTL      time.Duration
+	MaxNeighbours int
+}
+
+// LoadLeaderboardConfig reads LEADERBOARD_CACHE_SIZE (100 by default),
+// LEADERBOARD_CACHE_TTL (30s by default) and LEADERBOARD_MAX_NEIGHBOURS
+// (25 by default).
+func LoadLeaderboardConfig() LeaderboardConfig {
+	return LeaderboardConfig{
+		CacheSize:     intFromEnv("LEADERBOARD_CACHE_SIZE", 100),
+		CacheTTL:      durationFromEnv("LEADERBOARD_CACHE_TTL", 30*time.Second),
+		MaxNeighbours: intFromEnv("LEADERBOARD_MAX_NEIGHBOURS", 25),
+	}
+}
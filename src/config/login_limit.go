@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LoginLimitConfig sets how failed logins are throttled. After the free
+// attempts every failure doubles the wait before the next attempt, starting at
+// BackoffBase and capped at BackoffMax. Accounts reaching LockoutThreshold are
+// locked for LockoutDuration or until an admin unlocks them. Failures older
+// than Window are forgotten.
+type LoginLimitConfig struct {
+	AccountFreeAttempts int
+	IPFreeAttempts      int
+	BackoffBase         time.Duration
+	BackoffMax          time.Duration
+	LockoutThreshold    int
+	LockoutDuration     time.Duration
+	Window              time.Duration
+}
+
+// LoadLoginLimitConfig reads LOGIN_ACCOUNT_FREE_ATTEMPTS, LOGIN_IP_FREE_ATTEMPTS,
+// LOGIN_LOCKOUT_THRESHOLD, LOGIN_BACKOFF_BASE, LOGIN_BACKOFF_MAX,
+// LOGIN_LOCKOUT_DURATION and LOGIN_ATTEMPT_WINDOW, falling back to the defaults
+// when unset.
+func LoadLoginLimitConfig() LoginLimitConfig {
+	loginLimitConfig := LoginLimitConfig{
+		AccountFreeAttempts: intFromEnv("LOGIN_ACCOUNT_FREE_ATTEMPTS", 5),
+		IPFreeAttempts:      intFromEnv("LOGIN_IP_FREE_ATTEMPTS", 20),
+		BackoffBase:         durationFromEnv("LOGIN_BACKOFF_BASE", time.Second),
+		BackoffMax:          durationFromEnv("LOGIN_BACKOFF_MAX", 15*time.Minute),
+		LockoutThreshold:    intFromEnv("LOGIN_LOCKOUT_THRESHOLD", 15),
+		LockoutDuration:     durationFromEnv("LOGIN_LOCKOUT_DURATION", 30*time.Minute),
+		Window:              durationFromEnv("LOGIN_ATTEMPT_WINDOW", 24*time.Hour),
+	}
+
+	if loginLimitConfig.LockoutThreshold <= loginLimitConfig.AccountFreeAttempts {
+		panic("LOGIN_LOCKOUT_THRESHOLD must be greater than LOGIN_ACCOUNT_FREE_ATTEMPTS")
+	}
+
+	return loginLimitConfig
+}
+
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil || intValue <= 0 {
+		panic(fmt.Sprintf("invalid %s: %q", key, value))
+	}
+
+	return intValue
+}
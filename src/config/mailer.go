@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// MailerConfig selects and configures the Mailer implementation.
+type MailerConfig struct {
+	Driver       string // smtp, file or log
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FilePath     string
+}
+
+// LoadMailerConfig reads MAIL_DRIVER (log by default), MAIL_FROM, SMTP_HOST,
+// SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD and MAIL_FILE_PATH.
+func LoadMailerConfig() MailerConfig {
+	mailerConfig := MailerConfig{
+		Driver:       os.Getenv("MAIL_DRIVER"),
+		From:         os.Getenv("MAIL_FROM"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     587,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		FilePath:     os.Getenv("MAIL_FILE_PATH"),
+	}
+
+	if mailerConfig.Driver == "" {
+		mailerConfig.Driver = "log"
+	}
+
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		portInt, err := strconv.Atoi(port)
+		if err != nil {
+			panic(fmt.Sprintf("invalid SMTP_PORT: %q", port))
+		}
+		mailerConfig.SMTPPort = portInt
+	}
+
+	return mailerConfig
+}
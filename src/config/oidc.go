@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultOIDCStateTTL = 10 * time.Minute
+const defaultOIDCScopes = "openid email profile"
+
+// OIDCProviderConfig is an OpenID Connect provider players can sign in with.
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string // Base URL of the provider, the discovery document is read from it
+	ClientID     string
+	ClientSecret string // Empty for public clients, PKCE is always used
+	RedirectURL  string // Callback registered in the provider, /api/v1/auth/oidc/{provider}/callback
+	Scopes       []string
+}
+
+// OIDCConfig holds the configured social login providers, indexed by name.
+type OIDCConfig struct {
+	Providers map[string]OIDCProviderConfig
+	StateTTL  time.Duration // Time the user has to come back from the provider
+}
+
+// LoadOIDCConfig reads OIDC_PROVIDERS, a comma separated list of provider names,
+// and for every name OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID,
+// OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL and OIDC_<NAME>_SCOPES
+// ("openid email profile" by default), plus OIDC_STATE_TTL.
+func LoadOIDCConfig() OIDCConfig {
+	oidcConfig := OIDCConfig{
+		Providers: map[string]OIDCProviderConfig{},
+		StateTTL:  durationFromEnv("OIDC_STATE_TTL", defaultOIDCStateTTL),
+	}
+
+	providers := os.Getenv("OIDC_PROVIDERS")
+	if providers == "" {
+		return oidcConfig
+	}
+
+	for _, name := range strings.Split(providers, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:         name,
+			Issuer:       strings.TrimSuffix(os.Getenv(prefix+"ISSUER"), "/"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(stringFromEnv(prefix+"SCOPES", defaultOIDCScopes)),
+		}
+
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			panic(fmt.Sprintf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", prefix, prefix, prefix))
+		}
+
+		oidcConfig.Providers[name] = provider
+	}
+
+	return oidcConfig
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
+
+// PasswordHashConfig selects the algorithm of new password hashes. Stored
+// hashes made with another algorithm or other parameters keep working and are
+// replaced on the next login.
+type PasswordHashConfig struct {
+	Algorithm         string // argon2id or bcrypt
+	BcryptCost        int
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	// Argon2 hashes computed at once, each one takes Argon2Memory. Logins
+	// don't need an account, so without a bound they could exhaust the memory
+	Argon2MaxConcurrency int
+}
+
+// LoadPasswordHashConfig reads PASSWORD_HASH_ALGORITHM (argon2id by default),
+// BCRYPT_COST, ARGON2_MEMORY in KiB, ARGON2_ITERATIONS, ARGON2_PARALLELISM and
+// ARGON2_MAX_CONCURRENCY, falling back to the defaults when unset. The Argon2
+// defaults are the OWASP minimum of 19 MiB and 2 iterations, and as many
+// concurrent hashes as CPUs.
+func LoadPasswordHashConfig() PasswordHashConfig {
+	parallelism := intFromEnv("ARGON2_PARALLELISM", 2)
+	if parallelism > 255 {
+		panic(fmt.Sprintf("invalid ARGON2_PARALLELISM: %d", parallelism))
+	}
+
+	hashConfig := PasswordHashConfig{
+		Algorithm:            stringFromEnv("PASSWORD_HASH_ALGORITHM", PasswordHashArgon2id),
+		BcryptCost:           intFromEnv("BCRYPT_COST", bcrypt.DefaultCost),
+		Argon2Memory:         uint32(intFromEnv("ARGON2_MEMORY", 19*1024)),
+		Argon2Iterations:     uint32(intFromEnv("ARGON2_ITERATIONS", 2)),
+		Argon2Parallelism:    uint8(parallelism),
+		Argon2MaxConcurrency: intFromEnv("ARGON2_MAX_CONCURRENCY", runtime.NumCPU()),
+	}
+
+	if hashConfig.Algorithm != PasswordHashArgon2id && hashConfig.Algorithm != PasswordHashBcrypt {
+		panic(fmt.Sprintf("invalid PASSWORD_HASH_ALGORITHM: %q", os.Getenv("PASSWORD_HASH_ALGORITHM")))
+	}
+
+	if hashConfig.BcryptCost < bcrypt.MinCost || hashConfig.BcryptCost > bcrypt.MaxCost {
+		panic(fmt.Sprintf("invalid BCRYPT_COST: %d", hashConfig.BcryptCost))
+	}
+
+	return hashConfig
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// PlayerProfileConfig holds the progression every new player profile starts with
+// and how many profiles a user can have.
+type PlayerProfileConfig struct {
+	DefaultLevel       int
+	DefaultExperience  int
+	DefaultPoints      int
+	MaxProfilesPerUser int
+}
+
+// LoadPlayerProfileConfig reads PLAYER_DEFAULT_LEVEL (1 by default),
+// PLAYER_DEFAULT_EXPERIENCE and PLAYER_DEFAULT_POINTS (0 by default) and
+// PLAYER_MAX_PROFILES_PER_USER (3 by default).
+func LoadPlayerProfileConfig() PlayerProfileConfig {
+	return PlayerProfileConfig{
+		DefaultLevel:       intFromEnv("PLAYER_DEFAULT_LEVEL", 1),
+		DefaultExperience:  nonNegativeIntFromEnv("PLAYER_DEFAULT_EXPERIENCE", 0),
+		DefaultPoints:      nonNegativeIntFromEnv("PLAYER_DEFAULT_POINTS", 0),
+		MaxProfilesPerUser: intFromEnv("PLAYER_MAX_PROFILES_PER_USER", 3),
+	}
+}
+
+func nonNegativeIntFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil || intValue < 0 {
+		panic(fmt.Sprintf("invalid %s: %q", key, value))
+	}
+
+	return intValue
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+// RatingConfig sets the rating system of new matches and the rating of
+// players without rated matches. Elo moves ratings by up to EloKFactor per
+// match, Glicko-2 also tracks the deviation and volatility of the rating and
+// Glicko2Tau limits how fast the volatility changes.
+type RatingConfig struct {
+	System            string // elo or glicko2
+	InitialRating     float64
+	InitialDeviation  float64
+	InitialVolatility float64
+	EloKFactor        float64
+	Glicko2Tau        float64
+}
+
+// LoadRatingConfig reads RATING_SYSTEM (elo by default), RATING_INITIAL,
+// RATING_INITIAL_DEVIATION, RATING_INITIAL_VOLATILITY, RATING_ELO_K_FACTOR
+// and RATING_GLICKO2_TAU, falling back to the defaults when unset.
+func LoadRatingConfig() RatingConfig {
+	ratingConfig := RatingConfig{
+		System:            stringFromEnv("RATING_SYSTEM", models.RatingSystemElo),
+		InitialRating:     floatFromEnv("RATING_INITIAL", 1500),
+		InitialDeviation:  floatFromEnv("RATING_INITIAL_DEVIATION", 350),
+		InitialVolatility: floatFromEnv("RATING_INITIAL_VOLATILITY", 0.06),
+		EloKFactor:        floatFromEnv("RATING_ELO_K_FACTOR", 32),
+		Glicko2Tau:        floatFromEnv("RATING_GLICKO2_TAU", 0.5),
+	}
+
+	if ratingConfig.System != models.RatingSystemElo && ratingConfig.System != models.RatingSystemGlicko2 {
+		panic(fmt.Sprintf("invalid RATING_SYSTEM: %q", ratingConfig.System))
+	}
+
+	return ratingConfig
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// LoadTrustedProxies reads TRUSTED_PROXIES, the comma separated IPs and CIDRs
+// of the reverse proxies in front of the API. The client IP is only taken from
+// X-Forwarded-For when the request comes through one of them, otherwise any
+// client could pick its own IP and dodge the per-IP login throttling. Unset
+// means no proxy is trusted and nil is returned.
+func LoadTrustedProxies() []string {
+	value := os.Getenv("TRUSTED_PROXIES")
+	if value == "" {
+		return nil
+	}
+
+	var trustedProxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		_, _, cidrErr := net.ParseCIDR(proxy)
+		if cidrErr != nil && net.ParseIP(proxy) == nil {
+			panic(fmt.Sprintf("invalid TRUSTED_PROXIES entry: %q", proxy))
+		}
+
+		trustedProxies = append(trustedProxies, proxy)
+	}
+
+	return trustedProxies
+}
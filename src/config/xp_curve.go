@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	XPCurveLinear      = "linear"
+	XPCurveExponential = "exponential"
+	XPCurveTable       = "table"
+)
+
+// XPCurveConfig describes the experience needed to level up. Linear curves
+// cost Base experience per level, exponential curves multiply the cost of
+// each level by Factor and tables list the total experience of every level
+// from level 2.
+type XPCurveConfig struct {
+	Type     string // linear, exponential or table
+	Base     int
+	Factor   float64
+	Table    []int
+	MaxLevel int
+}
+
+// LoadXPCurveConfig reads XP_CURVE (linear by default), XP_CURVE_BASE,
+// XP_CURVE_FACTOR, XP_CURVE_TABLE as comma separated totals and
+// XP_MAX_LEVEL, falling back to the defaults when unset. The table sets
+// the max level of table curves.
+func LoadXPCurveConfig() XPCurveConfig {
+	curveConfig := XPCurveConfig{
+		Type:     stringFromEnv("XP_CURVE", XPCurveLinear),
+		Base:     intFromEnv("XP_CURVE_BASE", 100),
+		Factor:   floatFromEnv("XP_CURVE_FACTOR", 1.5),
+		MaxLevel: intFromEnv("XP_MAX_LEVEL", 100),
+	}
+
+	switch curveConfig.Type {
+	case XPCurveLinear:
+	case XPCurveExponential:
+		if curveConfig.Factor < 1 {
+			panic(fmt.Sprintf("invalid XP_CURVE_FACTOR: %v", curveConfig.Factor))
+		}
+	case XPCurveTable:
+		curveConfig.Table = xpTableFromEnv("XP_CURVE_TABLE")
+		curveConfig.MaxLevel = len(curveConfig.Table) + 1
+	default:
+		panic(fmt.Sprintf("invalid XP_CURVE: %q", os.Getenv("XP_CURVE")))
+	}
+
+	return curveConfig
+}
+
+// xpTableFromEnv parses increasing experience totals like "100,250,450".
+func xpTableFromEnv(key string) []int {
+	value := os.Getenv(key)
+	if value == "" {
+		panic(fmt.Sprintf("%s is required for table curves", key))
+	}
+
+	var table []int
+	for _, entry := range strings.Split(value, ",") {
+		total, err := strconv.Atoi(strings.TrimSpace(entry))
+		if err != nil || total <= 0 || (len(table) > 0 && total <= table[len(table)-1]) {
+			panic(fmt.Sprintf("invalid %s: %q", key, value))
+		}
+
+		table = append(table, total)
+	}
+
+	return table
+}
+
+func floatFromEnv(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil || floatValue <= 0 {
+		panic(fmt.Sprintf("invalid %s: %q", key, value))
+	}
+
+	return floatValue
+}
@@ -1,10 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/services"
 	"github.com/gin-gonic/gin"
 )
@@ -74,65 +76,58 @@ func (controller *AchievementController) CreateAchievement(ctx *gin.Context) {
 // GetAllAchievements godoc
 //
 //	@Summary		Get all achievements
-//	@Description	Get all achievements with pagination, default page is 1 and default pageSize is 10
+//	@Description	Get all achievements with pagination, default page is 1 and default pageSize is 10. Filter with parameters like name~=first (case-insensitive prefix search) on id and name. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before
 //	@Tags			Achievement
 //	@Accept			json
 //	@Produce		json
-//	@Param			page		query		int	false	"Page number"
-//	@Param			pageSize	query		int	false	"Page size"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			sort		query		string	false	"Sort fields, descending with a minus, like -id or name"
+//	@Param			after		query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before		query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total		query		bool	false	"Count the items matching the filters"
 //	@Success		200			{object}	response.BaseResponse{data=[]response.AchievementResponse}
 //	@Failure		400			{object}	response.BaseResponse
 //	@Failure		500			{object}	response.BaseResponse
 //	@Router			/achievements [get]
 //	@Security		BearerAuth
 func (controller *AchievementController) GetAllAchievements(ctx *gin.Context) {
-	page := ctx.DefaultQuery("page", "1")
-	pageSize := ctx.DefaultQuery("pageSize", "10")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid page",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeInt, err := strconv.Atoi(pageSize)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid pageSize",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	query, ok := listQuery(ctx)
+	if !ok {
 		return
 	}
 
-	achievements, err := controller.achievementService.GetAll(pageInt, pageSizeInt)
+	achievements, pagination, err := controller.achievementService.GetAll(pageRequest, query)
 	if err != nil {
+		status := 500
+		message := "Failed to get achievements"
+
+		if isListRequestError(err) {
+			status, message = 400, err.Error()
+		}
+
 		errorResponse := response.BaseResponse{
-			Code:    500,
+			Code:    status,
 			Status:  "Error",
-			Message: "Failed to get achievements",
+			Message: message,
 			Data:    nil,
 		}
 
-		ctx.JSON(500, errorResponse)
+		ctx.JSON(status, errorResponse)
 		return
 	}
 
 	webResponse := response.BaseResponse{
-		Code:    200,
-		Status:  "Success",
-		Message: "Achievements retrieved successfully",
-		Data:    achievements,
+		Code:       200,
+		Status:     "Success",
+		Message:    "Achievements retrieved successfully",
+		Data:       achievements,
+		Pagination: pagination,
 	}
 
 	ctx.JSON(200, webResponse)
@@ -312,18 +307,25 @@ func (controller *AchievementController) DeleteAchievement(ctx *gin.Context) {
 // GetAchievementWithPlayers godoc
 //
 //	@Summary		Get an achievement with players
-//	@Description	Get an achievement with players by ID
+//	@Description	Get an achievement with the players that unlocked it, paginated and sorted by unlock date, default page is 1, default pageSize is 10 and default order is desc
 //	@Tags			Achievement
 //	@Accept			json
 //	@Produce		json
-//	@Param			achievementID	path		int	true	"Achievement ID"
-//	@Success		200				{object}	response.BaseResponse
+//	@Param			achievementID	path		int		true	"Achievement ID"
+//	@Param			page			query		int		false	"Page number"
+//	@Param			pageSize		query		int		false	"Page size"
+//	@Param			order			query		string	false	"Sort order by unlock date"	Enums(asc, desc)
+//	@Success		200				{object}	response.BaseResponse{data=response.AchievementWithPlayers}
 //	@Failure		400				{object}	response.BaseResponse
+//	@Failure		404				{object}	response.BaseResponse
 //	@Failure		500				{object}	response.BaseResponse
 //	@Router			/achievements/{achievementID}/players [get]
 //	@Security		BearerAuth
 func (controller *AchievementController) GetAchievementWithPlayers(ctx *gin.Context) {
 	achievementID := ctx.Param("achievementID")
+	page := ctx.DefaultQuery("page", "1")
+	pageSize := ctx.DefaultQuery("pageSize", "10")
+	order := ctx.DefaultQuery("order", "desc")
 
 	achievementIDInt, err := strconv.Atoi(achievementID)
 	if err != nil {
@@ -338,8 +340,58 @@ func (controller *AchievementController) GetAchievementWithPlayers(ctx *gin.Cont
 		return
 	}
 
-	achievement, err := controller.achievementService.GetAchievementWithPlayers(uint(achievementIDInt))
+	pageInt, err := strconv.Atoi(page)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid page",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	pageSizeInt, err := strconv.Atoi(pageSize)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid pageSize",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	achievement, err := controller.achievementService.GetAchievementWithPlayers(uint(achievementIDInt), pageInt, pageSizeInt, order)
 	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidPagination) || errors.Is(err, helpers.ErrInvalidSortOrder) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		if errors.Is(err, helpers.ErrorAchievementNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
 		errorResponse := response.BaseResponse{
 			Code:    500,
 			Status:  "Error",
@@ -360,3 +412,159 @@ func (controller *AchievementController) GetAchievementWithPlayers(ctx *gin.Cont
 
 	ctx.JSON(200, webResponse)
 }
+
+// AwardAchievement godoc
+//
+//	@Summary		Award an achievement to a player
+//	@Description	Award an achievement to a player, awarding an achievement the player already has keeps the original unlock date
+//	@Tags			Achievement
+//	@Accept			json
+//	@Produce		json
+//	@Param			playerID		path		int	true	"Player ID"
+//	@Param			achievementID	path		int	true	"Achievement ID"
+//	@Success		200				{object}	response.BaseResponse{data=response.PlayerAchievementResponse}
+//	@Failure		400				{object}	response.BaseResponse
+//	@Failure		404				{object}	response.BaseResponse
+//	@Failure		500				{object}	response.BaseResponse
+//	@Router			/players/{playerID}/achievements/{achievementID} [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *AchievementController) AwardAchievement(ctx *gin.Context) {
+	playerIDInt, err := strconv.Atoi(ctx.Param("playerID"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	achievementIDInt, err := strconv.Atoi(ctx.Param("achievementID"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid achievementID",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	playerAchievement, err := controller.achievementService.AwardToPlayer(uint(playerIDInt), uint(achievementIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorPlayerProfileNotFound) || errors.Is(err, helpers.ErrorAchievementNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to award achievement",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Achievement awarded successfully",
+		Data:    playerAchievement,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// RevokeAchievement godoc
+//
+//	@Summary		Revoke an achievement from a player
+//	@Description	Revoke an achievement previously awarded to a player
+//	@Tags			Achievement
+//	@Accept			json
+//	@Produce		json
+//	@Param			playerID		path		int	true	"Player ID"
+//	@Param			achievementID	path		int	true	"Achievement ID"
+//	@Success		200				{object}	response.BaseResponse
+//	@Failure		400				{object}	response.BaseResponse
+//	@Failure		404				{object}	response.BaseResponse
+//	@Failure		500				{object}	response.BaseResponse
+//	@Router			/players/{playerID}/achievements/{achievementID} [delete]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *AchievementController) RevokeAchievement(ctx *gin.Context) {
+	playerIDInt, err := strconv.Atoi(ctx.Param("playerID"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	achievementIDInt, err := strconv.Atoi(ctx.Param("achievementID"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid achievementID",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.achievementService.RevokeFromPlayer(uint(playerIDInt), uint(achievementIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorPlayerAchievementNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to revoke achievement",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Achievement revoked successfully",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
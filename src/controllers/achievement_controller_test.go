@@ -9,6 +9,8 @@ import (
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -113,7 +115,7 @@ func TestAchievementController_GetAll(t *testing.T) {
 		router := gin.Default()
 		router.GET("/achievement", controller.GetAllAchievements)
 
-		mockAchievementService.On("GetAll", 1, 10).Return([]response.AchievementResponse{}, nil)
+		mockAchievementService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}).Return([]response.AchievementResponse{}, nil, nil)
 
 		req, err := http.NewRequest(http.MethodGet, "/achievement?page=1&pageSize=10", nil)
 		assert.NoError(t, err, "Expected no error creating request")
@@ -185,7 +187,7 @@ func TestAchievementController_GetAll(t *testing.T) {
 		router := gin.Default()
 		router.GET("/achievement", controller.GetAllAchievements)
 
-		mockAchievementService.On("GetAll", 1, 10).Return([]response.AchievementResponse{}, assert.AnError)
+		mockAchievementService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}).Return([]response.AchievementResponse{}, nil, assert.AnError)
 
 		req, err := http.NewRequest(http.MethodGet, "/achievement?page=1&pageSize=10", nil)
 		assert.NoError(t, err, "Expected no error creating request")
@@ -485,9 +487,9 @@ func TestAchievementController_GetAchievementWithPlayers(t *testing.T) {
 		router := gin.Default()
 		router.GET("/achievements/:achievementID/players", controller.GetAchievementWithPlayers)
 
-		mockAchievementService.On("GetAchievementWithPlayers", uint(1)).Return(&response.AchievementWithPlayers{}, nil)
+		mockAchievementService.On("GetAchievementWithPlayers", uint(1), 2, 5, "asc").Return(&response.AchievementWithPlayers{}, nil)
 
-		req, _ := http.NewRequest(http.MethodGet, "/achievements/1/players", nil)
+		req, _ := http.NewRequest(http.MethodGet, "/achievements/1/players?page=2&pageSize=5&order=asc", nil)
 		rec := httptest.NewRecorder()
 		router.ServeHTTP(rec, req)
 
@@ -521,7 +523,7 @@ func TestAchievementController_GetAchievementWithPlayers(t *testing.T) {
 		router := gin.Default()
 		router.GET("/achievements/:achievementID/players", controller.GetAchievementWithPlayers)
 
-		mockAchievementService.On("GetAchievementWithPlayers", uint(1)).Return(nil, assert.AnError)
+		mockAchievementService.On("GetAchievementWithPlayers", uint(1), 1, 10, "desc").Return(nil, assert.AnError)
 
 		req, _ := http.NewRequest(http.MethodGet, "/achievements/1/players", nil)
 		rec := httptest.NewRecorder()
@@ -530,4 +532,141 @@ func TestAchievementController_GetAchievementWithPlayers(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Status code should be 500")
 		mockAchievementService.AssertExpectations(t)
 	})
+
+	t.Run("GetAchievementWithPlayers_InvalidSortOrder", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.GET("/achievements/:achievementID/players", controller.GetAchievementWithPlayers)
+
+		mockAchievementService.On("GetAchievementWithPlayers", uint(1), 1, 10, "up").Return(nil, helpers.ErrInvalidSortOrder)
+
+		req, _ := http.NewRequest(http.MethodGet, "/achievements/1/players?order=up", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockAchievementService.AssertExpectations(t)
+	})
+
+	t.Run("GetAchievementWithPlayers_NotFound", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.GET("/achievements/:achievementID/players", controller.GetAchievementWithPlayers)
+
+		mockAchievementService.On("GetAchievementWithPlayers", uint(1), 1, 10, "desc").Return(nil, helpers.ErrorAchievementNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/achievements/1/players", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Status code should be 404")
+		mockAchievementService.AssertExpectations(t)
+	})
+}
+
+func TestAchievementController_AwardAchievement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("AwardAchievement_Success", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.POST("/players/:playerID/achievements/:achievementID", controller.AwardAchievement)
+
+		mockAchievementService.On("AwardToPlayer", uint(1), uint(2)).Return(&response.PlayerAchievementResponse{
+			PlayerID:      1,
+			AchievementID: 2,
+			NewlyUnlocked: true,
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/players/1/achievements/2", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		assert.Contains(t, rec.Body.String(), "\"newly_unlocked\":true")
+		mockAchievementService.AssertExpectations(t)
+	})
+
+	t.Run("AwardAchievement_InvalidID", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.POST("/players/:playerID/achievements/:achievementID", controller.AwardAchievement)
+
+		req, _ := http.NewRequest(http.MethodPost, "/players/1/achievements/abc", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockAchievementService.AssertNotCalled(t, "AwardToPlayer")
+	})
+
+	t.Run("AwardAchievement_NotFound", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.POST("/players/:playerID/achievements/:achievementID", controller.AwardAchievement)
+
+		mockAchievementService.On("AwardToPlayer", uint(1), uint(2)).Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		req, _ := http.NewRequest(http.MethodPost, "/players/1/achievements/2", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Status code should be 404")
+		mockAchievementService.AssertExpectations(t)
+	})
+
+	t.Run("AwardAchievement_FailedToAward", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.POST("/players/:playerID/achievements/:achievementID", controller.AwardAchievement)
+
+		mockAchievementService.On("AwardToPlayer", uint(1), uint(2)).Return(nil, assert.AnError)
+
+		req, _ := http.NewRequest(http.MethodPost, "/players/1/achievements/2", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Status code should be 500")
+		mockAchievementService.AssertExpectations(t)
+	})
+}
+
+func TestAchievementController_RevokeAchievement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("RevokeAchievement_Success", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.DELETE("/players/:playerID/achievements/:achievementID", controller.RevokeAchievement)
+
+		mockAchievementService.On("RevokeFromPlayer", uint(1), uint(2)).Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/players/1/achievements/2", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		mockAchievementService.AssertExpectations(t)
+	})
+
+	t.Run("RevokeAchievement_NotAwarded", func(t *testing.T) {
+		mockAchievementService := new(mocks.MockAchievementService)
+		controller := NewAchievementController(mockAchievementService)
+		router := gin.Default()
+		router.DELETE("/players/:playerID/achievements/:achievementID", controller.RevokeAchievement)
+
+		mockAchievementService.On("RevokeFromPlayer", uint(1), uint(2)).Return(helpers.ErrorPlayerAchievementNotFound)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/players/1/achievements/2", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Status code should be 404")
+		mockAchievementService.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyController struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyController(service services.APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: service,
+	}
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create an API key
+//	@Description	Create an API key for a game server or backend integration, only admins can do it. The key is only shown in this response
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateAPIKeyRequest	true	"Create API Key Request"
+//	@Success		201		{object}	response.BaseResponse{data=response.CreatedAPIKeyResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/api-keys [post]
+//	@Security		BearerAuth
+func (controller *APIKeyController) CreateAPIKey(ctx *gin.Context) {
+	createRequest := request.CreateAPIKeyRequest{}
+
+	err := ctx.ShouldBindJSON(&createRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	created, err := controller.apiKeyService.CreateAPIKey(ctx.GetUint("userID"), createRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrAPIKeyDataValidation) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to create api key",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    201,
+		Status:  "Success",
+		Message: "API key created, store it now, it won't be shown again",
+		Data:    created,
+	}
+
+	ctx.JSON(201, webResponse)
+}
+
+// GetAllAPIKeys godoc
+//
+//	@Summary		List the API keys
+//	@Description	All the API keys, revoked ones included, only admins can see them
+//	@Tags			API Keys
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=[]response.APIKeyResponse}
+//	@Failure		403	{object}	response.BaseResponse
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/api-keys [get]
+//	@Security		BearerAuth
+func (controller *APIKeyController) GetAllAPIKeys(ctx *gin.Context) {
+	apiKeys, err := controller.apiKeyService.GetAllAPIKeys()
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to get api keys",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "API keys found",
+		Data:    apiKeys,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke an API key
+//	@Description	The key stops working right away, only admins can do it
+//	@Tags			API Keys
+//	@Produce		json
+//	@Param			apiKeyID	path		int	true	"API Key ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		403			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/api-keys/{apiKeyID} [delete]
+//	@Security		BearerAuth
+func (controller *APIKeyController) RevokeAPIKey(ctx *gin.Context) {
+	apiKeyIDInt, err := strconv.Atoi(ctx.Param("apiKeyID"))
+	if err != nil || apiKeyIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidAPIKeyID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.apiKeyService.RevokeAPIKey(uint(apiKeyIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorAPIKeyNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to revoke api key",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "API key revoked",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *APIKeyController) AuthenticateAPIKeyFromService(key string) (*response.APIKeyResponse, error) {
+	return controller.apiKeyService.Authenticate(key)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyController_CreateAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(apiKeyController *APIKeyController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/api-keys", func(ctx *gin.Context) {
+			ctx.Set("userID", uint(1))
+			ctx.Next()
+		}, apiKeyController.CreateAPIKey)
+		return router
+	}
+
+	t.Run("CreateAPIKey_Success", func(t *testing.T) {
+		mockAPIKeyService := new(mocks.MockAPIKeyService)
+		apiKeyController := NewAPIKeyController(mockAPIKeyService)
+
+		createRequest := request.CreateAPIKeyRequest{Name: "eu-west", Scopes: []string{"achievements:award"}}
+		mockAPIKeyService.On("CreateAPIKey", uint(1), createRequest).Return(&response.CreatedAPIKeyResponse{Key: "pp_abc.secret"}, nil)
+
+		body, _ := json.Marshal(createRequest)
+		req, err := http.NewRequest(http.MethodPost, "/api-keys", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(apiKeyController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, "Expected status code 201")
+		assert.Contains(t, rec.Body.String(), "pp_abc.secret")
+		mockAPIKeyService.AssertExpectations(t)
+	})
+
+	t.Run("CreateAPIKey_ValidationError", func(t *testing.T) {
+		mockAPIKeyService := new(mocks.MockAPIKeyService)
+		apiKeyController := NewAPIKeyController(mockAPIKeyService)
+
+		createRequest := request.CreateAPIKeyRequest{Name: "eu-west", Scopes: []string{"users:delete"}}
+		mockAPIKeyService.On("CreateAPIKey", uint(1), createRequest).Return(nil, helpers.ErrAPIKeyDataValidation)
+
+		body, _ := json.Marshal(createRequest)
+		req, err := http.NewRequest(http.MethodPost, "/api-keys", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(apiKeyController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
+
+func TestAPIKeyController_GetAllAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAPIKeyService := new(mocks.MockAPIKeyService)
+	apiKeyController := NewAPIKeyController(mockAPIKeyService)
+	router := gin.Default()
+	router.GET("/api-keys", apiKeyController.GetAllAPIKeys)
+
+	mockAPIKeyService.On("GetAllAPIKeys").Return([]response.APIKeyResponse{{ID: 1, Name: "eu-west", Prefix: "pp_abc"}}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api-keys", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+	assert.Contains(t, rec.Body.String(), "pp_abc")
+}
+
+func TestAPIKeyController_RevokeAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		path   string
+		err    error
+		status int
+	}{
+		"Success":      {"/api-keys/1", nil, http.StatusOK},
+		"NotFound":     {"/api-keys/1", helpers.ErrorAPIKeyNotFound, http.StatusNotFound},
+		"InvalidID":    {"/api-keys/abc", nil, http.StatusBadRequest},
+		"ServiceError": {"/api-keys/1", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for name, tc := range cases {
+		t.Run("RevokeAPIKey_"+name, func(t *testing.T) {
+			mockAPIKeyService := new(mocks.MockAPIKeyService)
+			apiKeyController := NewAPIKeyController(mockAPIKeyService)
+			router := gin.Default()
+			router.DELETE("/api-keys/:apiKeyID", apiKeyController.RevokeAPIKey)
+
+			mockAPIKeyService.On("RevokeAPIKey", uint(1)).Return(tc.err)
+
+			req, err := http.NewRequest(http.MethodDelete, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/services"
 	"github.com/gin-gonic/gin"
 )
@@ -25,8 +30,11 @@ func NewAuthController(service services.AuthService) *AuthController {
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		request.LoginRequest	true	"Login Request"
-//	@Success		200		{object}	response.BaseResponse
+//	@Success		200		{object}	response.BaseResponse{data=response.LoginResponse}
 //	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		429		{object}	response.BaseResponse
 //	@Failure		500		{object}	response.BaseResponse
 //	@Router			/login [post]
 func (controller *AuthController) Login(ctx *gin.Context) {
@@ -45,8 +53,24 @@ func (controller *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	loginResponse, err := controller.authService.Login(loginRequest)
+	loginResponse, err := controller.authService.Login(loginRequest, clientInfo(ctx))
 	if err != nil {
+		if writeLoginLimitError(ctx, err) {
+			return
+		}
+
+		if errors.Is(err, helpers.ErrEmailNotVerified) {
+			errorResponse := response.BaseResponse{
+				Code:    403,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(403, errorResponse)
+			return
+		}
+
 		errorResponse := response.BaseResponse{
 			Code:    500,
 			Status:  "Error",
@@ -70,14 +94,416 @@ func (controller *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
+	if loginResponse.TwoFactorRequired {
+		webResponse := response.BaseResponse{
+			Code:    200,
+			Status:  "Success",
+			Message: "Two factor authentication required",
+			Data:    loginResponse,
+		}
+
+		ctx.JSON(200, webResponse)
+		return
+	}
+
 	ctx.Header("Authorization", "Bearer "+loginResponse.Token)
 
 	webResponse := response.BaseResponse{
 		Code:    200,
 		Status:  "Success",
 		Message: "Login successful",
+		Data:    loginResponse,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// VerifyTwoFactor godoc
+//
+//	@Summary		Finish a two factor login
+//	@Description	Exchange the challenge token returned by the login and a TOTP or recovery code for the tokens. The challenge can only be tried once
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.TwoFactorLoginRequest	true	"Two Factor Login Request"
+//	@Success		200		{object}	response.BaseResponse{data=response.LoginResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		429		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/2fa/verify [post]
+func (controller *AuthController) VerifyTwoFactor(ctx *gin.Context) {
+	verifyRequest := request.TwoFactorLoginRequest{}
+
+	err := ctx.ShouldBindJSON(&verifyRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	loginResponse, err := controller.authService.VerifyTwoFactor(verifyRequest, clientInfo(ctx))
+	if err != nil {
+		if writeLoginLimitError(ctx, err) {
+			return
+		}
+
+		if errors.Is(err, helpers.ErrTwoFactorDataValidation) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		if errors.Is(err, helpers.ErrInvalidTwoFactorChallenge) || errors.Is(err, helpers.ErrInvalidTwoFactorCode) || errors.Is(err, helpers.ErrTwoFactorNotEnabled) {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to login",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	ctx.Header("Authorization", "Bearer "+loginResponse.Token)
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Login successful",
+		Data:    loginResponse,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh the access token
+//	@Description	Exchange a refresh token for a new access token and a new refresh token, the presented refresh token can't be used again
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.RefreshTokenRequest	true	"Refresh Token Request"
+//	@Success		200		{object}	response.BaseResponse{data=response.LoginResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/refresh [post]
+func (controller *AuthController) Refresh(ctx *gin.Context) {
+	refreshRequest := request.RefreshTokenRequest{}
+
+	err := ctx.ShouldBindJSON(&refreshRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	refreshResponse, err := controller.authService.Refresh(refreshRequest, clientInfo(ctx))
+	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidRefreshToken) || errors.Is(err, helpers.ErrRefreshTokenReused) {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to refresh token",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	ctx.Header("Authorization", "Bearer "+refreshResponse.Token)
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Token refreshed successfully",
+		Data:    refreshResponse,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Logout godoc
+//
+//	@Summary		Logout from the application
+//	@Description	End the session of the access token used in the request, the refresh token is revoked too when it is sent in the body
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.LogoutRequest	false	"Logout Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/logout [post]
+//	@Security		BearerAuth
+func (controller *AuthController) Logout(ctx *gin.Context) {
+	logoutRequest := request.LogoutRequest{}
+
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&logoutRequest)
+		if err != nil {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: "Invalid request body",
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+	}
+
+	userID := ctx.GetUint("userID")
+	sessionID := ctx.GetUint("sessionID")
+	tokenID := ctx.GetString("tokenID")
+	expiresAt := ctx.GetTime("tokenExpiresAt")
+
+	err := controller.authService.Logout(userID, sessionID, tokenID, expiresAt, logoutRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to logout",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Logout successful",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// LogoutAll godoc
+//
+//	@Summary		Logout all the sessions of a user
+//	@Description	Revoke every access and refresh token issued to the user, only the user or an admin can do it
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			userID	path		int	true	"User ID"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/logout [post]
+//	@Security		BearerAuth
+func (controller *AuthController) LogoutAll(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+
+	userIDInt, err := strconv.Atoi(userID)
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.authService.LogoutAll(uint(userIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to logout all sessions",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "All sessions logged out",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// UnlockAccount godoc
+//
+//	@Summary		Unlock a user account
+//	@Description	Clear the failed login attempts of a user, lifting the lockout. Only admins can do it
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			userID	path		int	true	"User ID"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/unlock [post]
+//	@Security		BearerAuth
+func (controller *AuthController) UnlockAccount(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+
+	userIDInt, err := strconv.Atoi(userID)
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.authService.UnlockAccount(uint(userIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to unlock account",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Account unlocked",
 		Data:    nil,
 	}
 
 	ctx.JSON(200, webResponse)
 }
+
+// JWKS serves the public keys used to sign access tokens as a JSON Web Key Set,
+// so game servers and other services can verify tokens without the secret.
+// The body is the raw key set, not a BaseResponse, as expected by JWT libraries.
+func (controller *AuthController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(200, controller.authService.JWKS())
+}
+
+// writeLoginLimitError answers the errors shared by every login step: a bad
+// password or unknown email is a plain 401, and a throttled client gets a 429
+// with the seconds to wait in the Retry-After header.
+func writeLoginLimitError(ctx *gin.Context, err error) bool {
+	if errors.Is(err, helpers.ErrInvalidCredentials) {
+		errorResponse := response.BaseResponse{
+			Code:    401,
+			Status:  "Unauthorized",
+			Message: err.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(401, errorResponse)
+		return true
+	}
+
+	var retryAfterErr *helpers.RetryAfterError
+	if errors.As(err, &retryAfterErr) && errors.Is(err, helpers.ErrTooManyLoginAttempts) {
+		retryAfter := int(math.Ceil(retryAfterErr.RetryAfter.Seconds()))
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+
+		errorResponse := response.BaseResponse{
+			Code:    429,
+			Status:  "Error",
+			Message: helpers.ErrTooManyLoginAttempts.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(429, errorResponse)
+		return true
+	}
+
+	return false
+}
+
+// clientInfo describes the client of the request for the session list.
+func clientInfo(ctx *gin.Context) request.ClientInfo {
+	return request.ClientInfo{
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
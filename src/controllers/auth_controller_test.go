@@ -6,12 +6,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthController_Login(t *testing.T) {
@@ -44,7 +48,7 @@ func TestAuthController_Login(t *testing.T) {
 			Email:    "test@test.com",
 			Password: "password123456",
 		}
-		mockAuthService.On("Login", loginReq).Return(&response.LoginResponse{
+		mockAuthService.On("Login", loginReq, mock.Anything).Return(&response.LoginResponse{
 			Token: "token",
 		}, nil)
 
@@ -69,7 +73,7 @@ func TestAuthController_Login(t *testing.T) {
 			Password: "invalid",
 		}
 
-		mockAuthService.On("Login", loginReq).Return(&response.LoginResponse{}, assert.AnError)
+		mockAuthService.On("Login", loginReq, mock.Anything).Return(&response.LoginResponse{}, assert.AnError)
 
 		body, err := json.Marshal(loginReq)
 		assert.Nil(t, err)
@@ -89,4 +93,518 @@ func TestAuthController_Login(t *testing.T) {
 		assert.Nil(t, response.Data)
 
 	})
+
+	t.Run("Login_EmailNotVerified", func(t *testing.T) {
+		loginReq := request.LoginRequest{
+			Email:    "unverified@test.com",
+			Password: "password123456",
+		}
+
+		mockAuthService.On("Login", loginReq, mock.Anything).Return((*response.LoginResponse)(nil), helpers.ErrEmailNotVerified)
+
+		body, err := json.Marshal(loginReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, "Expected status code 403")
+	})
+
+	t.Run("Login_InvalidCredentials", func(t *testing.T) {
+		loginReq := request.LoginRequest{
+			Email:    "unknown@test.com",
+			Password: "password123456",
+		}
+
+		mockAuthService.On("Login", loginReq, mock.Anything).Return((*response.LoginResponse)(nil), helpers.ErrInvalidCredentials)
+
+		body, err := json.Marshal(loginReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var response response.BaseResponse
+		err = json.NewDecoder(rec.Body).Decode(&response)
+		assert.Nil(t, err, "Expected no error decoding response")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		assert.Equal(t, helpers.ErrInvalidCredentials.Error(), response.Message)
+	})
+
+	t.Run("Login_TooManyAttempts", func(t *testing.T) {
+		loginReq := request.LoginRequest{
+			Email:    "locked@test.com",
+			Password: "password123456",
+		}
+
+		throttledErr := &helpers.RetryAfterError{Err: helpers.ErrTooManyLoginAttempts, RetryAfter: 29*time.Second + 200*time.Millisecond}
+		mockAuthService.On("Login", loginReq, mock.Anything).Return((*response.LoginResponse)(nil), throttledErr)
+
+		body, err := json.Marshal(loginReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code, "Expected status code 429")
+		assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	})
+}
+
+func TestAuthController_Refresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Refresh_Success", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/auth/refresh", authController.Refresh)
+
+		refreshReq := request.RefreshTokenRequest{RefreshToken: "refresh-token"}
+		mockAuthService.On("Refresh", refreshReq, mock.Anything).Return(&response.LoginResponse{
+			Token:        "token",
+			RefreshToken: "new-refresh-token",
+			ExpiresIn:    900,
+		}, nil)
+
+		body, err := json.Marshal(refreshReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Equal(t, "Bearer token", rec.Header().Get("Authorization"))
+		assert.Contains(t, rec.Body.String(), "new-refresh-token")
+
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_InvalidBody", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/auth/refresh", authController.Refresh)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString("invalid"))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockAuthService.AssertNotCalled(t, "Refresh")
+	})
+
+	t.Run("Refresh_ReusedToken", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/auth/refresh", authController.Refresh)
+
+		refreshReq := request.RefreshTokenRequest{RefreshToken: "used-token"}
+		mockAuthService.On("Refresh", refreshReq, mock.Anything).Return(nil, helpers.ErrRefreshTokenReused)
+
+		body, err := json.Marshal(refreshReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_InternalError", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/auth/refresh", authController.Refresh)
+
+		refreshReq := request.RefreshTokenRequest{RefreshToken: "refresh-token"}
+		mockAuthService.On("Refresh", refreshReq, mock.Anything).Return(nil, assert.AnError)
+
+		body, err := json.Marshal(refreshReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+		mockAuthService.AssertExpectations(t)
+	})
+}
+
+func TestAuthController_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expiresAt := time.Now().Add(time.Hour)
+
+	setupRouter := func(authController *AuthController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/auth/logout", func(ctx *gin.Context) {
+			ctx.Set("userID", uint(1))
+			ctx.Set("sessionID", uint(2))
+			ctx.Set("tokenID", "token-id")
+			ctx.Set("tokenExpiresAt", expiresAt)
+			ctx.Next()
+		}, authController.Logout)
+		return router
+	}
+
+	t.Run("Logout_Success", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		router := setupRouter(NewAuthController(mockAuthService))
+
+		mockAuthService.On("Logout", uint(1), uint(2), "token-id", expiresAt, request.LogoutRequest{}).Return(nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/logout", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Logout_WithRefreshToken", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		router := setupRouter(NewAuthController(mockAuthService))
+
+		logoutReq := request.LogoutRequest{RefreshToken: "refresh-token"}
+		mockAuthService.On("Logout", uint(1), uint(2), "token-id", expiresAt, logoutReq).Return(nil)
+
+		body, err := json.Marshal(logoutReq)
+		assert.Nil(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Logout_InvalidBody", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		router := setupRouter(NewAuthController(mockAuthService))
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/logout", bytes.NewBufferString("invalid"))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockAuthService.AssertNotCalled(t, "Logout")
+	})
+
+	t.Run("Logout_Fail", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		router := setupRouter(NewAuthController(mockAuthService))
+
+		mockAuthService.On("Logout", uint(1), uint(2), "token-id", expiresAt, request.LogoutRequest{}).Return(assert.AnError)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/logout", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+		mockAuthService.AssertExpectations(t)
+	})
+}
+
+func TestAuthController_LogoutAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("LogoutAll_Success", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/logout", authController.LogoutAll)
+
+		mockAuthService.On("LogoutAll", uint(1)).Return(nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/1/logout", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("LogoutAll_InvalidUserID", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/logout", authController.LogoutAll)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/abc/logout", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockAuthService.AssertNotCalled(t, "LogoutAll")
+	})
+
+	t.Run("LogoutAll_UserNotFound", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/logout", authController.LogoutAll)
+
+		mockAuthService.On("LogoutAll", uint(1)).Return(helpers.ErrorUserNotFound)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/1/logout", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+		mockAuthService.AssertExpectations(t)
+	})
+}
+
+func TestAuthController_UnlockAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("UnlockAccount_Success", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/unlock", authController.UnlockAccount)
+
+		mockAuthService.On("UnlockAccount", uint(1)).Return(nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/1/unlock", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("UnlockAccount_InvalidUserID", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/unlock", authController.UnlockAccount)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/abc/unlock", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockAuthService.AssertNotCalled(t, "UnlockAccount")
+	})
+
+	t.Run("UnlockAccount_UserNotFound", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+		router := gin.Default()
+		router.POST("/users/:userID/unlock", authController.UnlockAccount)
+
+		mockAuthService.On("UnlockAccount", uint(1)).Return(helpers.ErrorUserNotFound)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/1/unlock", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+		mockAuthService.AssertExpectations(t)
+	})
+}
+
+func TestAuthController_JWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAuthService := new(mocks.MockAuthService)
+	authController := NewAuthController(mockAuthService)
+	router := gin.Default()
+	router.GET("/.well-known/jwks.json", authController.JWKS)
+
+	mockAuthService.On("JWKS").Return(auth.JWKS{Keys: []auth.JWK{{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "key", Crv: "Ed25519", X: "abc"}}})
+
+	req, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+
+	var jwks auth.JWKS
+	err = json.Unmarshal(rec.Body.Bytes(), &jwks)
+	assert.NoError(t, err, "Expected no error decoding key set")
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "key", jwks.Keys[0].Kid)
+
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestAuthController_TwoFactor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Login_TwoFactorRequired", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+
+		router := gin.Default()
+		router.POST("/login", authController.Login)
+
+		loginReq := request.LoginRequest{Email: "test@test.com", Password: "password123456"}
+		mockAuthService.On("Login", loginReq, mock.Anything).Return(&response.LoginResponse{TwoFactorRequired: true, ChallengeToken: "challenge"}, nil)
+
+		body, err := json.Marshal(loginReq)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Empty(t, rec.Header().Get("Authorization"), "Expected no bearer token before the second step")
+		assert.Contains(t, rec.Body.String(), `"challenge_token":"challenge"`)
+	})
+
+	t.Run("VerifyTwoFactor_Success", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+
+		router := gin.Default()
+		router.POST("/auth/2fa/verify", authController.VerifyTwoFactor)
+
+		verifyReq := request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}
+		mockAuthService.On("VerifyTwoFactor", verifyReq, mock.Anything).Return(&response.LoginResponse{Token: "token", RefreshToken: "refresh"}, nil)
+
+		body, err := json.Marshal(verifyReq)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/verify", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Equal(t, "Bearer token", rec.Header().Get("Authorization"))
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("VerifyTwoFactor_InvalidCode", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+
+		router := gin.Default()
+		router.POST("/auth/2fa/verify", authController.VerifyTwoFactor)
+
+		verifyReq := request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "000000"}
+		mockAuthService.On("VerifyTwoFactor", verifyReq, mock.Anything).Return(nil, helpers.ErrInvalidTwoFactorCode)
+
+		body, err := json.Marshal(verifyReq)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/verify", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+	})
+
+	t.Run("VerifyTwoFactor_InvalidBody", func(t *testing.T) {
+		mockAuthService := new(mocks.MockAuthService)
+		authController := NewAuthController(mockAuthService)
+
+		router := gin.Default()
+		router.POST("/auth/2fa/verify", authController.VerifyTwoFactor)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/verify", bytes.NewBufferString("{"))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
+
+func TestClientInfo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		router := gin.Default()
+		assert.NoError(t, router.SetTrustedProxies(trustedProxies))
+		router.GET("/client", func(ctx *gin.Context) {
+			ctx.String(http.StatusOK, clientInfo(ctx).IP)
+		})
+		return router
+	}
+
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		expectedIP     string
+	}{
+		{name: "ClientInfo_NoTrustedProxies", trustedProxies: nil, expectedIP: "10.0.0.1"},
+		{name: "ClientInfo_TrustedProxy", trustedProxies: []string{"10.0.0.0/8"}, expectedIP: "203.0.113.7"},
+		{name: "ClientInfo_UntrustedProxy", trustedProxies: []string{"192.168.0.1"}, expectedIP: "10.0.0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/client", nil)
+			assert.NoError(t, err, "Expected no error creating request")
+			req.RemoteAddr = "10.0.0.1:4321"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+			rec := httptest.NewRecorder()
+			newRouter(tc.trustedProxies).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedIP, rec.Body.String())
+		})
+	}
 }
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationController struct {
+	emailVerificationService services.EmailVerificationService
+}
+
+func NewEmailVerificationController(service services.EmailVerificationService) *EmailVerificationController {
+	return &EmailVerificationController{
+		emailVerificationService: service,
+	}
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify an email
+//	@Description	Mark the email of the user as verified using the token received by email
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			token	query		string	true	"Verification token"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/verify [get]
+func (controller *EmailVerificationController) VerifyEmail(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	err := controller.emailVerificationService.VerifyEmail(token)
+	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidVerificationToken) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to verify email",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Email verified successfully",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// ResendVerificationEmail godoc
+//
+//	@Summary		Resend the verification email
+//	@Description	Send a new verification link, at most once per configured interval. The response is the same whether the email is registered or not
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ResendVerificationRequest	true	"Resend Verification Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/verify/resend [post]
+func (controller *EmailVerificationController) ResendVerificationEmail(ctx *gin.Context) {
+	resendRequest := request.ResendVerificationRequest{}
+
+	err := ctx.ShouldBindJSON(&resendRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.emailVerificationService.ResendVerificationEmail(resendRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrVerificationDataValidation) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to resend verification email",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "If the email is registered and not verified you will receive a new verification link",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
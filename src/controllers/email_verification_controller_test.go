@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerificationController_VerifyEmail(t *testing.T) {
+	t.Run("VerifyEmail_Success", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.GET("/auth/verify", verificationController.VerifyEmail)
+
+		mockVerificationService.On("VerifyEmail", "token").Return(nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/verify?token=token", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockVerificationService.AssertExpectations(t)
+	})
+
+	t.Run("VerifyEmail_InvalidToken", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.GET("/auth/verify", verificationController.VerifyEmail)
+
+		mockVerificationService.On("VerifyEmail", "").Return(helpers.ErrInvalidVerificationToken)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/verify", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+
+	t.Run("VerifyEmail_InternalError", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.GET("/auth/verify", verificationController.VerifyEmail)
+
+		mockVerificationService.On("VerifyEmail", "token").Return(assert.AnError)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/verify?token=token", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+}
+
+func TestEmailVerificationController_ResendVerificationEmail(t *testing.T) {
+	t.Run("ResendVerificationEmail_Success", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.POST("/auth/verify/resend", verificationController.ResendVerificationEmail)
+
+		resendRequest := request.ResendVerificationRequest{Email: "test@test.com"}
+		mockVerificationService.On("ResendVerificationEmail", resendRequest).Return(nil)
+
+		body, err := json.Marshal(resendRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/verify/resend", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockVerificationService.AssertExpectations(t)
+	})
+
+	t.Run("ResendVerificationEmail_ValidationError", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.POST("/auth/verify/resend", verificationController.ResendVerificationEmail)
+
+		resendRequest := request.ResendVerificationRequest{Email: "invalid"}
+		mockVerificationService.On("ResendVerificationEmail", resendRequest).Return(helpers.ErrVerificationDataValidation)
+
+		body, err := json.Marshal(resendRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/verify/resend", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+
+	t.Run("ResendVerificationEmail_SendFails", func(t *testing.T) {
+		mockVerificationService := new(mocks.MockEmailVerificationService)
+		verificationController := NewEmailVerificationController(mockVerificationService)
+
+		router := gin.Default()
+		router.POST("/auth/verify/resend", verificationController.ResendVerificationEmail)
+
+		resendRequest := request.ResendVerificationRequest{Email: "test@test.com"}
+		mockVerificationService.On("ResendVerificationEmail", resendRequest).Return(helpers.ErrSendEmail)
+
+		body, err := json.Marshal(resendRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/verify/resend", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+}
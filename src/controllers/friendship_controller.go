@@ -0,0 +1,472 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type FriendshipController struct {
+	friendshipService services.FriendshipService
+}
+
+func NewFriendshipController(service services.FriendshipService) *FriendshipController {
+	return &FriendshipController{
+		friendshipService: service,
+	}
+}
+
+// SendFriendRequest godoc
+//
+//	@Summary		Send a friend request
+//	@Description	Send a friend request from the player to another player. Players that blocked each other, friends and players with a pending request either way can't send one
+//	@Tags			Friend
+//	@Accept			json
+//	@Produce		json
+//	@Param			playerID		path		int									true	"Player ID"
+//	@Param			friendRequest	body		request.SendFriendRequestRequest	true	"Player to befriend"
+//	@Success		201				{object}	response.BaseResponse{data=response.FriendRequestResponse}
+//	@Failure		400				{object}	response.BaseResponse
+//	@Failure		403				{object}	response.BaseResponse
+//	@Failure		404				{object}	response.BaseResponse
+//	@Failure		409				{object}	response.BaseResponse
+//	@Failure		500				{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friend-requests [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) SendFriendRequest(ctx *gin.Context) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	friendRequest := request.SendFriendRequestRequest{}
+
+	err := ctx.ShouldBindJSON(&friendRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	sent, err := controller.friendshipService.SendFriendRequest(playerID, friendRequest)
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, "Failed to send friend request")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    201,
+		Status:  "Success",
+		Message: "Friend request sent successfully",
+		Data:    sent,
+	}
+
+	ctx.JSON(201, webResponse)
+}
+
+// GetFriendRequests godoc
+//
+//	@Summary		Get the friend requests of a player
+//	@Description	Pending friend requests received or sent by the player sorted by id, paged like the friends of a player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int		true	"Player ID"
+//	@Param			direction	query		string	false	"incoming (default) or outgoing"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			after		query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before		query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total		query		bool	false	"Count the friend requests"
+//	@Success		200			{object}	response.BaseResponse{data=[]response.FriendRequestResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friend-requests [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) GetFriendRequests(ctx *gin.Context) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
+		return
+	}
+
+	friendRequests, pagination, err := controller.friendshipService.GetFriendRequests(playerID, ctx.DefaultQuery("direction", models.FriendRequestsIncoming), pageRequest)
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, "Failed to get friend requests")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:       200,
+		Status:     "Success",
+		Message:    "Friend requests fetched successfully",
+		Data:       friendRequests,
+		Pagination: pagination,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// AcceptFriendRequest godoc
+//
+//	@Summary		Accept a friend request
+//	@Description	Accept a friend request received by the player, both players become friends
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			requestID	path		int	true	"Friend request ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friend-requests/{requestID}/accept [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) AcceptFriendRequest(ctx *gin.Context) {
+	controller.answerFriendRequest(ctx, controller.friendshipService.AcceptFriendRequest, "Friend request accepted successfully", "Failed to accept friend request")
+}
+
+// DeclineFriendRequest godoc
+//
+//	@Summary		Decline a friend request
+//	@Description	Decline a friend request received by the player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			requestID	path		int	true	"Friend request ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friend-requests/{requestID}/decline [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) DeclineFriendRequest(ctx *gin.Context) {
+	controller.answerFriendRequest(ctx, controller.friendshipService.DeclineFriendRequest, "Friend request declined successfully", "Failed to decline friend request")
+}
+
+// CancelFriendRequest godoc
+//
+//	@Summary		Cancel a friend request
+//	@Description	Cancel a friend request sent by the player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			requestID	path		int	true	"Friend request ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friend-requests/{requestID} [delete]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) CancelFriendRequest(ctx *gin.Context) {
+	controller.answerFriendRequest(ctx, controller.friendshipService.CancelFriendRequest, "Friend request cancelled successfully", "Failed to cancel friend request")
+}
+
+// GetFriends godoc
+//
+//	@Summary		Get the friends of a player
+//	@Description	Friends of the player sorted by id, paged by page and pageSize or with the next_cursor and prev_cursor of the pagination as after and before
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int		true	"Player ID"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			after		query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before		query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total		query		bool	false	"Count the friends"
+//	@Success		200			{object}	response.BaseResponse{data=[]response.PlayerProfileResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friends [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) GetFriends(ctx *gin.Context) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
+		return
+	}
+
+	friends, pagination, err := controller.friendshipService.GetFriends(playerID, pageRequest)
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, "Failed to get friends")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:       200,
+		Status:     "Success",
+		Message:    "Friends fetched successfully",
+		Data:       friends,
+		Pagination: pagination,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetMutualFriends godoc
+//
+//	@Summary		Get the mutual friends of two players
+//	@Description	Players that are friends of both players sorted by id, paged like the friends of a player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID		path		int		true	"Player ID"
+//	@Param			otherPlayerID	path		int		true	"Other player ID"
+//	@Param			page			query		int		false	"Page number"
+//	@Param			pageSize		query		int		false	"Page size"
+//	@Param			after			query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before			query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total			query		bool	false	"Count the mutual friends"
+//	@Success		200				{object}	response.BaseResponse{data=[]response.PlayerProfileResponse}
+//	@Failure		400				{object}	response.BaseResponse
+//	@Failure		500				{object}	response.BaseResponse
+//	@Router			/players/{playerID}/mutual-friends/{otherPlayerID} [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) GetMutualFriends(ctx *gin.Context) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	otherPlayerID, ok := pathID(ctx, "otherPlayerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
+		return
+	}
+
+	friends, pagination, err := controller.friendshipService.GetMutualFriends(playerID, otherPlayerID, pageRequest)
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, "Failed to get mutual friends")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:       200,
+		Status:     "Success",
+		Message:    "Mutual friends fetched successfully",
+		Data:       friends,
+		Pagination: pagination,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Unfriend godoc
+//
+//	@Summary		Unfriend a player
+//	@Description	Remove a player from the friends of the player, for both of them
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			friendID	path		int	true	"Friend ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/friends/{friendID} [delete]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) Unfriend(ctx *gin.Context) {
+	controller.actOnPlayer(ctx, "friendID", controller.friendshipService.Unfriend, "Friend removed successfully", "Failed to remove friend")
+}
+
+// GetBlockedPlayers godoc
+//
+//	@Summary		Get the players blocked by a player
+//	@Description	Players blocked by the player sorted by id, paged like the friends of a player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int		true	"Player ID"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			after		query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before		query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total		query		bool	false	"Count the blocked players"
+//	@Success		200			{object}	response.BaseResponse{data=[]response.PlayerProfileResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/blocks [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) GetBlockedPlayers(ctx *gin.Context) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
+		return
+	}
+
+	blocked, pagination, err := controller.friendshipService.GetBlockedPlayers(playerID, pageRequest)
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, "Failed to get blocked players")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:       200,
+		Status:     "Success",
+		Message:    "Blocked players fetched successfully",
+		Data:       blocked,
+		Pagination: pagination,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// BlockPlayer godoc
+//
+//	@Summary		Block a player
+//	@Description	Block a player, ending the friendship and the pending requests between both players. Blocked players can't send friend requests to the player nor find the player in the player list
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			blockedID	path		int	true	"Player to block"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/blocks/{blockedID} [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) BlockPlayer(ctx *gin.Context) {
+	controller.actOnPlayer(ctx, "blockedID", controller.friendshipService.BlockPlayer, "Player blocked successfully", "Failed to block player")
+}
+
+// UnblockPlayer godoc
+//
+//	@Summary		Unblock a player
+//	@Description	Unblock a player blocked by the player
+//	@Tags			Friend
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			blockedID	path		int	true	"Player to unblock"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/blocks/{blockedID} [delete]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *FriendshipController) UnblockPlayer(ctx *gin.Context) {
+	controller.actOnPlayer(ctx, "blockedID", controller.friendshipService.UnblockPlayer, "Player unblocked successfully", "Failed to unblock player")
+}
+
+// answerFriendRequest runs an action of the player on one of its friend requests.
+func (controller *FriendshipController) answerFriendRequest(ctx *gin.Context, action func(playerProfileID uint, friendRequestID uint) error, successMessage string, failedMessage string) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	friendRequestID, ok := pathID(ctx, "requestID", helpers.ErrInvalidFriendRequestID)
+	if !ok {
+		return
+	}
+
+	controller.writeFriendshipResult(ctx, action(playerID, friendRequestID), successMessage, failedMessage)
+}
+
+// actOnPlayer runs an action of the player on another player, like unfriending or blocking it.
+func (controller *FriendshipController) actOnPlayer(ctx *gin.Context, otherPlayerParam string, action func(playerProfileID uint, otherPlayerProfileID uint) error, successMessage string, failedMessage string) {
+	playerID, ok := pathID(ctx, "playerID", helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	otherPlayerID, ok := pathID(ctx, otherPlayerParam, helpers.ErrInvalidPlayerProfileID)
+	if !ok {
+		return
+	}
+
+	controller.writeFriendshipResult(ctx, action(playerID, otherPlayerID), successMessage, failedMessage)
+}
+
+func (controller *FriendshipController) writeFriendshipResult(ctx *gin.Context, err error, successMessage string, failedMessage string) {
+	if err != nil {
+		controller.writeFriendshipError(ctx, err, failedMessage)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: successMessage,
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *FriendshipController) writeFriendshipError(ctx *gin.Context, err error, failedMessage string) {
+	status := 500
+	message := failedMessage
+
+	switch {
+	case errors.Is(err, helpers.ErrFriendRequestDataValidation), errors.Is(err, helpers.ErrInvalidPlayerProfileID), errors.Is(err, helpers.ErrInvalidFriendRequestID), errors.Is(err, helpers.ErrSelfFriendship), errors.Is(err, helpers.ErrInvalidFriendRequestDirection), isListRequestError(err):
+		status, message = 400, err.Error()
+	case errors.Is(err, helpers.ErrorPlayerBlocked):
+		status, message = 403, err.Error()
+	case errors.Is(err, helpers.ErrorPlayerProfileNotFound), errors.Is(err, helpers.ErrorFriendRequestNotFound), errors.Is(err, helpers.ErrorFriendshipNotFound), errors.Is(err, helpers.ErrorPlayerBlockNotFound):
+		status, message = 404, err.Error()
+	case errors.Is(err, helpers.ErrorAlreadyFriends), errors.Is(err, helpers.ErrorFriendRequestPending):
+		status, message = 409, err.Error()
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    status,
+		Status:  "Error",
+		Message: message,
+		Data:    nil,
+	}
+
+	ctx.JSON(status, errorResponse)
+}
+
+// pathID reads a positive id from the path, answering 400 with invalidErr otherwise.
+func pathID(ctx *gin.Context, param string, invalidErr error) (uint, bool) {
+	id, err := strconv.Atoi(ctx.Param(param))
+	if err != nil || id <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: invalidErr.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return 0, false
+	}
+
+	return uint(id), true
+}
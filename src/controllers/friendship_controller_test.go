@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFriendshipController_SendFriendRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(friendshipController *FriendshipController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/players/:playerID/friend-requests", friendshipController.SendFriendRequest)
+		return router
+	}
+
+	friendRequest := request.SendFriendRequestRequest{PlayerID: 2}
+
+	t.Run("SendFriendRequest_Success", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		mockFriendshipService.On("SendFriendRequest", uint(1), friendRequest).Return(&response.FriendRequestResponse{ID: 5, SenderID: 1, ReceiverID: 2}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/players/1/friend-requests", bytes.NewBufferString(`{"player_id":2}`))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, "Expected status code 201")
+		assert.Contains(t, rec.Body.String(), `"receiver_id":2`)
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		body         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "SendFriendRequest_InvalidPlayerID", path: "/players/abc/friend-requests", body: `{"player_id":2}`, expectedCode: http.StatusBadRequest},
+		{name: "SendFriendRequest_InvalidBody", path: "/players/1/friend-requests", body: `invalid json`, expectedCode: http.StatusBadRequest},
+		{name: "SendFriendRequest_Self", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: helpers.ErrSelfFriendship, expectedCode: http.StatusBadRequest},
+		{name: "SendFriendRequest_Blocked", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: helpers.ErrorPlayerBlocked, expectedCode: http.StatusForbidden},
+		{name: "SendFriendRequest_PlayerNotFound", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "SendFriendRequest_AlreadyFriends", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: helpers.ErrorAlreadyFriends, expectedCode: http.StatusConflict},
+		{name: "SendFriendRequest_Pending", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: helpers.ErrorFriendRequestPending, expectedCode: http.StatusConflict},
+		{name: "SendFriendRequest_ServiceError", path: "/players/1/friend-requests", body: `{"player_id":2}`, serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFriendshipService := new(mocks.MockFriendshipService)
+			friendshipController := NewFriendshipController(mockFriendshipService)
+
+			if tc.serviceErr != nil {
+				mockFriendshipService.On("SendFriendRequest", uint(1), friendRequest).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(friendshipController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			mockFriendshipService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendshipController_FriendRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(friendshipController *FriendshipController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/players/:playerID/friend-requests", friendshipController.GetFriendRequests)
+		router.POST("/players/:playerID/friend-requests/:requestID/accept", friendshipController.AcceptFriendRequest)
+		router.POST("/players/:playerID/friend-requests/:requestID/decline", friendshipController.DeclineFriendRequest)
+		router.DELETE("/players/:playerID/friend-requests/:requestID", friendshipController.CancelFriendRequest)
+		return router
+	}
+
+	t.Run("GetFriendRequests_DefaultIncoming", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		mockFriendshipService.On("GetFriendRequests", uint(2), models.FriendRequestsIncoming, request.PageRequest{Page: 1, PageSize: 10}).Return([]response.FriendRequestResponse{{ID: 5, SenderID: 1, ReceiverID: 2}}, &response.Pagination{}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/2/friend-requests", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	t.Run("GetFriendRequests_InvalidDirection", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		mockFriendshipService.On("GetFriendRequests", uint(2), "sideways", request.PageRequest{Page: 1, PageSize: 10}).Return(nil, nil, helpers.ErrInvalidFriendRequestDirection)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/2/friend-requests?direction=sideways", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		serviceCall  string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "AcceptFriendRequest_Success", method: http.MethodPost, path: "/players/2/friend-requests/5/accept", serviceCall: "AcceptFriendRequest", expectedCode: http.StatusOK},
+		{name: "AcceptFriendRequest_NotFound", method: http.MethodPost, path: "/players/2/friend-requests/5/accept", serviceCall: "AcceptFriendRequest", serviceErr: helpers.ErrorFriendRequestNotFound, expectedCode: http.StatusNotFound},
+		{name: "AcceptFriendRequest_InvalidRequestID", method: http.MethodPost, path: "/players/2/friend-requests/abc/accept", expectedCode: http.StatusBadRequest},
+		{name: "DeclineFriendRequest_Success", method: http.MethodPost, path: "/players/2/friend-requests/5/decline", serviceCall: "DeclineFriendRequest", expectedCode: http.StatusOK},
+		{name: "CancelFriendRequest_Success", method: http.MethodDelete, path: "/players/2/friend-requests/5", serviceCall: "CancelFriendRequest", expectedCode: http.StatusOK},
+		{name: "CancelFriendRequest_ServiceError", method: http.MethodDelete, path: "/players/2/friend-requests/5", serviceCall: "CancelFriendRequest", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFriendshipService := new(mocks.MockFriendshipService)
+			friendshipController := NewFriendshipController(mockFriendshipService)
+
+			if tc.serviceCall != "" {
+				mockFriendshipService.On(tc.serviceCall, uint(2), uint(5)).Return(tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(friendshipController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			mockFriendshipService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendshipController_GetFriends(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(friendshipController *FriendshipController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/players/:playerID/friends", friendshipController.GetFriends)
+		router.GET("/players/:playerID/mutual-friends/:otherPlayerID", friendshipController.GetMutualFriends)
+		return router
+	}
+
+	t.Run("GetFriends_Success", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		total := int64(3)
+		pageRequest := request.PageRequest{Page: 1, PageSize: 2, Total: true}
+		mockFriendshipService.On("GetFriends", uint(1), pageRequest).Return([]response.PlayerProfileResponse{{ID: 3}, {ID: 4}}, &response.Pagination{NextCursor: helpers.EncodeCursor(4), HasMore: true, Total: &total}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/friends?pageSize=2&total=true", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"total":3`)
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	t.Run("GetFriends_InvalidCursor", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		pageRequest := request.PageRequest{Page: 1, PageSize: 10, After: "nope"}
+		mockFriendshipService.On("GetFriends", uint(1), pageRequest).Return(nil, nil, helpers.ErrInvalidCursor)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/friends?after=nope", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	t.Run("GetMutualFriends_Success", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		pageRequest := request.PageRequest{Page: 1, PageSize: 10}
+		mockFriendshipService.On("GetMutualFriends", uint(1), uint(2), pageRequest).Return([]response.PlayerProfileResponse{{ID: 3}}, &response.Pagination{}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/mutual-friends/2", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	t.Run("GetMutualFriends_InvalidOtherPlayerID", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/mutual-friends/0", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
+
+func TestFriendshipController_Players(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(friendshipController *FriendshipController) *gin.Engine {
+		router := gin.Default()
+		router.DELETE("/players/:playerID/friends/:friendID", friendshipController.Unfriend)
+		router.GET("/players/:playerID/blocks", friendshipController.GetBlockedPlayers)
+		router.POST("/players/:playerID/blocks/:blockedID", friendshipController.BlockPlayer)
+		router.DELETE("/players/:playerID/blocks/:blockedID", friendshipController.UnblockPlayer)
+		return router
+	}
+
+	t.Run("GetBlockedPlayers_Success", func(t *testing.T) {
+		mockFriendshipService := new(mocks.MockFriendshipService)
+		friendshipController := NewFriendshipController(mockFriendshipService)
+
+		pageRequest := request.PageRequest{Page: 1, PageSize: 10, After: helpers.EncodeCursor(1)}
+		mockFriendshipService.On("GetBlockedPlayers", uint(1), pageRequest).Return([]response.PlayerProfileResponse{{ID: 2}}, &response.Pagination{PrevCursor: helpers.EncodeCursor(2)}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/blocks?after="+helpers.EncodeCursor(1), nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(friendshipController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"prev_cursor"`)
+		mockFriendshipService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		serviceCall  string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "Unfriend_Success", method: http.MethodDelete, path: "/players/1/friends/2", serviceCall: "Unfriend", expectedCode: http.StatusOK},
+		{name: "Unfriend_NotFriends", method: http.MethodDelete, path: "/players/1/friends/2", serviceCall: "Unfriend", serviceErr: helpers.ErrorFriendshipNotFound, expectedCode: http.StatusNotFound},
+		{name: "Unfriend_InvalidFriendID", method: http.MethodDelete, path: "/players/1/friends/abc", expectedCode: http.StatusBadRequest},
+		{name: "BlockPlayer_Success", method: http.MethodPost, path: "/players/1/blocks/2", serviceCall: "BlockPlayer", expectedCode: http.StatusOK},
+		{name: "BlockPlayer_Self", method: http.MethodPost, path: "/players/1/blocks/2", serviceCall: "BlockPlayer", serviceErr: helpers.ErrSelfFriendship, expectedCode: http.StatusBadRequest},
+		{name: "BlockPlayer_PlayerNotFound", method: http.MethodPost, path: "/players/1/blocks/2", serviceCall: "BlockPlayer", serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "UnblockPlayer_Success", method: http.MethodDelete, path: "/players/1/blocks/2", serviceCall: "UnblockPlayer", expectedCode: http.StatusOK},
+		{name: "UnblockPlayer_NotBlocked", method: http.MethodDelete, path: "/players/1/blocks/2", serviceCall: "UnblockPlayer", serviceErr: helpers.ErrorPlayerBlockNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFriendshipService := new(mocks.MockFriendshipService)
+			friendshipController := NewFriendshipController(mockFriendshipService)
+
+			if tc.serviceCall != "" {
+				mockFriendshipService.On(tc.serviceCall, uint(1), uint(2)).Return(tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(friendshipController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			mockFriendshipService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ImpersonationController struct {
+	impersonationService services.ImpersonationService
+}
+
+func NewImpersonationController(service services.ImpersonationService) *ImpersonationController {
+	return &ImpersonationController{
+		impersonationService: service,
+	}
+}
+
+// Impersonate godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Issue a short-lived token to see the API as the user, only admins can do it. The token can't be refreshed, destructive operations are refused and every request made with it is logged
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			userID	path		int	true	"User ID"
+//	@Success		200		{object}	response.BaseResponse{data=response.ImpersonationResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/impersonate/{userID} [post]
+//	@Security		BearerAuth
+func (controller *ImpersonationController) Impersonate(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	impersonation, err := controller.impersonationService.Impersonate(ctx.GetUint("userID"), uint(userIDInt))
+	if err != nil {
+		status := 500
+		message := "Failed to impersonate user"
+
+		switch {
+		case errors.Is(err, helpers.ErrorUserNotFound):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrCannotImpersonate):
+			status, message = 403, err.Error()
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    status,
+			Status:  "Error",
+			Message: message,
+			Data:    nil,
+		}
+
+		ctx.JSON(status, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Impersonation token issued",
+		Data:    impersonation,
+	}
+
+	ctx.JSON(200, webResponse)
+}
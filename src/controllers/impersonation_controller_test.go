@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpersonationController_Impersonate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(impersonationController *ImpersonationController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/auth/impersonate/:userID", func(ctx *gin.Context) {
+			ctx.Set("userID", uint(1))
+			ctx.Next()
+		}, impersonationController.Impersonate)
+		return router
+	}
+
+	t.Run("Impersonate_Success", func(t *testing.T) {
+		mockImpersonationService := new(mocks.MockImpersonationService)
+		impersonationController := NewImpersonationController(mockImpersonationService)
+
+		mockImpersonationService.On("Impersonate", uint(1), uint(42)).Return(&response.ImpersonationResponse{Token: "token", UserID: 42, ImpersonatorID: 1}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/impersonate/42", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(impersonationController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"impersonator_id":1`)
+		mockImpersonationService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "Impersonate_InvalidUserID", path: "/auth/impersonate/abc", expectedCode: http.StatusBadRequest},
+		{name: "Impersonate_UserNotFound", path: "/auth/impersonate/42", serviceErr: helpers.ErrorUserNotFound, expectedCode: http.StatusNotFound},
+		{name: "Impersonate_PrivilegedUser", path: "/auth/impersonate/42", serviceErr: helpers.ErrCannotImpersonate, expectedCode: http.StatusForbidden},
+		{name: "Impersonate_ServiceError", path: "/auth/impersonate/42", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockImpersonationService := new(mocks.MockImpersonationService)
+			impersonationController := NewImpersonationController(mockImpersonationService)
+
+			if tc.serviceErr != nil {
+				mockImpersonationService.On("Impersonate", uint(1), uint(42)).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(impersonationController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type LeaderboardController struct {
+	leaderboardService services.LeaderboardService
+}
+
+func NewLeaderboardController(service services.LeaderboardService) *LeaderboardController {
+	return &LeaderboardController{
+		leaderboardService: service,
+	}
+}
+
+// GetLeaderboard godoc
+//
+//	@Summary		Get a leaderboard
+//	@Description	Rank the players by points, level or experience, best first. Competitive ranking skips the ranks after a tie (1, 2, 2, 4), dense ranking doesn't (1, 2, 2, 3). The top of each board can be a few seconds old
+//	@Tags			Leaderboard
+//	@Produce		json
+//	@Param			metric		path		string	true	"points, season_points, level or experience"
+//	@Param			ranking		query		string	false	"competitive (default) or dense"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Success		200			{object}	response.BaseResponse{data=response.LeaderboardResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/leaderboards/{metric} [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *LeaderboardController) GetLeaderboard(ctx *gin.Context) {
+	page, pageSize, ok := paginationQuery(ctx)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := controller.leaderboardService.GetLeaderboard(ctx.Param("metric"), ctx.DefaultQuery("ranking", models.RankingCompetitive), page, pageSize)
+	if err != nil {
+		controller.writeLeaderboardError(ctx, err)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Leaderboard fetched successfully",
+		Data:    leaderboard,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetPlayerNeighbourhood godoc
+//
+//	@Summary		Get the rank of a player
+//	@Description	Rank of the player in a leaderboard with the players ranked right above and below
+//	@Tags			Leaderboard
+//	@Produce		json
+//	@Param			metric		path		string	true	"points, season_points, level or experience"
+//	@Param			playerID	path		int		true	"Player ID"
+//	@Param			ranking		query		string	false	"competitive (default) or dense"
+//	@Param			neighbours	query		int		false	"Players above and below, 5 by default"
+//	@Success		200			{object}	response.BaseResponse{data=response.LeaderboardResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/leaderboards/{metric}/players/{playerID} [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *LeaderboardController) GetPlayerNeighbourhood(ctx *gin.Context) {
+	playerIDInt, err := strconv.Atoi(ctx.Param("playerID"))
+	if err != nil || playerIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	neighbours, err := strconv.Atoi(ctx.DefaultQuery("neighbours", "5"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidNeighbours.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	leaderboard, err := controller.leaderboardService.GetPlayerNeighbourhood(ctx.Param("metric"), ctx.DefaultQuery("ranking", models.RankingCompetitive), uint(playerIDInt), neighbours)
+	if err != nil {
+		controller.writeLeaderboardError(ctx, err)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Player rank fetched successfully",
+		Data:    leaderboard,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *LeaderboardController) writeLeaderboardError(ctx *gin.Context, err error) {
+	status := 500
+	message := "Failed to get leaderboard"
+
+	switch {
+	case errors.Is(err, helpers.ErrInvalidLeaderboardMetric), errors.Is(err, helpers.ErrInvalidRanking), errors.Is(err, helpers.ErrInvalidPagination), errors.Is(err, helpers.ErrInvalidNeighbours), errors.Is(err, helpers.ErrInvalidPlayerProfileID):
+		status, message = 400, err.Error()
+	case errors.Is(err, helpers.ErrorPlayerProfileNotFound):
+		status, message = 404, err.Error()
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    status,
+		Status:  "Error",
+		Message: message,
+		Data:    nil,
+	}
+
+	ctx.JSON(status, errorResponse)
+}
+
+// paginationQuery reads the page and pageSize query parameters, 1 and 10 by default.
+func paginationQuery(ctx *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid page",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return 0, 0, false
+	}
+
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid pageSize",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return 0, 0, false
+	}
+
+	return page, pageSize, true
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLeaderboardController_GetLeaderboard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(leaderboardController *LeaderboardController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/leaderboards/:metric", leaderboardController.GetLeaderboard)
+		return router
+	}
+
+	t.Run("GetLeaderboard_Success", func(t *testing.T) {
+		mockLeaderboardService := new(mocks.MockLeaderboardService)
+		leaderboardController := NewLeaderboardController(mockLeaderboardService)
+
+		leaderboard := &response.LeaderboardResponse{
+			Metric:  models.LeaderboardMetricPoints,
+			Ranking: models.RankingCompetitive,
+			Entries: []response.LeaderboardEntryResponse{{Rank: 1, PlayerID: 3, Nickname: "pro", Value: 300}},
+		}
+		mockLeaderboardService.On("GetLeaderboard", models.LeaderboardMetricPoints, models.RankingCompetitive, 1, 10).Return(leaderboard, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/leaderboards/points", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(leaderboardController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"nickname":"pro"`)
+		mockLeaderboardService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "GetLeaderboard_InvalidPage", path: "/leaderboards/points?page=abc", expectedCode: http.StatusBadRequest},
+		{name: "GetLeaderboard_InvalidMetric", path: "/leaderboards/points?ranking=dense&page=2&pageSize=5", serviceErr: helpers.ErrInvalidLeaderboardMetric, expectedCode: http.StatusBadRequest},
+		{name: "GetLeaderboard_InvalidRanking", path: "/leaderboards/points?ranking=dense&page=2&pageSize=5", serviceErr: helpers.ErrInvalidRanking, expectedCode: http.StatusBadRequest},
+		{name: "GetLeaderboard_ServiceError", path: "/leaderboards/points?ranking=dense&page=2&pageSize=5", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLeaderboardService := new(mocks.MockLeaderboardService)
+			leaderboardController := NewLeaderboardController(mockLeaderboardService)
+
+			if tc.serviceErr != nil {
+				mockLeaderboardService.On("GetLeaderboard", models.LeaderboardMetricPoints, models.RankingDense, 2, 5).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(leaderboardController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestLeaderboardController_GetPlayerNeighbourhood(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(leaderboardController *LeaderboardController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/leaderboards/:metric/players/:playerID", leaderboardController.GetPlayerNeighbourhood)
+		return router
+	}
+
+	t.Run("GetPlayerNeighbourhood_Success", func(t *testing.T) {
+		mockLeaderboardService := new(mocks.MockLeaderboardService)
+		leaderboardController := NewLeaderboardController(mockLeaderboardService)
+
+		mockLeaderboardService.On("GetPlayerNeighbourhood", models.LeaderboardMetricLevel, models.RankingCompetitive, uint(7), 5).Return(&response.LeaderboardResponse{}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/leaderboards/level/players/7", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(leaderboardController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockLeaderboardService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "GetPlayerNeighbourhood_InvalidPlayerID", path: "/leaderboards/level/players/abc", expectedCode: http.StatusBadRequest},
+		{name: "GetPlayerNeighbourhood_InvalidNeighboursQuery", path: "/leaderboards/level/players/7?neighbours=abc", expectedCode: http.StatusBadRequest},
+		{name: "GetPlayerNeighbourhood_TooManyNeighbours", path: "/leaderboards/level/players/7?neighbours=2", serviceErr: helpers.ErrInvalidNeighbours, expectedCode: http.StatusBadRequest},
+		{name: "GetPlayerNeighbourhood_PlayerNotFound", path: "/leaderboards/level/players/7?neighbours=2", serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "GetPlayerNeighbourhood_ServiceError", path: "/leaderboards/level/players/7?neighbours=2", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLeaderboardService := new(mocks.MockLeaderboardService)
+			leaderboardController := NewLeaderboardController(mockLeaderboardService)
+
+			if tc.serviceErr != nil {
+				mockLeaderboardService.On("GetPlayerNeighbourhood", models.LeaderboardMetricLevel, models.RankingCompetitive, uint(7), 2).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(leaderboardController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			mockLeaderboardService.AssertNotCalled(t, "GetLeaderboard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
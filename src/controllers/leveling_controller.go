@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type LevelingController struct {
+	levelingService services.LevelingService
+}
+
+func NewLevelingController(service services.LevelingService) *LevelingController {
+	return &LevelingController{
+		levelingService: service,
+	}
+}
+
+// GrantExperience godoc
+//
+//	@Summary		Grant experience to a player
+//	@Description	Add experience to a player, the level goes up as many times as the XP curve allows. Reserved to game servers and admins
+//	@Tags			Player
+//	@Accept			json
+//	@Produce		json
+//	@Param			playerID	path		int								true	"Player ID"
+//	@Param			request		body		request.GrantExperienceRequest	true	"Grant Experience Request"
+//	@Success		200			{object}	response.BaseResponse{data=response.ExperienceGrantResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		409			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/experience [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *LevelingController) GrantExperience(ctx *gin.Context) {
+	playerIDInt, err := strconv.Atoi(ctx.Param("playerID"))
+	if err != nil || playerIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	grantRequest := request.GrantExperienceRequest{}
+
+	err = ctx.ShouldBindJSON(&grantRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	experienceGrant, err := controller.levelingService.GrantExperience(uint(playerIDInt), grantRequest)
+	if err != nil {
+		status := 500
+		message := "Failed to grant experience"
+
+		switch {
+		case errors.Is(err, helpers.ErrExperienceDataValidation):
+			status, message = 400, err.Error()
+		case errors.Is(err, helpers.ErrorPlayerProfileNotFound):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrExperienceGrantConflict):
+			status, message = 409, err.Error()
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    status,
+			Status:  "Error",
+			Message: message,
+			Data:    nil,
+		}
+
+		ctx.JSON(status, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Experience granted successfully",
+		Data:    experienceGrant,
+	}
+
+	ctx.JSON(200, webResponse)
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelingController_GrantExperience(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(levelingController *LevelingController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/players/:playerID/experience", levelingController.GrantExperience)
+		return router
+	}
+
+	grantRequest := request.GrantExperienceRequest{Amount: 250}
+
+	t.Run("GrantExperience_Success", func(t *testing.T) {
+		mockLevelingService := new(mocks.MockLevelingService)
+		levelingController := NewLevelingController(mockLevelingService)
+
+		mockLevelingService.On("GrantExperience", uint(1), grantRequest).Return(&response.ExperienceGrantResponse{PlayerID: 1, Level: 3, LevelsGained: 2}, nil)
+
+		body, _ := json.Marshal(grantRequest)
+		req, err := http.NewRequest(http.MethodPost, "/players/1/experience", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(levelingController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"levels_gained":2`)
+		mockLevelingService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		body         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "GrantExperience_InvalidPlayerID", path: "/players/abc/experience", body: `{"amount":250}`, expectedCode: http.StatusBadRequest},
+		{name: "GrantExperience_InvalidBody", path: "/players/1/experience", body: `invalid json`, expectedCode: http.StatusBadRequest},
+		{name: "GrantExperience_ValidationError", path: "/players/1/experience", body: `{"amount":250}`, serviceErr: helpers.ErrExperienceDataValidation, expectedCode: http.StatusBadRequest},
+		{name: "GrantExperience_PlayerNotFound", path: "/players/1/experience", body: `{"amount":250}`, serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "GrantExperience_Conflict", path: "/players/1/experience", body: `{"amount":250}`, serviceErr: helpers.ErrExperienceGrantConflict, expectedCode: http.StatusConflict},
+		{name: "GrantExperience_ServiceError", path: "/players/1/experience", body: `{"amount":250}`, serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLevelingService := new(mocks.MockLevelingService)
+			levelingController := NewLevelingController(mockLevelingService)
+
+			if tc.serviceErr != nil {
+				mockLevelingService.On("GrantExperience", uint(1), grantRequest).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(levelingController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxListFilters    = 10
+	maxListSortFields = 3
+)
+
+// listFilterPattern splits a query parameter into field, operator and value.
+// The longer operators come first so level>=10 isn't read as level> = 10.
+var listFilterPattern = regexp.MustCompile(`^([A-Za-z_]+)(~=|!=|>=|<=|=|>|<)(.*)$`)
+
+// listQueryParameters are the parameters of the list endpoints that aren't filters.
+var listQueryParameters = map[string]bool{"page": true, "pageSize": true, "sort": true, "after": true, "before": true, "total": true}
+
+// pageQuery reads the page of a list endpoint, by page and pageSize like
+// paginationQuery or after and before an opaque cursor, and whether to count
+// the total.
+func pageQuery(ctx *gin.Context) (request.PageRequest, bool) {
+	page, pageSize, ok := paginationQuery(ctx)
+	if !ok {
+		return request.PageRequest{}, false
+	}
+
+	total, err := strconv.ParseBool(ctx.DefaultQuery("total", "false"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid total",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return request.PageRequest{}, false
+	}
+
+	return request.PageRequest{
+		Page:     page,
+		PageSize: pageSize,
+		After:    ctx.Query("after"),
+		Before:   ctx.Query("before"),
+		Total:    total,
+	}, true
+}
+
+// isListRequestError reports whether a list endpoint failed because of the
+// page or the list query of the request.
+func isListRequestError(err error) bool {
+	return errors.Is(err, helpers.ErrInvalidListQuery) ||
+		errors.Is(err, helpers.ErrInvalidPagination) ||
+		errors.Is(err, helpers.ErrInvalidCursor) ||
+		errors.Is(err, helpers.ErrConflictingCursors)
+}
+
+// listQuery reads the filters and the sort parameter of a list endpoint,
+// like ?nickname~=noob&level>=10&sort=-points,nickname. The repositories
+// decide which fields can be used.
+func listQuery(ctx *gin.Context) (models.ListQuery, bool) {
+	query, err := parseListQuery(ctx.Request.URL.RawQuery)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: err.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return models.ListQuery{}, false
+	}
+
+	return query, true
+}
+
+// parseListQuery works on the raw query string because operators like >=
+// don't survive the key=value parsing of url.Values.
+func parseListQuery(rawQuery string) (models.ListQuery, error) {
+	var query models.ListQuery
+
+	for _, parameter := range strings.Split(rawQuery, "&") {
+		if parameter == "" {
+			continue
+		}
+
+		decoded, err := url.QueryUnescape(parameter)
+		if err != nil {
+			return models.ListQuery{}, fmt.Errorf("%w: %q", helpers.ErrInvalidListQuery, parameter)
+		}
+
+		match := listFilterPattern.FindStringSubmatch(decoded)
+		if match == nil {
+			return models.ListQuery{}, fmt.Errorf("%w: %q", helpers.ErrInvalidListQuery, decoded)
+		}
+
+		field, operator, value := match[1], match[2], match[3]
+
+		if field == "sort" && operator == models.FilterEqual {
+			query.Sort, err = parseSort(value)
+			if err != nil {
+				return models.ListQuery{}, err
+			}
+			continue
+		}
+
+		if listQueryParameters[field] {
+			continue
+		}
+
+		if len(query.Filters) == maxListFilters {
+			return models.ListQuery{}, fmt.Errorf("%w: up to %d filters", helpers.ErrInvalidListQuery, maxListFilters)
+		}
+
+		query.Filters = append(query.Filters, models.Filter{Field: field, Operator: operator, Value: value})
+	}
+
+	return query, nil
+}
+
+// parseSort reads comma separated fields, descending when prefixed with a minus.
+func parseSort(value string) ([]models.SortField, error) {
+	fields := strings.Split(value, ",")
+	if len(fields) > maxListSortFields {
+		return nil, fmt.Errorf("%w: sort by up to %d fields", helpers.ErrInvalidListQuery, maxListSortFields)
+	}
+
+	sort := make([]models.SortField, 0, len(fields))
+	for _, field := range fields {
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty sort field", helpers.ErrInvalidListQuery)
+		}
+
+		sort = append(sort, models.SortField{Field: field, Descending: descending})
+	}
+
+	return sort, nil
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListQuery(t *testing.T) {
+	t.Run("Operators", func(t *testing.T) {
+		query, err := parseListQuery("a=1&b!=2&c>3&d>=4&e<5&f<=6&g~=x%25y&page=2&pageSize=5")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Filter{
+			{Field: "a", Operator: models.FilterEqual, Value: "1"},
+			{Field: "b", Operator: models.FilterNotEqual, Value: "2"},
+			{Field: "c", Operator: models.FilterGreater, Value: "3"},
+			{Field: "d", Operator: models.FilterGreaterOrEqual, Value: "4"},
+			{Field: "e", Operator: models.FilterLess, Value: "5"},
+			{Field: "f", Operator: models.FilterLessOrEqual, Value: "6"},
+			{Field: "g", Operator: models.FilterPrefix, Value: "x%y"},
+		}, query.Filters)
+		assert.Empty(t, query.Sort)
+	})
+
+	t.Run("EncodedOperators", func(t *testing.T) {
+		query, err := parseListQuery("level%3E%3D10")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Filter{{Field: "level", Operator: models.FilterGreaterOrEqual, Value: "10"}}, query.Filters)
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		query, err := parseListQuery("sort=-points,nickname")
+
+		assert.NoError(t, err)
+		assert.Empty(t, query.Filters)
+		assert.Equal(t, []models.SortField{{Field: "points", Descending: true}, {Field: "nickname"}}, query.Sort)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		rawQueries := map[string]string{
+			"NoOperator":      "level",
+			"BadField":        "level1=2",
+			"BadEscape":       "nickname=%zz",
+			"EmptySortField":  "sort=points,,level",
+			"TooManySorts":    "sort=a,b,c,d",
+			"TooManyFilters":  "a=1&a=2&a=3&a=4&a=5&a=6&a=7&a=8&a=9&a=10&a=11",
+			"DescendingEmpty": "sort=-",
+		}
+
+		for name, rawQuery := range rawQueries {
+			t.Run(name, func(t *testing.T) {
+				_, err := parseListQuery(rawQuery)
+				assert.ErrorIs(t, err, helpers.ErrInvalidListQuery)
+			})
+		}
+	})
+}
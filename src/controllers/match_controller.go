@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MatchController struct {
+	matchService services.MatchService
+}
+
+func NewMatchController(service services.MatchService) *MatchController {
+	return &MatchController{
+		matchService: service,
+	}
+}
+
+// SubmitMatch godoc
+//
+//	@Summary		Submit a match result
+//	@Description	Save the result of a match and update the ratings of its players with the configured rating system (Elo or Glicko-2). Every player is rated against each opposing team, the better placement wins and equal placements draw
+//	@Tags			Match
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.SubmitMatchRequest	true	"Submit Match Request"
+//	@Success		201		{object}	response.BaseResponse{data=response.MatchResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/matches [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *MatchController) SubmitMatch(ctx *gin.Context) {
+	matchRequest := request.SubmitMatchRequest{}
+
+	err := ctx.ShouldBindJSON(&matchRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	match, err := controller.matchService.SubmitMatch(matchRequest)
+	if err != nil {
+		controller.writeMatchError(ctx, err, "Failed to submit match")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    201,
+		Status:  "Success",
+		Message: "Match submitted",
+		Data:    match,
+	}
+
+	ctx.JSON(201, webResponse)
+}
+
+// GetPlayerMatches godoc
+//
+//	@Summary		Get the matches of a player
+//	@Description	Rated matches of the player with the rating won or lost in each one, latest first
+//	@Tags			Match
+//	@Produce		json
+//	@Param			playerID	path		int	true	"Player ID"
+//	@Param			page		query		int	false	"Page number"
+//	@Param			pageSize	query		int	false	"Page size"
+//	@Success		200			{object}	response.BaseResponse{data=[]response.PlayerMatchResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/matches [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *MatchController) GetPlayerMatches(ctx *gin.Context) {
+	playerIDInt, err := strconv.Atoi(ctx.Param("playerID"))
+	if err != nil || playerIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	page, pageSize, ok := paginationQuery(ctx)
+	if !ok {
+		return
+	}
+
+	matches, err := controller.matchService.GetPlayerMatches(uint(playerIDInt), page, pageSize)
+	if err != nil {
+		controller.writeMatchError(ctx, err, "Failed to get matches")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Matches fetched successfully",
+		Data:    matches,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *MatchController) writeMatchError(ctx *gin.Context, err error, failedMessage string) {
+	status := 500
+	message := failedMessage
+
+	switch {
+	case errors.Is(err, helpers.ErrMatchDataValidation), errors.Is(err, helpers.ErrDuplicateMatchParticipant), errors.Is(err, helpers.ErrInvalidMatchTeams), errors.Is(err, helpers.ErrInvalidPlayerProfileID), errors.Is(err, helpers.ErrInvalidPagination):
+		status, message = 400, err.Error()
+	case errors.Is(err, helpers.ErrorPlayerProfileNotFound):
+		status, message = 404, err.Error()
+	case errors.Is(err, helpers.ErrorPlayerRatingConflict):
+		status, message = 409, err.Error()
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    status,
+		Status:  "Error",
+		Message: message,
+		Data:    nil,
+	}
+
+	ctx.JSON(status, errorResponse)
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchController_SubmitMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(matchController *MatchController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/matches", matchController.SubmitMatch)
+		return router
+	}
+
+	body := `{"participants":[{"player_id":1,"team":1,"placement":1},{"player_id":2,"team":2,"placement":2}]}`
+	matchRequest := request.SubmitMatchRequest{
+		Participants: []request.MatchParticipantRequest{
+			{PlayerID: 1, Team: 1, Placement: 1},
+			{PlayerID: 2, Team: 2, Placement: 2},
+		},
+	}
+
+	t.Run("SubmitMatch_Success", func(t *testing.T) {
+		mockMatchService := new(mocks.MockMatchService)
+		matchController := NewMatchController(mockMatchService)
+
+		mockMatchService.On("SubmitMatch", matchRequest).Return(&response.MatchResponse{
+			ID:           1,
+			RatingSystem: models.RatingSystemElo,
+			Participants: []response.MatchParticipantResponse{{PlayerID: 1, RatingDelta: 16}, {PlayerID: 2, RatingDelta: -16}},
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/matches", bytes.NewBufferString(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(matchController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, "Expected status code 201")
+		assert.Contains(t, rec.Body.String(), `"rating_delta":-16`)
+		mockMatchService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		body         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "SubmitMatch_InvalidBody", body: `invalid json`, expectedCode: http.StatusBadRequest},
+		{name: "SubmitMatch_ValidationError", body: body, serviceErr: helpers.ErrMatchDataValidation, expectedCode: http.StatusBadRequest},
+		{name: "SubmitMatch_DuplicateParticipant", body: body, serviceErr: helpers.ErrDuplicateMatchParticipant, expectedCode: http.StatusBadRequest},
+		{name: "SubmitMatch_InvalidTeams", body: body, serviceErr: helpers.ErrInvalidMatchTeams, expectedCode: http.StatusBadRequest},
+		{name: "SubmitMatch_PlayerNotFound", body: body, serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "SubmitMatch_RatingConflict", body: body, serviceErr: helpers.ErrorPlayerRatingConflict, expectedCode: http.StatusConflict},
+		{name: "SubmitMatch_ServiceError", body: body, serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockMatchService := new(mocks.MockMatchService)
+			matchController := NewMatchController(mockMatchService)
+
+			if tc.serviceErr != nil {
+				mockMatchService.On("SubmitMatch", matchRequest).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/matches", bytes.NewBufferString(tc.body))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(matchController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestMatchController_GetPlayerMatches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(matchController *MatchController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/players/:playerID/matches", matchController.GetPlayerMatches)
+		return router
+	}
+
+	t.Run("GetPlayerMatches_Success", func(t *testing.T) {
+		mockMatchService := new(mocks.MockMatchService)
+		matchController := NewMatchController(mockMatchService)
+
+		mockMatchService.On("GetPlayerMatches", uint(1), 1, 10).Return([]response.PlayerMatchResponse{{MatchID: 3, RatingDelta: 12.5}}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/players/1/matches", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(matchController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"rating_delta":12.5`)
+		mockMatchService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "GetPlayerMatches_InvalidPlayerID", path: "/players/abc/matches", expectedCode: http.StatusBadRequest},
+		{name: "GetPlayerMatches_InvalidPage", path: "/players/1/matches?page=abc", expectedCode: http.StatusBadRequest},
+		{name: "GetPlayerMatches_PlayerNotFound", path: "/players/1/matches", serviceErr: helpers.ErrorPlayerProfileNotFound, expectedCode: http.StatusNotFound},
+		{name: "GetPlayerMatches_ServiceError", path: "/players/1/matches", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockMatchService := new(mocks.MockMatchService)
+			matchController := NewMatchController(mockMatchService)
+
+			if tc.serviceErr != nil {
+				mockMatchService.On("GetPlayerMatches", uint(1), 1, 10).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(matchController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCController struct {
+	oidcService services.OIDCService
+}
+
+func NewOIDCController(service services.OIDCService) *OIDCController {
+	return &OIDCController{
+		oidcService: service,
+	}
+}
+
+// Providers godoc
+//
+//	@Summary		List the social login providers
+//	@Description	Names of the identity providers players can sign in with
+//	@Tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=[]string}
+//	@Router			/auth/oidc/providers [get]
+func (controller *OIDCController) Providers(ctx *gin.Context) {
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Identity providers found",
+		Data:    controller.oidcService.Providers(),
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Login godoc
+//
+//	@Summary		Sign in with an identity provider
+//	@Description	Redirect the browser to the provider, which sends the user back to the callback
+//	@Tags			Auth
+//	@Param			provider	path	string	true	"Provider name"
+//	@Success		302
+//	@Failure		404	{object}	response.BaseResponse
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/auth/oidc/{provider}/login [get]
+func (controller *OIDCController) Login(ctx *gin.Context) {
+	authorization, err := controller.oidcService.Authorize(ctx.Param("provider"), 0)
+	if err != nil {
+		controller.writeAuthorizeError(ctx, err)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, authorization.AuthorizationURL)
+}
+
+// Link godoc
+//
+//	@Summary		Link an identity provider to the account
+//	@Description	Start a login with the provider that links its identity to the authenticated user. The client sends the user to the returned URL
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Success		200			{object}	response.BaseResponse{data=response.OIDCAuthorizationResponse}
+//	@Failure		401			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/auth/oidc/{provider}/link [post]
+//	@Security		BearerAuth
+func (controller *OIDCController) Link(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+
+	authorization, err := controller.oidcService.Authorize(ctx.Param("provider"), userID)
+	if err != nil {
+		controller.writeAuthorizeError(ctx, err)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Send the user to the authorization URL",
+		Data:    authorization,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Callback godoc
+//
+//	@Summary		Finish a social login
+//	@Description	Redirect URL of the providers. Exchange the code for the identity and sign the user in, registering new players
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Param			code		query		string	true	"Authorization code"
+//	@Param			state		query		string	true	"State of the login"
+//	@Success		200			{object}	response.BaseResponse{data=response.LoginResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		401			{object}	response.BaseResponse
+//	@Failure		403			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		409			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/auth/oidc/{provider}/callback [get]
+func (controller *OIDCController) Callback(ctx *gin.Context) {
+	code := ctx.Query("code")
+	state := ctx.Query("state")
+
+	// The provider reports a refused or cancelled login with ?error=
+	if ctx.Query("error") != "" || code == "" || state == "" {
+		errorResponse := response.BaseResponse{
+			Code:    401,
+			Status:  "Unauthorized",
+			Message: helpers.ErrOIDCLoginFailed.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(401, errorResponse)
+		return
+	}
+
+	loginResponse, err := controller.oidcService.Callback(ctx.Param("provider"), state, code, clientInfo(ctx))
+	if err != nil {
+		status := 500
+		message := "Failed to login"
+
+		switch {
+		case errors.Is(err, helpers.ErrInvalidOIDCState), errors.Is(err, helpers.ErrOIDCEmailRequired):
+			status, message = 400, err.Error()
+		case errors.Is(err, helpers.ErrOIDCLoginFailed):
+			status, message = 401, err.Error()
+		case errors.Is(err, helpers.ErrEmailNotVerified):
+			status, message = 403, err.Error()
+		case errors.Is(err, helpers.ErrUnknownOIDCProvider):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrOIDCEmailInUse), errors.Is(err, helpers.ErrIdentityAlreadyLinked):
+			status, message = 409, err.Error()
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    status,
+			Status:  "Error",
+			Message: message,
+			Data:    nil,
+		}
+
+		ctx.JSON(status, errorResponse)
+		return
+	}
+
+	if loginResponse.TwoFactorRequired {
+		webResponse := response.BaseResponse{
+			Code:    200,
+			Status:  "Success",
+			Message: "Two factor authentication required",
+			Data:    loginResponse,
+		}
+
+		ctx.JSON(200, webResponse)
+		return
+	}
+
+	ctx.Header("Authorization", "Bearer "+loginResponse.Token)
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Login successful",
+		Data:    loginResponse,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetIdentities godoc
+//
+//	@Summary		List the linked identities of a user
+//	@Description	Identity providers linked to the account, only the user or an admin can see them
+//	@Tags			Users
+//	@Produce		json
+//	@Param			userID	path		int	true	"User ID"
+//	@Success		200		{object}	response.BaseResponse{data=[]response.UserIdentityResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/identities [get]
+//	@Security		BearerAuth
+func (controller *OIDCController) GetIdentities(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	identities, err := controller.oidcService.GetIdentities(uint(userIDInt))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to get identities",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Identities found",
+		Data:    identities,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Unlink godoc
+//
+//	@Summary		Unlink an identity provider
+//	@Description	Remove the link between the account and the provider, only the user or an admin can do it
+//	@Tags			Users
+//	@Produce		json
+//	@Param			userID		path		int		true	"User ID"
+//	@Param			provider	path		string	true	"Provider name"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		403			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/users/{userID}/identities/{provider} [delete]
+//	@Security		BearerAuth
+func (controller *OIDCController) Unlink(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.oidcService.Unlink(uint(userIDInt), ctx.Param("provider"))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserIdentityNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to unlink identity",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Identity unlinked",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *OIDCController) writeAuthorizeError(ctx *gin.Context, err error) {
+	if errors.Is(err, helpers.ErrUnknownOIDCProvider) {
+		errorResponse := response.BaseResponse{
+			Code:    404,
+			Status:  "Error",
+			Message: err.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(404, errorResponse)
+		return
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    500,
+		Status:  "Error",
+		Message: "Failed to start the login",
+		Data:    nil,
+	}
+
+	ctx.JSON(500, errorResponse)
+}
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOIDCController_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Login_Redirect", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.GET("/auth/oidc/:provider/login", oidcController.Login)
+
+		mockOIDCService.On("Authorize", "google", uint(0)).Return(&response.OIDCAuthorizationResponse{AuthorizationURL: "https://accounts.example.com/authorize?state=abc"}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/oidc/google/login", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusFound, rec.Code, "Expected status code 302")
+		assert.Equal(t, "https://accounts.example.com/authorize?state=abc", rec.Header().Get("Location"))
+	})
+
+	t.Run("Login_UnknownProvider", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.GET("/auth/oidc/:provider/login", oidcController.Login)
+
+		mockOIDCService.On("Authorize", "myspace", uint(0)).Return(nil, helpers.ErrUnknownOIDCProvider)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/oidc/myspace/login", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+	})
+}
+
+func TestOIDCController_Link(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockOIDCService := new(mocks.MockOIDCService)
+	oidcController := NewOIDCController(mockOIDCService)
+	router := gin.Default()
+	router.POST("/auth/oidc/:provider/link", func(ctx *gin.Context) {
+		ctx.Set("userID", uint(7))
+		ctx.Next()
+	}, oidcController.Link)
+
+	mockOIDCService.On("Authorize", "google", uint(7)).Return(&response.OIDCAuthorizationResponse{AuthorizationURL: "https://accounts.example.com/authorize"}, nil)
+
+	req, err := http.NewRequest(http.MethodPost, "/auth/oidc/google/link", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+	assert.Contains(t, rec.Body.String(), "https://accounts.example.com/authorize")
+	mockOIDCService.AssertExpectations(t)
+}
+
+func TestOIDCController_Callback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Callback_Success", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.GET("/auth/oidc/:provider/callback", oidcController.Callback)
+
+		mockOIDCService.On("Callback", "google", "state", "code", mock.Anything).Return(&response.LoginResponse{Token: "token", RefreshToken: "refresh"}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/oidc/google/callback?code=code&state=state", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Equal(t, "Bearer token", rec.Header().Get("Authorization"))
+	})
+
+	t.Run("Callback_ProviderError", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.GET("/auth/oidc/:provider/callback", oidcController.Callback)
+
+		req, err := http.NewRequest(http.MethodGet, "/auth/oidc/google/callback?error=access_denied&state=state", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		mockOIDCService.AssertNotCalled(t, "Callback", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	errorCases := map[string]struct {
+		err    error
+		status int
+	}{
+		"InvalidState":  {helpers.ErrInvalidOIDCState, http.StatusBadRequest},
+		"LoginFailed":   {helpers.ErrOIDCLoginFailed, http.StatusUnauthorized},
+		"EmailInUse":    {helpers.ErrOIDCEmailInUse, http.StatusConflict},
+		"AlreadyLinked": {helpers.ErrIdentityAlreadyLinked, http.StatusConflict},
+		"Internal":      {assert.AnError, http.StatusInternalServerError},
+	}
+
+	for name, tc := range errorCases {
+		t.Run("Callback_"+name, func(t *testing.T) {
+			mockOIDCService := new(mocks.MockOIDCService)
+			oidcController := NewOIDCController(mockOIDCService)
+			router := gin.Default()
+			router.GET("/auth/oidc/:provider/callback", oidcController.Callback)
+
+			mockOIDCService.On("Callback", "google", "state", "code", mock.Anything).Return(nil, tc.err)
+
+			req, err := http.NewRequest(http.MethodGet, "/auth/oidc/google/callback?code=code&state=state", nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestOIDCController_Identities(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetIdentities_Success", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.GET("/users/:userID/identities", oidcController.GetIdentities)
+
+		mockOIDCService.On("GetIdentities", uint(1)).Return([]response.UserIdentityResponse{{Provider: "google", Email: "test@test.com"}}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/users/1/identities", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var webResponse response.BaseResponse
+		err = json.NewDecoder(rec.Body).Decode(&webResponse)
+		assert.NoError(t, err, "Expected no error decoding response")
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Len(t, webResponse.Data, 1)
+	})
+
+	t.Run("Unlink_Success", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.DELETE("/users/:userID/identities/:provider", oidcController.Unlink)
+
+		mockOIDCService.On("Unlink", uint(1), "google").Return(nil)
+
+		req, err := http.NewRequest(http.MethodDelete, "/users/1/identities/google", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockOIDCService.AssertExpectations(t)
+	})
+
+	t.Run("Unlink_NotLinked", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.DELETE("/users/:userID/identities/:provider", oidcController.Unlink)
+
+		mockOIDCService.On("Unlink", uint(1), "google").Return(helpers.ErrorUserIdentityNotFound)
+
+		req, err := http.NewRequest(http.MethodDelete, "/users/1/identities/google", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+	})
+
+	t.Run("Unlink_InvalidUserID", func(t *testing.T) {
+		mockOIDCService := new(mocks.MockOIDCService)
+		oidcController := NewOIDCController(mockOIDCService)
+		router := gin.Default()
+		router.DELETE("/users/:userID/identities/:provider", oidcController.Unlink)
+
+		req, err := http.NewRequest(http.MethodDelete, "/users/abc/identities/google", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
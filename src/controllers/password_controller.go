@@ -0,0 +1,250 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/middleware"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordController struct {
+	passwordService services.PasswordService
+}
+
+func NewPasswordController(service services.PasswordService) *PasswordController {
+	return &PasswordController{
+		passwordService: service,
+	}
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Send an email with a single use link to reset the password, the response is the same whether the email is registered or not
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ForgotPasswordRequest	true	"Forgot Password Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/forgot-password [post]
+func (controller *PasswordController) ForgotPassword(ctx *gin.Context) {
+	forgotRequest := request.ForgotPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(&forgotRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.passwordService.ForgotPassword(forgotRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrPasswordDataValidation) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to request password reset",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "If the email is registered you will receive a link to reset your password",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset the password
+//	@Description	Set a new password using the token received by email, every session of the user is logged out
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ResetPasswordRequest	true	"Reset Password Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/reset-password [post]
+func (controller *PasswordController) ResetPassword(ctx *gin.Context) {
+	resetRequest := request.ResetPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(&resetRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.passwordService.ResetPassword(resetRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrPasswordDataValidation) || errors.Is(err, helpers.ErrInvalidPasswordResetToken) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to reset password",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Password reset successfully",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change the password of a user
+//	@Description	Change the password checking the current one, admins can set the password of another user without it. Every session of the user is logged out
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			userID	path		int								true	"User ID"
+//	@Param			request	body		request.ChangePasswordRequest	true	"Change Password Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/password [put]
+//	@Security		BearerAuth
+func (controller *PasswordController) ChangePassword(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+
+	userIDUint64, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil || userIDUint64 == 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	userIDUint := uint(userIDUint64)
+
+	changeRequest := request.ChangePasswordRequest{}
+
+	err = ctx.ShouldBindJSON(&changeRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	// Only a user manager changing someone else's password can skip the current one,
+	// the router refuses it for users with permissions the manager lacks
+	requireCurrentPassword := !middleware.HasPermission(ctx, models.PermissionUsersWrite) || ctx.GetUint("userID") == userIDUint
+
+	err = controller.passwordService.ChangePassword(userIDUint, requireCurrentPassword, changeRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrPasswordDataValidation) || errors.Is(err, helpers.ErrInvalidCurrentPassword) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		if errors.Is(err, helpers.ErrorUserNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to change password",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Password changed successfully",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordController_ForgotPassword(t *testing.T) {
+	t.Run("ForgotPassword_Success", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/forgot-password", passwordController.ForgotPassword)
+
+		forgotRequest := request.ForgotPasswordRequest{Email: "test@test.com"}
+		mockPasswordService.On("ForgotPassword", forgotRequest).Return(nil)
+
+		body, err := json.Marshal(forgotRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockPasswordService.AssertExpectations(t)
+	})
+
+	t.Run("ForgotPassword_InvalidBody", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/forgot-password", passwordController.ForgotPassword)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewBufferString("{"))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+
+	t.Run("ForgotPassword_SendFails", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/forgot-password", passwordController.ForgotPassword)
+
+		forgotRequest := request.ForgotPasswordRequest{Email: "test@test.com"}
+		mockPasswordService.On("ForgotPassword", forgotRequest).Return(helpers.ErrSendEmail)
+
+		body, err := json.Marshal(forgotRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+}
+
+func TestPasswordController_ResetPassword(t *testing.T) {
+	t.Run("ResetPassword_Success", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/reset-password", passwordController.ResetPassword)
+
+		resetRequest := request.ResetPasswordRequest{Token: "token", Password: "newpassword"}
+		mockPasswordService.On("ResetPassword", resetRequest).Return(nil)
+
+		body, err := json.Marshal(resetRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/reset-password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockPasswordService.AssertExpectations(t)
+	})
+
+	t.Run("ResetPassword_InvalidToken", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/reset-password", passwordController.ResetPassword)
+
+		resetRequest := request.ResetPasswordRequest{Token: "expired", Password: "newpassword"}
+		mockPasswordService.On("ResetPassword", resetRequest).Return(helpers.ErrInvalidPasswordResetToken)
+
+		body, err := json.Marshal(resetRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/reset-password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+
+	t.Run("ResetPassword_InternalError", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		passwordController := NewPasswordController(mockPasswordService)
+
+		router := gin.Default()
+		router.POST("/auth/reset-password", passwordController.ResetPassword)
+
+		resetRequest := request.ResetPasswordRequest{Token: "token", Password: "newpassword"}
+		mockPasswordService.On("ResetPassword", resetRequest).Return(assert.AnError)
+
+		body, err := json.Marshal(resetRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/reset-password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+}
+
+func TestPasswordController_ChangePassword(t *testing.T) {
+	// newRouter authenticates every request as the given user and role, admins manage users
+	newRouter := func(passwordController *PasswordController, authUserID uint, role string) *gin.Engine {
+		router := gin.Default()
+		router.PUT("/users/:userID/password", func(ctx *gin.Context) {
+			ctx.Set("userID", authUserID)
+			ctx.Set("role", role)
+			if role == "admin" {
+				ctx.Set("permissions", []string{models.PermissionUsersWrite})
+			}
+			ctx.Next()
+		}, passwordController.ChangePassword)
+		return router
+	}
+
+	changeRequest := request.ChangePasswordRequest{CurrentPassword: "oldpassword", NewPassword: "newpassword"}
+
+	t.Run("ChangePassword_Success", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "user")
+
+		mockPasswordService.On("ChangePassword", uint(1), true, changeRequest).Return(nil)
+
+		body, err := json.Marshal(changeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/1/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockPasswordService.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword_AdminForOtherUser", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "admin")
+
+		forceRequest := request.ChangePasswordRequest{NewPassword: "newpassword"}
+		mockPasswordService.On("ChangePassword", uint(2), false, forceRequest).Return(nil)
+
+		body, err := json.Marshal(forceRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/2/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockPasswordService.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword_AdminForOwnAccount", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "admin")
+
+		mockPasswordService.On("ChangePassword", uint(1), true, changeRequest).Return(nil)
+
+		body, err := json.Marshal(changeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/1/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockPasswordService.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword_WrongCurrentPassword", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "user")
+
+		mockPasswordService.On("ChangePassword", uint(1), true, changeRequest).Return(helpers.ErrInvalidCurrentPassword)
+
+		body, err := json.Marshal(changeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/1/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+
+	t.Run("ChangePassword_UserNotFound", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "admin")
+
+		mockPasswordService.On("ChangePassword", uint(9), false, changeRequest).Return(helpers.ErrorUserNotFound)
+
+		body, err := json.Marshal(changeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/9/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+	})
+
+	t.Run("ChangePassword_InvalidUserID", func(t *testing.T) {
+		mockPasswordService := new(mocks.MockPasswordService)
+		router := newRouter(NewPasswordController(mockPasswordService), 1, "admin")
+
+		body, err := json.Marshal(changeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, "/users/abc/password", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
@@ -1,11 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/middleware"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/services"
 	"github.com/gin-gonic/gin"
 )
@@ -23,16 +26,20 @@ func NewPlayerProfileController(service services.PlayerProfileService) *PlayerPr
 // CreatePlayerProfile godoc
 //
 //	@Summary		Create a new player profile
-//	@Description	Create a new player profile with the input payload
+//	@Description	Create a new player profile for the authenticated user, player moderators can create it for another user
 //	@Tags			Player
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		request.CreatePlayerProfileRequest	true	"Create Player Profile Request"
 //	@Success		200		{object}	response.BaseResponse
 //	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
 //	@Failure		500		{object}	response.BaseResponse
 //	@Router			/players [post]
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 func (controller *PlayerProfileController) CreatePlayerProfile(ctx *gin.Context) {
 	createPlayerProfileRequest := request.CreatePlayerProfileRequest{}
 
@@ -49,16 +56,44 @@ func (controller *PlayerProfileController) CreatePlayerProfile(ctx *gin.Context)
 		return
 	}
 
+	// The profile belongs to the authenticated user, only player moderators can pick another owner
+	authUserID := ctx.GetUint("userID")
+	if createPlayerProfileRequest.UserID == 0 {
+		createPlayerProfileRequest.UserID = authUserID
+	}
+
+	if createPlayerProfileRequest.UserID != authUserID && !middleware.HasPermission(ctx, models.PermissionPlayersModerate) {
+		errorResponse := response.BaseResponse{
+			Code:    403,
+			Status:  "Error",
+			Message: helpers.ErrPlayerProfileOwnerNotAllowed.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(403, errorResponse)
+		return
+	}
+
 	err = controller.playerProfileService.Create(createPlayerProfileRequest)
 	if err != nil {
+		status, message := 500, "Failed to create player profile"
+		switch {
+		case errors.Is(err, helpers.ErrPlayerProfileDataValidation):
+			status, message = 400, err.Error()
+		case errors.Is(err, helpers.ErrorUserNotFound):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrPlayerProfileLimitReached):
+			status, message = 409, err.Error()
+		}
+
 		errorResponse := response.BaseResponse{
-			Code:    500,
+			Code:    status,
 			Status:  "Error",
-			Message: "Failed to create player profile",
+			Message: message,
 			Data:    nil,
 		}
 
-		ctx.JSON(500, errorResponse)
+		ctx.JSON(status, errorResponse)
 		return
 	}
 
@@ -75,65 +110,58 @@ func (controller *PlayerProfileController) CreatePlayerProfile(ctx *gin.Context)
 // GetAllPlayers godoc
 //
 //	@Summary		Get all players
-//	@Description	Get all players with pagination, by default page is 1 and pageSize is 10
+//	@Description	Get all players with pagination, by default page is 1 and pageSize is 10. Filter with parameters like level>=10 or nickname~=noob (case-insensitive prefix search) on id, nickname, level, experience, points and user_id. Numeric fields take =, !=, >, >=, < and <=, nickname takes =, != and ~=. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before. Players that blocked the caller are left out
 //	@Tags			Player
 //	@Accept			json
 //	@Produce		json
-//	@Param			page		query		int	false	"Page number"
-//	@Param			pageSize	query		int	false	"Page size"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			sort		query		string	false	"Sort fields, descending with a minus, like -points,nickname"
+//	@Param			after		query		string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before		query		string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total		query		bool	false	"Count the items matching the filters"
 //	@Success		200			{object}	response.BaseResponse{data=[]response.PlayerProfileResponse}
 //	@Failure		400			{object}	response.BaseResponse
 //	@Failure		500			{object}	response.BaseResponse
 //	@Router			/players [get]
 //	@Security		BearerAuth
 func (controller *PlayerProfileController) GetAllPlayers(ctx *gin.Context) {
-	page := ctx.DefaultQuery("page", "1")
-	pageSize := ctx.DefaultQuery("pageSize", "10")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid page",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeInt, err := strconv.Atoi(pageSize)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid pageSize",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	query, ok := listQuery(ctx)
+	if !ok {
 		return
 	}
 
-	players, err := controller.playerProfileService.GetAll(pageInt, pageSizeInt)
+	players, pagination, err := controller.playerProfileService.GetAll(pageRequest, query, ctx.GetUint("userID"))
 	if err != nil {
+		status := 500
+		message := "Failed to get players"
+
+		if isListRequestError(err) {
+			status, message = 400, err.Error()
+		}
+
 		errorResponse := response.BaseResponse{
-			Code:    500,
+			Code:    status,
 			Status:  "Error",
-			Message: "Failed to get players",
+			Message: message,
 			Data:    nil,
 		}
 
-		ctx.JSON(500, errorResponse)
+		ctx.JSON(status, errorResponse)
 		return
 	}
 
 	webResponse := response.BaseResponse{
-		Code:    200,
-		Status:  "Success",
-		Message: "Players fetched successfully",
-		Data:    players,
+		Code:       200,
+		Status:     "Success",
+		Message:    "Players fetched successfully",
+		Data:       players,
+		Pagination: pagination,
 	}
 
 	ctx.JSON(200, webResponse)
@@ -205,6 +233,7 @@ func (controller *PlayerProfileController) GetPlayerByID(ctx *gin.Context) {
 //	@Failure		500			{object}	response.BaseResponse
 //	@Router			/players/{playerID} [put]
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 func (controller *PlayerProfileController) UpdatePlayer(ctx *gin.Context) {
 	playerID := ctx.Param("playerID")
 	updatePlayerProfileRequest := request.UpdatePlayerProfileRequest{}
@@ -258,6 +287,83 @@ func (controller *PlayerProfileController) UpdatePlayer(ctx *gin.Context) {
 	ctx.JSON(200, webResponse)
 }
 
+// UpdatePlayerProgress godoc
+//
+//	@Summary		Update player progression by ID
+//	@Description	Update the experience and points of a player, the level follows from the experience on the XP curve. Reserved to game servers and admins
+//	@Tags			Player
+//	@Accept			json
+//	@Produce		json
+//	@Param			playerID	path		int									true	"Player ID"
+//	@Param			request		body		request.UpdatePlayerProgressRequest	true	"Update Player Progress Request"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/players/{playerID}/progress [put]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *PlayerProfileController) UpdatePlayerProgress(ctx *gin.Context) {
+	playerID := ctx.Param("playerID")
+	updatePlayerProgressRequest := request.UpdatePlayerProgressRequest{}
+
+	err := ctx.ShouldBindJSON(&updatePlayerProgressRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	playerIDInt, err := strconv.Atoi(playerID)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidPlayerProfileID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.playerProfileService.UpdateProgress(uint(playerIDInt), updatePlayerProgressRequest)
+	if err != nil {
+		status, message := 500, "Failed to update player progress"
+		switch {
+		case errors.Is(err, helpers.ErrInvalidPlayerProfileID), errors.Is(err, helpers.ErrPlayerProfileDataValidation):
+			status, message = 400, err.Error()
+		case errors.Is(err, helpers.ErrorPlayerProfileNotFound):
+			status, message = 404, err.Error()
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    status,
+			Status:  "Error",
+			Message: message,
+			Data:    nil,
+		}
+
+		ctx.JSON(status, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Player progress updated successfully",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
 // DeletePlayer godoc
 //
 //	@Summary		Delete player by ID
@@ -313,18 +419,25 @@ func (controller *PlayerProfileController) DeletePlayer(ctx *gin.Context) {
 // GetPlayerWithAchievements godoc
 //
 //	@Summary		Get player with achievements by ID
-//	@Description	Get player with achievements by ID
+//	@Description	Get player with the unlocked achievements, paginated and sorted by unlock date, default page is 1, default pageSize is 10 and default order is desc
 //	@Tags			Player
 //	@Accept			json
 //	@Produce		json
-//	@Param			playerID	path		int	true	"Player ID"
-//	@Success		200			{object}	response.BaseResponse
+//	@Param			playerID	path		int		true	"Player ID"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Param			order		query		string	false	"Sort order by unlock date"	Enums(asc, desc)
+//	@Success		200			{object}	response.BaseResponse{data=response.PlayerWithAchievements}
 //	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
 //	@Failure		500			{object}	response.BaseResponse
 //	@Router			/players/{playerID}/achievements [get]
 //	@Security		BearerAuth
 func (controller *PlayerProfileController) GetPlayerWithAchievements(ctx *gin.Context) {
 	playerID := ctx.Param("playerID")
+	page := ctx.DefaultQuery("page", "1")
+	pageSize := ctx.DefaultQuery("pageSize", "10")
+	order := ctx.DefaultQuery("order", "desc")
 
 	playerIDInt, err := strconv.Atoi(playerID)
 	if err != nil {
@@ -339,8 +452,58 @@ func (controller *PlayerProfileController) GetPlayerWithAchievements(ctx *gin.Co
 		return
 	}
 
-	player, err := controller.playerProfileService.GetPlayerWithAchievements(uint(playerIDInt))
+	pageInt, err := strconv.Atoi(page)
 	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid page",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	pageSizeInt, err := strconv.Atoi(pageSize)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid pageSize",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	player, err := controller.playerProfileService.GetPlayerWithAchievements(uint(playerIDInt), pageInt, pageSizeInt, order)
+	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidPagination) || errors.Is(err, helpers.ErrInvalidSortOrder) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		if errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
 		errorResponse := response.BaseResponse{
 			Code:    500,
 			Status:  "Error",
@@ -9,6 +9,8 @@ import (
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -16,23 +18,19 @@ import (
 
 func TestPlayerController_Create(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	t.Run("CreatePlayer_Success", func(t *testing.T) {
-		mockPlayerService := new(mocks.MockPlayerProfileService)
-		controller := NewPlayerProfileController(mockPlayerService)
-		router := gin.Default()
-		router.POST("/player", controller.CreatePlayerProfile)
-
-		reqBody := request.CreatePlayerProfileRequest{
-			Nickname:   "dieg0",
-			Avatar:     "https://avatar.com",
-			Level:      1,
-			Experience: 10,
-			Points:     100,
-			UserID:     1,
-		}
-
-		mockPlayerService.On("Create", reqBody).Return(nil)
 
+	// newRouter authenticates every request as the given user, moderators create profiles for anyone
+	newRouter := func(controller *PlayerProfileController, authUserID uint, permissions ...string) *gin.Engine {
+		router := gin.Default()
+		router.POST("/player", func(ctx *gin.Context) {
+			ctx.Set("userID", authUserID)
+			ctx.Set("permissions", permissions)
+			ctx.Next()
+		}, controller.CreatePlayerProfile)
+		return router
+	}
+
+	postPlayer := func(router *gin.Engine, reqBody request.CreatePlayerProfileRequest) *httptest.ResponseRecorder {
 		body, _ := json.Marshal(reqBody)
 		req, err := http.NewRequest(http.MethodPost, "/player", bytes.NewBuffer(body))
 		assert.NoError(t, err, "Expected no error in creating request")
@@ -40,56 +38,108 @@ func TestPlayerController_Create(t *testing.T) {
 
 		rec := httptest.NewRecorder()
 		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("CreatePlayer_Success", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		router := newRouter(NewPlayerProfileController(mockPlayerService), 1)
+
+		reqBody := request.CreatePlayerProfileRequest{
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
+		}
+
+		// The owner comes from the token
+		mockPlayerService.On("Create", request.CreatePlayerProfileRequest{
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
+			UserID:   1,
+		}).Return(nil)
+
+		rec := postPlayer(router, reqBody)
 
 		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
 		mockPlayerService.AssertExpectations(t)
 	})
 
-	t.Run("CreatePlayer_InvalidRequestBody", func(t *testing.T) {
+	t.Run("CreatePlayer_OtherUserForbidden", func(t *testing.T) {
 		mockPlayerService := new(mocks.MockPlayerProfileService)
-		controller := NewPlayerProfileController(mockPlayerService)
-		router := gin.Default()
-		router.POST("/player", controller.CreatePlayerProfile)
+		router := newRouter(NewPlayerProfileController(mockPlayerService), 1)
 
-		req, err := http.NewRequest(http.MethodPost, "/player", bytes.NewBuffer([]byte("invalid json")))
-		assert.NoError(t, err, "Expected no error in creating request")
-		req.Header.Set("Content-Type", "application/json")
+		rec := postPlayer(router, request.CreatePlayerProfileRequest{
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
+			UserID:   2,
+		})
 
-		rec := httptest.NewRecorder()
-		router.ServeHTTP(rec, req)
-
-		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		assert.Equal(t, http.StatusForbidden, rec.Code, "Status code should be 403")
 		mockPlayerService.AssertNotCalled(t, "Create")
 	})
 
-	t.Run("CreatePlayer_FailedToCreate", func(t *testing.T) {
+	t.Run("CreatePlayer_ModeratorForOtherUser", func(t *testing.T) {
 		mockPlayerService := new(mocks.MockPlayerProfileService)
-		controller := NewPlayerProfileController(mockPlayerService)
-		router := gin.Default()
-		router.POST("/player", controller.CreatePlayerProfile)
+		router := newRouter(NewPlayerProfileController(mockPlayerService), 1, models.PermissionPlayersModerate)
 
 		reqBody := request.CreatePlayerProfileRequest{
-			Nickname:   "",
-			Avatar:     "https://avatar.com",
-			Level:      0,
-			Experience: 10,
-			Points:     100,
-			UserID:     1,
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
+			UserID:   2,
 		}
 
-		mockPlayerService.On("Create", reqBody).Return(assert.AnError)
+		mockPlayerService.On("Create", reqBody).Return(nil)
+
+		rec := postPlayer(router, reqBody)
 
-		body, _ := json.Marshal(reqBody)
-		req, err := http.NewRequest(http.MethodPost, "/player", bytes.NewBuffer(body))
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePlayer_InvalidRequestBody", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		router := newRouter(NewPlayerProfileController(mockPlayerService), 1)
+
+		req, err := http.NewRequest(http.MethodPost, "/player", bytes.NewBuffer([]byte("invalid json")))
 		assert.NoError(t, err, "Expected no error in creating request")
 		req.Header.Set("Content-Type", "application/json")
 
 		rec := httptest.NewRecorder()
 		router.ServeHTTP(rec, req)
 
-		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Status code should be 500")
-		mockPlayerService.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertNotCalled(t, "Create")
 	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{"CreatePlayer_ValidationError", helpers.ErrPlayerProfileDataValidation, http.StatusBadRequest},
+		{"CreatePlayer_UserNotFound", helpers.ErrorUserNotFound, http.StatusNotFound},
+		{"CreatePlayer_LimitReached", helpers.ErrPlayerProfileLimitReached, http.StatusConflict},
+		{"CreatePlayer_FailedToCreate", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPlayerService := new(mocks.MockPlayerProfileService)
+			router := newRouter(NewPlayerProfileController(mockPlayerService), 1)
+
+			reqBody := request.CreatePlayerProfileRequest{
+				Nickname: "dieg0",
+				Avatar:   "https://avatar.com",
+				UserID:   1,
+			}
+
+			mockPlayerService.On("Create", reqBody).Return(tc.err)
+
+			rec := postPlayer(router, reqBody)
+
+			assert.Equal(t, tc.statusCode, rec.Code)
+			mockPlayerService.AssertExpectations(t)
+		})
+	}
 }
 
 func TestPlayerController_GetAll(t *testing.T) {
@@ -100,7 +150,7 @@ func TestPlayerController_GetAll(t *testing.T) {
 		router := gin.Default()
 		router.GET("/players", controller.GetAllPlayers)
 
-		mockPlayerService.On("GetAll", 1, 10).Return([]response.PlayerProfileResponse{}, nil)
+		mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, uint(0)).Return([]response.PlayerProfileResponse{}, nil, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/players?page=1&pageSize=10", nil)
 		rec := httptest.NewRecorder()
@@ -136,7 +186,7 @@ func TestPlayerController_GetAll(t *testing.T) {
 		router := gin.Default()
 		router.GET("/players", controller.GetAllPlayers)
 
-		mockPlayerService.On("GetAll", 1, 10).Return(nil, assert.AnError)
+		mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, uint(0)).Return(nil, nil, assert.AnError)
 
 		req, _ := http.NewRequest(http.MethodGet, "/players?page=1&pageSize=10", nil)
 		rec := httptest.NewRecorder()
@@ -145,6 +195,138 @@ func TestPlayerController_GetAll(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Status code should be 500")
 		mockPlayerService.AssertExpectations(t)
 	})
+
+	t.Run("GetAllPlayers_ListQuery", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", controller.GetAllPlayers)
+
+		query := models.ListQuery{
+			Filters: []models.Filter{
+				{Field: "nickname", Operator: models.FilterPrefix, Value: "noob master"},
+				{Field: "level", Operator: models.FilterGreaterOrEqual, Value: "10"},
+			},
+			Sort: []models.SortField{{Field: "points", Descending: true}, {Field: "nickname"}},
+		}
+		mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, query, uint(0)).Return([]response.PlayerProfileResponse{}, nil, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players?page=1&pageSize=10&nickname~=noob%20master&level>=10&sort=-points,nickname", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_MalformedListQuery", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", controller.GetAllPlayers)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players?page=1&pageSize=10&level", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("GetAllPlayers_InvalidListQuery", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", controller.GetAllPlayers)
+
+		query := models.ListQuery{Filters: []models.Filter{{Field: "avatar", Operator: models.FilterEqual, Value: "a.png"}}}
+		mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, query, uint(0)).Return([]response.PlayerProfileResponse(nil), nil, helpers.ErrInvalidListQuery)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players?page=1&pageSize=10&avatar=a.png", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_Cursor", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", controller.GetAllPlayers)
+
+		total := int64(25)
+		pagination := &response.Pagination{NextCursor: "next", PrevCursor: "prev", HasMore: true, Total: &total}
+		pageRequest := request.PageRequest{Page: 1, PageSize: 10, After: "cursor", Total: true}
+		mockPlayerService.On("GetAll", pageRequest, models.ListQuery{}, uint(0)).Return([]response.PlayerProfileResponse{}, pagination, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players?after=cursor&total=true", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+
+		var response response.BaseResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NoError(t, err, "Should be able to unmarshal response")
+		assert.Equal(t, pagination, response.Pagination)
+
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_Viewer", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", func(ctx *gin.Context) {
+			ctx.Set("userID", uint(7))
+			ctx.Next()
+		}, controller.GetAllPlayers)
+
+		mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, uint(7)).Return([]response.PlayerProfileResponse{}, nil, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_InvalidTotal", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/players", controller.GetAllPlayers)
+
+		req, _ := http.NewRequest(http.MethodGet, "/players?total=maybe", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("GetAllPlayers_InvalidPage", func(t *testing.T) {
+		errorCases := []error{helpers.ErrInvalidCursor, helpers.ErrConflictingCursors, helpers.ErrInvalidPagination}
+
+		for _, serviceErr := range errorCases {
+			mockPlayerService := new(mocks.MockPlayerProfileService)
+			controller := NewPlayerProfileController(mockPlayerService)
+			router := gin.Default()
+			router.GET("/players", controller.GetAllPlayers)
+
+			mockPlayerService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10, Before: "cursor"}, models.ListQuery{}, uint(0)).Return(nil, nil, serviceErr)
+
+			req, _ := http.NewRequest(http.MethodGet, "/players?before=cursor", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, serviceErr.Error())
+			mockPlayerService.AssertExpectations(t)
+		}
+	})
 }
 
 func TestPlayerController_GetPlayerByID(t *testing.T) {
@@ -212,11 +394,8 @@ func TestPlayerController_Update(t *testing.T) {
 		router.PUT("/player/:playerID", controller.UpdatePlayer)
 
 		reqBody := request.UpdatePlayerProfileRequest{
-			Nickname:   "dieg0",
-			Avatar:     "https://avatar.com",
-			Level:      1,
-			Experience: 10,
-			Points:     100,
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
 		}
 
 		mockPlayerService.On("Update", uint(1), reqBody).Return(nil)
@@ -267,11 +446,8 @@ func TestPlayerController_Update(t *testing.T) {
 		router.PUT("/player/:playerID", controller.UpdatePlayer)
 
 		reqBody := request.UpdatePlayerProfileRequest{
-			Nickname:   "dieg0",
-			Avatar:     "https://avatar.com",
-			Level:      1,
-			Experience: 10,
-			Points:     100,
+			Nickname: "dieg0",
+			Avatar:   "https://avatar.com",
 		}
 
 		body, _ := json.Marshal(reqBody)
@@ -292,6 +468,83 @@ func TestPlayerController_Update(t *testing.T) {
 	})
 }
 
+func TestPlayerController_UpdateProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("UpdateProgress_Success", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.PUT("/player/:playerID/progress", controller.UpdatePlayerProgress)
+
+		reqBody := request.UpdatePlayerProgressRequest{
+			Experience: 200,
+			Points:     150,
+		}
+
+		mockPlayerService.On("UpdateProgress", uint(1), reqBody).Return(nil)
+
+		body, _ := json.Marshal(reqBody)
+		req, err := http.NewRequest(http.MethodPut, "/player/1/progress", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error in creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Status code should be 200")
+		mockPlayerService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateProgress_InvalidPlayerID", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.PUT("/player/:playerID/progress", controller.UpdatePlayerProgress)
+
+		body, _ := json.Marshal(request.UpdatePlayerProgressRequest{Points: 150})
+		req, err := http.NewRequest(http.MethodPut, "/player/asd/progress", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error in creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertNotCalled(t, "UpdateProgress")
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{"UpdateProgress_ValidationError", helpers.ErrPlayerProfileDataValidation, http.StatusBadRequest},
+		{"UpdateProgress_NotFound", helpers.ErrorPlayerProfileNotFound, http.StatusNotFound},
+		{"UpdateProgress_InternalError", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPlayerService := new(mocks.MockPlayerProfileService)
+			controller := NewPlayerProfileController(mockPlayerService)
+			router := gin.Default()
+			router.PUT("/player/:playerID/progress", controller.UpdatePlayerProgress)
+
+			reqBody := request.UpdatePlayerProgressRequest{Points: 150}
+			mockPlayerService.On("UpdateProgress", uint(1), reqBody).Return(tc.err)
+
+			body, _ := json.Marshal(reqBody)
+			req, err := http.NewRequest(http.MethodPut, "/player/1/progress", bytes.NewBuffer(body))
+			assert.NoError(t, err, "Expected no error in creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.statusCode, rec.Code)
+			mockPlayerService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestPlayerController_Delete(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -361,9 +614,9 @@ func TestPlayerController_GetPlayerWithAchievements(t *testing.T) {
 		router := gin.Default()
 		router.GET("/player/:playerID/achievements", controller.GetPlayerWithAchievements)
 
-		mockPlayerService.On("GetPlayerWithAchievements", uint(1)).Return(&response.PlayerWithAchievements{}, nil)
+		mockPlayerService.On("GetPlayerWithAchievements", uint(1), 2, 5, "asc").Return(&response.PlayerWithAchievements{}, nil)
 
-		req, _ := http.NewRequest(http.MethodGet, "/player/1/achievements", nil)
+		req, _ := http.NewRequest(http.MethodGet, "/player/1/achievements?page=2&pageSize=5&order=asc", nil)
 		rec := httptest.NewRecorder()
 		router.ServeHTTP(rec, req)
 
@@ -397,7 +650,7 @@ func TestPlayerController_GetPlayerWithAchievements(t *testing.T) {
 		router := gin.Default()
 		router.GET("/player/:playerID/achievements", controller.GetPlayerWithAchievements)
 
-		mockPlayerService.On("GetPlayerWithAchievements", uint(1)).Return(nil, assert.AnError)
+		mockPlayerService.On("GetPlayerWithAchievements", uint(1), 1, 10, "desc").Return(nil, assert.AnError)
 
 		req, _ := http.NewRequest(http.MethodGet, "/player/1/achievements", nil)
 		rec := httptest.NewRecorder()
@@ -406,4 +659,34 @@ func TestPlayerController_GetPlayerWithAchievements(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Status code should be 500")
 		mockPlayerService.AssertExpectations(t)
 	})
+
+	t.Run("GetPlayerWithAchievements_InvalidPageSize", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/player/:playerID/achievements", controller.GetPlayerWithAchievements)
+
+		req, _ := http.NewRequest(http.MethodGet, "/player/1/achievements?pageSize=abc", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Status code should be 400")
+		mockPlayerService.AssertNotCalled(t, "GetPlayerWithAchievements")
+	})
+
+	t.Run("GetPlayerWithAchievements_NotFound", func(t *testing.T) {
+		mockPlayerService := new(mocks.MockPlayerProfileService)
+		controller := NewPlayerProfileController(mockPlayerService)
+		router := gin.Default()
+		router.GET("/player/:playerID/achievements", controller.GetPlayerWithAchievements)
+
+		mockPlayerService.On("GetPlayerWithAchievements", uint(1), 1, 10, "desc").Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/player/1/achievements", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "Status code should be 404")
+		mockPlayerService.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,377 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	roleService services.RoleService
+}
+
+func NewRoleController(service services.RoleService) *RoleController {
+	return &RoleController{
+		roleService: service,
+	}
+}
+
+// GetPermissions godoc
+//
+//	@Summary		List the permissions
+//	@Description	Catalog of the permissions that can be granted to roles and API keys
+//	@Tags			Roles
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=[]response.PermissionResponse}
+//	@Failure		403	{object}	response.BaseResponse
+//	@Router			/permissions [get]
+//	@Security		BearerAuth
+func (controller *RoleController) GetPermissions(ctx *gin.Context) {
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Permissions found",
+		Data:    controller.roleService.GetPermissions(),
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetAllRoles godoc
+//
+//	@Summary		List the roles
+//	@Description	All the roles with their permissions
+//	@Tags			Roles
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=[]response.RoleResponse}
+//	@Failure		403	{object}	response.BaseResponse
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/roles [get]
+//	@Security		BearerAuth
+func (controller *RoleController) GetAllRoles(ctx *gin.Context) {
+	roles, err := controller.roleService.GetAllRoles()
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to get roles",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Roles found",
+		Data:    roles,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetRole godoc
+//
+//	@Summary		Get a role
+//	@Description	Get a role and its permissions by ID
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			roleID	path		int	true	"Role ID"
+//	@Success		200		{object}	response.BaseResponse{data=response.RoleResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/roles/{roleID} [get]
+//	@Security		BearerAuth
+func (controller *RoleController) GetRole(ctx *gin.Context) {
+	roleID, ok := controller.roleIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	role, err := controller.roleService.GetRole(roleID)
+	if err != nil {
+		controller.writeRoleError(ctx, err, "Failed to get role")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Role found",
+		Data:    role,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a role
+//	@Description	Create a role with a set of permissions, users can then be given the role
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateRoleRequest	true	"Create Role Request"
+//	@Success		201		{object}	response.BaseResponse{data=response.RoleResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/roles [post]
+//	@Security		BearerAuth
+func (controller *RoleController) CreateRole(ctx *gin.Context) {
+	createRequest := request.CreateRoleRequest{}
+
+	err := ctx.ShouldBindJSON(&createRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	role, err := controller.roleService.CreateRole(createRequest)
+	if err != nil {
+		controller.writeRoleError(ctx, err, "Failed to create role")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    201,
+		Status:  "Success",
+		Message: "Role created",
+		Data:    role,
+	}
+
+	ctx.JSON(201, webResponse)
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a role
+//	@Description	Replace the description and permissions of a role, the change applies to every user with the role. Admin keeps every permission and the default role of new accounts can't get any
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			roleID	path		int							true	"Role ID"
+//	@Param			request	body		request.UpdateRoleRequest	true	"Update Role Request"
+//	@Success		200		{object}	response.BaseResponse{data=response.RoleResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/roles/{roleID} [put]
+//	@Security		BearerAuth
+func (controller *RoleController) UpdateRole(ctx *gin.Context) {
+	roleID, ok := controller.roleIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	updateRequest := request.UpdateRoleRequest{}
+
+	err := ctx.ShouldBindJSON(&updateRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	role, err := controller.roleService.UpdateRole(roleID, updateRequest)
+	if err != nil {
+		controller.writeRoleError(ctx, err, "Failed to update role")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Role updated",
+		Data:    role,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a role
+//	@Description	Delete a role no user has, built-in roles can't be deleted
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			roleID	path		int	true	"Role ID"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/roles/{roleID} [delete]
+//	@Security		BearerAuth
+func (controller *RoleController) DeleteRole(ctx *gin.Context) {
+	roleID, ok := controller.roleIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	err := controller.roleService.DeleteRole(roleID)
+	if err != nil {
+		controller.writeRoleError(ctx, err, "Failed to delete role")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Role deleted",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// AssignRole godoc
+//
+//	@Summary		Change the role of a user
+//	@Description	Give a user another role, their access tokens are revoked so the change applies on the next refresh. The last admin can't be demoted
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			userID	path		int							true	"User ID"
+//	@Param			request	body		request.AssignRoleRequest	true	"Assign Role Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/role [put]
+//	@Security		BearerAuth
+func (controller *RoleController) AssignRole(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	assignRequest := request.AssignRoleRequest{}
+
+	err = ctx.ShouldBindJSON(&assignRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.roleService.AssignRole(uint(userIDInt), assignRequest)
+	if err != nil {
+		status := 500
+		message := "Failed to change role"
+
+		switch {
+		case errors.Is(err, helpers.ErrRoleDataValidation), errors.Is(err, helpers.ErrorRoleNotFound):
+			status, message = 400, err.Error()
+		case errors.Is(err, helpers.ErrorUserNotFound):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrLastAdmin):
+			status, message = 409, err.Error()
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    status,
+			Status:  "Error",
+			Message: message,
+			Data:    nil,
+		}
+
+		ctx.JSON(status, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Role changed",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *RoleController) roleIDParam(ctx *gin.Context) (uint, bool) {
+	roleIDInt, err := strconv.Atoi(ctx.Param("roleID"))
+	if err != nil || roleIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidRoleID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return 0, false
+	}
+
+	return uint(roleIDInt), true
+}
+
+func (controller *RoleController) writeRoleError(ctx *gin.Context, err error, failedMessage string) {
+	status := 500
+	message := failedMessage
+
+	switch {
+	case errors.Is(err, helpers.ErrRoleDataValidation), errors.Is(err, helpers.ErrUnknownPermission), errors.Is(err, helpers.ErrInvalidRoleID):
+		status, message = 400, err.Error()
+	case errors.Is(err, helpers.ErrorRoleNotFound):
+		status, message = 404, err.Error()
+	case errors.Is(err, helpers.ErrRoleAlreadyExists), errors.Is(err, helpers.ErrRoleInUse), errors.Is(err, helpers.ErrBuiltInRole), errors.Is(err, helpers.ErrPrivilegedDefaultRole):
+		status, message = 409, err.Error()
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    status,
+		Status:  "Error",
+		Message: message,
+		Data:    nil,
+	}
+
+	ctx.JSON(status, errorResponse)
+}
+
+func (controller *RoleController) GetUserPermissionsFromService(userID uint) ([]string, error) {
+	return controller.roleService.GetUserPermissions(userID)
+}
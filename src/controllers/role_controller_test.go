@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRoleController_GetAllRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRoleService := new(mocks.MockRoleService)
+	roleController := NewRoleController(mockRoleService)
+	router := gin.Default()
+	router.GET("/roles", roleController.GetAllRoles)
+
+	mockRoleService.On("GetAllRoles").Return([]response.RoleResponse{{ID: 1, Name: "admin"}, {ID: 4, Name: "moderator"}}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/roles", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var webResponse response.BaseResponse
+	err = json.NewDecoder(rec.Body).Decode(&webResponse)
+	assert.NoError(t, err, "Expected no error decoding response")
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+	assert.Len(t, webResponse.Data, 2)
+}
+
+func TestRoleController_CreateRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("CreateRole_Success", func(t *testing.T) {
+		mockRoleService := new(mocks.MockRoleService)
+		roleController := NewRoleController(mockRoleService)
+		router := gin.Default()
+		router.POST("/roles", roleController.CreateRole)
+
+		createRequest := request.CreateRoleRequest{Name: "moderator", Permissions: []string{"players:moderate"}}
+		mockRoleService.On("CreateRole", createRequest).Return(&response.RoleResponse{ID: 4, Name: "moderator"}, nil)
+
+		body, _ := json.Marshal(createRequest)
+		req, err := http.NewRequest(http.MethodPost, "/roles", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, "Expected status code 201")
+		mockRoleService.AssertExpectations(t)
+	})
+
+	errorCases := map[string]struct {
+		err    error
+		status int
+	}{
+		"UnknownPermission": {helpers.ErrUnknownPermission, http.StatusBadRequest},
+		"AlreadyExists":     {helpers.ErrRoleAlreadyExists, http.StatusConflict},
+		"Internal":          {assert.AnError, http.StatusInternalServerError},
+	}
+
+	for name, tc := range errorCases {
+		t.Run("CreateRole_"+name, func(t *testing.T) {
+			mockRoleService := new(mocks.MockRoleService)
+			roleController := NewRoleController(mockRoleService)
+			router := gin.Default()
+			router.POST("/roles", roleController.CreateRole)
+
+			mockRoleService.On("CreateRole", mock.Anything).Return(nil, tc.err)
+
+			req, err := http.NewRequest(http.MethodPost, "/roles", bytes.NewBufferString(`{"name":"moderator"}`))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestRoleController_UpdateRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("UpdateRole_Success", func(t *testing.T) {
+		mockRoleService := new(mocks.MockRoleService)
+		roleController := NewRoleController(mockRoleService)
+		router := gin.Default()
+		router.PUT("/roles/:roleID", roleController.UpdateRole)
+
+		updateRequest := request.UpdateRoleRequest{Description: "Moderators", Permissions: []string{"players:moderate"}}
+		mockRoleService.On("UpdateRole", uint(4), updateRequest).Return(&response.RoleResponse{ID: 4, Name: "moderator"}, nil)
+
+		body, _ := json.Marshal(updateRequest)
+		req, err := http.NewRequest(http.MethodPut, "/roles/4", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockRoleService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateRole_InvalidID", func(t *testing.T) {
+		mockRoleService := new(mocks.MockRoleService)
+		roleController := NewRoleController(mockRoleService)
+		router := gin.Default()
+		router.PUT("/roles/:roleID", roleController.UpdateRole)
+
+		req, err := http.NewRequest(http.MethodPut, "/roles/abc", bytes.NewBufferString(`{}`))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockRoleService.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleController_DeleteRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	errorCases := map[string]struct {
+		err    error
+		status int
+	}{
+		"Success":  {nil, http.StatusOK},
+		"NotFound": {helpers.ErrorRoleNotFound, http.StatusNotFound},
+		"InUse":    {helpers.ErrRoleInUse, http.StatusConflict},
+		"BuiltIn":  {helpers.ErrBuiltInRole, http.StatusConflict},
+	}
+
+	for name, tc := range errorCases {
+		t.Run("DeleteRole_"+name, func(t *testing.T) {
+			mockRoleService := new(mocks.MockRoleService)
+			roleController := NewRoleController(mockRoleService)
+			router := gin.Default()
+			router.DELETE("/roles/:roleID", roleController.DeleteRole)
+
+			mockRoleService.On("DeleteRole", uint(4)).Return(tc.err)
+
+			req, err := http.NewRequest(http.MethodDelete, "/roles/4", nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestRoleController_AssignRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	errorCases := map[string]struct {
+		err    error
+		status int
+	}{
+		"Success":      {nil, http.StatusOK},
+		"UnknownRole":  {helpers.ErrorRoleNotFound, http.StatusBadRequest},
+		"UserNotFound": {helpers.ErrorUserNotFound, http.StatusNotFound},
+		"LastAdmin":    {helpers.ErrLastAdmin, http.StatusConflict},
+	}
+
+	for name, tc := range errorCases {
+		t.Run("AssignRole_"+name, func(t *testing.T) {
+			mockRoleService := new(mocks.MockRoleService)
+			roleController := NewRoleController(mockRoleService)
+			router := gin.Default()
+			router.PUT("/users/:userID/role", roleController.AssignRole)
+
+			mockRoleService.On("AssignRole", uint(7), request.AssignRoleRequest{Role: "moderator"}).Return(tc.err)
+
+			req, err := http.NewRequest(http.MethodPut, "/users/7/role", bytes.NewBufferString(`{"role":"moderator"}`))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type SeasonController struct {
+	seasonService services.SeasonService
+}
+
+func NewSeasonController(service services.SeasonService) *SeasonController {
+	return &SeasonController{
+		seasonService: service,
+	}
+}
+
+// StartSeason godoc
+//
+//	@Summary		Start a new season
+//	@Description	End the running season archiving the final season points standings, reset the season points of every player and start a new season. Lifetime points, level and experience are kept
+//	@Tags			Leaderboard
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.StartSeasonRequest	true	"Start Season Request"
+//	@Success		201		{object}	response.BaseResponse{data=response.SeasonResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/seasons [post]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *SeasonController) StartSeason(ctx *gin.Context) {
+	startRequest := request.StartSeasonRequest{}
+
+	err := ctx.ShouldBindJSON(&startRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	season, err := controller.seasonService.StartSeason(startRequest)
+	if err != nil {
+		controller.writeSeasonError(ctx, err, "Failed to start season")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    201,
+		Status:  "Success",
+		Message: "Season started",
+		Data:    season,
+	}
+
+	ctx.JSON(201, webResponse)
+}
+
+// GetAllSeasons godoc
+//
+//	@Summary		Get all seasons
+//	@Description	Get all the seasons, latest first
+//	@Tags			Leaderboard
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=[]response.SeasonResponse}
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/seasons [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *SeasonController) GetAllSeasons(ctx *gin.Context) {
+	seasons, err := controller.seasonService.GetAllSeasons()
+	if err != nil {
+		controller.writeSeasonError(ctx, err, "Failed to get seasons")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Seasons fetched successfully",
+		Data:    seasons,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetCurrentSeason godoc
+//
+//	@Summary		Get the current season
+//	@Description	Get the running season, its board is the season_points leaderboard
+//	@Tags			Leaderboard
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=response.SeasonResponse}
+//	@Failure		404	{object}	response.BaseResponse
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/seasons/current [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *SeasonController) GetCurrentSeason(ctx *gin.Context) {
+	season, err := controller.seasonService.GetCurrentSeason()
+	if err != nil {
+		controller.writeSeasonError(ctx, err, "Failed to get current season")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Season fetched successfully",
+		Data:    season,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// GetStandings godoc
+//
+//	@Summary		Get the standings of a season
+//	@Description	Final season points standings of an ended season, best first
+//	@Tags			Leaderboard
+//	@Produce		json
+//	@Param			seasonID	path		int	true	"Season ID"
+//	@Param			page		query		int	false	"Page number"
+//	@Param			pageSize	query		int	false	"Page size"
+//	@Success		200			{object}	response.BaseResponse{data=[]response.SeasonStandingResponse}
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/seasons/{seasonID}/standings [get]
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+func (controller *SeasonController) GetStandings(ctx *gin.Context) {
+	seasonIDInt, err := strconv.Atoi(ctx.Param("seasonID"))
+	if err != nil || seasonIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidSeasonID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	page, pageSize, ok := paginationQuery(ctx)
+	if !ok {
+		return
+	}
+
+	standings, err := controller.seasonService.GetStandings(uint(seasonIDInt), page, pageSize)
+	if err != nil {
+		controller.writeSeasonError(ctx, err, "Failed to get standings")
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Standings fetched successfully",
+		Data:    standings,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func (controller *SeasonController) writeSeasonError(ctx *gin.Context, err error, failedMessage string) {
+	status := 500
+	message := failedMessage
+
+	switch {
+	case errors.Is(err, helpers.ErrSeasonDataValidation), errors.Is(err, helpers.ErrInvalidSeasonID), errors.Is(err, helpers.ErrInvalidPagination):
+		status, message = 400, err.Error()
+	case errors.Is(err, helpers.ErrorSeasonNotFound):
+		status, message = 404, err.Error()
+	case errors.Is(err, helpers.ErrorSeasonAlreadyExists), errors.Is(err, helpers.ErrorSeasonAlreadyEnded):
+		status, message = 409, err.Error()
+	}
+
+	errorResponse := response.BaseResponse{
+		Code:    status,
+		Status:  "Error",
+		Message: message,
+		Data:    nil,
+	}
+
+	ctx.JSON(status, errorResponse)
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeasonController_StartSeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(seasonController *SeasonController) *gin.Engine {
+		router := gin.Default()
+		router.POST("/seasons", seasonController.StartSeason)
+		return router
+	}
+
+	startRequest := request.StartSeasonRequest{Name: "Season 2"}
+
+	t.Run("StartSeason_Success", func(t *testing.T) {
+		mockSeasonService := new(mocks.MockSeasonService)
+		seasonController := NewSeasonController(mockSeasonService)
+
+		mockSeasonService.On("StartSeason", startRequest).Return(&response.SeasonResponse{ID: 2, Name: "Season 2", StartedAt: time.Now()}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/seasons", bytes.NewBufferString(`{"name":"Season 2"}`))
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(seasonController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code, "Expected status code 201")
+		assert.Contains(t, rec.Body.String(), `"name":"Season 2"`)
+		mockSeasonService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		body         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "StartSeason_InvalidBody", body: `invalid json`, expectedCode: http.StatusBadRequest},
+		{name: "StartSeason_ValidationError", body: `{"name":"Season 2"}`, serviceErr: helpers.ErrSeasonDataValidation, expectedCode: http.StatusBadRequest},
+		{name: "StartSeason_AlreadyExists", body: `{"name":"Season 2"}`, serviceErr: helpers.ErrorSeasonAlreadyExists, expectedCode: http.StatusConflict},
+		{name: "StartSeason_AlreadyEnded", body: `{"name":"Season 2"}`, serviceErr: helpers.ErrorSeasonAlreadyEnded, expectedCode: http.StatusConflict},
+		{name: "StartSeason_ServiceError", body: `{"name":"Season 2"}`, serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSeasonService := new(mocks.MockSeasonService)
+			seasonController := NewSeasonController(mockSeasonService)
+
+			if tc.serviceErr != nil {
+				mockSeasonService.On("StartSeason", startRequest).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/seasons", bytes.NewBufferString(tc.body))
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(seasonController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestSeasonController_GetCurrentSeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSeasonService := new(mocks.MockSeasonService)
+	seasonController := NewSeasonController(mockSeasonService)
+
+	router := gin.Default()
+	router.GET("/seasons/current", seasonController.GetCurrentSeason)
+
+	mockSeasonService.On("GetCurrentSeason").Return(nil, helpers.ErrorSeasonNotFound)
+
+	req, err := http.NewRequest(http.MethodGet, "/seasons/current", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Expected status code 404")
+}
+
+func TestSeasonController_GetAllSeasons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSeasonService := new(mocks.MockSeasonService)
+	seasonController := NewSeasonController(mockSeasonService)
+
+	router := gin.Default()
+	router.GET("/seasons", seasonController.GetAllSeasons)
+
+	mockSeasonService.On("GetAllSeasons").Return([]response.SeasonResponse{{ID: 1, Name: "Season 1"}}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/seasons", nil)
+	assert.NoError(t, err, "Expected no error creating request")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+	assert.Contains(t, rec.Body.String(), `"name":"Season 1"`)
+}
+
+func TestSeasonController_GetStandings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(seasonController *SeasonController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/seasons/:seasonID/standings", seasonController.GetStandings)
+		return router
+	}
+
+	t.Run("GetStandings_Success", func(t *testing.T) {
+		mockSeasonService := new(mocks.MockSeasonService)
+		seasonController := NewSeasonController(mockSeasonService)
+
+		mockSeasonService.On("GetStandings", uint(1), 2, 20).Return([]response.SeasonStandingResponse{{Rank: 21, PlayerID: 4}}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/seasons/1/standings?page=2&pageSize=20", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(seasonController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"rank":21`)
+		mockSeasonService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "GetStandings_InvalidSeasonID", path: "/seasons/abc/standings", expectedCode: http.StatusBadRequest},
+		{name: "GetStandings_InvalidPageSize", path: "/seasons/1/standings?pageSize=abc", expectedCode: http.StatusBadRequest},
+		{name: "GetStandings_SeasonNotFound", path: "/seasons/1/standings", serviceErr: helpers.ErrorSeasonNotFound, expectedCode: http.StatusNotFound},
+		{name: "GetStandings_ServiceError", path: "/seasons/1/standings", serviceErr: assert.AnError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSeasonService := new(mocks.MockSeasonService)
+			seasonController := NewSeasonController(mockSeasonService)
+
+			if tc.serviceErr != nil {
+				mockSeasonService.On("GetStandings", uint(1), 1, 10).Return(nil, tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			newRouter(seasonController).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
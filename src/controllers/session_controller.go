@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionController struct {
+	sessionService services.SessionService
+}
+
+func NewSessionController(service services.SessionService) *SessionController {
+	return &SessionController{
+		sessionService: service,
+	}
+}
+
+// GetSessions godoc
+//
+//	@Summary		List the active sessions of a user
+//	@Description	Devices signed in to the account, only the user or an admin can see them. The session making the request is marked as current
+//	@Tags			Users
+//	@Produce		json
+//	@Param			userID	path		int	true	"User ID"
+//	@Success		200		{object}	response.BaseResponse{data=[]response.SessionResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		403		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/users/{userID}/sessions [get]
+//	@Security		BearerAuth
+func (controller *SessionController) GetSessions(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	sessions, err := controller.sessionService.GetSessions(uint(userIDInt), ctx.GetUint("sessionID"))
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to get sessions",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Sessions found",
+		Data:    sessions,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// EndSession godoc
+//
+//	@Summary		Sign out a session
+//	@Description	Sign out a device remotely, its refresh token stops working and its access tokens are rejected right away. Only the user or an admin can do it
+//	@Tags			Users
+//	@Produce		json
+//	@Param			userID		path		int	true	"User ID"
+//	@Param			sessionID	path		int	true	"Session ID"
+//	@Success		200			{object}	response.BaseResponse
+//	@Failure		400			{object}	response.BaseResponse
+//	@Failure		403			{object}	response.BaseResponse
+//	@Failure		404			{object}	response.BaseResponse
+//	@Failure		500			{object}	response.BaseResponse
+//	@Router			/users/{userID}/sessions/{sessionID} [delete]
+//	@Security		BearerAuth
+func (controller *SessionController) EndSession(ctx *gin.Context) {
+	userIDInt, err := strconv.Atoi(ctx.Param("userID"))
+	if err != nil || userIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidUserID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	sessionIDInt, err := strconv.Atoi(ctx.Param("sessionID"))
+	if err != nil || sessionIDInt <= 0 {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: helpers.ErrInvalidSessionID.Error(),
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.sessionService.EndSession(uint(userIDInt), uint(sessionIDInt))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorSessionNotFound) {
+			errorResponse := response.BaseResponse{
+				Code:    404,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(404, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to end session",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Session ended",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionController_GetSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(sessionController *SessionController) *gin.Engine {
+		router := gin.Default()
+		router.GET("/users/:userID/sessions", func(ctx *gin.Context) {
+			ctx.Set("sessionID", uint(2))
+			ctx.Next()
+		}, sessionController.GetSessions)
+		return router
+	}
+
+	t.Run("GetSessions_Success", func(t *testing.T) {
+		mockSessionService := new(mocks.MockSessionService)
+		sessionController := NewSessionController(mockSessionService)
+
+		mockSessionService.On("GetSessions", uint(1), uint(2)).Return([]response.SessionResponse{{ID: 2, DeviceName: "Living room PS5", Current: true}}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, "/users/1/sessions", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(sessionController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), "Living room PS5")
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("GetSessions_InvalidUserID", func(t *testing.T) {
+		mockSessionService := new(mocks.MockSessionService)
+		sessionController := NewSessionController(mockSessionService)
+
+		req, err := http.NewRequest(http.MethodGet, "/users/abc/sessions", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(sessionController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+		mockSessionService.AssertNotCalled(t, "GetSessions")
+	})
+
+	t.Run("GetSessions_ServiceError", func(t *testing.T) {
+		mockSessionService := new(mocks.MockSessionService)
+		sessionController := NewSessionController(mockSessionService)
+
+		mockSessionService.On("GetSessions", uint(1), uint(2)).Return(nil, assert.AnError)
+
+		req, err := http.NewRequest(http.MethodGet, "/users/1/sessions", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		newRouter(sessionController).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+}
+
+func TestSessionController_EndSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		path         string
+		serviceErr   error
+		callsService bool
+		expectedCode int
+	}{
+		{name: "EndSession_Success", path: "/users/1/sessions/2", callsService: true, expectedCode: http.StatusOK},
+		{name: "EndSession_NotFound", path: "/users/1/sessions/2", serviceErr: helpers.ErrorSessionNotFound, callsService: true, expectedCode: http.StatusNotFound},
+		{name: "EndSession_ServiceError", path: "/users/1/sessions/2", serviceErr: assert.AnError, callsService: true, expectedCode: http.StatusInternalServerError},
+		{name: "EndSession_InvalidUserID", path: "/users/abc/sessions/2", expectedCode: http.StatusBadRequest},
+		{name: "EndSession_InvalidSessionID", path: "/users/1/sessions/0", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSessionService := new(mocks.MockSessionService)
+			sessionController := NewSessionController(mockSessionService)
+			router := gin.Default()
+			router.DELETE("/users/:userID/sessions/:sessionID", sessionController.EndSession)
+
+			if tc.callsService {
+				mockSessionService.On("EndSession", uint(1), uint(2)).Return(tc.serviceErr)
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, tc.path, nil)
+			assert.NoError(t, err, "Expected no error creating request")
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if !tc.callsService {
+				mockSessionService.AssertNotCalled(t, "EndSession")
+			}
+		})
+	}
+}
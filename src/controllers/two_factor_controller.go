@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorController(service services.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{
+		twoFactorService: service,
+	}
+}
+
+// Enroll godoc
+//
+//	@Summary		Start the two factor enrollment
+//	@Description	Create a TOTP secret for the authenticated user, it isn't enforced until it is confirmed with a code
+//	@Tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	response.BaseResponse{data=response.TwoFactorEnrollmentResponse}
+//	@Failure		400	{object}	response.BaseResponse
+//	@Failure		401	{object}	response.BaseResponse
+//	@Failure		500	{object}	response.BaseResponse
+//	@Router			/auth/2fa/enroll [post]
+//	@Security		BearerAuth
+func (controller *TwoFactorController) Enroll(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+
+	enrollment, err := controller.twoFactorService.Enroll(userID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrTwoFactorAlreadyEnabled) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to enroll two factor authentication",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Scan the URI with an authenticator app and confirm with a code",
+		Data:    enrollment,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Confirm godoc
+//
+//	@Summary		Confirm the two factor enrollment
+//	@Description	Enable two factor authentication with a code of the authenticator app. The response has the recovery codes, they are only shown once. Wrong codes count as failed logins
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.TwoFactorCodeRequest	true	"Two Factor Code Request"
+//	@Success		200		{object}	response.BaseResponse{data=response.RecoveryCodesResponse}
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		429		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/2fa/confirm [post]
+//	@Security		BearerAuth
+func (controller *TwoFactorController) Confirm(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+
+	codeRequest := request.TwoFactorCodeRequest{}
+
+	err := ctx.ShouldBindJSON(&codeRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	recoveryCodes, err := controller.twoFactorService.Confirm(userID, codeRequest, clientInfo(ctx))
+	if err != nil {
+		if writeLoginLimitError(ctx, err) {
+			return
+		}
+
+		if isTwoFactorClientError(err) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to confirm two factor authentication",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Two factor authentication enabled",
+		Data:    recoveryCodes,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+// Disable godoc
+//
+//	@Summary		Disable two factor authentication
+//	@Description	Disable two factor authentication with the current password and a TOTP or recovery code, the recovery codes are deleted. Wrong passwords and codes count as failed logins
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.DisableTwoFactorRequest	true	"Disable Two Factor Request"
+//	@Success		200		{object}	response.BaseResponse
+//	@Failure		400		{object}	response.BaseResponse
+//	@Failure		401		{object}	response.BaseResponse
+//	@Failure		429		{object}	response.BaseResponse
+//	@Failure		500		{object}	response.BaseResponse
+//	@Router			/auth/2fa/disable [post]
+//	@Security		BearerAuth
+func (controller *TwoFactorController) Disable(ctx *gin.Context) {
+	userID := ctx.GetUint("userID")
+
+	disableRequest := request.DisableTwoFactorRequest{}
+
+	err := ctx.ShouldBindJSON(&disableRequest)
+	if err != nil {
+		errorResponse := response.BaseResponse{
+			Code:    400,
+			Status:  "Error",
+			Message: "Invalid request body",
+			Data:    nil,
+		}
+
+		ctx.JSON(400, errorResponse)
+		return
+	}
+
+	err = controller.twoFactorService.Disable(userID, disableRequest, clientInfo(ctx))
+	if err != nil {
+		if writeLoginLimitError(ctx, err) {
+			return
+		}
+
+		if isTwoFactorClientError(err) {
+			errorResponse := response.BaseResponse{
+				Code:    400,
+				Status:  "Error",
+				Message: err.Error(),
+				Data:    nil,
+			}
+
+			ctx.JSON(400, errorResponse)
+			return
+		}
+
+		errorResponse := response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to disable two factor authentication",
+			Data:    nil,
+		}
+
+		ctx.JSON(500, errorResponse)
+		return
+	}
+
+	webResponse := response.BaseResponse{
+		Code:    200,
+		Status:  "Success",
+		Message: "Two factor authentication disabled",
+		Data:    nil,
+	}
+
+	ctx.JSON(200, webResponse)
+}
+
+func isTwoFactorClientError(err error) bool {
+	return errors.Is(err, helpers.ErrTwoFactorDataValidation) ||
+		errors.Is(err, helpers.ErrInvalidTwoFactorCode) ||
+		errors.Is(err, helpers.ErrInvalidCurrentPassword) ||
+		errors.Is(err, helpers.ErrTwoFactorNotEnabled) ||
+		errors.Is(err, helpers.ErrTwoFactorAlreadyEnabled)
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTwoFactorRouter authenticates every request as the user 1.
+func newTwoFactorRouter(twoFactorController *TwoFactorController) *gin.Engine {
+	router := gin.Default()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("userID", uint(1))
+		ctx.Set("role", "user")
+		ctx.Next()
+	})
+	router.POST("/auth/2fa/enroll", twoFactorController.Enroll)
+	router.POST("/auth/2fa/confirm", twoFactorController.Confirm)
+	router.POST("/auth/2fa/disable", twoFactorController.Disable)
+	return router
+}
+
+func TestTwoFactorController_Enroll(t *testing.T) {
+	t.Run("Enroll_Success", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		mockTwoFactorService.On("Enroll", uint(1)).Return(&response.TwoFactorEnrollmentResponse{Secret: "SECRET", URI: "otpauth://totp/test"}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/enroll", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"secret":"SECRET"`)
+		mockTwoFactorService.AssertExpectations(t)
+	})
+
+	t.Run("Enroll_AlreadyEnabled", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		mockTwoFactorService.On("Enroll", uint(1)).Return(nil, helpers.ErrTwoFactorAlreadyEnabled)
+
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/enroll", nil)
+		assert.NoError(t, err, "Expected no error creating request")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
+
+func TestTwoFactorController_Confirm(t *testing.T) {
+	t.Run("Confirm_Success", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		codeRequest := request.TwoFactorCodeRequest{Code: "123456"}
+		mockTwoFactorService.On("Confirm", uint(1), codeRequest, mock.Anything).Return(&response.RecoveryCodesResponse{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+
+		body, err := json.Marshal(codeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/confirm", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), "abcde-fghij")
+		mockTwoFactorService.AssertExpectations(t)
+	})
+
+	t.Run("Confirm_WrongCode", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		codeRequest := request.TwoFactorCodeRequest{Code: "000000"}
+		mockTwoFactorService.On("Confirm", uint(1), codeRequest, mock.Anything).Return(nil, helpers.ErrInvalidTwoFactorCode)
+
+		body, err := json.Marshal(codeRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/confirm", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "Expected status code 400")
+	})
+}
+
+func TestTwoFactorController_Disable(t *testing.T) {
+	t.Run("Disable_Success", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		disableRequest := request.DisableTwoFactorRequest{Password: "password", Code: "123456"}
+		mockTwoFactorService.On("Disable", uint(1), disableRequest, mock.Anything).Return(nil)
+
+		body, err := json.Marshal(disableRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/disable", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		mockTwoFactorService.AssertExpectations(t)
+	})
+
+	t.Run("Disable_InternalError", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		disableRequest := request.DisableTwoFactorRequest{Password: "password", Code: "123456"}
+		mockTwoFactorService.On("Disable", uint(1), disableRequest, mock.Anything).Return(assert.AnError)
+
+		body, err := json.Marshal(disableRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/disable", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "Expected status code 500")
+	})
+
+	t.Run("Disable_Throttled", func(t *testing.T) {
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		router := newTwoFactorRouter(NewTwoFactorController(mockTwoFactorService))
+
+		disableRequest := request.DisableTwoFactorRequest{Password: "password", Code: "123456"}
+		mockTwoFactorService.On("Disable", uint(1), disableRequest, mock.Anything).Return(&helpers.RetryAfterError{Err: helpers.ErrTooManyLoginAttempts, RetryAfter: time.Minute})
+
+		body, err := json.Marshal(disableRequest)
+		assert.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/auth/2fa/disable", bytes.NewBuffer(body))
+		assert.NoError(t, err, "Expected no error creating request")
+		req.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code, "Expected status code 429")
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/dieg0code/player-profile/src/data/request"
@@ -76,12 +77,16 @@ func (controller *UserController) CreateUser(ctx *gin.Context) {
 // GetAllUsers godoc
 //
 //	@Summary		Get all users
-//	@Description	Get all users, can be paginated, default page is 1 and default pageSize is 10
+//	@Description	Get all users, can be paginated, default page is 1 and default pageSize is 10. Filter with parameters like age>=18 or role=admin on id, user_name, age and role. Numeric fields take =, !=, >, >=, < and <=, the others take = and !=. Lists sorted by id can also be paged with the next_cursor and prev_cursor of the pagination as after and before
 //	@Tags			User
 //	@Accept			json
 //	@Produce		json
 //	@Param			page			query	int		false	"Page number"
 //	@Param			pageSize		query	int		false	"Page size"
+//	@Param			sort			query	string	false	"Sort fields, descending with a minus, like -age,user_name"
+//	@Param			after			query	string	false	"Items after the cursor, the next_cursor of a previous response"
+//	@Param			before			query	string	false	"Items before the cursor, the prev_cursor of a previous response"
+//	@Param			total			query	bool	false	"Count the items matching the filters"
 //
 //	@Success		200	{object}	response.BaseResponse
 //	@Failure		400	{object}	response.BaseResponse
@@ -91,53 +96,42 @@ func (controller *UserController) CreateUser(ctx *gin.Context) {
 //	@x-order		1
 //	@Security		BearerAuth
 func (controller *UserController) GetAllUsers(ctx *gin.Context) {
-	page := ctx.DefaultQuery("page", "1")
-	pageSize := ctx.DefaultQuery("pageSize", "10")
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid page",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	pageRequest, ok := pageQuery(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeInt, err := strconv.Atoi(pageSize)
-	if err != nil {
-		errorResponse := response.BaseResponse{
-			Code:    400,
-			Status:  "Error",
-			Message: "Invalid pageSize",
-			Data:    nil,
-		}
-
-		ctx.JSON(400, errorResponse)
+	query, ok := listQuery(ctx)
+	if !ok {
 		return
 	}
 
-	users, err := controller.userService.GetAll(pageInt, pageSizeInt)
+	users, pagination, err := controller.userService.GetAll(pageRequest, query)
 	if err != nil {
+		status := 500
+		message := "Failed to get users"
+
+		if isListRequestError(err) {
+			status, message = 400, err.Error()
+		}
+
 		errorResponse := response.BaseResponse{
-			Code:    500,
+			Code:    status,
 			Status:  "Error",
-			Message: "Failed to get users",
+			Message: message,
 			Data:    nil,
 		}
 
-		ctx.JSON(500, errorResponse)
+		ctx.JSON(status, errorResponse)
 		return
 	}
 
 	webResponse := response.BaseResponse{
-		Code:    200,
-		Status:  "Success",
-		Message: "Users fetched successfully",
-		Data:    users,
+		Code:       200,
+		Status:     "Success",
+		Message:    "Users fetched successfully",
+		Data:       users,
+		Pagination: pagination,
 	}
 
 	ctx.JSON(200, webResponse)
@@ -272,13 +266,15 @@ func (controller *UserController) UpdateUser(ctx *gin.Context) {
 // DeleteUser godoc
 //
 //	@Summary		Delete user by ID
-//	@Description	Delete user by ID
+//	@Description	Delete user by ID, the last admin can't be deleted
 //	@Tags			User
 //	@Accept			json
 //	@Produce		json
 //	@Param			userID	path		int	true	"User ID"
 //	@Success		200		{object}	response.BaseResponse
 //	@Failure		400		{object}	response.BaseResponse
+//	@Failure		404		{object}	response.BaseResponse
+//	@Failure		409		{object}	response.BaseResponse
 //	@Failure		500		{object}	response.BaseResponse
 //	@Router			/users/{userID} [delete]
 //
@@ -303,14 +299,24 @@ func (controller *UserController) DeleteUser(ctx *gin.Context) {
 
 	err = controller.userService.Delete(userIDUint)
 	if err != nil {
+		status := 500
+		message := "Failed to delete user"
+
+		switch {
+		case errors.Is(err, helpers.ErrorUserNotFound):
+			status, message = 404, err.Error()
+		case errors.Is(err, helpers.ErrLastAdmin):
+			status, message = 409, err.Error()
+		}
+
 		errorResponse := response.BaseResponse{
-			Code:    500,
+			Code:    status,
 			Status:  "Error",
-			Message: "Failed to delete user",
+			Message: message,
 			Data:    nil,
 		}
 
-		ctx.JSON(500, errorResponse)
+		ctx.JSON(status, errorResponse)
 		return
 	}
 
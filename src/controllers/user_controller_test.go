@@ -10,6 +10,8 @@ import (
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -89,7 +91,7 @@ func TestUserController_GetAllUsers(t *testing.T) {
 		router := gin.Default()
 		router.GET("/users", controller.GetAllUsers)
 
-		mockUserService.On("GetAll", 1, 10).Return([]response.UserResponse{}, nil)
+		mockUserService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}).Return([]response.UserResponse{}, nil, nil)
 
 		req, _ := http.NewRequest(http.MethodGet, "/users?page=1&pageSize=10", nil)
 		rec := httptest.NewRecorder()
@@ -130,7 +132,7 @@ func TestUserController_GetAllUsers(t *testing.T) {
 		router := gin.Default()
 		router.GET("/users", controller.GetAllUsers)
 
-		mockUserService.On("GetAll", 1, 10).Return([]response.UserResponse{}, errors.New("Service Error"))
+		mockUserService.On("GetAll", request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}).Return([]response.UserResponse{}, nil, errors.New("Service Error"))
 
 		req, _ := http.NewRequest(http.MethodGet, "/users?page=1&pageSize=10", nil)
 		rec := httptest.NewRecorder()
@@ -394,4 +396,20 @@ func TestUserController_Delete(t *testing.T) {
 
 		mockUserService.AssertExpectations(t)
 	})
+
+	t.Run("Delete_LastAdmin", func(t *testing.T) {
+		mockUserService := new(mocks.MockUserService)
+		controller := NewUserController(mockUserService)
+		router := gin.Default()
+		router.DELETE("/users/:userID", controller.DeleteUser)
+
+		mockUserService.On("Delete", uint(1)).Return(helpers.ErrLastAdmin)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code, "Status code should be 409")
+		mockUserService.AssertExpectations(t)
+	})
 }
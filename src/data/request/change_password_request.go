@@ -0,0 +1,8 @@
+package request
+
+// ChangePasswordRequest represents the request structure to change the password of a user
+// @Description Change password request structure, the current password can only be omitted by an admin changing another user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"omitempty,max=255" example:"012345678" extensions:"x-order=0"`  // Current password
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=255" example:"876543210" extensions:"x-order=1"` // New password
+}
@@ -0,0 +1,8 @@
+package request
+
+// ClientInfo describes the client of a login or a refresh, it is taken from
+// the HTTP request and recorded on the session.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+// CreateAPIKeyRequest represents the request structure for creating an API key
+// @Description Create API key request structure
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=3,max=100" example:"eu-west game server" extensions:"x-order=0"`               // Name to recognize the key
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required,max=50" example:"achievements:award" extensions:"x-order=1"` // Permissions granted to the key
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-01-01T00:00:00Z" extensions:"x-order=2"`                                          // Optional expiration date
+}
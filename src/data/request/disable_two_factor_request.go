@@ -0,0 +1,8 @@
+package request
+
+// DisableTwoFactorRequest represents the request structure to disable two factor authentication
+// @Description Disable two factor request structure, the password and a code are both required
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required,max=255" example:"012345678" extensions:"x-order=0"` // Current password
+	Code     string `json:"code" validate:"required,max=32" example:"123456" extensions:"x-order=1"`         // TOTP code or recovery code
+}
@@ -0,0 +1,7 @@
+package request
+
+// ForgotPasswordRequest represents the request structure to ask for a password reset email
+// @Description Forgot password request structure
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email" example:"example@example.com" extensions:"x-order=0"` // User email
+}
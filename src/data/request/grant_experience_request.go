@@ -0,0 +1,7 @@
+package request
+
+// GrantExperienceRequest represents the request structure for granting experience to a player
+// @Description Grant experience request structure, the level follows the configured XP curve
+type GrantExperienceRequest struct {
+	Amount int `json:"amount" validate:"required,gt=0,max=2147483647" example:"250" extensions:"x-order=0"` // Experience earned
+}
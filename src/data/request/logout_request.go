@@ -0,0 +1,7 @@
+package request
+
+// LogoutRequest represents the request structure for logout
+// @Description Logout request structure
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"3q2-7wEAAAB..." extensions:"x-order=0"` // Optional refresh token to revoke along with the access token
+}
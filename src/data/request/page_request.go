@@ -0,0 +1,12 @@
+package request
+
+// PageRequest selects the page of a list endpoint, by page number unless one
+// of the opaque After or Before cursors is given. Total asks for the number of
+// items matching the filters.
+type PageRequest struct {
+	Page     int
+	PageSize int
+	After    string
+	Before   string
+	Total    bool
+}
@@ -0,0 +1,7 @@
+package request
+
+// RefreshTokenRequest represents the request structure to rotate a refresh token
+// @Description Refresh token request structure
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required" example:"3q2-7wEAAAB..." extensions:"x-order=0"` // Refresh token issued at login or by a previous refresh
+}
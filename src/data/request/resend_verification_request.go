@@ -0,0 +1,7 @@
+package request
+
+// ResendVerificationRequest represents the request structure to ask for a new verification email
+// @Description Resend verification email request structure
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email" example:"example@example.com" extensions:"x-order=0"` // User email
+}
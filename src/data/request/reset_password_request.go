@@ -0,0 +1,8 @@
+package request
+
+// ResetPasswordRequest represents the request structure to set a new password with a reset token
+// @Description Reset password request structure
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required" example:"3q2-7wEAAAB..." extensions:"x-order=0"`             // Token received by email
+	Password string `json:"password" validate:"required,min=8,max=255" example:"012345678" extensions:"x-order=1"` // New password
+}
@@ -0,0 +1,22 @@
+package request
+
+// CreateRoleRequest represents the request structure for creating a role
+// @Description Create role request structure
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=3,max=50" example:"moderator" extensions:"x-order=0"`              // Role name, lowercase letters, digits, - and _
+	Description string   `json:"description" validate:"max=255" example:"Community moderator" extensions:"x-order=1"`           // Role description
+	Permissions []string `json:"permissions" validate:"dive,required,max=50" example:"players:moderate" extensions:"x-order=2"` // Permissions granted to the role
+}
+
+// UpdateRoleRequest represents the request structure for updating a role, the permissions are replaced
+// @Description Update role request structure
+type UpdateRoleRequest struct {
+	Description string   `json:"description" validate:"max=255" example:"Community moderator" extensions:"x-order=0"`           // Role description
+	Permissions []string `json:"permissions" validate:"dive,required,max=50" example:"players:moderate" extensions:"x-order=1"` // Permissions granted to the role
+}
+
+// AssignRoleRequest represents the request structure for changing the role of a user
+// @Description Assign role request structure
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,max=50" example:"moderator" extensions:"x-order=0"` // Name of the new role
+}
@@ -0,0 +1,7 @@
+package request
+
+// SendFriendRequestRequest represents the request structure for sending a friend request
+// @Description Send friend request structure
+type SendFriendRequestRequest struct {
+	PlayerID uint `json:"player_id" validate:"required" example:"2" extensions:"x-order=0"` // Player ID of the receiver
+}
@@ -0,0 +1,7 @@
+package request
+
+// StartSeasonRequest represents the request structure for starting a new leaderboard season
+// @Description Start season request structure, the running season ends and the season points of every player are reset
+type StartSeasonRequest struct {
+	Name string `json:"name" validate:"required,min=3,max=100" example:"Season 2" extensions:"x-order=0"` // Season name, must be unique
+}
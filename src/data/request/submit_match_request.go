@@ -0,0 +1,18 @@
+package request
+
+import "time"
+
+// SubmitMatchRequest represents the request structure for submitting a match result
+// @Description Submit match request structure, the ratings of the players are updated with the configured rating system
+type SubmitMatchRequest struct {
+	PlayedAt     *time.Time                `json:"played_at,omitempty" example:"2024-08-01T12:00:00Z" extensions:"x-order=0"`  // When the match ended, now by default
+	Participants []MatchParticipantRequest `json:"participants" validate:"required,min=2,max=100,dive" extensions:"x-order=1"` // Players of the match
+}
+
+// MatchParticipantRequest represents a player of a submitted match
+// @Description Match participant structure, placement 1 won and players of the same team share the placement
+type MatchParticipantRequest struct {
+	PlayerID  uint `json:"player_id" validate:"required" example:"1" extensions:"x-order=0"`       // Player ID
+	Team      int  `json:"team" validate:"required,gte=1" example:"1" extensions:"x-order=1"`      // Team number, one per player in free-for-all matches
+	Placement int  `json:"placement" validate:"required,gte=1" example:"1" extensions:"x-order=2"` // Final placement of the team, ties share it
+}
@@ -0,0 +1,7 @@
+package request
+
+// TwoFactorCodeRequest represents the request structure carrying a TOTP or recovery code
+// @Description Two factor code request structure
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32" example:"123456" extensions:"x-order=0"` // TOTP code or recovery code
+}
@@ -0,0 +1,9 @@
+package request
+
+// TwoFactorLoginRequest represents the request structure for the second step of the login
+// @Description Two factor login request structure
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required" example:"3q2-7wEAAAB..." extensions:"x-order=0"` // Challenge token returned by the login
+	Code           string `json:"code" validate:"required,max=32" example:"123456" extensions:"x-order=1"`             // TOTP code or recovery code
+	DeviceName     string `json:"device_name" validate:"max=100" example:"Living room PS5" extensions:"x-order=2"`     // Name shown in the session list, optional
+}
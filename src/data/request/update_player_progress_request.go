@@ -0,0 +1,8 @@
+package request
+
+// UpdatePlayerProgressRequest represents the request structure for updating the progression of a player profile
+// @Description Update player profile progression, only game servers and admins can send it. The level follows from the experience.
+type UpdatePlayerProgressRequest struct {
+	Experience int `json:"experience" validate:"gte=0" example:"200" extensions:"x-order=0"` // Player experience
+	Points     int `json:"points" validate:"gte=0" example:"200" extensions:"x-order=1"`     // Player points
+}
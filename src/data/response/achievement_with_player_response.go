@@ -1,5 +1,7 @@
 package response
 
+import "time"
+
 // AchievementWithPlayers represents the response structure for achievement with players data
 // @Description Achievement with players response structure
 type AchievementWithPlayers struct {
@@ -11,6 +13,7 @@ type AchievementWithPlayers struct {
 // PlayerSumary represents the response structure for player data used in AchievementWithPlayers
 // @Description Player summary response structure
 type PlayerSumary struct {
-	ID       uint   `json:"plyer_id" example:"1" extensions:"x-order=0"`                // Player ID (primary key) in the database
-	Nickname string `json:"player_nickname" example:"elPepe123" extensions:"x-order=1"` // Player nickname
+	ID         uint      `json:"plyer_id" example:"1" extensions:"x-order=0"`                       // Player ID (primary key) in the database
+	Nickname   string    `json:"player_nickname" example:"elPepe123" extensions:"x-order=1"`        // Player nickname
+	UnlockedAt time.Time `json:"unlocked_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"` // Date the player unlocked the achievement
 }
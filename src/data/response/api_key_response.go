@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+// APIKeyResponse represents the response structure of an API key, the secret is never returned
+// @Description API key response structure
+type APIKeyResponse struct {
+	ID         uint       `json:"id" example:"1" extensions:"x-order=0"`                              // API key ID
+	Name       string     `json:"name" example:"eu-west game server" extensions:"x-order=1"`          // Name of the key
+	Prefix     string     `json:"prefix" example:"pp_1a2b3c4d5e6f" extensions:"x-order=2"`            // Public part of the key
+	Scopes     []string   `json:"scopes" example:"achievements:award" extensions:"x-order=3"`         // Permissions granted to the key
+	CreatedAt  time.Time  `json:"created_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=4"`   // Creation date
+	ExpiresAt  *time.Time `json:"expires_at" example:"2025-01-01T00:00:00Z" extensions:"x-order=5"`   // Expiration date, null if it doesn't expire
+	LastUsedAt *time.Time `json:"last_used_at" example:"2024-08-02T12:00:00Z" extensions:"x-order=6"` // Last time the key was used
+	RevokedAt  *time.Time `json:"revoked_at" example:"2024-08-03T12:00:00Z" extensions:"x-order=7"`   // Revocation date, null while the key is active
+}
+
+// CreatedAPIKeyResponse represents the response structure of a new API key
+// @Description Created API key response structure, the key is only shown once
+type CreatedAPIKeyResponse struct {
+	Key    string         `json:"key" example:"pp_1a2b3c4d5e6f.secret" extensions:"x-order=0"` // Value of the X-API-Key header
+	APIKey APIKeyResponse `json:"api_key" extensions:"x-order=1"`                              // Stored key
+}
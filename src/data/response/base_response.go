@@ -3,8 +3,18 @@ package response
 // BaseResponse represents the base structure of the API response
 // @Description Base response structure
 type BaseResponse struct {
-	Code    int         `json:"code" extensions:"x-order=0"`    // HTTP status code of the response
-	Status  string      `json:"status" extensions:"x-order=1"`  // Status of the response
-	Message string      `json:"message" extensions:"x-order=2"` // Message of the response
-	Data    interface{} `json:"data" extensions:"x-order=3"`    // Data payload of the response
+	Code       int         `json:"code" extensions:"x-order=0"`                 // HTTP status code of the response
+	Status     string      `json:"status" extensions:"x-order=1"`               // Status of the response
+	Message    string      `json:"message" extensions:"x-order=2"`              // Message of the response
+	Data       interface{} `json:"data" extensions:"x-order=3"`                 // Data payload of the response
+	Pagination *Pagination `json:"pagination,omitempty" extensions:"x-order=4"` // Pagination of list responses
+}
+
+// Pagination describes the page of a list response
+// @Description Pagination of a list response
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty" extensions:"x-order=0"` // Cursor to pass as after for the next page, for lists sorted by id
+	PrevCursor string `json:"prev_cursor,omitempty" extensions:"x-order=1"` // Cursor to pass as before for the previous page, for lists sorted by id
+	HasMore    bool   `json:"has_more" extensions:"x-order=2"`              // Whether there are more items in the direction of the request
+	Total      *int64 `json:"total,omitempty" extensions:"x-order=3"`       // Number of items matching the filters, when asked for
 }
@@ -0,0 +1,13 @@
+package response
+
+// ExperienceGrantResponse represents the response structure of an experience grant
+// @Description Experience grant response structure
+type ExperienceGrantResponse struct {
+	PlayerID              uint `json:"player_id" example:"1" extensions:"x-order=0"`                 // Player ID
+	ExperienceGained      int  `json:"experience_gained" example:"250" extensions:"x-order=1"`       // Experience added, less than the amount at the max level
+	Experience            int  `json:"experience" example:"350" extensions:"x-order=2"`              // Total experience of the player
+	Level                 int  `json:"level" example:"4" extensions:"x-order=3"`                     // Level after the grant
+	LevelsGained          int  `json:"levels_gained" example:"2" extensions:"x-order=4"`             // Levels reached with the grant
+	ExperienceToNextLevel int  `json:"experience_to_next_level" example:"50" extensions:"x-order=5"` // Experience missing for the next level, 0 at the max level
+	MaxLevel              int  `json:"max_level" example:"100" extensions:"x-order=6"`               // Highest level of the curve
+}
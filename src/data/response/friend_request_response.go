@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+// FriendRequestResponse represents the response structure of a pending friend request
+// @Description Friend request response structure
+type FriendRequestResponse struct {
+	ID               uint      `json:"id" example:"1" extensions:"x-order=0"`                            // Friend request ID
+	SenderID         uint      `json:"sender_id" example:"1" extensions:"x-order=1"`                     // Player ID of the sender
+	SenderNickname   string    `json:"sender_nickname" example:"elPepe123" extensions:"x-order=2"`       // Nickname of the sender
+	ReceiverID       uint      `json:"receiver_id" example:"2" extensions:"x-order=3"`                   // Player ID of the receiver
+	ReceiverNickname string    `json:"receiver_nickname" example:"noobMaster" extensions:"x-order=4"`    // Nickname of the receiver
+	CreatedAt        time.Time `json:"created_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=5"` // When the request was sent
+}
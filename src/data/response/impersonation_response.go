@@ -0,0 +1,10 @@
+package response
+
+// ImpersonationResponse represents the response structure of an impersonation
+// @Description Impersonation response structure, the token can't be refreshed
+type ImpersonationResponse struct {
+	Token          string `json:"token" extensions:"x-order=0"`                       // JWT token of the impersonated user
+	ExpiresIn      int64  `json:"expires_in" example:"600" extensions:"x-order=1"`    // Token lifetime in seconds
+	UserID         uint   `json:"user_id" example:"42" extensions:"x-order=2"`        // Impersonated user
+	ImpersonatorID uint   `json:"impersonator_id" example:"1" extensions:"x-order=3"` // Admin acting as the user
+}
@@ -0,0 +1,18 @@
+package response
+
+// LeaderboardEntryResponse represents a ranked player
+// @Description Leaderboard entry response structure
+type LeaderboardEntryResponse struct {
+	Rank     int    `json:"rank" example:"1" extensions:"x-order=0"`             // Rank of the player, tied players share it
+	PlayerID uint   `json:"player_id" example:"1" extensions:"x-order=1"`        // Player ID
+	Nickname string `json:"nickname" example:"elPepe123" extensions:"x-order=2"` // Player nickname
+	Value    int    `json:"value" example:"1500" extensions:"x-order=3"`         // Value of the metric
+}
+
+// LeaderboardResponse represents the response structure of a leaderboard
+// @Description Leaderboard response structure
+type LeaderboardResponse struct {
+	Metric  string                     `json:"metric" example:"points" extensions:"x-order=0"`       // points, season_points, level or experience
+	Ranking string                     `json:"ranking" example:"competitive" extensions:"x-order=1"` // competitive or dense
+	Entries []LeaderboardEntryResponse `json:"entries" extensions:"x-order=2"`                       // Ranked players, best first
+}
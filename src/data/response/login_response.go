@@ -1,7 +1,12 @@
 package response
 
 // LoginResponse represents the response structure for login data
-// @Description Login response structure
+// @Description Login response structure. When two factor authentication is enabled the
+// @Description login only returns a challenge token to exchange with a code for the tokens
 type LoginResponse struct {
-	Token string `json:"token"` // JWT token
+	Token             string `json:"token"`                         // JWT token
+	RefreshToken      string `json:"refresh_token"`                 // Opaque refresh token, single use
+	ExpiresIn         int64  `json:"expires_in"`                    // Access token lifetime in seconds
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"` // A TOTP code is needed to finish the login
+	ChallengeToken    string `json:"challenge_token,omitempty"`     // Token to send with the code to /auth/2fa/verify
 }
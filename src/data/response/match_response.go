@@ -0,0 +1,38 @@
+package response
+
+import "time"
+
+// MatchResponse represents the response structure of a rated match
+// @Description Match response structure
+type MatchResponse struct {
+	ID           uint                       `json:"id" example:"1" extensions:"x-order=0"`                           // Match ID
+	RatingSystem string                     `json:"rating_system" example:"elo" extensions:"x-order=1"`              // elo or glicko2
+	PlayedAt     time.Time                  `json:"played_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"` // When the match ended
+	Participants []MatchParticipantResponse `json:"participants" extensions:"x-order=3"`                             // Players with their new ratings
+}
+
+// MatchParticipantResponse represents a player of a rated match
+// @Description Match participant response structure
+type MatchParticipantResponse struct {
+	PlayerID     uint    `json:"player_id" example:"1" extensions:"x-order=0"`        // Player ID
+	Team         int     `json:"team" example:"1" extensions:"x-order=1"`             // Team number
+	Placement    int     `json:"placement" example:"1" extensions:"x-order=2"`        // Final placement of the team
+	RatingBefore float64 `json:"rating_before" example:"1500" extensions:"x-order=3"` // Rating before the match
+	RatingAfter  float64 `json:"rating_after" example:"1516" extensions:"x-order=4"`  // Rating after the match
+	RatingDelta  float64 `json:"rating_delta" example:"16" extensions:"x-order=5"`    // Rating won or lost
+	Deviation    float64 `json:"deviation" example:"350" extensions:"x-order=6"`      // Rating deviation after the match, Glicko-2 only
+}
+
+// PlayerMatchResponse represents a match in the history of a player
+// @Description Player match response structure
+type PlayerMatchResponse struct {
+	MatchID      uint      `json:"match_id" example:"1" extensions:"x-order=0"`                     // Match ID
+	RatingSystem string    `json:"rating_system" example:"elo" extensions:"x-order=1"`              // elo or glicko2
+	PlayedAt     time.Time `json:"played_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"` // When the match ended
+	Team         int       `json:"team" example:"1" extensions:"x-order=3"`                         // Team of the player
+	Placement    int       `json:"placement" example:"1" extensions:"x-order=4"`                    // Final placement of the team
+	RatingBefore float64   `json:"rating_before" example:"1500" extensions:"x-order=5"`             // Rating before the match
+	RatingAfter  float64   `json:"rating_after" example:"1516" extensions:"x-order=6"`              // Rating after the match
+	RatingDelta  float64   `json:"rating_delta" example:"16" extensions:"x-order=7"`                // Rating won or lost
+	Deviation    float64   `json:"deviation" example:"350" extensions:"x-order=8"`                  // Rating deviation after the match, Glicko-2 only
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// OIDCAuthorizationResponse represents the response structure to start a social login
+// @Description Social login authorization response structure
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"` // Provider page to send the user to
+}
+
+// UserIdentityResponse represents the response structure of a linked identity
+// @Description Linked identity response structure
+type UserIdentityResponse struct {
+	Provider string    `json:"provider"` // Name of the identity provider
+	Email    string    `json:"email"`    // Email reported by the provider
+	LinkedAt time.Time `json:"linked_at"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// PlayerAchievementResponse represents the response structure for an achievement awarded to a player
+// @Description Player achievement response structure
+type PlayerAchievementResponse struct {
+	PlayerID      uint      `json:"player_id" example:"1" extensions:"x-order=0"`                      // Player ID
+	AchievementID uint      `json:"achievement_id" example:"1" extensions:"x-order=1"`                 // Achievement ID
+	UnlockedAt    time.Time `json:"unlocked_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"` // Date the achievement was unlocked
+	NewlyUnlocked bool      `json:"newly_unlocked" example:"true" extensions:"x-order=3"`              // False when the player already had the achievement
+}
@@ -1,5 +1,7 @@
 package response
 
+import "time"
+
 // PlayerWithAchievements represents the response structure for player with achievements data
 // @Description Player with achievements response structure
 type PlayerWithAchievements struct {
@@ -11,6 +13,7 @@ type PlayerWithAchievements struct {
 // AchievementsSumary represents the response structure for achievements data used in PlayerWithAchievements
 // @Description Achievements summary response structure
 type AchievementsSumary struct {
-	ID   uint   `json:"achievement_id" example:"1" extensions:"x-order=0"`             // Achievement ID
-	Name string `json:"achievement_name" example:"First blood" extensions:"x-order=1"` // Achievement name
+	ID         uint      `json:"achievement_id" example:"1" extensions:"x-order=0"`                 // Achievement ID
+	Name       string    `json:"achievement_name" example:"First blood" extensions:"x-order=1"`     // Achievement name
+	UnlockedAt time.Time `json:"unlocked_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"` // Date the player unlocked the achievement
 }
@@ -0,0 +1,17 @@
+package response
+
+// RoleResponse represents the response structure of a role
+// @Description Role response structure
+type RoleResponse struct {
+	ID          uint     `json:"id" example:"1" extensions:"x-order=0"`                            // Role ID
+	Name        string   `json:"name" example:"moderator" extensions:"x-order=1"`                  // Role name
+	Description string   `json:"description" example:"Community moderator" extensions:"x-order=2"` // Role description
+	Permissions []string `json:"permissions" example:"players:moderate" extensions:"x-order=3"`    // Permissions granted to the role
+}
+
+// PermissionResponse represents the response structure of a permission of the catalog
+// @Description Permission response structure
+type PermissionResponse struct {
+	Name        string `json:"name" example:"players:moderate" extensions:"x-order=0"`                            // Permission name
+	Description string `json:"description" example:"Update and delete any player profile" extensions:"x-order=1"` // What the permission allows
+}
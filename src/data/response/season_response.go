@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+// SeasonResponse represents the response structure of a leaderboard season
+// @Description Season response structure
+type SeasonResponse struct {
+	ID        uint       `json:"id" example:"2" extensions:"x-order=0"`                                    // Season ID
+	Name      string     `json:"name" example:"Season 2" extensions:"x-order=1"`                           // Season name
+	StartedAt time.Time  `json:"started_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=2"`         // Start date
+	EndedAt   *time.Time `json:"ended_at,omitempty" example:"2024-11-01T12:00:00Z" extensions:"x-order=3"` // End date, missing while running
+}
+
+// SeasonStandingResponse represents the final position of a player in a season
+// @Description Season standing response structure
+type SeasonStandingResponse struct {
+	Rank       int    `json:"rank" example:"1" extensions:"x-order=0"`             // Competitive rank at the end of the season
+	PlayerID   uint   `json:"player_id" example:"1" extensions:"x-order=1"`        // Player ID
+	Nickname   string `json:"nickname" example:"elPepe123" extensions:"x-order=2"` // Nickname at the end of the season
+	Points     int    `json:"points" example:"1500" extensions:"x-order=3"`        // Season points
+	Level      int    `json:"level" example:"12" extensions:"x-order=4"`           // Level at the end of the season
+	Experience int    `json:"experience" example:"4500" extensions:"x-order=5"`    // Experience at the end of the season
+}
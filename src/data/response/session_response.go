@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+// SessionResponse represents the response structure of a signed in device
+// @Description Session response structure
+type SessionResponse struct {
+	ID         uint      `json:"id" example:"1" extensions:"x-order=0"`                                                     // Session ID
+	DeviceName string    `json:"device_name" example:"Living room PS5" extensions:"x-order=1"`                              // Name given on login, empty if none
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (PlayStation; PlayStation 5/2.26)" extensions:"x-order=2"` // User agent of the login
+	IP         string    `json:"ip" example:"203.0.113.7" extensions:"x-order=3"`                                           // Last IP address seen
+	CreatedAt  time.Time `json:"created_at" example:"2024-08-01T12:00:00Z" extensions:"x-order=4"`                          // Login date
+	LastSeenAt time.Time `json:"last_seen_at" example:"2024-08-02T12:00:00Z" extensions:"x-order=5"`                        // Last login or token refresh
+	Current    bool      `json:"current" example:"true" extensions:"x-order=6"`                                             // Whether the session made the request
+}
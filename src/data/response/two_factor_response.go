@@ -0,0 +1,14 @@
+package response
+
+// TwoFactorEnrollmentResponse represents the response structure of a TOTP enrollment
+// @Description Two factor enrollment response structure
+type TwoFactorEnrollmentResponse struct {
+	Secret string `json:"secret"` // Base32 secret, for manual entry in the authenticator app
+	URI    string `json:"uri"`    // otpauth:// URI to show as a QR code
+}
+
+// RecoveryCodesResponse represents the response structure with the recovery codes
+// @Description Recovery codes response structure, the codes are only shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"` // Single use codes accepted instead of a TOTP code
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// cursorPrefix versions the cursors so their content can change later.
+const cursorPrefix = "id:"
+
+// EncodeCursor returns the opaque cursor of a list position, the id of an item.
+func EncodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+// DecodeCursor returns the id of a cursor made by EncodeCursor.
+func DecodeCursor(cursor string) (uint, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(decoded), cursorPrefix), 10, 0)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return uint(id), nil
+}
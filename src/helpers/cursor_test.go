@@ -0,0 +1,26 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	id, err := DecodeCursor(EncodeCursor(42))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), id)
+
+	for _, cursor := range []string{
+		"",
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("42")),
+		base64.RawURLEncoding.EncodeToString([]byte("id:abc")),
+		base64.RawURLEncoding.EncodeToString([]byte("id:0")),
+		base64.RawURLEncoding.EncodeToString([]byte("id:-1")),
+	} {
+		_, err := DecodeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
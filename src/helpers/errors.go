@@ -1,6 +1,9 @@
 package helpers
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var ErrRegisterNotFound = errors.New("register not found")
 
@@ -23,10 +26,12 @@ var ErrorGetAllPlayerProfiles = errors.New("error getting all player profiles")
 var ErrorAchievementNotFound = errors.New("achievement not found")
 var ErrorUpdateAchievement = errors.New("error updating achievement")
 var ErrorDeletingAchievement = errors.New("error deleting achievement")
+var ErrorPlayerAchievementNotFound = errors.New("player does not have the achievement")
 
 // Services
 
 var ErrInvalidPagination = errors.New("invalid pagination")
+var ErrInvalidSortOrder = errors.New("invalid sort order, must be asc or desc")
 
 // User errors.
 
@@ -36,6 +41,8 @@ var ErrUserDataValidation = errors.New("user data validation error")
 // Player Profile errors.
 var ErrInvalidPlayerProfileID = errors.New("invalid player profile id")
 var ErrPlayerProfileDataValidation = errors.New("player profile data validation error")
+var ErrPlayerProfileOwnerNotAllowed = errors.New("not allowed to create player profiles for other users")
+var ErrPlayerProfileLimitReached = errors.New("maximum number of player profiles reached")
 
 var ErrRepository = errors.New("error in repository")
 
@@ -44,3 +51,129 @@ var ErrAchievementDataValidation = errors.New("achievement data validation error
 var ErrInvalidAchievementID = errors.New("invalid achievement id")
 var ErrAchievementNotFound = errors.New("achievement not found")
 var ErrAchievementRepository = errors.New("error in achievement repository")
+
+// Refresh token errors.
+var ErrorRefreshTokenNotFound = errors.New("refresh token not found")
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected, session revoked")
+
+// User token errors.
+var ErrorUserTokenNotFound = errors.New("user token not found")
+var ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+var ErrPasswordDataValidation = errors.New("password data validation error")
+var ErrSendEmail = errors.New("failed to send email")
+var ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+
+// Password hash errors.
+var ErrPasswordMismatch = errors.New("password doesn't match the hash")
+var ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+// Email verification errors.
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+var ErrEmailNotVerified = errors.New("email not verified")
+var ErrVerificationDataValidation = errors.New("verification data validation error")
+
+// Two factor errors.
+var ErrorTwoFactorNotFound = errors.New("two factor authentication not found")
+var ErrTwoFactorNotEnabled = errors.New("two factor authentication is not enabled")
+var ErrTwoFactorAlreadyEnabled = errors.New("two factor authentication is already enabled")
+var ErrInvalidTwoFactorCode = errors.New("invalid two factor code")
+var ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two factor challenge")
+var ErrTwoFactorDataValidation = errors.New("two factor data validation error")
+
+// Login errors.
+var ErrorLoginAttemptNotFound = errors.New("login attempt not found")
+var ErrInvalidCredentials = errors.New("invalid email or password")
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+
+// RetryAfterError is returned when a request is throttled, RetryAfter tells
+// the client when it can try again.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// Social login errors.
+var ErrorUserIdentityNotFound = errors.New("linked identity not found")
+var ErrorOIDCStateNotFound = errors.New("oidc state not found")
+var ErrUnknownOIDCProvider = errors.New("unknown identity provider")
+var ErrInvalidOIDCState = errors.New("invalid or expired login state")
+var ErrOIDCLoginFailed = errors.New("identity provider login failed")
+var ErrOIDCEmailRequired = errors.New("the identity provider didn't share an email")
+var ErrOIDCEmailInUse = errors.New("email already registered, sign in and link the identity from your account")
+var ErrIdentityAlreadyLinked = errors.New("identity already linked to another account")
+
+// API key errors.
+var ErrorAPIKeyNotFound = errors.New("api key not found")
+var ErrInvalidAPIKeyID = errors.New("invalid api key id")
+var ErrInvalidAPIKey = errors.New("invalid, expired or revoked api key")
+var ErrAPIKeyDataValidation = errors.New("api key data validation error")
+
+// Session errors.
+var ErrorSessionNotFound = errors.New("session not found")
+var ErrInvalidSessionID = errors.New("invalid session id")
+
+// Impersonation errors.
+var ErrCannotImpersonate = errors.New("only users without permissions can be impersonated")
+var ErrImpersonationNotAllowed = errors.New("not allowed while impersonating a user")
+
+// Role errors.
+var ErrorRoleNotFound = errors.New("role not found")
+var ErrInvalidRoleID = errors.New("invalid role id")
+var ErrRoleDataValidation = errors.New("role data validation error")
+var ErrUnknownPermission = errors.New("unknown permission")
+var ErrRoleAlreadyExists = errors.New("role already exists")
+var ErrRoleInUse = errors.New("role is assigned to users")
+var ErrBuiltInRole = errors.New("built-in roles can't be deleted and admin keeps every permission")
+var ErrLastAdmin = errors.New("the last admin can't be deleted or lose the admin role")
+var ErrInvalidDefaultRole = errors.New("DEFAULT_ROLE must be an existing role")
+var ErrPrivilegedDefaultRole = errors.New("DEFAULT_ROLE can't grant permissions")
+var ErrAdminAlreadyExists = errors.New("an admin already exists")
+var ErrPrivilegedTarget = errors.New("the user has permissions you don't have")
+
+// Leveling errors.
+var ErrExperienceDataValidation = errors.New("experience data validation error")
+var ErrExperienceGrantConflict = errors.New("the experience of the player keeps changing, try again")
+
+// Leaderboard errors.
+var ErrInvalidLeaderboardMetric = errors.New("invalid leaderboard metric, must be points, level or experience")
+var ErrInvalidRanking = errors.New("invalid ranking, must be competitive or dense")
+var ErrInvalidNeighbours = errors.New("invalid neighbours")
+
+// Season errors.
+var ErrorSeasonNotFound = errors.New("season not found")
+var ErrorSeasonAlreadyExists = errors.New("season already exists")
+var ErrorSeasonAlreadyEnded = errors.New("the current season was ended by another request")
+var ErrInvalidSeasonID = errors.New("invalid season id")
+var ErrSeasonDataValidation = errors.New("season data validation error")
+
+// Match errors.
+var ErrorPlayerRatingConflict = errors.New("players of the match were rated by another match at the same time, try again")
+var ErrMatchDataValidation = errors.New("match data validation error")
+var ErrDuplicateMatchParticipant = errors.New("a player can only take part once in a match")
+var ErrInvalidMatchTeams = errors.New("a match needs two teams and the players of a team must share the placement")
+
+// Friendship errors.
+var ErrorFriendRequestNotFound = errors.New("friend request not found")
+var ErrorFriendshipNotFound = errors.New("the players aren't friends")
+var ErrorPlayerBlockNotFound = errors.New("the player isn't blocked")
+var ErrorAlreadyFriends = errors.New("the players are already friends")
+var ErrorFriendRequestPending = errors.New("a friend request between the players is already pending")
+var ErrorPlayerBlocked = errors.New("friend requests between the players are blocked")
+var ErrFriendRequestDataValidation = errors.New("friend request data validation error")
+var ErrInvalidFriendRequestID = errors.New("invalid friend request id")
+var ErrInvalidFriendRequestDirection = errors.New("invalid direction, must be incoming or outgoing")
+var ErrSelfFriendship = errors.New("players can't befriend or block themselves")
+
+// List query errors.
+var ErrInvalidListQuery = errors.New("invalid list query")
+var ErrInvalidCursor = errors.New("invalid cursor")
+var ErrConflictingCursors = errors.New("after and before can't be combined")
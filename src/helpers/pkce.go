@@ -0,0 +1,12 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCEChallenge returns the S256 code challenge of a PKCE code verifier (RFC 7636).
+func PKCEChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors of RFC 6238 appendix B for SHA-1, truncated to 6 digits.
+func TestTOTPCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "Unexpected code at %d", unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+
+	t.Run("ValidateTOTP_CurrentStep", func(t *testing.T) {
+		code, err := TOTPCode(secret, TOTPStep(now))
+		require.NoError(t, err)
+
+		step, ok := ValidateTOTP(secret, code, now, 1)
+		assert.True(t, ok)
+		assert.Equal(t, TOTPStep(now), step)
+	})
+
+	t.Run("ValidateTOTP_ClockDrift", func(t *testing.T) {
+		code, err := TOTPCode(secret, TOTPStep(now)-1)
+		require.NoError(t, err)
+
+		step, ok := ValidateTOTP(secret, code, now, 1)
+		assert.True(t, ok)
+		assert.Equal(t, TOTPStep(now)-1, step)
+
+		_, ok = ValidateTOTP(secret, code, now.Add(2*TOTPPeriod*time.Second), 1)
+		assert.False(t, ok, "Expected codes outside the skew to be refused")
+	})
+
+	t.Run("ValidateTOTP_WrongCode", func(t *testing.T) {
+		_, ok := ValidateTOTP(secret, "12345", now, 1)
+		assert.False(t, ok)
+
+		_, ok = ValidateTOTP(secret, "abcdef", now, 1)
+		assert.False(t, ok)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Player Profile", "test@test.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Player%20Profile:test@test.com?"), uri)
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Player+Profile")
+}
+
+func TestRecoveryCode(t *testing.T) {
+	code, err := GenerateRecoveryCode()
+	require.NoError(t, err)
+	assert.Len(t, code, 11)
+	assert.Equal(t, code, NormalizeRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))+" "))
+}
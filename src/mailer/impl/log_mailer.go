@@ -0,0 +1,61 @@
+package impl
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer writes the emails to the application log instead of sending them,
+// meant for local development.
+type LogMailer struct{}
+
+// Send implements mailer.Mailer.
+func (m *LogMailer) Send(message mailer.Message) error {
+	logrus.WithFields(logrus.Fields{
+		"to":      message.To,
+		"subject": message.Subject,
+	}).Info("[LogMailer.Send] " + message.Body)
+
+	return nil
+}
+
+func NewLogMailer() mailer.Mailer {
+	return &LogMailer{}
+}
+
+// FileMailer appends the emails to a file instead of sending them, meant for
+// local development and end to end tests.
+type FileMailer struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+// Send implements mailer.Mailer.
+func (m *FileMailer) Send(message mailer.Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	file, err := os.OpenFile(m.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		logrus.WithError(err).Error("[FileMailer.Send] Failed to open mail file")
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), message.To, message.Subject, message.Body)
+	if err != nil {
+		logrus.WithError(err).Error("[FileMailer.Send] Failed to write email")
+		return err
+	}
+
+	return nil
+}
+
+func NewFileMailer(path string) mailer.Mailer {
+	return &FileMailer{Path: path}
+}
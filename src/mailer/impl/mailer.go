@@ -0,0 +1,28 @@
+package impl
+
+import (
+	"fmt"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/mailer"
+)
+
+// NewMailer returns the mailer selected by the configuration driver.
+func NewMailer(mailerConfig config.MailerConfig) (mailer.Mailer, error) {
+	switch mailerConfig.Driver {
+	case "smtp":
+		if mailerConfig.SMTPHost == "" || mailerConfig.From == "" {
+			return nil, fmt.Errorf("SMTP_HOST and MAIL_FROM are required with the smtp mailer")
+		}
+		return NewSMTPMailer(mailerConfig.SMTPHost, mailerConfig.SMTPPort, mailerConfig.SMTPUsername, mailerConfig.SMTPPassword, mailerConfig.From), nil
+	case "file":
+		if mailerConfig.FilePath == "" {
+			return nil, fmt.Errorf("MAIL_FILE_PATH is required with the file mailer")
+		}
+		return NewFileMailer(mailerConfig.FilePath), nil
+	case "log":
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver %q", mailerConfig.Driver)
+	}
+}
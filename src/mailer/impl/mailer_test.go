@@ -0,0 +1,74 @@
+package impl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMailer(t *testing.T) {
+	t.Run("NewMailer_Log", func(t *testing.T) {
+		m, err := NewMailer(config.MailerConfig{Driver: "log"})
+		require.NoError(t, err)
+		assert.IsType(t, &LogMailer{}, m)
+	})
+
+	t.Run("NewMailer_File", func(t *testing.T) {
+		m, err := NewMailer(config.MailerConfig{Driver: "file", FilePath: "mail.log"})
+		require.NoError(t, err)
+		assert.IsType(t, &FileMailer{}, m)
+	})
+
+	t.Run("NewMailer_SMTP", func(t *testing.T) {
+		m, err := NewMailer(config.MailerConfig{Driver: "smtp", SMTPHost: "localhost", SMTPPort: 587, From: "noreply@test.com"})
+		require.NoError(t, err)
+		assert.IsType(t, &SMTPMailer{}, m)
+	})
+
+	t.Run("NewMailer_MissingSettings", func(t *testing.T) {
+		_, err := NewMailer(config.MailerConfig{Driver: "smtp"})
+		assert.Error(t, err)
+
+		_, err = NewMailer(config.MailerConfig{Driver: "file"})
+		assert.Error(t, err)
+	})
+
+	t.Run("NewMailer_UnknownDriver", func(t *testing.T) {
+		_, err := NewMailer(config.MailerConfig{Driver: "pigeon"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := NewFileMailer(path)
+
+	require.NoError(t, m.Send(mailer.Message{To: "first@test.com", Subject: "First", Body: "first body"}))
+	require.NoError(t, m.Send(mailer.Message{To: "second@test.com", Subject: "Second", Body: "second body"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "To: first@test.com\nSubject: First\n\nfirst body")
+	assert.Contains(t, string(content), "To: second@test.com\nSubject: Second\n\nsecond body")
+}
+
+func TestBuildMessage(t *testing.T) {
+	raw := string(buildMessage("noreply@test.com", mailer.Message{
+		To:      "test@test.com",
+		Subject: "Reset your password",
+		Body:    "line one\nline two",
+	}))
+
+	headers, body, found := strings.Cut(raw, "\r\n\r\n")
+	require.True(t, found, "Expected headers and body to be separated by an empty line")
+	assert.Contains(t, headers, "From: noreply@test.com\r\n")
+	assert.Contains(t, headers, "To: test@test.com\r\n")
+	assert.Contains(t, headers, "Subject: Reset your password\r\n")
+	assert.Equal(t, "line one\r\nline two", body)
+}
@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/sirupsen/logrus"
+)
+
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// Send implements mailer.Mailer.
+func (m *SMTPMailer) Send(message mailer.Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	address := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+
+	err := smtp.SendMail(address, auth, m.From, []string{message.To}, buildMessage(m.From, message))
+	if err != nil {
+		logrus.WithError(err).Error("[SMTPMailer.Send] Failed to send email")
+		return err
+	}
+
+	return nil
+}
+
+// buildMessage formats the message as a plain text RFC 5322 email.
+func buildMessage(from string, message mailer.Message) []byte {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "From: %s\r\n", from)
+	fmt.Fprintf(&builder, "To: %s\r\n", message.To)
+	fmt.Fprintf(&builder, "Subject: %s\r\n", message.Subject)
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return []byte(builder.String())
+}
+
+func NewSMTPMailer(host string, port int, username string, password string, from string) mailer.Mailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
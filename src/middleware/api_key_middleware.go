@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API key of game servers and backend integrations.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyRole is the role of requests authenticated with an API key, they are
+// authorized with the scopes of the key.
+const APIKeyRole = "api_key"
+
+type AuthenticateAPIKeyFunc func(string) (*response.APIKeyResponse, error)
+
+// APIKeyAuthMiddleware authenticates the request with the X-API-Key header.
+func APIKeyAuthMiddleware(authenticate AuthenticateAPIKeyFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(APIKeyHeader)
+
+		if key == "" {
+			ctx.JSON(401, response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "API key is required",
+				Data:    nil,
+			})
+			ctx.Abort()
+			return
+		}
+
+		apiKey, err := authenticate(key)
+		if err != nil {
+			if !errors.Is(err, helpers.ErrInvalidAPIKey) {
+				ctx.JSON(500, response.BaseResponse{
+					Code:    500,
+					Status:  "Error",
+					Message: "Failed to authenticate api key",
+					Data:    nil,
+				})
+				ctx.Abort()
+				return
+			}
+
+			ctx.JSON(401, response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: err.Error(),
+				Data:    nil,
+			})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("role", APIKeyRole)
+		ctx.Set("apiKeyID", apiKey.ID)
+		ctx.Set("permissions", apiKey.Scopes)
+
+		ctx.Next()
+	}
+}
+
+// APIKeyOrJWTAuthMiddleware authenticates with the API key when the request
+// has one and with the bearer token otherwise.
+func APIKeyOrJWTAuthMiddleware(apiKeyAuth gin.HandlerFunc, jwtAuth gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader(APIKeyHeader) != "" {
+			apiKeyAuth(ctx)
+			return
+		}
+
+		jwtAuth(ctx)
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func authenticateTestAPIKey(key string) (*response.APIKeyResponse, error) {
+	if key != "pp_abc.secret" {
+		return nil, helpers.ErrInvalidAPIKey
+	}
+
+	return &response.APIKeyResponse{ID: 1, Scopes: []string{models.PermissionAchievementsAward}}, nil
+}
+
+func TestAPIKeyOrJWTAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtAuth := func(ctx *gin.Context) {
+		ctx.Set("role", "user")
+		ctx.Next()
+	}
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.Use(APIKeyOrJWTAuthMiddleware(APIKeyAuthMiddleware(authenticateTestAPIKey), jwtAuth))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"role": ctx.GetString("role"), "apiKeyID": ctx.GetUint("apiKeyID"), "permissions": ctx.GetStringSlice("permissions")})
+		})
+		return router
+	}
+
+	t.Run("ValidAPIKey", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(APIKeyHeader, "pp_abc.secret")
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.JSONEq(t, `{"role":"api_key","apiKeyID":1,"permissions":["achievements:award"]}`, rec.Body.String())
+	})
+
+	t.Run("InvalidAPIKey", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(APIKeyHeader, "pp_abc.wrong")
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+	})
+
+	t.Run("FallsBackToJWT", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.Contains(t, rec.Body.String(), `"role":"user"`)
+	})
+}
+
+func TestAPIKeyPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.Use(APIKeyAuthMiddleware(authenticateTestAPIKey))
+		router.POST("/players/:playerID/achievements", RequirePermission(models.PermissionAchievementsAward), func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+		router.DELETE("/achievements", RequirePermission(models.PermissionAchievementsWrite), func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+		return router
+	}
+
+	t.Run("API key with the scope", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/players/1/achievements", nil)
+		req.Header.Set(APIKeyHeader, "pp_abc.secret")
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+	})
+
+	t.Run("API key without the scope", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/achievements", nil)
+		req.Header.Set(APIKeyHeader, "pp_abc.secret")
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, "Expected status code 403")
+	})
+}
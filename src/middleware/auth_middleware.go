@@ -1,15 +1,18 @@
 package middleware
 
 import (
+	"math"
 	"strings"
+	"time"
 
-	auth "github.com/dieg0code/player-profile/src/auth/impl"
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
 )
 
-func JWTAuthMiddleware() gin.HandlerFunc {
+func JWTAuthMiddleware(authUtils auth.AuthUtils, revocationStore auth.RevocationStore, sessionStore auth.SessionStore, permissionStore auth.PermissionStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		tokenString := ctx.GetHeader("Authorization")
 
@@ -28,8 +31,6 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 
 		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-		authUtils := auth.NewJWTAth()
-
 		token, err := authUtils.ParseToken(tokenString)
 		if err != nil || !token.Valid {
 			errorResponse := response.BaseResponse{
@@ -74,7 +75,7 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		userID := uint(userIDFloat)
 
 		role, ok := claims["role"].(string)
-		if !ok || (role != "user" && role != "admin") {
+		if !ok {
 			errorResponse := response.BaseResponse{
 				Code:    401,
 				Status:  "Unauthorized",
@@ -87,9 +88,141 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// A role deleted since the token was issued no longer grants access
+		permissions, ok := permissionStore.Permissions(role)
+		if !ok {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Unauthorized",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		tokenID, ok := claims["jti"].(string)
+		if !ok || tokenID == "" {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Invalid token",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		// Tokens are issued with millisecond precision, so a login right after a
+		// logout from every device isn't taken for one of the revoked tokens
+		issuedAtFloat, ok := claims["iat"].(float64)
+		if !ok {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Invalid token",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		expiresAt, err := claims.GetExpirationTime()
+		if err != nil || expiresAt == nil {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Invalid token",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		if revocationStore.IsRevoked(tokenID, userID, time.UnixMilli(int64(math.Round(issuedAtFloat*1000)))) {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Token has been revoked",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		// Tokens issued before sessions were recorded carry no sid
+		sessionIDFloat, hasSession := claims["sid"].(float64)
+		sessionID := uint(sessionIDFloat)
+		if hasSession && sessionStore.IsEnded(sessionID) {
+			errorResponse := response.BaseResponse{
+				Code:    401,
+				Status:  "Unauthorized",
+				Message: "Session has ended",
+				Data:    nil,
+			}
+
+			ctx.JSON(401, errorResponse)
+			ctx.Abort()
+			return
+		}
+
+		// An admin impersonating the user is in the act claim
+		var impersonatorID uint
+		if act, found := claims["act"]; found {
+			actorIDFloat, ok := actorUserID(act)
+			if !ok {
+				errorResponse := response.BaseResponse{
+					Code:    401,
+					Status:  "Unauthorized",
+					Message: "Invalid token",
+					Data:    nil,
+				}
+
+				ctx.JSON(401, errorResponse)
+				ctx.Abort()
+				return
+			}
+			impersonatorID = uint(actorIDFloat)
+		}
+
 		ctx.Set("userID", userID)
+		ctx.Set("impersonatorID", impersonatorID)
+		ctx.Set("sessionID", sessionID)
 		ctx.Set("role", role)
+		ctx.Set("permissions", permissions)
+		ctx.Set("tokenID", tokenID)
+		ctx.Set("tokenExpiresAt", expiresAt.Time)
 
 		ctx.Next()
+
+		if impersonatorID != 0 {
+			logrus.WithFields(logrus.Fields{
+				"impersonatorID": impersonatorID,
+				"userID":         userID,
+				"method":         ctx.Request.Method,
+				"path":           ctx.Request.URL.Path,
+				"status":         ctx.Writer.Status(),
+			}).Info("[JWTAuthMiddleware] Impersonated request")
+		}
 	}
 }
+
+func actorUserID(act interface{}) (float64, bool) {
+	actClaims, ok := act.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	actorID, ok := actClaims["userID"].(float64)
+	return actorID, ok && actorID > 0
+}
@@ -6,25 +6,53 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	auth "github.com/dieg0code/player-profile/src/auth/impl"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+func newRevocationStoreMock(revoked bool) *mocks.MockRevocationStore {
+	revocationStore := new(mocks.MockRevocationStore)
+	revocationStore.On("IsRevoked", mock.Anything, mock.Anything, mock.Anything).Return(revoked)
+	return revocationStore
+}
+
+func newSessionStoreMock(ended bool) *mocks.MockSessionStore {
+	sessionStore := new(mocks.MockSessionStore)
+	sessionStore.On("IsEnded", mock.Anything).Return(ended)
+	return sessionStore
+}
+
+func newPermissionStoreMock() *mocks.MockPermissionStore {
+	permissionStore := new(mocks.MockPermissionStore)
+	permissionStore.On("Permissions", "admin").Return([]string{models.PermissionUsersWrite}, true)
+	permissionStore.On("Permissions", "user").Return([]string{}, true)
+	permissionStore.On("Permissions", mock.Anything).Return(nil, false)
+	return permissionStore
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	t.Run("ValidToken", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(200, gin.H{"message": "success"})
 		})
 
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"jti":    "token-id",
 			"userID": 1,
 			"role":   "admin",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Hour).Unix(),
 		})
 
 		tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
@@ -43,7 +71,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("InvalidToken", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(200, gin.H{"message": "success"})
 		})
@@ -61,7 +89,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("NoToken", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(200, gin.H{"message": "success"})
 		})
@@ -78,7 +106,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("Valid token invalid claims", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -101,7 +129,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("Invalid Prefix", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -119,7 +147,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("Invalid role claim", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -149,7 +177,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("Missing userID claim", func(t *testing.T) {
 		router := gin.New()
-		router.Use(JWTAuthMiddleware())
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
 		router.GET("/test", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
 		})
@@ -172,4 +200,199 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), "Invalid token", "Expected response body to contain 'Invalid token'")
 	})
 
+	t.Run("RevokedToken", func(t *testing.T) {
+		authUtils := auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET"))))
+		revocationStore := new(mocks.MockRevocationStore)
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(authUtils, revocationStore, newSessionStoreMock(false), newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		tokenString, err := authUtils.GenerateToken(1, "user", 1)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		revocationStore.On("IsRevoked", mock.AnythingOfType("string"), uint(1), mock.AnythingOfType("time.Time")).Return(true)
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		assert.Contains(t, rec.Body.String(), "Token has been revoked", "Expected response body to contain 'Token has been revoked'")
+		revocationStore.AssertExpectations(t)
+	})
+
+	t.Run("EndedSession", func(t *testing.T) {
+		authUtils := auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET"))))
+		sessionStore := new(mocks.MockSessionStore)
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(authUtils, newRevocationStoreMock(false), sessionStore, newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		tokenString, err := authUtils.GenerateToken(1, "user", 7)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		sessionStore.On("IsEnded", uint(7)).Return(true)
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		assert.Contains(t, rec.Body.String(), "Session has ended", "Expected response body to contain 'Session has ended'")
+		sessionStore.AssertExpectations(t)
+	})
+
+	t.Run("Missing sid claim", func(t *testing.T) {
+		sessionStore := new(mocks.MockSessionStore)
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), sessionStore, newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"sessionID": ctx.GetUint("sessionID")})
+		})
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userID": 1,
+			"role":   "user",
+			"jti":    "legacy",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		})
+		tokenString, _ := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.JSONEq(t, `{"sessionID":0}`, rec.Body.String())
+		sessionStore.AssertNotCalled(t, "IsEnded", mock.Anything)
+	})
+
+	t.Run("ImpersonationToken", func(t *testing.T) {
+		authUtils := auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET"))))
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(authUtils, newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"userID": ctx.GetUint("userID"), "impersonatorID": ctx.GetUint("impersonatorID")})
+		})
+
+		tokenString, err := authUtils.GenerateImpersonationToken(42, "user", 1, time.Minute)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.JSONEq(t, `{"userID":42,"impersonatorID":1}`, rec.Body.String())
+	})
+
+	t.Run("Invalid act claim", func(t *testing.T) {
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userID": 42,
+			"role":   "user",
+			"jti":    "impersonation",
+			"act":    "admin",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		})
+		tokenString, _ := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		assert.Contains(t, rec.Body.String(), "Invalid token", "Expected response body to contain 'Invalid token'")
+	})
+
+	t.Run("Missing jti claim", func(t *testing.T) {
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET")))), newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userID": 1,
+			"role":   "user",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		})
+		tokenString, _ := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+
+		req, err := http.NewRequest(http.MethodGet, "/test", nil)
+		assert.Nil(t, err, "Expected no error creating request")
+
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+		assert.Contains(t, rec.Body.String(), "Invalid token", "Expected response body to contain 'Invalid token'")
+	})
+}
+
+func TestAuthMiddleware_Permissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authUtils := auth.NewJWTAth(time.Hour, auth.NewHMACKeySet([]byte(os.Getenv("JWT_SECRET"))))
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.Use(JWTAuthMiddleware(authUtils, newRevocationStoreMock(false), newSessionStoreMock(false), newPermissionStoreMock()))
+		router.GET("/test", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{"permissions": ctx.GetStringSlice("permissions")})
+		})
+		return router
+	}
+
+	t.Run("Role permissions are loaded", func(t *testing.T) {
+		tokenString, err := authUtils.GenerateToken(1, "admin", 1)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, "Expected status code 200")
+		assert.JSONEq(t, `{"permissions":["users:write"]}`, rec.Body.String())
+	})
+
+	t.Run("Deleted role", func(t *testing.T) {
+		tokenString, err := authUtils.GenerateToken(1, "moderator", 1)
+		assert.Nil(t, err, "Expected no error generating token")
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		newRouter().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "Expected status code 401")
+	})
 }
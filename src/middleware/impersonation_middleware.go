@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// DenyImpersonation refuses the request when an admin is impersonating the
+// user, it guards the operations that can't be undone or that change how the
+// account signs in.
+func DenyImpersonation() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if IsImpersonating(ctx) {
+			ctx.JSON(403, response.BaseResponse{
+				Code:    403,
+				Status:  "Forbidden",
+				Message: helpers.ErrImpersonationNotAllowed.Error(),
+				Data:    nil,
+			})
+
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// IsImpersonating reports whether the request uses a token issued to an admin impersonating the user.
+func IsImpersonating(ctx *gin.Context) bool {
+	return ctx.GetUint("impersonatorID") != 0
+}
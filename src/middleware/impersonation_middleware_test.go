@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDenyImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Not impersonating", func(t *testing.T) {
+		router := setupRouter(1)
+		router.Use(DenyImpersonation())
+		router.DELETE("/test", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+
+		w := performRequest(router, "DELETE", "/test")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("Impersonating", func(t *testing.T) {
+		router := setupRouter(42)
+		router.Use(func(ctx *gin.Context) {
+			ctx.Set("impersonatorID", uint(1))
+		}, DenyImpersonation())
+		router.DELETE("/test", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+
+		w := performRequest(router, "DELETE", "/test")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "response status should be 403")
+		assert.Contains(t, w.Body.String(), "not allowed while impersonating a user")
+	})
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+type GetUserPermissionsFunc func(uint) ([]string, error)
+
+// DenyPrivilegeEscalation refuses the request on the user of the :userID path
+// parameter when the role of that user holds a permission the caller doesn't.
+// Otherwise a role allowed to manage users could reset the password or the
+// email of an admin and take over the account. Users acting on their own
+// account are let through.
+func DenyPrivilegeEscalation(getUserPermissions GetUserPermissionsFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		paramUserID, err := strconv.ParseUint(ctx.Param("userID"), 10, 64)
+		if err != nil {
+			writeOwnerCheckError(ctx, helpers.ErrInvalidUserID)
+			return
+		}
+
+		if uint(paramUserID) == ctx.GetUint("userID") {
+			ctx.Next()
+			return
+		}
+
+		userPermissions, err := getUserPermissions(uint(paramUserID))
+		if err != nil {
+			// The handler answers for the missing user
+			if errors.Is(err, helpers.ErrorUserNotFound) {
+				ctx.Next()
+				return
+			}
+
+			writeOwnerCheckError(ctx, err)
+			return
+		}
+
+		callerPermissions := ctx.GetStringSlice("permissions")
+		for _, permission := range userPermissions {
+			if !slices.Contains(callerPermissions, permission) {
+				ctx.JSON(403, response.BaseResponse{
+					Code:    403,
+					Status:  "Forbidden",
+					Message: helpers.ErrPrivilegedTarget.Error(),
+					Data:    nil,
+				})
+
+				ctx.Abort()
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDenyPrivilegeEscalation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// User 1 is an admin, user 2 a moderator and user 3 a player
+	admin := []string{models.PermissionUsersRead, models.PermissionUsersWrite, models.PermissionRolesManage}
+	moderator := []string{models.PermissionUsersRead, models.PermissionUsersWrite}
+	getUserPermissions := func(userID uint) ([]string, error) {
+		switch userID {
+		case 1:
+			return admin, nil
+		case 2:
+			return moderator, nil
+		case 3:
+			return nil, nil
+		}
+		return nil, helpers.ErrorUserNotFound
+	}
+
+	newRouter := func(userID uint, permissions ...string) *gin.Engine {
+		router := setupRouter(userID, permissions...)
+		router.Use(RequirePermission(models.PermissionUsersWrite, UserOwner()), DenyPrivilegeEscalation(getUserPermissions))
+		router.PUT("/users/:userID/password", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+		return router
+	}
+
+	t.Run("Moderator on an admin", func(t *testing.T) {
+		w := performRequest(newRouter(2, moderator...), "PUT", "/users/1/password")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "response status should be 403")
+		assert.Contains(t, w.Body.String(), helpers.ErrPrivilegedTarget.Error())
+	})
+
+	t.Run("Moderator on a player", func(t *testing.T) {
+		w := performRequest(newRouter(2, moderator...), "PUT", "/users/3/password")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("Moderator on another moderator", func(t *testing.T) {
+		w := performRequest(newRouter(4, moderator...), "PUT", "/users/2/password")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("Admin on a moderator", func(t *testing.T) {
+		w := performRequest(newRouter(1, admin...), "PUT", "/users/2/password")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("Admin on their own account", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/users/1/password")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("Missing user is left to the handler", func(t *testing.T) {
+		w := performRequest(newRouter(2, moderator...), "PUT", "/users/9/password")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+}
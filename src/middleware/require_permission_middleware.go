@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+type GetPlayerFunc func(uint) (*response.PlayerProfileResponse, error)
+
+// OwnerCheck reports whether the authenticated user owns the resource of the request.
+type OwnerCheck func(ctx *gin.Context, userID uint) (bool, error)
+
+// RequirePermission lets the request through when the user or API key holds
+// the permission or, failing that, when one of the owner checks matches.
+// The permissions are set in the context by the authentication middlewares.
+func RequirePermission(permission string, ownerChecks ...OwnerCheck) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if HasPermission(ctx, permission) {
+			ctx.Next()
+			return
+		}
+
+		// API keys don't belong to a user, they only have their permissions
+		authUserID := ctx.GetUint("userID")
+		if authUserID != 0 {
+			for _, ownerCheck := range ownerChecks {
+				owner, err := ownerCheck(ctx, authUserID)
+				if err != nil {
+					writeOwnerCheckError(ctx, err)
+					return
+				}
+
+				if owner {
+					ctx.Next()
+					return
+				}
+			}
+		}
+
+		ctx.JSON(403, response.BaseResponse{
+			Code:    403,
+			Status:  "Forbidden",
+			Message: "You are not allowed to perform this action",
+			Data:    nil,
+		})
+
+		ctx.Abort()
+	}
+}
+
+// HasPermission reports whether the authenticated user or API key holds the permission.
+func HasPermission(ctx *gin.Context, permission string) bool {
+	return slices.Contains(ctx.GetStringSlice("permissions"), permission)
+}
+
+// UserOwner matches the user of the :userID path parameter.
+func UserOwner() OwnerCheck {
+	return func(ctx *gin.Context, userID uint) (bool, error) {
+		paramUserID, err := strconv.ParseUint(ctx.Param("userID"), 10, 64)
+		if err != nil {
+			return false, helpers.ErrInvalidUserID
+		}
+
+		return uint(paramUserID) == userID, nil
+	}
+}
+
+// PlayerOwner matches the owner of the player profile of the :playerID path parameter.
+func PlayerOwner(getPlayer GetPlayerFunc) OwnerCheck {
+	return func(ctx *gin.Context, userID uint) (bool, error) {
+		playerID, err := strconv.ParseUint(ctx.Param("playerID"), 10, 64)
+		if err != nil {
+			return false, helpers.ErrInvalidPlayerProfileID
+		}
+
+		player, err := getPlayer(uint(playerID))
+		if err != nil {
+			return false, helpers.ErrorPlayerProfileNotFound
+		}
+
+		return player.UserID == userID, nil
+	}
+}
+
+func writeOwnerCheckError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, helpers.ErrInvalidUserID):
+		ctx.JSON(400, response.BaseResponse{
+			Code:    400,
+			Status:  "Bad Request",
+			Message: "Invalid user ID",
+			Data:    nil,
+		})
+	case errors.Is(err, helpers.ErrInvalidPlayerProfileID):
+		ctx.JSON(400, response.BaseResponse{
+			Code:    400,
+			Status:  "Bad Request",
+			Message: "Invalid player ID",
+			Data:    nil,
+		})
+	case errors.Is(err, helpers.ErrorPlayerProfileNotFound):
+		ctx.JSON(404, response.BaseResponse{
+			Code:    404,
+			Status:  "Not Found",
+			Message: "Player not found",
+			Data:    nil,
+		})
+	default:
+		ctx.JSON(500, response.BaseResponse{
+			Code:    500,
+			Status:  "Error",
+			Message: "Failed to check the permissions",
+			Data:    nil,
+		})
+	}
+
+	ctx.Abort()
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Permission granted", func(t *testing.T) {
+		router := setupRouter(1, models.PermissionAchievementsWrite)
+		router.Use(RequirePermission(models.PermissionAchievementsWrite))
+		router.POST("/test", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+
+		w := performRequest(router, "POST", "/test")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+		assert.Contains(t, w.Body.String(), "success", "response body should contain 'success'")
+	})
+
+	t.Run("Permission missing", func(t *testing.T) {
+		router := setupRouter(1, models.PermissionUsersRead)
+		router.Use(RequirePermission(models.PermissionAchievementsWrite))
+		router.POST("/test", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+
+		w := performRequest(router, "POST", "/test")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "response status should be 403")
+		assert.Contains(t, w.Body.String(), "You are not allowed to perform this action")
+	})
+}
+
+func TestRequirePermission_UserOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(userID uint, permissions ...string) *gin.Engine {
+		router := setupRouter(userID, permissions...)
+		router.Use(RequirePermission(models.PermissionUsersWrite, UserOwner()))
+		router.PUT("/users/:userID", func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"message": "success"})
+		})
+		return router
+	}
+
+	t.Run("Permission holder on another user", func(t *testing.T) {
+		w := performRequest(newRouter(1, models.PermissionUsersWrite), "PUT", "/users/2")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("User on their own account", func(t *testing.T) {
+		w := performRequest(newRouter(2), "PUT", "/users/2")
+
+		assert.Equal(t, http.StatusOK, w.Code, "response status should be 200")
+	})
+
+	t.Run("User on another account", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/users/2")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "response status should be 403")
+	})
+
+	t.Run("API keys own nothing", func(t *testing.T) {
+		w := performRequest(newRouter(0), "PUT", "/users/0")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "response status should be 403")
+	})
+
+	t.Run("Invalid user ID", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/users/abc")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "response status should be 400")
+		assert.Contains(t, w.Body.String(), "Invalid user ID")
+	})
+}
+
+func TestRequirePermission_PlayerOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	getPlayer := func(playerID uint) (*response.PlayerProfileResponse, error) {
+		if playerID != 1 {
+			return nil, errors.New("player not found")
+		}
+		return &response.PlayerProfileResponse{ID: 1, UserID: 1}, nil
+	}
+
+	newRouter := func(userID uint, permissions ...string) *gin.Engine {
+		router := setupRouter(userID, permissions...)
+		router.Use(RequirePermission(models.PermissionPlayersModerate, PlayerOwner(getPlayer)))
+		router.PUT("/players/:playerID", func(ctx *gin.Context) {
+			ctx.String(200, "OK")
+		})
+		return router
+	}
+
+	t.Run("Moderator bypasses the owner check", func(t *testing.T) {
+		w := performRequest(newRouter(2, models.PermissionPlayersModerate), "PUT", "/players/5")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/players/1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "OK")
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		w := performRequest(newRouter(2), "PUT", "/players/1")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Player not found", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/players/3")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Player not found")
+	})
+
+	t.Run("Invalid player ID", func(t *testing.T) {
+		w := performRequest(newRouter(1), "PUT", "/players/abc")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid player ID")
+	})
+}
+
+func setupRouter(userID uint, permissions ...string) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("permissions", permissions)
+	})
+	return router
+}
+
+func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
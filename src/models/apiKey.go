@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey is a credential for game servers and backend integrations, sent in
+// the X-API-Key header. The prefix identifies the key and only the SHA-256
+// hash of the secret is stored.
+type APIKey struct {
+	gorm.Model
+	Name       string `gorm:"type:varchar(100);not null"`
+	Prefix     string `gorm:"type:varchar(16);uniqueIndex;not null"`
+	SecretHash string `gorm:"type:varchar(64);not null"`
+	Scopes     string `gorm:"type:varchar(255);not null"` // Space separated permissions
+	CreatedBy  uint   `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Directions of the friend requests of a player.
+const (
+	FriendRequestsIncoming = "incoming"
+	FriendRequestsOutgoing = "outgoing"
+)
+
+// FriendRequest is a pending friend request between two players, it is
+// deleted once accepted, declined or canceled.
+type FriendRequest struct {
+	ID         uint          `gorm:"primaryKey"`
+	SenderID   uint          `gorm:"not null;uniqueIndex:idx_friend_requests_pair"`
+	ReceiverID uint          `gorm:"not null;uniqueIndex:idx_friend_requests_pair;index"`
+	CreatedAt  time.Time     `gorm:"not null"`
+	Sender     PlayerProfile `gorm:"foreignKey:SenderID"`
+	Receiver   PlayerProfile `gorm:"foreignKey:ReceiverID"`
+}
+
+// Friendship is one side of the friendship of two players. Each friendship
+// is saved once per player so the friends of a player are a single lookup.
+type Friendship struct {
+	PlayerProfileID uint      `gorm:"primaryKey"`
+	FriendID        uint      `gorm:"primaryKey;index"`
+	CreatedAt       time.Time `gorm:"not null"`
+}
+
+// PlayerBlock stops friend requests between the players and hides the blocker
+// from the searches of the blocked player.
+type PlayerBlock struct {
+	BlockerID uint      `gorm:"primaryKey"`
+	BlockedID uint      `gorm:"primaryKey;index"`
+	CreatedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// Metrics players can be ranked by, they match the columns of the player profiles.
+// Points rank the players of all time, season points the running season.
+const (
+	LeaderboardMetricPoints       = "points"
+	LeaderboardMetricSeasonPoints = "season_points"
+	LeaderboardMetricLevel        = "level"
+	LeaderboardMetricExperience   = "experience"
+)
+
+// Rankings of the leaderboards. Competitive ranking skips the ranks after a
+// tie (1, 2, 2, 4), dense ranking doesn't (1, 2, 2, 3).
+const (
+	RankingCompetitive = "competitive"
+	RankingDense       = "dense"
+)
+
+// IsLeaderboardMetric reports whether players can be ranked by the metric.
+func IsLeaderboardMetric(metric string) bool {
+	return metric == LeaderboardMetricPoints || metric == LeaderboardMetricSeasonPoints || metric == LeaderboardMetricLevel || metric == LeaderboardMetricExperience
+}
+
+// LeaderboardValue returns the value of the metric for the player.
+func (p *PlayerProfile) LeaderboardValue(metric string) int {
+	switch metric {
+	case LeaderboardMetricSeasonPoints:
+		return p.SeasonPoints
+	case LeaderboardMetricLevel:
+		return p.Level
+	case LeaderboardMetricExperience:
+		return p.Experience
+	default:
+		return p.Points
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+// Filter operators of the list endpoints, written between the field and the
+// value in the query string, like level>=10 or nickname~=noob.
+const (
+	FilterEqual          = "="
+	FilterNotEqual       = "!="
+	FilterGreater        = ">"
+	FilterGreaterOrEqual = ">="
+	FilterLess           = "<"
+	FilterLessOrEqual    = "<="
+	FilterPrefix         = "~=" // Case-insensitive prefix search
+)
+
+// Filter keeps the items whose field compares to the value with the operator.
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// SortField orders a list by a field, ascending unless Descending.
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ListQuery filters and sorts a list endpoint. Filters are combined with AND
+// and the list is sorted by the fields in order, then by id.
+type ListQuery struct {
+	Filters []Filter
+	Sort    []SortField
+}
+
+// Page is the slice of a list a repository reads, Limit items from Offset.
+// A cursor reads the items after AfterID or before BeforeID instead, which
+// requires the list to be sorted by id alone.
+type Page struct {
+	Offset   int
+	Limit    int
+	AfterID  uint
+	BeforeID uint
+}
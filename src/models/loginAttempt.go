@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LoginAttempt counts the consecutive failed logins of a key, an email or a
+// client IP.
+type LoginAttempt struct {
+	Key           string    `gorm:"column:attempt_key;type:varchar(320);primaryKey"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rating systems the matches can be rated with.
+const (
+	RatingSystemElo     = "elo"
+	RatingSystemGlicko2 = "glicko2"
+)
+
+// Match is a result submitted by a game server. Its participants keep the
+// rating of the player before and after the match.
+type Match struct {
+	gorm.Model
+	RatingSystem string             `gorm:"type:varchar(20);not null"`
+	PlayedAt     time.Time          `gorm:"not null"`
+	Participants []MatchParticipant `gorm:"foreignKey:MatchID"`
+}
+
+// MatchParticipant is a player of a match. Placement 1 won, players of the
+// same team share the placement.
+type MatchParticipant struct {
+	ID              uint    `gorm:"primaryKey"`
+	MatchID         uint    `gorm:"not null;index"`
+	Match           Match   `gorm:"foreignKey:MatchID"`
+	PlayerProfileID uint    `gorm:"not null;index"`
+	Team            int     `gorm:"not null"`
+	Placement       int     `gorm:"not null"`
+	RatingBefore    float64 `gorm:"not null"`
+	RatingAfter     float64 `gorm:"not null"`
+	DeviationBefore float64 `gorm:"not null"`
+	DeviationAfter  float64 `gorm:"not null"`
+}
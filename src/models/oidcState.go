@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OIDCState is a social login in progress, created when the user is sent to
+// the provider and consumed once by the callback. Only the SHA-256 hash of the
+// state parameter is stored.
+type OIDCState struct {
+	gorm.Model
+	StateHash    string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Provider     string    `gorm:"type:varchar(64);not null"`
+	Nonce        string    `gorm:"type:varchar(64);not null"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null"` // PKCE verifier sent with the code
+	LinkUserID   *uint     // Set when a signed in user links the identity to their account
+	ExpiresAt    time.Time `gorm:"not null"`
+	UsedAt       *time.Time
+}
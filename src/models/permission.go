@@ -0,0 +1,55 @@
+package models
+
+// Permissions checked by the API. They are defined here and granted to roles
+// stored in the database.
+const (
+	PermissionUsersRead         = "users:read"
+	PermissionUsersWrite        = "users:write"
+	PermissionPlayersModerate   = "players:moderate"
+	PermissionPlayersProgress   = "players:progress"
+	PermissionAchievementsWrite = "achievements:write"
+	PermissionAchievementsAward = "achievements:award"
+	PermissionAPIKeysManage     = "api_keys:manage"
+	PermissionRolesManage       = "roles:manage"
+	PermissionUsersImpersonate  = "users:impersonate"
+	PermissionSeasonsManage     = "seasons:manage"
+	PermissionMatchesSubmit     = "matches:submit"
+)
+
+// Permission describes an entry of the catalog.
+type Permission struct {
+	Name        string
+	Description string
+}
+
+// Permissions is the catalog of permissions that can be granted.
+var Permissions = []Permission{
+	{PermissionUsersRead, "See the private data of any user, like the linked identities"},
+	{PermissionUsersWrite, "Update, delete, unlock and sign out any user"},
+	{PermissionPlayersModerate, "Create player profiles for any user, update and delete any player profile"},
+	{PermissionPlayersProgress, "Update the level, experience and points of the players"},
+	{PermissionAchievementsWrite, "Create, update and delete achievements"},
+	{PermissionAchievementsAward, "Award and revoke achievements of the players"},
+	{PermissionAPIKeysManage, "Create, list and revoke API keys"},
+	{PermissionRolesManage, "Create, update and delete roles"},
+	{PermissionUsersImpersonate, "Sign in as another user to see what they see"},
+	{PermissionSeasonsManage, "Start a new leaderboard season, archiving the current one"},
+	{PermissionMatchesSubmit, "Submit match results, rating the players"},
+}
+
+// IsPermission reports whether the name is in the catalog.
+func IsPermission(name string) bool {
+	for _, permission := range Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAPIKeyScope reports whether the permission can be granted to an API key.
+// Keys can't manage keys or roles nor impersonate users, that stays with the admins.
+func IsAPIKeyScope(name string) bool {
+	return IsPermission(name) && name != PermissionAPIKeysManage && name != PermissionRolesManage && name != PermissionUsersImpersonate
+}
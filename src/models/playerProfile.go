@@ -10,8 +10,9 @@ type PlayerProfile struct {
 	Nickname     string        `gorm:"type:varchar(255);unique;not null" validate:"required"`
 	Avatar       string        `gorm:"type:varchar(255);not null" validate:"required"`
 	Level        int           `gorm:"type:int;not null" validate:"required"`
-	Experience   int           `gorm:"type:int;not null" validate:"required"`
-	Points       int           `gorm:"type:int;not null" validate:"required"`
+	Experience   int           `gorm:"type:int;not null" validate:"gte=0"`
+	Points       int           `gorm:"type:int;not null" validate:"gte=0"`
+	SeasonPoints int           `gorm:"type:int;not null;default:0;index"`     // Points earned in the running season
 	UserID       uint          `gorm:"type:int;not null" validate:"required"` // Clave foránea
 	User         User          `gorm:"foreignKey:UserID"`                     // Relación con User
 	Achievements []Achievement `gorm:"many2many:player_profile_achievements"`
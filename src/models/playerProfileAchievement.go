@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// PlayerProfileAchievement is the join row of the many2many relation between
+// PlayerProfile and Achievement, it records when the achievement was unlocked.
+type PlayerProfileAchievement struct {
+	PlayerProfileID uint          `gorm:"primaryKey"`
+	AchievementID   uint          `gorm:"primaryKey"`
+	UnlockedAt      time.Time     `gorm:"not null"`
+	PlayerProfile   PlayerProfile `gorm:"foreignKey:PlayerProfileID"`
+	Achievement     Achievement   `gorm:"foreignKey:AchievementID"`
+}
+
+func (PlayerProfileAchievement) TableName() string {
+	return "player_profile_achievements"
+}
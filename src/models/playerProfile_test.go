@@ -28,6 +28,25 @@ func TestValidationPlayerProfile(t *testing.T) {
 		require.NoError(t, err, "Error validating player profile")
 	})
 
+	t.Run("Validate_StartingProgress", func(t *testing.T) {
+		newProfile := PlayerProfile{
+			Nickname: "PlayerOne",
+			Avatar:   "avatarURL",
+			Level:    1,
+			UserID:   1,
+			User: User{
+				UserName: "ValidUser",
+				PassWord: "ValidPass123",
+				Email:    "user@example.com",
+				Age:      30,
+				Role:     "user",
+			},
+		}
+
+		err := newProfile.Validate()
+		require.NoError(t, err, "New profiles start without experience nor points")
+	})
+
 	t.Run("Validate_Invalid", func(t *testing.T) {
 
 		invalidProfile := PlayerProfile{
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PlayerRating is the matchmaking rating of a player, created with the first
+// rated match. MatchesPlayed also versions the row, a match only updates the
+// rating it read.
+type PlayerRating struct {
+	ID              uint    `gorm:"primaryKey"`
+	PlayerProfileID uint    `gorm:"not null;uniqueIndex"`
+	Rating          float64 `gorm:"not null"`
+	Deviation       float64 `gorm:"not null"`
+	Volatility      float64 `gorm:"not null"`
+	MatchesPlayed   int     `gorm:"not null"`
+	UpdatedAt       time.Time
+}
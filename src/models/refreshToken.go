@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is an opaque long-lived token used to obtain new access tokens.
+// Only the SHA-256 hash of the token is stored, tokens issued by rotation share
+// the FamilyID of the login that started the chain.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint       `gorm:"not null;index"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null"`
+	FamilyID  string     `gorm:"type:varchar(64);index;not null"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // Set when the token is exchanged for a new pair
+	RevokedAt *time.Time // Set when the whole family is revoked
+}
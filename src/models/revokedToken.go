@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RevokedToken is an access token invalidated before its expiration, identified
+// by its jti claim. Rows can be purged once ExpiresAt has passed.
+type RevokedToken struct {
+	JTI       string    `gorm:"type:varchar(64);primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// UserRevocation invalidates every access token of the user issued up to RevokedAt.
+type UserRevocation struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false"`
+	RevokedAt time.Time `gorm:"not null"`
+}
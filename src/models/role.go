@@ -0,0 +1,34 @@
+package models
+
+import "gorm.io/gorm"
+
+// Roles created at startup. Admin always holds every permission.
+const (
+	AdminRole      = "admin"
+	UserRole       = "user"
+	GameServerRole = "game_server"
+)
+
+// Role is a named set of permissions, users reference it by name.
+type Role struct {
+	gorm.Model
+	Name        string           `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Description string           `gorm:"type:varchar(255)"`
+	Permissions []RolePermission `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// RolePermission grants one of the permissions of the catalog to a role.
+type RolePermission struct {
+	RoleID     uint   `gorm:"primaryKey"`
+	Permission string `gorm:"type:varchar(50);primaryKey"`
+}
+
+// PermissionNames returns the names of the permissions of the role.
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, rolePermission := range r.Permissions {
+		names = append(names, rolePermission.Permission)
+	}
+
+	return names
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Season is a period of the season points leaderboard. Starting a new season
+// ends the current one, archives its standings and resets the season points of
+// the players, their lifetime points are kept.
+type Season struct {
+	gorm.Model
+	Name      string     `gorm:"type:varchar(100);uniqueIndex;not null"`
+	StartedAt time.Time  `gorm:"not null"`
+	EndedAt   *time.Time `gorm:"index"` // Nil while the season is running
+}
+
+// SeasonStanding is the final position of a player in an ended season. The
+// nickname is copied so the standings survive profile changes.
+type SeasonStanding struct {
+	ID              uint   `gorm:"primaryKey"`
+	SeasonID        uint   `gorm:"not null;index"`
+	PlayerProfileID uint   `gorm:"not null"`
+	Nickname        string `gorm:"type:varchar(255);not null"`
+	Rank            int    `gorm:"not null"`
+	Points          int    `gorm:"not null"`
+	Level           int    `gorm:"not null"`
+	Experience      int    `gorm:"not null"`
+}
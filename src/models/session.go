@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session is a signed in device. It starts on login and lives as long as its
+// refresh token family, the access tokens carry its ID in the sid claim.
+type Session struct {
+	gorm.Model
+	UserID     uint       `gorm:"not null;index"`
+	FamilyID   string     `gorm:"type:varchar(64);uniqueIndex;not null"` // Refresh token family of the session
+	DeviceName string     `gorm:"type:varchar(100)"`
+	UserAgent  string     `gorm:"type:varchar(255)"`
+	IP         string     `gorm:"type:varchar(45)"`
+	LastSeenAt time.Time  `gorm:"not null"` // Last login or token refresh
+	EndedAt    *time.Time `gorm:"index"`    // Set on logout or when the session is killed
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 )
@@ -11,8 +13,10 @@ type User struct {
 	PassWord string          `gorm:"type:varchar(255);not null" validate:"required"`
 	Email    string          `gorm:"type:varchar(255);unique;not null" validate:"required"`
 	Age      int             `gorm:"type:int;not null" validate:"required"`
-	Role     string          `gorm:"type:varchar(255);not null" validate:"required,oneof=admin user"`
-	Profiles []PlayerProfile `gorm:"foreignKey:UserID"` // Relación uno a muchos con PlayerProfile
+	Role     string          `gorm:"type:varchar(255);not null" validate:"required,max=50"` // Name of a Role
+	Profiles []PlayerProfile `gorm:"foreignKey:UserID"`                                     // Relación uno a muchos con PlayerProfile
+
+	EmailVerifiedAt *time.Time // Nil until the user opens the verification link
 }
 
 func (u *User) Validate() error {
@@ -0,0 +1,13 @@
+package models
+
+import "gorm.io/gorm"
+
+// UserIdentity links the account of an OpenID Connect provider, identified by
+// its issuer subject, to a user.
+type UserIdentity struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	Provider string `gorm:"type:varchar(64);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email    string `gorm:"type:varchar(255)"` // Email reported by the provider when it was linked
+}
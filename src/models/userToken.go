@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Purposes of the single use tokens sent to the users by email.
+const (
+	PasswordResetToken      = "password_reset"
+	EmailVerificationToken  = "email_verification"
+	TwoFactorChallengeToken = "two_factor_challenge"
+)
+
+// UserToken is a single use token sent to a user, like a password reset or
+// email verification link.
+// Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	Purpose   string    `gorm:"type:varchar(32);not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
@@ -8,6 +8,9 @@ type AchievementRepository interface {
 	UpdateAchievement(achievementID uint, achievement *models.Achievement) error
 	DeleteAchievement(achievementID uint) error
 	CheckAchievementExists(achievementID uint) (bool, error)
-	GetAllAchievements(offset int, pageSize int) ([]models.Achievement, error)
-	GetAchievementWithPlayers(achievementID uint) (*models.Achievement, error)
+	GetAllAchievements(page models.Page, listQuery models.ListQuery) ([]models.Achievement, error)
+	CountAchievements(listQuery models.ListQuery) (int64, error)
+	GetAchievementPlayers(achievementID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error)
+	AwardAchievement(playerProfileID uint, achievementID uint) (*models.PlayerProfileAchievement, bool, error)
+	RevokeAchievement(playerProfileID uint, achievementID uint) error
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(apiKey *models.APIKey) error
+	GetAPIKey(apiKeyID uint) (*models.APIKey, error)
+	GetAllAPIKeys() ([]models.APIKey, error)
+	FindByPrefix(prefix string) (*models.APIKey, error)
+	RevokeAPIKey(apiKeyID uint) error
+	UpdateLastUsed(apiKeyID uint, lastUsedAt time.Time) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type FriendshipRepository interface {
+	// CreateFriendRequest saves a pending friend request unless a player is
+	// missing, the players are friends, a request between them is pending or
+	// one blocked the other.
+	CreateFriendRequest(friendRequest *models.FriendRequest) error
+	// GetFriendRequest returns the friend request with both players.
+	GetFriendRequest(friendRequestID uint) (*models.FriendRequest, error)
+	// GetFriendRequests returns the pending requests received or sent by the
+	// player with both players, sorted by id.
+	GetFriendRequests(playerProfileID uint, incoming bool, page models.Page) ([]models.FriendRequest, error)
+	CountFriendRequests(playerProfileID uint, incoming bool) (int64, error)
+	// AcceptFriendRequest deletes the pending requests between the players
+	// and saves both sides of the friendship in one transaction.
+	AcceptFriendRequest(friendRequest *models.FriendRequest) error
+	DeleteFriendRequest(friendRequestID uint) error
+	// DeleteFriendship deletes both sides of the friendship.
+	DeleteFriendship(playerProfileID uint, friendID uint) error
+	GetFriends(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error)
+	CountFriends(playerProfileID uint) (int64, error)
+	// GetMutualFriends returns the players who are friends of both players.
+	GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, page models.Page) ([]models.PlayerProfile, error)
+	CountMutualFriends(playerProfileID uint, otherPlayerProfileID uint) (int64, error)
+	// BlockPlayer saves the block and deletes the friendship and the pending
+	// requests between the players in one transaction.
+	BlockPlayer(block *models.PlayerBlock) error
+	UnblockPlayer(blockerID uint, blockedID uint) error
+	GetBlockedPlayers(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error)
+	CountBlockedPlayers(playerProfileID uint) (int64, error)
+}
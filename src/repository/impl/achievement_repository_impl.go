@@ -2,30 +2,53 @@ package impl
 
 import (
 	"errors"
+	"slices"
+	"time"
 
 	h "github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
 	r "github.com/dieg0code/player-profile/src/repository"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AchivementRepositoryImpl struct {
 	Db *gorm.DB
 }
 
-// GetAchievementWithPlayers implements repository.AchievementRepository.
-func (a *AchivementRepositoryImpl) GetAchievementWithPlayers(achievementID uint) (*models.Achievement, error) {
-	var achievement models.Achievement
+// GetAchievementPlayers implements repository.AchievementRepository.
+func (a *AchivementRepositoryImpl) GetAchievementPlayers(achievementID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error) {
+	exists, err := a.CheckAchievementExists(achievementID)
+	if err != nil {
+		logrus.WithError(err).Error("[AchivementRepositoryImpl.GetAchievementPlayers] Failed to check if achievement exists")
+		return nil, err
+	}
 
-	result := a.Db.Preload("PlayerProfiles").Where(IDPlaceHolder, achievementID).First(&achievement)
+	if !exists {
+		return nil, h.ErrorAchievementNotFound
+	}
+
+	order := UnlockedAtDescOrder
+	if sortOrder == "asc" {
+		order = UnlockedAtAscOrder
+	}
+
+	var playerAchievements []models.PlayerProfileAchievement
+
+	result := a.Db.InnerJoins("PlayerProfile").
+		Where("player_profile_achievements.achievement_id = ?", achievementID).
+		Order(order).
+		Order("player_profile_achievements.player_profile_id").
+		Offset(offset).Limit(pageSize).
+		Find(&playerAchievements)
 
 	if result.Error != nil {
-		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.GetAchievementWithPlayers] Failed to get achievement with players")
+		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.GetAchievementPlayers] Failed to get achievement players")
 		return nil, result.Error
 	}
 
-	return &achievement, nil
+	return playerAchievements, nil
 }
 
 func NewAchievementRepositoryImpl(db *gorm.DB) r.AchievementRepository {
@@ -68,20 +91,53 @@ func (a *AchivementRepositoryImpl) GetAchievement(achievementID uint) (*models.A
 	return &achievementFound, nil
 }
 
+// achievementListFields are the fields achievements can be filtered and sorted by.
+var achievementListFields = map[string]listField{
+	"id":   {column: "id", numeric: true},
+	"name": {column: "name", searchable: true},
+}
+
 // GetAllAchievements implements repository.AchievementRepository.
-func (a *AchivementRepositoryImpl) GetAllAchievements(offset int, pageSize int) ([]models.Achievement, error) {
+func (a *AchivementRepositoryImpl) GetAllAchievements(page models.Page, listQuery models.ListQuery) ([]models.Achievement, error) {
 	var achievements []models.Achievement
 
-	result := a.Db.Offset(offset).Limit(pageSize).Find(&achievements)
+	scope, err := listQueryScope(listQuery, page, achievementListFields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := a.Db.Scopes(scope).Find(&achievements)
 
 	if result.Error != nil {
 		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.GetAllAchievements] Failed to get all achievements")
 		return nil, result.Error
 	}
 
+	if page.BeforeID != 0 {
+		slices.Reverse(achievements)
+	}
+
 	return achievements, nil
 }
 
+// CountAchievements implements repository.AchievementRepository.
+func (a *AchivementRepositoryImpl) CountAchievements(listQuery models.ListQuery) (int64, error) {
+	var count int64
+
+	scope, err := listFilterScope(listQuery.Filters, achievementListFields)
+	if err != nil {
+		return 0, err
+	}
+
+	result := a.Db.Model(&models.Achievement{}).Scopes(scope).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.CountAchievements] Failed to count achievements")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
 // UpdateAchievement implements repository.AchievementRepository.
 func (a *AchivementRepositoryImpl) UpdateAchievement(achievementID uint, achievement *models.Achievement) error {
 	exists, err := a.CheckAchievementExists(achievementID)
@@ -142,3 +198,69 @@ func (a *AchivementRepositoryImpl) CheckAchievementExists(achievementID uint) (b
 	}
 	return exists > 0, nil
 }
+
+// AwardAchievement implements repository.AchievementRepository.
+// Awarding an achievement the player already has keeps the original unlock date,
+// the returned bool reports whether the achievement was newly unlocked.
+func (a *AchivementRepositoryImpl) AwardAchievement(playerProfileID uint, achievementID uint) (*models.PlayerProfileAchievement, bool, error) {
+	var playerExists int64
+
+	result := a.Db.Model(&models.PlayerProfile{}).Where(IDPlaceHolder, playerProfileID).Count(&playerExists)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.AwardAchievement] Failed to check if player profile exists")
+		return nil, false, result.Error
+	}
+
+	if playerExists == 0 {
+		return nil, false, h.ErrorPlayerProfileNotFound
+	}
+
+	exists, err := a.CheckAchievementExists(achievementID)
+	if err != nil {
+		logrus.WithError(err).Error("[AchivementRepositoryImpl.AwardAchievement] Failed to check if achievement exists")
+		return nil, false, err
+	}
+
+	if !exists {
+		return nil, false, h.ErrorAchievementNotFound
+	}
+
+	playerAchievement := models.PlayerProfileAchievement{
+		PlayerProfileID: playerProfileID,
+		AchievementID:   achievementID,
+		UnlockedAt:      time.Now().UTC(),
+	}
+
+	result = a.Db.Clauses(clause.OnConflict{DoNothing: true}).Create(&playerAchievement)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.AwardAchievement] Failed to award achievement")
+		return nil, false, result.Error
+	}
+
+	newlyUnlocked := result.RowsAffected > 0
+
+	if !newlyUnlocked {
+		result = a.Db.Where(PlayerAndAchievementIDPlaceHolder, playerProfileID, achievementID).First(&playerAchievement)
+		if result.Error != nil {
+			logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.AwardAchievement] Failed to get awarded achievement")
+			return nil, false, result.Error
+		}
+	}
+
+	return &playerAchievement, newlyUnlocked, nil
+}
+
+// RevokeAchievement implements repository.AchievementRepository.
+func (a *AchivementRepositoryImpl) RevokeAchievement(playerProfileID uint, achievementID uint) error {
+	result := a.Db.Where(PlayerAndAchievementIDPlaceHolder, playerProfileID, achievementID).Delete(&models.PlayerProfileAchievement{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[AchivementRepositoryImpl.RevokeAchievement] Failed to revoke achievement")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return h.ErrorPlayerAchievementNotFound
+	}
+
+	return nil
+}
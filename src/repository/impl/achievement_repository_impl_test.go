@@ -3,6 +3,7 @@ package impl
 import (
 	"testing"
 
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils"
 	"github.com/stretchr/testify/require"
@@ -22,7 +23,6 @@ func TestAchievementRepository_CreateAchievement(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -44,7 +44,7 @@ func TestAchievementRepository_CreateAchievement(t *testing.T) {
 			Points:     50,
 			UserID:     user.ID,
 		}
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievement
@@ -80,7 +80,6 @@ func TestAchievementRepository_CreateAchievement(t *testing.T) {
 		}
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create user
@@ -97,7 +96,7 @@ func TestAchievementRepository_CreateAchievement(t *testing.T) {
 		}
 
 		// Create player profile
-		err = playerProfileRepo.CreatePlayerProfile(testPlayerProfile1)
+		err = db.Create(testPlayerProfile1).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		testAchievement1 := &models.Achievement{
@@ -129,7 +128,6 @@ func TestAchievementRepository_GetAchievement(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -153,7 +151,7 @@ func TestAchievementRepository_GetAchievement(t *testing.T) {
 			UserID:     user.ID,
 		}
 
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievement
@@ -202,7 +200,6 @@ func TwardAchievementRepository_GetAllAchievements(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -226,7 +223,7 @@ func TwardAchievementRepository_GetAllAchievements(t *testing.T) {
 			UserID:     user.ID,
 		}
 
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievements
@@ -247,7 +244,7 @@ func TwardAchievementRepository_GetAllAchievements(t *testing.T) {
 		require.NoError(t, err, "Error creating achievement 2")
 
 		// Get all achievements
-		allAchievements, err := achievementRepo.GetAllAchievements(0, 10)
+		allAchievements, err := achievementRepo.GetAllAchievements(models.Page{Limit: 10}, models.ListQuery{})
 		require.NoError(t, err, "Error getting all achievements")
 		require.Len(t, allAchievements, 2, "Expected 2 achievements")
 		require.Equal(t, achievements1.Name, allAchievements[0].Name, "Achievement 1 names do not match")
@@ -267,7 +264,7 @@ func TwardAchievementRepository_GetAllAchievements(t *testing.T) {
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Get all achievements when there are none
-		allAchievements, err := achievementRepo.GetAllAchievements(0, 10)
+		allAchievements, err := achievementRepo.GetAllAchievements(models.Page{Limit: 10}, models.ListQuery{})
 		require.NoError(t, err, "Error getting all achievements")
 		require.Len(t, allAchievements, 0, "Expected 0 achievements")
 	})
@@ -285,7 +282,6 @@ func TestAchievementRepository_UpdateAchievement(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -309,7 +305,7 @@ func TestAchievementRepository_UpdateAchievement(t *testing.T) {
 			UserID:     user.ID,
 		}
 
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievement
@@ -369,7 +365,6 @@ func TestAchievementRepository_DeleteAchievement(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -393,7 +388,7 @@ func TestAchievementRepository_DeleteAchievement(t *testing.T) {
 			UserID:     user.ID,
 		}
 
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievement
@@ -444,7 +439,6 @@ func TestAchievementRepository_CheckAchievementExists(t *testing.T) {
 		}()
 
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -468,7 +462,7 @@ func TestAchievementRepository_CheckAchievementExists(t *testing.T) {
 			UserID:     user.ID,
 		}
 
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
+		err = db.Create(playerProfile).Error
 		require.NoError(t, err, "Error creating player profile")
 
 		// Create Achievement
@@ -505,9 +499,9 @@ func TestAchievementRepository_CheckAchievementExists(t *testing.T) {
 	})
 }
 
-func TestAchievementRepository_GetAchievementWithPlayers(t *testing.T) {
-	t.Run("GetAchievementWithPlayers_Success", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{})
+func TestAchievementRepository_GetAchievementPlayers(t *testing.T) {
+	t.Run("GetAchievementPlayers_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
 		defer func() {
 			sqlDB, _ := db.DB()
 			err := sqlDB.Close()
@@ -516,7 +510,6 @@ func TestAchievementRepository_GetAchievementWithPlayers(t *testing.T) {
 			}
 		}()
 		userRepo := NewUserRepositoryImpl(db)
-		playerProfileRepo := NewPlayerProfileRepositoryImpl(db)
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Create User
@@ -530,42 +523,49 @@ func TestAchievementRepository_GetAchievementWithPlayers(t *testing.T) {
 		err := userRepo.CreateUser(user)
 		require.NoError(t, err, "Error creating user")
 
-		// Create PlayerProfile
-		playerProfile := &models.PlayerProfile{
-			Nickname:   "testnick",
-			Avatar:     "test.png",
-			Level:      1,
-			Experience: 100,
-			Points:     50,
-			UserID:     user.ID,
-		}
-
-		err = playerProfileRepo.CreatePlayerProfile(playerProfile)
-		require.NoError(t, err, "Error creating player profile")
-
 		// Create Achievement
 		achievement := &models.Achievement{
 			Name:        "Test Achievement",
 			Description: "This is a test achievement",
-			PlayerProfiles: []models.PlayerProfile{
-				*playerProfile,
-			},
 		}
 
 		err = achievementRepo.CreateAchievement(achievement)
 		require.NoError(t, err, "Error creating achievement")
 
-		// Get Achievement with Players
-		achievementWithPlayers, err := achievementRepo.GetAchievementWithPlayers(achievement.ID)
-		require.NoError(t, err, "Error getting achievement with players")
-		require.Equal(t, achievement.Name, achievementWithPlayers.Name, "Achievement names do not match")
-		require.Equal(t, achievement.Description, achievementWithPlayers.Description, "Achievement descriptions do not match")
-		require.Len(t, achievementWithPlayers.PlayerProfiles, 1, "Expected 1 player profile")
-		require.Equal(t, playerProfile.Nickname, achievementWithPlayers.PlayerProfiles[0].Nickname, "Player profile nicknames do not match")
+		// Create PlayerProfiles holding the achievement
+		for _, nickname := range []string{"first", "second", "third"} {
+			playerProfile := &models.PlayerProfile{
+				Nickname:   nickname,
+				Avatar:     "test.png",
+				Level:      1,
+				Experience: 100,
+				Points:     50,
+				UserID:     user.ID,
+			}
+
+			err = db.Create(playerProfile).Error
+			require.NoError(t, err, "Error creating player profile")
+
+			_, _, err = achievementRepo.AwardAchievement(playerProfile.ID, achievement.ID)
+			require.NoError(t, err, "Error awarding achievement")
+		}
+
+		// Get first page of players, oldest unlock first
+		playerAchievements, err := achievementRepo.GetAchievementPlayers(achievement.ID, 0, 2, "asc")
+		require.NoError(t, err, "Error getting achievement players")
+		require.Len(t, playerAchievements, 2, "Expected 2 player profiles")
+		require.Equal(t, "first", playerAchievements[0].PlayerProfile.Nickname, "Player profile nicknames do not match")
+		require.Equal(t, "second", playerAchievements[1].PlayerProfile.Nickname, "Player profile nicknames do not match")
+
+		// Get second page of players
+		playerAchievements, err = achievementRepo.GetAchievementPlayers(achievement.ID, 2, 2, "asc")
+		require.NoError(t, err, "Error getting achievement players")
+		require.Len(t, playerAchievements, 1, "Expected 1 player profile")
+		require.Equal(t, "third", playerAchievements[0].PlayerProfile.Nickname, "Player profile nicknames do not match")
 	})
 
-	t.Run("GetAchievementWithPlayers_NotFound", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{})
+	t.Run("GetAchievementPlayers_NotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
 		defer func() {
 			sqlDB, _ := db.DB()
 			err := sqlDB.Close()
@@ -575,9 +575,9 @@ func TestAchievementRepository_GetAchievementWithPlayers(t *testing.T) {
 		}()
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
-		// Get non-existent achievement with players
-		_, err := achievementRepo.GetAchievementWithPlayers(1)
-		require.Error(t, err, "Expected error getting achievement with players")
+		// Get players of non-existent achievement
+		_, err := achievementRepo.GetAchievementPlayers(1, 0, 10, "desc")
+		require.Error(t, err, "Expected error getting achievement players")
 	})
 }
 
@@ -611,7 +611,7 @@ func TestGetAllAchievements(t *testing.T) {
 		require.NoError(t, err, "Error creating achievement 2")
 
 		// Get all achievements
-		allAchievements, err := achievementRepo.GetAllAchievements(0, 10)
+		allAchievements, err := achievementRepo.GetAllAchievements(models.Page{Limit: 10}, models.ListQuery{})
 		require.NoError(t, err, "Error getting all achievements")
 		require.Len(t, allAchievements, 2, "Expected 2 achievements")
 		require.Equal(t, achievements1.Name, allAchievements[0].Name, "Achievement 1 names do not match")
@@ -631,9 +631,186 @@ func TestGetAllAchievements(t *testing.T) {
 		achievementRepo := NewAchievementRepositoryImpl(db)
 
 		// Get all achievements when there are none
-		allAchievements, err := achievementRepo.GetAllAchievements(0, 10)
+		allAchievements, err := achievementRepo.GetAllAchievements(models.Page{Limit: 10}, models.ListQuery{})
 		require.NoError(t, err, "Error getting all achievements")
 		require.Len(t, allAchievements, 0, "Expected 0 achievements")
 	})
 
 }
+
+func TestAchievementRepository_AwardAchievement(t *testing.T) {
+	t.Run("AwardAchievement_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		userRepo := NewUserRepositoryImpl(db)
+		achievementRepo := NewAchievementRepositoryImpl(db)
+
+		user := &models.User{
+			UserName: "testuser",
+			PassWord: "testpass",
+			Email:    "test@test.com",
+			Age:      25,
+		}
+		err := userRepo.CreateUser(user)
+		require.NoError(t, err, "Error creating user")
+
+		playerProfile := &models.PlayerProfile{
+			Nickname:   "testnick",
+			Avatar:     "test.png",
+			Level:      1,
+			Experience: 100,
+			Points:     50,
+			UserID:     user.ID,
+		}
+		err = db.Create(playerProfile).Error
+		require.NoError(t, err, "Error creating player profile")
+
+		achievement := &models.Achievement{
+			Name:        "Test Achievement",
+			Description: "This is a test achievement",
+		}
+		err = achievementRepo.CreateAchievement(achievement)
+		require.NoError(t, err, "Error creating achievement")
+
+		// Award the achievement
+		awarded, newlyUnlocked, err := achievementRepo.AwardAchievement(playerProfile.ID, achievement.ID)
+		require.NoError(t, err, "Error awarding achievement")
+		require.True(t, newlyUnlocked, "Expected achievement to be newly unlocked")
+		require.False(t, awarded.UnlockedAt.IsZero(), "Expected unlock date to be set")
+
+		// Award it again, the original unlock date must be kept
+		awardedAgain, newlyUnlocked, err := achievementRepo.AwardAchievement(playerProfile.ID, achievement.ID)
+		require.NoError(t, err, "Error awarding achievement twice")
+		require.False(t, newlyUnlocked, "Expected achievement to not be newly unlocked")
+		require.True(t, awarded.UnlockedAt.Equal(awardedAgain.UnlockedAt), "Expected unlock date to be kept")
+
+		var count int64
+		db.Model(&models.PlayerProfileAchievement{}).Count(&count)
+		require.Equal(t, int64(1), count, "Expected a single join row")
+
+		// The achievement is visible through the many2many relation
+		var playerWithAchievements models.PlayerProfile
+		err = db.Preload("Achievements").First(&playerWithAchievements, playerProfile.ID).Error
+		require.NoError(t, err, "Error getting player with achievements")
+		require.Len(t, playerWithAchievements.Achievements, 1, "Expected 1 achievement")
+	})
+
+	t.Run("AwardAchievement_PlayerNotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		achievementRepo := NewAchievementRepositoryImpl(db)
+
+		achievement := &models.Achievement{
+			Name:        "Test Achievement",
+			Description: "This is a test achievement",
+		}
+		err := achievementRepo.CreateAchievement(achievement)
+		require.NoError(t, err, "Error creating achievement")
+
+		_, _, err = achievementRepo.AwardAchievement(99, achievement.ID)
+		require.ErrorIs(t, err, helpers.ErrorPlayerProfileNotFound, "Expected player not found error")
+	})
+
+	t.Run("AwardAchievement_AchievementNotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		userRepo := NewUserRepositoryImpl(db)
+		achievementRepo := NewAchievementRepositoryImpl(db)
+
+		user := &models.User{
+			UserName: "testuser",
+			PassWord: "testpass",
+			Email:    "test@test.com",
+			Age:      25,
+		}
+		err := userRepo.CreateUser(user)
+		require.NoError(t, err, "Error creating user")
+
+		playerProfile := &models.PlayerProfile{
+			Nickname:   "testnick",
+			Avatar:     "test.png",
+			Level:      1,
+			Experience: 100,
+			Points:     50,
+			UserID:     user.ID,
+		}
+		err = db.Create(playerProfile).Error
+		require.NoError(t, err, "Error creating player profile")
+
+		_, _, err = achievementRepo.AwardAchievement(playerProfile.ID, 99)
+		require.ErrorIs(t, err, helpers.ErrorAchievementNotFound, "Expected achievement not found error")
+	})
+}
+
+func TestAchievementRepository_RevokeAchievement(t *testing.T) {
+	t.Run("RevokeAchievement_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		userRepo := NewUserRepositoryImpl(db)
+		achievementRepo := NewAchievementRepositoryImpl(db)
+
+		user := &models.User{
+			UserName: "testuser",
+			PassWord: "testpass",
+			Email:    "test@test.com",
+			Age:      25,
+		}
+		err := userRepo.CreateUser(user)
+		require.NoError(t, err, "Error creating user")
+
+		playerProfile := &models.PlayerProfile{
+			Nickname:   "testnick",
+			Avatar:     "test.png",
+			Level:      1,
+			Experience: 100,
+			Points:     50,
+			UserID:     user.ID,
+		}
+		err = db.Create(playerProfile).Error
+		require.NoError(t, err, "Error creating player profile")
+
+		achievement := &models.Achievement{
+			Name:        "Test Achievement",
+			Description: "This is a test achievement",
+		}
+		err = achievementRepo.CreateAchievement(achievement)
+		require.NoError(t, err, "Error creating achievement")
+
+		_, _, err = achievementRepo.AwardAchievement(playerProfile.ID, achievement.ID)
+		require.NoError(t, err, "Error awarding achievement")
+
+		err = achievementRepo.RevokeAchievement(playerProfile.ID, achievement.ID)
+		require.NoError(t, err, "Error revoking achievement")
+
+		err = achievementRepo.RevokeAchievement(playerProfile.ID, achievement.ID)
+		require.ErrorIs(t, err, helpers.ErrorPlayerAchievementNotFound, "Expected error revoking an achievement the player does not have")
+	})
+}
@@ -0,0 +1,104 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateAPIKey implements repository.APIKeyRepository.
+func (ak *APIKeyRepositoryImpl) CreateAPIKey(apiKey *models.APIKey) error {
+	result := ak.Db.Create(apiKey)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.CreateAPIKey] Failed to create api key")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetAPIKey implements repository.APIKeyRepository.
+func (ak *APIKeyRepositoryImpl) GetAPIKey(apiKeyID uint) (*models.APIKey, error) {
+	var apiKey models.APIKey
+
+	result := ak.Db.Where(IDPlaceHolder, apiKeyID).First(&apiKey)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorAPIKeyNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.GetAPIKey] Failed to get api key")
+		return nil, result.Error
+	}
+
+	return &apiKey, nil
+}
+
+// GetAllAPIKeys implements repository.APIKeyRepository.
+func (ak *APIKeyRepositoryImpl) GetAllAPIKeys() ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+
+	result := ak.Db.Order("id").Find(&apiKeys)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.GetAllAPIKeys] Failed to get api keys")
+		return nil, result.Error
+	}
+
+	return apiKeys, nil
+}
+
+// FindByPrefix implements repository.APIKeyRepository.
+func (ak *APIKeyRepositoryImpl) FindByPrefix(prefix string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+
+	result := ak.Db.Where(PrefixPlaceHolder, prefix).First(&apiKey)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorAPIKeyNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.FindByPrefix] Failed to find api key")
+		return nil, result.Error
+	}
+
+	return &apiKey, nil
+}
+
+// RevokeAPIKey implements repository.APIKeyRepository.
+// The key is kept for the audit trail, revoking it again keeps the first date.
+func (ak *APIKeyRepositoryImpl) RevokeAPIKey(apiKeyID uint) error {
+	result := ak.Db.Model(&models.APIKey{}).
+		Where(IDPlaceHolder, apiKeyID).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.RevokeAPIKey] Failed to revoke api key")
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateLastUsed implements repository.APIKeyRepository.
+func (ak *APIKeyRepositoryImpl) UpdateLastUsed(apiKeyID uint, lastUsedAt time.Time) error {
+	result := ak.Db.Model(&models.APIKey{}).Where(IDPlaceHolder, apiKeyID).Update("last_used_at", lastUsedAt)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[APIKeyRepositoryImpl.UpdateLastUsed] Failed to update api key last use")
+		return result.Error
+	}
+
+	return nil
+}
+
+func NewAPIKeyRepositoryImpl(db *gorm.DB) r.APIKeyRepository {
+	return &APIKeyRepositoryImpl{Db: db}
+}
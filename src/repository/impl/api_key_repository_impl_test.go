@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepositoryImpl_FindByPrefix(t *testing.T) {
+	db := testutils.SetupTestDB(&models.APIKey{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewAPIKeyRepositoryImpl(db)
+
+	require.NoError(t, repo.CreateAPIKey(&models.APIKey{Name: "eu-west", Prefix: "abc123", SecretHash: "hash", Scopes: models.PermissionAchievementsAward, CreatedBy: 1}), "Error creating api key")
+
+	found, err := repo.FindByPrefix("abc123")
+	require.NoError(t, err, "Error finding api key")
+	require.Equal(t, "eu-west", found.Name)
+
+	_, err = repo.FindByPrefix("def456")
+	require.Equal(t, helpers.ErrorAPIKeyNotFound, err, "Expected api key not found error")
+
+	// Prefixes identify the keys, they can't repeat
+	require.Error(t, repo.CreateAPIKey(&models.APIKey{Name: "us-east", Prefix: "abc123", SecretHash: "hash", Scopes: models.PermissionAchievementsAward, CreatedBy: 1}), "Expected duplicated prefix error")
+}
+
+func TestAPIKeyRepositoryImpl_RevokeAndLastUsed(t *testing.T) {
+	db := testutils.SetupTestDB(&models.APIKey{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewAPIKeyRepositoryImpl(db)
+
+	apiKey := &models.APIKey{Name: "eu-west", Prefix: "abc123", SecretHash: "hash", Scopes: models.PermissionAchievementsAward, CreatedBy: 1}
+	require.NoError(t, repo.CreateAPIKey(apiKey), "Error creating api key")
+	require.NoError(t, repo.CreateAPIKey(&models.APIKey{Name: "us-east", Prefix: "def456", SecretHash: "hash", Scopes: models.PermissionPlayersModerate, CreatedBy: 1}), "Error creating api key")
+
+	lastUsedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.UpdateLastUsed(apiKey.ID, lastUsedAt), "Error updating last use")
+
+	require.NoError(t, repo.RevokeAPIKey(apiKey.ID), "Error revoking api key")
+	revoked, err := repo.GetAPIKey(apiKey.ID)
+	require.NoError(t, err, "Error getting api key")
+	require.NotNil(t, revoked.RevokedAt)
+	require.True(t, revoked.LastUsedAt.Equal(lastUsedAt))
+
+	// Revoking it again keeps the original date
+	revokedAt := *revoked.RevokedAt
+	require.NoError(t, repo.RevokeAPIKey(apiKey.ID), "Error revoking api key again")
+	revoked, err = repo.GetAPIKey(apiKey.ID)
+	require.NoError(t, err, "Error getting api key")
+	require.True(t, revoked.RevokedAt.Equal(revokedAt))
+
+	apiKeys, err := repo.GetAllAPIKeys()
+	require.NoError(t, err, "Error getting api keys")
+	require.Len(t, apiKeys, 2)
+	require.Equal(t, "eu-west", apiKeys[0].Name)
+
+	_, err = repo.GetAPIKey(99)
+	require.Equal(t, helpers.ErrorAPIKeyNotFound, err, "Expected api key not found error")
+}
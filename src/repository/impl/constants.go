@@ -5,3 +5,41 @@ const EmailPlaceHolder = "email = ?"
 const AchievementIDPlaceHolder = "achievement_id = ?"
 const PlayerProfileIDPlaceHolder = "player_profile_id = ?"
 const PlayerAndAchievementIDPlaceHolder = "player_profile_id = ? AND achievement_id = ?"
+const UnlockedAtAscOrder = "player_profile_achievements.unlocked_at ASC"
+const UnlockedAtDescOrder = "player_profile_achievements.unlocked_at DESC"
+const TokenHashPlaceHolder = "token_hash = ?"
+const FamilyIDPlaceHolder = "family_id = ?"
+const UserIDPlaceHolder = "user_id = ?"
+const ExpiresAtAfterPlaceHolder = "expires_at > ?"
+const ExpiresAtBeforePlaceHolder = "expires_at <= ?"
+const PurposeAndTokenHashPlaceHolder = "purpose = ? AND token_hash = ?"
+const UserIDAndPurposePlaceHolder = "user_id = ? AND purpose = ?"
+const CodeHashPlaceHolder = "code_hash = ?"
+const AttemptKeyPlaceHolder = "attempt_key = ?"
+const ProviderAndSubjectPlaceHolder = "provider = ? AND subject = ?"
+const UserIDAndProviderPlaceHolder = "user_id = ? AND provider = ?"
+const StateHashPlaceHolder = "state_hash = ?"
+const PrefixPlaceHolder = "prefix = ?"
+const RolePlaceHolder = "role = ?"
+const NamePlaceHolder = "name = ?"
+const RoleIDPlaceHolder = "role_id = ?"
+const LastSeenAtAfterPlaceHolder = "last_seen_at > ?"
+const EndedAtAfterPlaceHolder = "ended_at > ?"
+const ExperienceAndLevelPlaceHolder = "experience = ? AND level = ?"
+const SeasonIDPlaceHolder = "season_id = ?"
+const MatchesPlayedPlaceHolder = "matches_played = ?"
+const SenderIDPlaceHolder = "sender_id = ?"
+const ReceiverIDPlaceHolder = "receiver_id = ?"
+const PlayerAndFriendIDPlaceHolder = "player_profile_id = ? AND friend_id = ?"
+const BlockerAndBlockedIDPlaceHolder = "blocker_id = ? AND blocked_id = ?"
+const BlockerIDPlaceHolder = "blocker_id = ?"
+const SenderAndReceiverEitherWayPlaceHolder = "(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)"
+const PlayerAndFriendEitherWayPlaceHolder = "(player_profile_id = ? AND friend_id = ?) OR (player_profile_id = ? AND friend_id = ?)"
+const SenderOrReceiverIDPlaceHolder = "sender_id = ? OR receiver_id = ?"
+const PlayerOrFriendIDPlaceHolder = "player_profile_id = ? OR friend_id = ?"
+const BlockerOrBlockedIDPlaceHolder = "blocker_id = ? OR blocked_id = ?"
+const BlockerAndBlockedEitherWayPlaceHolder = "(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)"
+
+// SeasonPointsChangeExpression adds the change of the points to the season
+// points, it reads the points before the update.
+const SeasonPointsChangeExpression = "CASE WHEN season_points + ? - points > 0 THEN season_points + ? - points ELSE 0 END"
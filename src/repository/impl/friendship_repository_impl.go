@@ -0,0 +1,383 @@
+package impl
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FriendshipRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateFriendRequest implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) CreateFriendRequest(friendRequest *models.FriendRequest) error {
+	sender, receiver := friendRequest.SenderID, friendRequest.ReceiverID
+
+	err := f.Db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		result := tx.Model(&models.PlayerProfile{}).Where("id IN ?", []uint{sender, receiver}).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if count != 2 {
+			return helpers.ErrorPlayerProfileNotFound
+		}
+
+		result = tx.Model(&models.PlayerBlock{}).Where(BlockerAndBlockedEitherWayPlaceHolder, sender, receiver, receiver, sender).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if count > 0 {
+			return helpers.ErrorPlayerBlocked
+		}
+
+		result = tx.Model(&models.Friendship{}).Where(PlayerAndFriendIDPlaceHolder, sender, receiver).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if count > 0 {
+			return helpers.ErrorAlreadyFriends
+		}
+
+		result = tx.Model(&models.FriendRequest{}).Where(SenderAndReceiverEitherWayPlaceHolder, sender, receiver, receiver, sender).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if count > 0 {
+			return helpers.ErrorFriendRequestPending
+		}
+
+		// The same request sent twice at the same time
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(friendRequest)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return helpers.ErrorFriendRequestPending
+		}
+
+		return nil
+	})
+	if err != nil && !isFriendRequestRejection(err) {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.CreateFriendRequest] Failed to create friend request")
+	}
+
+	return err
+}
+
+// GetFriendRequest implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) GetFriendRequest(friendRequestID uint) (*models.FriendRequest, error) {
+	var friendRequest models.FriendRequest
+
+	result := f.Db.Preload("Sender").Preload("Receiver").First(&friendRequest, friendRequestID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorFriendRequestNotFound
+		}
+
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.GetFriendRequest] Failed to get friend request")
+		return nil, result.Error
+	}
+
+	return &friendRequest, nil
+}
+
+// GetFriendRequests implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) GetFriendRequests(playerProfileID uint, incoming bool, page models.Page) ([]models.FriendRequest, error) {
+	var friendRequests []models.FriendRequest
+
+	scope, err := listQueryScope(models.ListQuery{}, page, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := f.friendRequests(playerProfileID, incoming).Preload("Sender").Preload("Receiver").Scopes(scope).Find(&friendRequests)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.GetFriendRequests] Failed to get friend requests")
+		return nil, result.Error
+	}
+
+	if page.BeforeID != 0 {
+		slices.Reverse(friendRequests)
+	}
+
+	return friendRequests, nil
+}
+
+// CountFriendRequests implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) CountFriendRequests(playerProfileID uint, incoming bool) (int64, error) {
+	var count int64
+
+	result := f.friendRequests(playerProfileID, incoming).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.CountFriendRequests] Failed to count friend requests")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// AcceptFriendRequest implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) AcceptFriendRequest(friendRequest *models.FriendRequest) error {
+	sender, receiver := friendRequest.SenderID, friendRequest.ReceiverID
+
+	err := f.Db.Transaction(func(tx *gorm.DB) error {
+		// Deleting the request first makes concurrent accepts of the same request fail
+		result := tx.Delete(&models.FriendRequest{}, friendRequest.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return helpers.ErrorFriendRequestNotFound
+		}
+
+		// Both players may have sent a request at the same time
+		result = tx.Where(SenderAndReceiverEitherWayPlaceHolder, sender, receiver, receiver, sender).Delete(&models.FriendRequest{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now()
+		friendships := []models.Friendship{
+			{PlayerProfileID: sender, FriendID: receiver, CreatedAt: now},
+			{PlayerProfileID: receiver, FriendID: sender, CreatedAt: now},
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&friendships).Error
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorFriendRequestNotFound) {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.AcceptFriendRequest] Failed to accept friend request")
+	}
+
+	return err
+}
+
+// DeleteFriendRequest implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) DeleteFriendRequest(friendRequestID uint) error {
+	result := f.Db.Delete(&models.FriendRequest{}, friendRequestID)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.DeleteFriendRequest] Failed to delete friend request")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorFriendRequestNotFound
+	}
+
+	return nil
+}
+
+// DeleteFriendship implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) DeleteFriendship(playerProfileID uint, friendID uint) error {
+	result := f.Db.Where(PlayerAndFriendEitherWayPlaceHolder, playerProfileID, friendID, friendID, playerProfileID).Delete(&models.Friendship{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.DeleteFriendship] Failed to delete friendship")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorFriendshipNotFound
+	}
+
+	return nil
+}
+
+// GetFriends implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) GetFriends(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	friends, err := f.getPlayerPage(page, f.friendIDs(playerProfileID))
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.GetFriends] Failed to get friends")
+		return nil, err
+	}
+
+	return friends, nil
+}
+
+// CountFriends implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) CountFriends(playerProfileID uint) (int64, error) {
+	var count int64
+
+	result := f.Db.Model(&models.PlayerProfile{}).Where("id IN (?)", f.friendIDs(playerProfileID)).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.CountFriends] Failed to count friends")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetMutualFriends implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	friends, err := f.getPlayerPage(page, f.friendIDs(playerProfileID), f.friendIDs(otherPlayerProfileID))
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.GetMutualFriends] Failed to get mutual friends")
+		return nil, err
+	}
+
+	return friends, nil
+}
+
+// CountMutualFriends implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) CountMutualFriends(playerProfileID uint, otherPlayerProfileID uint) (int64, error) {
+	var count int64
+
+	result := f.Db.Model(&models.PlayerProfile{}).
+		Where("id IN (?)", f.friendIDs(playerProfileID)).
+		Where("id IN (?)", f.friendIDs(otherPlayerProfileID)).
+		Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.CountMutualFriends] Failed to count mutual friends")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// BlockPlayer implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) BlockPlayer(block *models.PlayerBlock) error {
+	blocker, blocked := block.BlockerID, block.BlockedID
+
+	err := f.Db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		result := tx.Model(&models.PlayerProfile{}).Where(IDPlaceHolder, blocked).Count(&count)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if count == 0 {
+			return helpers.ErrorPlayerProfileNotFound
+		}
+
+		// Blocking twice keeps the first block
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(block)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where(PlayerAndFriendEitherWayPlaceHolder, blocker, blocked, blocked, blocker).Delete(&models.Friendship{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Where(SenderAndReceiverEitherWayPlaceHolder, blocker, blocked, blocked, blocker).Delete(&models.FriendRequest{}).Error
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.BlockPlayer] Failed to block player")
+	}
+
+	return err
+}
+
+// UnblockPlayer implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) UnblockPlayer(blockerID uint, blockedID uint) error {
+	result := f.Db.Where(BlockerAndBlockedIDPlaceHolder, blockerID, blockedID).Delete(&models.PlayerBlock{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.UnblockPlayer] Failed to unblock player")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorPlayerBlockNotFound
+	}
+
+	return nil
+}
+
+// GetBlockedPlayers implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) GetBlockedPlayers(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	players, err := f.getPlayerPage(page, f.blockedIDs(playerProfileID))
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipRepositoryImpl.GetBlockedPlayers] Failed to get blocked players")
+		return nil, err
+	}
+
+	return players, nil
+}
+
+// CountBlockedPlayers implements repository.FriendshipRepository.
+func (f *FriendshipRepositoryImpl) CountBlockedPlayers(playerProfileID uint) (int64, error) {
+	var count int64
+
+	result := f.Db.Model(&models.PlayerProfile{}).Where("id IN (?)", f.blockedIDs(playerProfileID)).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[FriendshipRepositoryImpl.CountBlockedPlayers] Failed to count blocked players")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// friendIDs is the subquery of the ids of the friends of the player.
+func (f *FriendshipRepositoryImpl) friendIDs(playerProfileID uint) *gorm.DB {
+	return f.Db.Model(&models.Friendship{}).Select("friend_id").Where(PlayerProfileIDPlaceHolder, playerProfileID)
+}
+
+// blockedIDs is the subquery of the ids of the players blocked by the player.
+func (f *FriendshipRepositoryImpl) blockedIDs(playerProfileID uint) *gorm.DB {
+	return f.Db.Model(&models.PlayerBlock{}).Select("blocked_id").Where(BlockerIDPlaceHolder, playerProfileID)
+}
+
+// friendRequests selects the requests received or sent by the player.
+func (f *FriendshipRepositoryImpl) friendRequests(playerProfileID uint, incoming bool) *gorm.DB {
+	condition := SenderIDPlaceHolder
+	if incoming {
+		condition = ReceiverIDPlaceHolder
+	}
+
+	return f.Db.Model(&models.FriendRequest{}).Where(condition, playerProfileID)
+}
+
+// getPlayerPage reads a page of the players whose id is in every subquery.
+func (f *FriendshipRepositoryImpl) getPlayerPage(page models.Page, idSubqueries ...*gorm.DB) ([]models.PlayerProfile, error) {
+	var players []models.PlayerProfile
+
+	scope, err := listQueryScope(models.ListQuery{}, page, playerProfileListFields)
+	if err != nil {
+		return nil, err
+	}
+
+	db := f.Db.Model(&models.PlayerProfile{})
+	for _, ids := range idSubqueries {
+		db = db.Where("id IN (?)", ids)
+	}
+
+	result := db.Scopes(scope).Find(&players)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if page.BeforeID != 0 {
+		slices.Reverse(players)
+	}
+
+	return players, nil
+}
+
+// isFriendRequestRejection reports whether the friend request was refused
+// because of the players, not because of the database.
+func isFriendRequestRejection(err error) bool {
+	return errors.Is(err, helpers.ErrorPlayerProfileNotFound) ||
+		errors.Is(err, helpers.ErrorPlayerBlocked) ||
+		errors.Is(err, helpers.ErrorAlreadyFriends) ||
+		errors.Is(err, helpers.ErrorFriendRequestPending)
+}
+
+func NewFriendshipRepositoryImpl(db *gorm.DB) r.FriendshipRepository {
+	return &FriendshipRepositoryImpl{Db: db}
+}
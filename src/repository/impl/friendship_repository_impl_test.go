@@ -0,0 +1,212 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupFriendshipDB(t *testing.T) (*gorm.DB, []models.PlayerProfile) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.FriendRequest{}, &models.Friendship{}, &models.PlayerBlock{})
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	})
+
+	return db, seedLeaderboard(t, db, 10, 20, 30, 40)
+}
+
+func befriend(t *testing.T, repo *FriendshipRepositoryImpl, sender uint, receiver uint) {
+	friendRequest := &models.FriendRequest{SenderID: sender, ReceiverID: receiver}
+	require.NoError(t, repo.CreateFriendRequest(friendRequest), "Error creating friend request")
+	require.NoError(t, repo.AcceptFriendRequest(friendRequest), "Error accepting friend request")
+}
+
+func TestFriendshipRepositoryImpl_CreateFriendRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		db, players := setupFriendshipDB(t)
+		repo := &FriendshipRepositoryImpl{Db: db}
+
+		friendRequest := &models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[1].ID}
+		require.NoError(t, repo.CreateFriendRequest(friendRequest), "Error creating friend request")
+		require.NotZero(t, friendRequest.ID)
+
+		stored, err := repo.GetFriendRequest(friendRequest.ID)
+		require.NoError(t, err, "Error getting friend request")
+		require.Equal(t, players[0].Nickname, stored.Sender.Nickname)
+		require.Equal(t, players[1].Nickname, stored.Receiver.Nickname)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		db, players := setupFriendshipDB(t)
+		repo := &FriendshipRepositoryImpl{Db: db}
+
+		require.NoError(t, repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[1].ID}))
+		befriend(t, repo, players[0].ID, players[2].ID)
+		require.NoError(t, repo.BlockPlayer(&models.PlayerBlock{BlockerID: players[3].ID, BlockedID: players[0].ID}))
+
+		cases := map[string]struct {
+			receiver uint
+			err      error
+		}{
+			"Pending":        {receiver: players[1].ID, err: helpers.ErrorFriendRequestPending},
+			"AlreadyFriends": {receiver: players[2].ID, err: helpers.ErrorAlreadyFriends},
+			"Blocked":        {receiver: players[3].ID, err: helpers.ErrorPlayerBlocked},
+			"MissingPlayer":  {receiver: 999, err: helpers.ErrorPlayerProfileNotFound},
+		}
+
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				err := repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[0].ID, ReceiverID: c.receiver})
+				require.ErrorIs(t, err, c.err)
+			})
+		}
+
+		// The other player can't send one back either
+		err := repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[1].ID, ReceiverID: players[0].ID})
+		require.ErrorIs(t, err, helpers.ErrorFriendRequestPending)
+
+		err = repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[3].ID})
+		require.ErrorIs(t, err, helpers.ErrorPlayerBlocked)
+	})
+}
+
+func TestFriendshipRepositoryImpl_AcceptFriendRequest(t *testing.T) {
+	db, players := setupFriendshipDB(t)
+	repo := &FriendshipRepositoryImpl{Db: db}
+
+	friendRequest := &models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[1].ID}
+	require.NoError(t, repo.CreateFriendRequest(friendRequest))
+	// A crossed request sent at the same time
+	require.NoError(t, db.Create(&models.FriendRequest{SenderID: players[1].ID, ReceiverID: players[0].ID}).Error)
+
+	require.NoError(t, repo.AcceptFriendRequest(friendRequest), "Error accepting friend request")
+
+	var requests int64
+	require.NoError(t, db.Model(&models.FriendRequest{}).Count(&requests).Error)
+	require.Zero(t, requests, "Pending requests should be deleted")
+
+	for _, pair := range [][2]uint{{players[0].ID, players[1].ID}, {players[1].ID, players[0].ID}} {
+		friends, err := repo.GetFriends(pair[0], models.Page{Limit: 10})
+		require.NoError(t, err, "Error getting friends")
+		require.Len(t, friends, 1)
+		require.Equal(t, pair[1], friends[0].ID)
+	}
+
+	err := repo.AcceptFriendRequest(friendRequest)
+	require.ErrorIs(t, err, helpers.ErrorFriendRequestNotFound)
+}
+
+func TestFriendshipRepositoryImpl_GetFriendRequests(t *testing.T) {
+	db, players := setupFriendshipDB(t)
+	repo := &FriendshipRepositoryImpl{Db: db}
+
+	require.NoError(t, repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[1].ID, ReceiverID: players[0].ID}))
+	require.NoError(t, repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[2].ID, ReceiverID: players[0].ID}))
+	require.NoError(t, repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[3].ID}))
+
+	incoming, err := repo.GetFriendRequests(players[0].ID, true, models.Page{Limit: 10})
+	require.NoError(t, err, "Error getting friend requests")
+	require.Len(t, incoming, 2)
+	require.Equal(t, players[1].ID, incoming[0].SenderID, "Sorted by id")
+	require.Equal(t, players[1].Nickname, incoming[0].Sender.Nickname)
+
+	previous, err := repo.GetFriendRequests(players[0].ID, true, models.Page{Limit: 10, BeforeID: incoming[1].ID})
+	require.NoError(t, err, "Error getting friend requests")
+	require.Len(t, previous, 1)
+	require.Equal(t, incoming[0].ID, previous[0].ID)
+
+	outgoing, err := repo.GetFriendRequests(players[0].ID, false, models.Page{Limit: 10})
+	require.NoError(t, err, "Error getting friend requests")
+	require.Len(t, outgoing, 1)
+	require.Equal(t, players[3].ID, outgoing[0].ReceiverID)
+
+	count, err := repo.CountFriendRequests(players[0].ID, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestFriendshipRepositoryImpl_DeleteFriendship(t *testing.T) {
+	db, players := setupFriendshipDB(t)
+	repo := &FriendshipRepositoryImpl{Db: db}
+
+	befriend(t, repo, players[0].ID, players[1].ID)
+
+	require.NoError(t, repo.DeleteFriendship(players[1].ID, players[0].ID), "Error deleting friendship")
+
+	count, err := repo.CountFriends(players[0].ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	err = repo.DeleteFriendship(players[0].ID, players[1].ID)
+	require.ErrorIs(t, err, helpers.ErrorFriendshipNotFound)
+}
+
+func TestFriendshipRepositoryImpl_GetMutualFriends(t *testing.T) {
+	db, players := setupFriendshipDB(t)
+	repo := &FriendshipRepositoryImpl{Db: db}
+
+	befriend(t, repo, players[0].ID, players[2].ID)
+	befriend(t, repo, players[0].ID, players[3].ID)
+	befriend(t, repo, players[1].ID, players[2].ID)
+	befriend(t, repo, players[1].ID, players[3].ID)
+	befriend(t, repo, players[0].ID, players[1].ID)
+
+	mutual, err := repo.GetMutualFriends(players[0].ID, players[1].ID, models.Page{Limit: 10})
+	require.NoError(t, err, "Error getting mutual friends")
+	require.Len(t, mutual, 2)
+	require.Equal(t, players[2].ID, mutual[0].ID)
+	require.Equal(t, players[3].ID, mutual[1].ID)
+
+	mutual, err = repo.GetMutualFriends(players[0].ID, players[1].ID, models.Page{Limit: 10, BeforeID: players[3].ID})
+	require.NoError(t, err, "Error getting mutual friends")
+	require.Len(t, mutual, 1)
+	require.Equal(t, players[2].ID, mutual[0].ID)
+
+	count, err := repo.CountMutualFriends(players[0].ID, players[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestFriendshipRepositoryImpl_BlockPlayer(t *testing.T) {
+	db, players := setupFriendshipDB(t)
+	repo := &FriendshipRepositoryImpl{Db: db}
+
+	befriend(t, repo, players[0].ID, players[1].ID)
+	require.NoError(t, repo.CreateFriendRequest(&models.FriendRequest{SenderID: players[2].ID, ReceiverID: players[0].ID}))
+
+	require.NoError(t, repo.BlockPlayer(&models.PlayerBlock{BlockerID: players[0].ID, BlockedID: players[1].ID}), "Error blocking player")
+	require.NoError(t, repo.BlockPlayer(&models.PlayerBlock{BlockerID: players[0].ID, BlockedID: players[2].ID}), "Error blocking player")
+	require.NoError(t, repo.BlockPlayer(&models.PlayerBlock{BlockerID: players[0].ID, BlockedID: players[2].ID}), "Blocking twice should be allowed")
+
+	count, err := repo.CountFriends(players[1].ID)
+	require.NoError(t, err)
+	require.Zero(t, count, "Blocking should end the friendship")
+
+	var requests int64
+	require.NoError(t, db.Model(&models.FriendRequest{}).Count(&requests).Error)
+	require.Zero(t, requests, "Blocking should delete the pending requests")
+
+	blocked, err := repo.GetBlockedPlayers(players[0].ID, models.Page{Limit: 10, AfterID: players[1].ID})
+	require.NoError(t, err, "Error getting blocked players")
+	require.Len(t, blocked, 1)
+	require.Equal(t, players[2].ID, blocked[0].ID)
+
+	count, err = repo.CountBlockedPlayers(players[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	err = repo.BlockPlayer(&models.PlayerBlock{BlockerID: players[0].ID, BlockedID: 999})
+	require.ErrorIs(t, err, helpers.ErrorPlayerProfileNotFound)
+
+	require.NoError(t, repo.UnblockPlayer(players[0].ID, players[1].ID), "Error unblocking player")
+	err = repo.UnblockPlayer(players[0].ID, players[1].ID)
+	require.ErrorIs(t, err, helpers.ErrorPlayerBlockNotFound)
+}
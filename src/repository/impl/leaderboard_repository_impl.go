@@ -0,0 +1,80 @@
+package impl
+
+import (
+	"fmt"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LeaderboardRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// GetRankedPlayers implements repository.LeaderboardRepository.
+func (l *LeaderboardRepositoryImpl) GetRankedPlayers(metric string, offset int, limit int) ([]models.PlayerProfile, error) {
+	// The metric is used as a column name, never trust it
+	if !models.IsLeaderboardMetric(metric) {
+		return nil, helpers.ErrInvalidLeaderboardMetric
+	}
+
+	var players []models.PlayerProfile
+
+	result := l.Db.Order(metric + " DESC").Order("id").Offset(offset).Limit(limit).Find(&players)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LeaderboardRepositoryImpl.GetRankedPlayers] Failed to get ranked players")
+		return nil, result.Error
+	}
+
+	return players, nil
+}
+
+// CountPlayersAbove implements repository.LeaderboardRepository.
+func (l *LeaderboardRepositoryImpl) CountPlayersAbove(metric string, value int, distinct bool) (int64, error) {
+	if !models.IsLeaderboardMetric(metric) {
+		return 0, helpers.ErrInvalidLeaderboardMetric
+	}
+
+	var count int64
+
+	query := l.Db.Model(&models.PlayerProfile{}).Where(fmt.Sprintf("%s > ?", metric), value)
+	if distinct {
+		query = query.Distinct(metric)
+	}
+
+	result := query.Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LeaderboardRepositoryImpl.CountPlayersAbove] Failed to count players")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// GetPlayerPosition implements repository.LeaderboardRepository.
+func (l *LeaderboardRepositoryImpl) GetPlayerPosition(metric string, playerProfile *models.PlayerProfile) (int64, error) {
+	if !models.IsLeaderboardMetric(metric) {
+		return 0, helpers.ErrInvalidLeaderboardMetric
+	}
+
+	var position int64
+
+	value := playerProfile.LeaderboardValue(metric)
+
+	result := l.Db.Model(&models.PlayerProfile{}).
+		Where(fmt.Sprintf("%[1]s > ? OR (%[1]s = ? AND id < ?)", metric), value, value, playerProfile.ID).
+		Count(&position)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LeaderboardRepositoryImpl.GetPlayerPosition] Failed to get player position")
+		return 0, result.Error
+	}
+
+	return position, nil
+}
+
+func NewLeaderboardRepositoryImpl(db *gorm.DB) r.LeaderboardRepository {
+	return &LeaderboardRepositoryImpl{Db: db}
+}
@@ -0,0 +1,96 @@
+package impl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedLeaderboard creates players with the given points, in order.
+func seedLeaderboard(t *testing.T, db *gorm.DB, points ...int) []models.PlayerProfile {
+	players := make([]models.PlayerProfile, len(points))
+	for i, p := range points {
+		players[i] = models.PlayerProfile{Nickname: fmt.Sprintf("player%d", i), Avatar: "avatar.png", Level: 1, Points: p, UserID: 1}
+	}
+
+	require.NoError(t, db.Create(&players).Error, "Error creating players")
+	return players
+}
+
+func TestLeaderboardRepositoryImpl_GetRankedPlayers(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewLeaderboardRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 50, 300, 100, 300)
+
+	ranked, err := repo.GetRankedPlayers(models.LeaderboardMetricPoints, 0, 3)
+	require.NoError(t, err, "Error getting ranked players")
+	require.Len(t, ranked, 3)
+	require.Equal(t, players[1].ID, ranked[0].ID, "Expected ties ordered by id")
+	require.Equal(t, players[3].ID, ranked[1].ID, "Expected ties ordered by id")
+	require.Equal(t, players[2].ID, ranked[2].ID)
+
+	page, err := repo.GetRankedPlayers(models.LeaderboardMetricPoints, 3, 3)
+	require.NoError(t, err, "Error getting ranked players")
+	require.Len(t, page, 1)
+	require.Equal(t, players[0].ID, page[0].ID)
+
+	_, err = repo.GetRankedPlayers("points; DROP TABLE player_profiles", 0, 3)
+	require.Equal(t, helpers.ErrInvalidLeaderboardMetric, err, "Expected invalid metric error")
+}
+
+func TestLeaderboardRepositoryImpl_CountPlayersAbove(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewLeaderboardRepositoryImpl(db)
+
+	seedLeaderboard(t, db, 300, 300, 200, 100)
+
+	above, err := repo.CountPlayersAbove(models.LeaderboardMetricPoints, 100, false)
+	require.NoError(t, err, "Error counting players")
+	require.Equal(t, int64(3), above, "Expected every player above")
+
+	distinctAbove, err := repo.CountPlayersAbove(models.LeaderboardMetricPoints, 100, true)
+	require.NoError(t, err, "Error counting players")
+	require.Equal(t, int64(2), distinctAbove, "Expected the tied players to count once")
+}
+
+func TestLeaderboardRepositoryImpl_GetPlayerPosition(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewLeaderboardRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 300, 200, 300, 100)
+
+	position, err := repo.GetPlayerPosition(models.LeaderboardMetricPoints, &players[2])
+	require.NoError(t, err, "Error getting player position")
+	require.Equal(t, int64(1), position, "Expected the tied player with the lower id first")
+
+	position, err = repo.GetPlayerPosition(models.LeaderboardMetricPoints, &players[3])
+	require.NoError(t, err, "Error getting player position")
+	require.Equal(t, int64(3), position)
+}
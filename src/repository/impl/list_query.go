@@ -0,0 +1,142 @@
+package impl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"gorm.io/gorm"
+)
+
+// listField is a column the list endpoints can filter and sort by. Only
+// numeric fields can be compared and only searchable fields take prefixes.
+type listField struct {
+	column     string
+	numeric    bool
+	searchable bool
+}
+
+// listComparisons are the SQL operators of the filters, the prefix search is built apart.
+var listComparisons = map[string]string{
+	models.FilterEqual:          "=",
+	models.FilterNotEqual:       "<>",
+	models.FilterGreater:        ">",
+	models.FilterGreaterOrEqual: ">=",
+	models.FilterLess:           "<",
+	models.FilterLessOrEqual:    "<=",
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listFilterScope translates the filters of a list query into a GORM scope.
+// Field names only reach the SQL through the whitelist and values are always
+// bound as parameters.
+func listFilterScope(filters []models.Filter, fields map[string]listField) (func(*gorm.DB) *gorm.DB, error) {
+	conditions := make([]string, 0, len(filters))
+	values := make([]interface{}, 0, len(filters))
+
+	for _, filter := range filters {
+		field, ok := fields[filter.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", helpers.ErrInvalidListQuery, filter.Field)
+		}
+
+		if filter.Operator == models.FilterPrefix {
+			if !field.searchable {
+				return nil, fmt.Errorf("%w: %q can't be searched", helpers.ErrInvalidListQuery, filter.Field)
+			}
+
+			conditions = append(conditions, fmt.Sprintf(`LOWER(%s) LIKE ? ESCAPE '\'`, field.column))
+			values = append(values, likeEscaper.Replace(strings.ToLower(filter.Value))+"%")
+			continue
+		}
+
+		comparison, ok := listComparisons[filter.Operator]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown operator %q", helpers.ErrInvalidListQuery, filter.Operator)
+		}
+
+		if !field.numeric {
+			if filter.Operator != models.FilterEqual && filter.Operator != models.FilterNotEqual {
+				return nil, fmt.Errorf("%w: %q can only be compared with = and !=", helpers.ErrInvalidListQuery, filter.Field)
+			}
+
+			conditions = append(conditions, fmt.Sprintf("%s %s ?", field.column, comparison))
+			values = append(values, filter.Value)
+			continue
+		}
+
+		number, err := strconv.Atoi(filter.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q must be a number", helpers.ErrInvalidListQuery, filter.Field)
+		}
+
+		conditions = append(conditions, fmt.Sprintf("%s %s ?", field.column, comparison))
+		values = append(values, number)
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		for i, condition := range conditions {
+			db = db.Where(condition, values[i])
+		}
+
+		return db
+	}, nil
+}
+
+// listQueryScope translates the list query and the page into a GORM scope.
+// Lists are sorted by id last so pages are stable. A cursor needs the list
+// sorted by id alone, the ids before it are read backwards from the cursor
+// so the repositories reverse them.
+func listQueryScope(query models.ListQuery, page models.Page, fields map[string]listField) (func(*gorm.DB) *gorm.DB, error) {
+	filterScope, err := listFilterScope(query.Filters, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if (page.AfterID != 0 || page.BeforeID != 0) && len(query.Sort) > 0 {
+		return nil, fmt.Errorf("%w: cursors can't be combined with sort", helpers.ErrInvalidListQuery)
+	}
+
+	orders := make([]string, 0, len(query.Sort)+1)
+	for _, sort := range query.Sort {
+		field, ok := fields[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown sort field %q", helpers.ErrInvalidListQuery, sort.Field)
+		}
+
+		if sort.Descending {
+			orders = append(orders, field.column+" DESC")
+		} else {
+			orders = append(orders, field.column)
+		}
+	}
+
+	if page.BeforeID != 0 {
+		orders = append(orders, "id DESC")
+	} else {
+		orders = append(orders, "id")
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Scopes(filterScope)
+
+		switch {
+		case page.AfterID != 0:
+			db = db.Where("id > ?", page.AfterID)
+		case page.BeforeID != 0:
+			db = db.Where("id < ?", page.BeforeID)
+		default:
+			db = db.Offset(page.Offset)
+		}
+
+		for _, order := range orders {
+			db = db.Order(order)
+		}
+
+		return db.Limit(page.Limit)
+	}, nil
+}
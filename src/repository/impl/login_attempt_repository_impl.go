@@ -0,0 +1,79 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// GetLoginAttempt implements repository.LoginAttemptRepository.
+func (la *LoginAttemptRepositoryImpl) GetLoginAttempt(key string) (*models.LoginAttempt, error) {
+	var loginAttempt models.LoginAttempt
+
+	result := la.Db.Where(AttemptKeyPlaceHolder, key).First(&loginAttempt)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorLoginAttemptNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LoginAttemptRepositoryImpl.GetLoginAttempt] Failed to get login attempt")
+		return nil, result.Error
+	}
+
+	return &loginAttempt, nil
+}
+
+// RecordFailure implements repository.LoginAttemptRepository.
+// The counter is incremented in the database so concurrent failures are all
+// counted. A failure after window without failures starts a new count.
+func (la *LoginAttemptRepositoryImpl) RecordFailure(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	result := la.Db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "attempt_key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failures":        gorm.Expr("CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END", now.Add(-window)),
+			"last_failure_at": now,
+		}),
+	}).Create(&models.LoginAttempt{Key: key, Failures: 1, LastFailureAt: now})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LoginAttemptRepositoryImpl.RecordFailure] Failed to record login failure")
+		return nil, result.Error
+	}
+
+	return la.GetLoginAttempt(key)
+}
+
+// LockUntil implements repository.LoginAttemptRepository.
+func (la *LoginAttemptRepositoryImpl) LockUntil(key string, lockedUntil time.Time) error {
+	result := la.Db.Model(&models.LoginAttempt{}).Where(AttemptKeyPlaceHolder, key).Update("locked_until", lockedUntil)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LoginAttemptRepositoryImpl.LockUntil] Failed to lock key")
+		return result.Error
+	}
+
+	return nil
+}
+
+// DeleteLoginAttempt implements repository.LoginAttemptRepository.
+func (la *LoginAttemptRepositoryImpl) DeleteLoginAttempt(key string) error {
+	result := la.Db.Where(AttemptKeyPlaceHolder, key).Delete(&models.LoginAttempt{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[LoginAttemptRepositoryImpl.DeleteLoginAttempt] Failed to delete login attempt")
+		return result.Error
+	}
+
+	return nil
+}
+
+func NewLoginAttemptRepositoryImpl(db *gorm.DB) r.LoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{Db: db}
+}
@@ -0,0 +1,72 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptRepositoryImpl_RecordFailure(t *testing.T) {
+	db := testutils.SetupTestDB(&models.LoginAttempt{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewLoginAttemptRepositoryImpl(db)
+
+	_, err := repo.GetLoginAttempt("email:test@test.com")
+	require.Equal(t, helpers.ErrorLoginAttemptNotFound, err, "Expected login attempt not found error")
+
+	now := time.Now()
+	loginAttempt, err := repo.RecordFailure("email:test@test.com", now, time.Hour)
+	require.NoError(t, err, "Error recording failure")
+	require.Equal(t, 1, loginAttempt.Failures)
+
+	loginAttempt, err = repo.RecordFailure("email:test@test.com", now.Add(time.Minute), time.Hour)
+	require.NoError(t, err, "Error recording failure")
+	require.Equal(t, 2, loginAttempt.Failures)
+
+	// Other keys keep their own counter
+	loginAttempt, err = repo.RecordFailure("ip:10.0.0.1", now, time.Hour)
+	require.NoError(t, err, "Error recording failure")
+	require.Equal(t, 1, loginAttempt.Failures)
+
+	// A failure after a quiet window starts counting again
+	loginAttempt, err = repo.RecordFailure("email:test@test.com", now.Add(3*time.Hour), time.Hour)
+	require.NoError(t, err, "Error recording failure")
+	require.Equal(t, 1, loginAttempt.Failures)
+}
+
+func TestLoginAttemptRepositoryImpl_LockAndDelete(t *testing.T) {
+	db := testutils.SetupTestDB(&models.LoginAttempt{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewLoginAttemptRepositoryImpl(db)
+
+	_, err := repo.RecordFailure("email:test@test.com", time.Now(), time.Hour)
+	require.NoError(t, err, "Error recording failure")
+
+	lockedUntil := time.Now().Add(time.Hour)
+	require.NoError(t, repo.LockUntil("email:test@test.com", lockedUntil), "Error locking key")
+
+	loginAttempt, err := repo.GetLoginAttempt("email:test@test.com")
+	require.NoError(t, err, "Error getting login attempt")
+	require.NotNil(t, loginAttempt.LockedUntil)
+	require.WithinDuration(t, lockedUntil, *loginAttempt.LockedUntil, time.Second)
+
+	require.NoError(t, repo.DeleteLoginAttempt("email:test@test.com"), "Error deleting login attempt")
+	_, err = repo.GetLoginAttempt("email:test@test.com")
+	require.Equal(t, helpers.ErrorLoginAttemptNotFound, err, "Expected login attempt to be deleted")
+}
@@ -0,0 +1,102 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MatchRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// GetPlayerRatings implements repository.MatchRepository.
+func (m *MatchRepositoryImpl) GetPlayerRatings(playerProfileIDs []uint) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+
+	result := m.Db.Where("player_profile_id IN ?", playerProfileIDs).Find(&ratings)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[MatchRepositoryImpl.GetPlayerRatings] Failed to get player ratings")
+		return nil, result.Error
+	}
+
+	return ratings, nil
+}
+
+// CreateMatch implements repository.MatchRepository.
+func (m *MatchRepositoryImpl) CreateMatch(match *models.Match, ratings []models.PlayerRating) error {
+	err := m.Db.Transaction(func(tx *gorm.DB) error {
+		playerProfileIDs := make([]uint, len(match.Participants))
+		for i, participant := range match.Participants {
+			playerProfileIDs[i] = participant.PlayerProfileID
+		}
+
+		var players int64
+
+		result := tx.Model(&models.PlayerProfile{}).Where("id IN ?", playerProfileIDs).Count(&players)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if players != int64(len(playerProfileIDs)) {
+			return helpers.ErrorPlayerProfileNotFound
+		}
+
+		for i := range ratings {
+			rating := &ratings[i]
+
+			if rating.ID == 0 {
+				// Another match created the rating first
+				result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(rating)
+			} else {
+				result = tx.Model(&models.PlayerRating{}).
+					Where(IDPlaceHolder, rating.ID).
+					Where(MatchesPlayedPlaceHolder, rating.MatchesPlayed-1).
+					Updates(map[string]interface{}{
+						"rating":         rating.Rating,
+						"deviation":      rating.Deviation,
+						"volatility":     rating.Volatility,
+						"matches_played": rating.MatchesPlayed,
+					})
+			}
+
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return helpers.ErrorPlayerRatingConflict
+			}
+		}
+
+		return tx.Create(match).Error
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorPlayerRatingConflict) && !errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+		logrus.WithError(err).Error("[MatchRepositoryImpl.CreateMatch] Failed to create match")
+	}
+
+	return err
+}
+
+// GetPlayerMatches implements repository.MatchRepository.
+func (m *MatchRepositoryImpl) GetPlayerMatches(playerProfileID uint, offset int, pageSize int) ([]models.MatchParticipant, error) {
+	var participants []models.MatchParticipant
+
+	// Participants are inserted in the order the matches are rated
+	result := m.Db.Preload("Match").Where(PlayerProfileIDPlaceHolder, playerProfileID).Order("id DESC").Offset(offset).Limit(pageSize).Find(&participants)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[MatchRepositoryImpl.GetPlayerMatches] Failed to get player matches")
+		return nil, result.Error
+	}
+
+	return participants, nil
+}
+
+func NewMatchRepositoryImpl(db *gorm.DB) r.MatchRepository {
+	return &MatchRepositoryImpl{Db: db}
+}
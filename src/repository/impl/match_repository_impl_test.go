@@ -0,0 +1,106 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMatch returns a 1v1 match between the players, the first one won.
+func newTestMatch(winner uint, loser uint, ratingAfter float64) *models.Match {
+	return &models.Match{
+		RatingSystem: models.RatingSystemElo,
+		PlayedAt:     time.Now(),
+		Participants: []models.MatchParticipant{
+			{PlayerProfileID: winner, Team: 1, Placement: 1, RatingBefore: 1500, RatingAfter: ratingAfter},
+			{PlayerProfileID: loser, Team: 2, Placement: 2, RatingBefore: 1500, RatingAfter: 3000 - ratingAfter},
+		},
+	}
+}
+
+func TestMatchRepositoryImpl_CreateMatch(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.Match{}, &models.MatchParticipant{}, &models.PlayerRating{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewMatchRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 0, 0)
+
+	ratings := []models.PlayerRating{
+		{PlayerProfileID: players[0].ID, Rating: 1516, MatchesPlayed: 1},
+		{PlayerProfileID: players[1].ID, Rating: 1484, MatchesPlayed: 1},
+	}
+	require.NoError(t, repo.CreateMatch(newTestMatch(players[0].ID, players[1].ID, 1516), ratings), "Error creating match")
+
+	stored, err := repo.GetPlayerRatings([]uint{players[0].ID, players[1].ID})
+	require.NoError(t, err, "Error getting player ratings")
+	require.Len(t, stored, 2)
+
+	// A second submission read the ratings before the first match was saved
+	staleRatings := []models.PlayerRating{
+		{PlayerProfileID: players[0].ID, Rating: 1516, MatchesPlayed: 1},
+		{PlayerProfileID: players[1].ID, Rating: 1484, MatchesPlayed: 1},
+	}
+	err = repo.CreateMatch(newTestMatch(players[0].ID, players[1].ID, 1516), staleRatings)
+	require.Equal(t, helpers.ErrorPlayerRatingConflict, err, "Expected rating conflict error")
+
+	for i := range stored {
+		stored[i].Rating += 10
+		stored[i].MatchesPlayed++
+	}
+	require.NoError(t, repo.CreateMatch(newTestMatch(players[1].ID, players[0].ID, 1510), stored), "Error creating match")
+
+	// An update from a stale version doesn't touch the rating
+	stored[0].Rating = 0
+	err = repo.CreateMatch(newTestMatch(players[0].ID, players[1].ID, 1516), stored)
+	require.Equal(t, helpers.ErrorPlayerRatingConflict, err, "Expected rating conflict error")
+
+	current, err := repo.GetPlayerRatings([]uint{players[0].ID})
+	require.NoError(t, err, "Error getting player ratings")
+	require.Equal(t, 1526.0, current[0].Rating)
+	require.Equal(t, 2, current[0].MatchesPlayed)
+
+	var matches int64
+	require.NoError(t, db.Model(&models.Match{}).Count(&matches).Error)
+	require.Equal(t, int64(2), matches, "Expected the conflicting matches to be rolled back")
+
+	history, err := repo.GetPlayerMatches(players[0].ID, 0, 10)
+	require.NoError(t, err, "Error getting player matches")
+	require.Len(t, history, 2)
+	require.Equal(t, 2, history[0].Placement, "Expected the latest match first")
+	require.Equal(t, models.RatingSystemElo, history[0].Match.RatingSystem, "Expected the match to be loaded")
+}
+
+func TestMatchRepositoryImpl_CreateMatch_PlayerNotFound(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.Match{}, &models.MatchParticipant{}, &models.PlayerRating{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewMatchRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 0)
+
+	ratings := []models.PlayerRating{
+		{PlayerProfileID: players[0].ID, Rating: 1516, MatchesPlayed: 1},
+		{PlayerProfileID: 99, Rating: 1484, MatchesPlayed: 1},
+	}
+	err := repo.CreateMatch(newTestMatch(players[0].ID, 99, 1516), ratings)
+	require.Equal(t, helpers.ErrorPlayerProfileNotFound, err, "Expected player profile not found error")
+
+	stored, err := repo.GetPlayerRatings([]uint{players[0].ID})
+	require.NoError(t, err, "Error getting player ratings")
+	require.Empty(t, stored, "Expected no rating to be saved")
+}
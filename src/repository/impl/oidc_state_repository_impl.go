@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type OIDCStateRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateOIDCState implements repository.OIDCStateRepository.
+func (st *OIDCStateRepositoryImpl) CreateOIDCState(oidcState *models.OIDCState) error {
+	result := st.Db.Create(oidcState)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[OIDCStateRepositoryImpl.CreateOIDCState] Failed to create oidc state")
+		return result.Error
+	}
+
+	return nil
+}
+
+// FindByStateHash implements repository.OIDCStateRepository.
+func (st *OIDCStateRepositoryImpl) FindByStateHash(stateHash string) (*models.OIDCState, error) {
+	var oidcState models.OIDCState
+
+	result := st.Db.Where(StateHashPlaceHolder, stateHash).First(&oidcState)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorOIDCStateNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[OIDCStateRepositoryImpl.FindByStateHash] Failed to find oidc state")
+		return nil, result.Error
+	}
+
+	return &oidcState, nil
+}
+
+// MarkAsUsed implements repository.OIDCStateRepository.
+// Only one of two concurrent callbacks with the same state gets true.
+func (st *OIDCStateRepositoryImpl) MarkAsUsed(oidcStateID uint) (bool, error) {
+	result := st.Db.Model(&models.OIDCState{}).
+		Where(IDPlaceHolder, oidcStateID).
+		Where("used_at IS NULL").
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[OIDCStateRepositoryImpl.MarkAsUsed] Failed to mark oidc state as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func NewOIDCStateRepositoryImpl(db *gorm.DB) r.OIDCStateRepository {
+	return &OIDCStateRepositoryImpl{Db: db}
+}
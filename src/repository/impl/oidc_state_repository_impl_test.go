@@ -0,0 +1,50 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCStateRepositoryImpl(t *testing.T) {
+	db := testutils.SetupTestDB(&models.OIDCState{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewOIDCStateRepositoryImpl(db)
+
+	linkUserID := uint(7)
+	oidcState := &models.OIDCState{
+		StateHash:    helpers.HashToken("state"),
+		Provider:     "google",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		LinkUserID:   &linkUserID,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+	require.NoError(t, repo.CreateOIDCState(oidcState), "Error creating oidc state")
+
+	found, err := repo.FindByStateHash(helpers.HashToken("state"))
+	require.NoError(t, err, "Error finding oidc state")
+	require.Equal(t, "verifier", found.CodeVerifier)
+	require.Equal(t, linkUserID, *found.LinkUserID)
+
+	_, err = repo.FindByStateHash(helpers.HashToken("other"))
+	require.Equal(t, helpers.ErrorOIDCStateNotFound, err, "Expected oidc state not found error")
+
+	marked, err := repo.MarkAsUsed(oidcState.ID)
+	require.NoError(t, err)
+	require.True(t, marked, "Expected the first use to mark the state")
+
+	marked, err = repo.MarkAsUsed(oidcState.ID)
+	require.NoError(t, err)
+	require.False(t, marked, "Expected the state to be single use")
+}
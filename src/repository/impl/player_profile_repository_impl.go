@@ -2,23 +2,25 @@ package impl
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
 	r "github.com/dieg0code/player-profile/src/repository"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PlayerProfileRepositoryImpl struct {
 	Db *gorm.DB
 }
 
-// GetPlayerWithAchievements implements repository.PlayerProfileRepository.
-func (p *PlayerProfileRepositoryImpl) GetPlayerWithAchievements(playerProfileID uint) (*models.PlayerProfile, error) {
+// GetPlayerAchievements implements repository.PlayerProfileRepository.
+func (p *PlayerProfileRepositoryImpl) GetPlayerAchievements(playerProfileID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error) {
 	exists, err := p.CheckPlayerProfileExists(playerProfileID)
 	if err != nil {
-		logrus.WithError(err).Error("[PlayerProfileRepositoryImpl.GetPlayerWithAchievements] Failed to check if player profile exists")
+		logrus.WithError(err).Error("[PlayerProfileRepositoryImpl.GetPlayerAchievements] Failed to check if player profile exists")
 		return nil, err
 	}
 
@@ -26,32 +28,66 @@ func (p *PlayerProfileRepositoryImpl) GetPlayerWithAchievements(playerProfileID
 		return nil, helpers.ErrorPlayerProfileNotFound
 	}
 
-	var playerProfileFound models.PlayerProfile
+	order := UnlockedAtDescOrder
+	if sortOrder == "asc" {
+		order = UnlockedAtAscOrder
+	}
 
-	result := p.Db.Preload("Achievements").Where(IDPlaceHolder, playerProfileID).First(&playerProfileFound)
+	var playerAchievements []models.PlayerProfileAchievement
+
+	result := p.Db.InnerJoins("Achievement").
+		Where("player_profile_achievements.player_profile_id = ?", playerProfileID).
+		Order(order).
+		Order("player_profile_achievements.achievement_id").
+		Offset(offset).Limit(pageSize).
+		Find(&playerAchievements)
 
 	if result.Error != nil {
-		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.GetPlayerWithAchievements] Failed to get player profile with achievements")
+		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.GetPlayerAchievements] Failed to get player achievements")
 		return nil, result.Error
 	}
 
-	return &playerProfileFound, nil
+	return playerAchievements, nil
 }
 
 func NewPlayerProfileRepositoryImpl(db *gorm.DB) r.PlayerProfileRepository {
 	return &PlayerProfileRepositoryImpl{Db: db}
 }
 
-// CreatePlayerProfile implements repository.PlayerProfileRepository.
-func (p *PlayerProfileRepositoryImpl) CreatePlayerProfile(playerProfile *models.PlayerProfile) error {
-
-	result := p.Db.Create(playerProfile)
-	if result.Error != nil {
-		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.CreatePlayerProfile] Failed to create player profile")
-		return result.Error
-	}
-
-	return nil
+// CreatePlayerProfileWithinLimit implements repository.PlayerProfileRepository.
+// Concurrent creations for the same user wait on the lock, so they can't both
+// see room for one more profile.
+func (p *PlayerProfileRepositoryImpl) CreatePlayerProfileWithinLimit(playerProfile *models.PlayerProfile, maxProfiles int) error {
+	return p.Db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where(IDPlaceHolder, playerProfile.UserID).First(&user)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return helpers.ErrorUserNotFound
+		}
+		if result.Error != nil {
+			logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.CreatePlayerProfileWithinLimit] Failed to lock user")
+			return result.Error
+		}
+
+		var count int64
+		result = tx.Model(&models.PlayerProfile{}).Where(UserIDPlaceHolder, playerProfile.UserID).Count(&count)
+		if result.Error != nil {
+			logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.CreatePlayerProfileWithinLimit] Failed to count player profiles")
+			return result.Error
+		}
+
+		if count >= int64(maxProfiles) {
+			return helpers.ErrPlayerProfileLimitReached
+		}
+
+		result = tx.Create(playerProfile)
+		if result.Error != nil {
+			logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.CreatePlayerProfileWithinLimit] Failed to create player profile")
+			return result.Error
+		}
+
+		return nil
+	})
 }
 
 // GetPlayerProfile implements repository.PlayerProfileRepository.
@@ -78,19 +114,71 @@ func (p *PlayerProfileRepositoryImpl) GetPlayerProfile(playerProfileID uint) (*m
 	return &playerProfileFound, nil
 }
 
+// playerProfileListFields are the fields players can be filtered and sorted by.
+var playerProfileListFields = map[string]listField{
+	"id":         {column: "id", numeric: true},
+	"nickname":   {column: "nickname", searchable: true},
+	"level":      {column: "level", numeric: true},
+	"experience": {column: "experience", numeric: true},
+	"points":     {column: "points", numeric: true},
+	"user_id":    {column: "user_id", numeric: true},
+}
+
 // GetAllPlayerProfiles implements repository.PlayerProfileRepository.
-func (p *PlayerProfileRepositoryImpl) GetAllPlayerProfiles(offset int, pageSize int) ([]models.PlayerProfile, error) {
+func (p *PlayerProfileRepositoryImpl) GetAllPlayerProfiles(page models.Page, listQuery models.ListQuery, viewerUserID uint) ([]models.PlayerProfile, error) {
 	var playerProfiles []models.PlayerProfile
 
-	result := p.Db.Offset(offset).Limit(pageSize).Find(&playerProfiles)
+	scope, err := listQueryScope(listQuery, page, playerProfileListFields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := p.Db.Scopes(p.hiddenFrom(viewerUserID), scope).Find(&playerProfiles)
 	if result.Error != nil {
 		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.GetAllPlayerProfiles] Failed to get all player profiles")
 		return nil, helpers.ErrorGetAllPlayerProfiles
 	}
 
+	if page.BeforeID != 0 {
+		slices.Reverse(playerProfiles)
+	}
+
 	return playerProfiles, nil
 }
 
+// CountPlayerProfiles implements repository.PlayerProfileRepository.
+func (p *PlayerProfileRepositoryImpl) CountPlayerProfiles(listQuery models.ListQuery, viewerUserID uint) (int64, error) {
+	var count int64
+
+	scope, err := listFilterScope(listQuery.Filters, playerProfileListFields)
+	if err != nil {
+		return 0, err
+	}
+
+	result := p.Db.Model(&models.PlayerProfile{}).Scopes(p.hiddenFrom(viewerUserID), scope).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.CountPlayerProfiles] Failed to count player profiles")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+// hiddenFrom leaves out the players who blocked a player of the viewer, no
+// one is hidden from API keys.
+func (p *PlayerProfileRepositoryImpl) hiddenFrom(viewerUserID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerUserID == 0 {
+			return db
+		}
+
+		viewerPlayerIDs := p.Db.Model(&models.PlayerProfile{}).Select("id").Where(UserIDPlaceHolder, viewerUserID)
+		blockerIDs := p.Db.Model(&models.PlayerBlock{}).Select("blocker_id").Where("blocked_id IN (?)", viewerPlayerIDs)
+
+		return db.Where("id NOT IN (?)", blockerIDs)
+	}
+}
+
 // UpdatePlayerProfile implements repository.PlayerProfileRepository.
 func (p *PlayerProfileRepositoryImpl) UpdatePlayerProfile(playerProfileID uint, playerProfile *models.PlayerProfile) error {
 	exists, err := p.CheckPlayerProfileExists(playerProfileID)
@@ -112,6 +200,51 @@ func (p *PlayerProfileRepositoryImpl) UpdatePlayerProfile(playerProfileID uint,
 	return nil
 }
 
+// UpdatePlayerProgress implements repository.PlayerProfileRepository.
+// Level, experience and points are always written, zero values included. The
+// season points change by as much as the points, without going below zero.
+func (p *PlayerProfileRepositoryImpl) UpdatePlayerProgress(playerProfileID uint, playerProfile *models.PlayerProfile) error {
+	exists, err := p.CheckPlayerProfileExists(playerProfileID)
+	if err != nil {
+		logrus.WithError(err).Error("[PlayerProfileRepositoryImpl.UpdatePlayerProgress] Failed to check if player profile exists")
+		return err
+	}
+
+	if !exists {
+		return helpers.ErrorPlayerProfileNotFound
+	}
+
+	result := p.Db.Model(&models.PlayerProfile{}).Where(IDPlaceHolder, playerProfileID).
+		Updates(map[string]interface{}{
+			"level":         playerProfile.Level,
+			"experience":    playerProfile.Experience,
+			"points":        playerProfile.Points,
+			"season_points": gorm.Expr(SeasonPointsChangeExpression, playerProfile.Points, playerProfile.Points),
+		})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.UpdatePlayerProgress] Failed to update player progress")
+		return helpers.ErrorUpdatePlayer
+	}
+
+	return nil
+}
+
+// UpdatePlayerExperience implements repository.PlayerProfileRepository.
+// The update only applies while the level and experience are still the ones
+// of the profile, so concurrent grants don't overwrite each other.
+func (p *PlayerProfileRepositoryImpl) UpdatePlayerExperience(playerProfile *models.PlayerProfile, level int, experience int) (bool, error) {
+	result := p.Db.Model(&models.PlayerProfile{}).
+		Where(IDPlaceHolder, playerProfile.ID).
+		Where(ExperienceAndLevelPlaceHolder, playerProfile.Experience, playerProfile.Level).
+		Updates(map[string]interface{}{"level": level, "experience": experience})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.UpdatePlayerExperience] Failed to update player experience")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // DeletePlayerProfile implements repository.PlayerProfileRepository.
 func (p *PlayerProfileRepositoryImpl) DeletePlayerProfile(playerProfileID uint) error {
 	exists, err := p.CheckPlayerProfileExists(playerProfileID)
@@ -125,10 +258,27 @@ func (p *PlayerProfileRepositoryImpl) DeletePlayerProfile(playerProfileID uint)
 		return helpers.ErrorPlayerProfileNotFound
 	}
 
-	result := p.Db.Delete(&models.PlayerProfile{}, playerProfileID)
-
-	if result.Error != nil {
-		logrus.WithError(result.Error).Error("[PlayerProfileRepositoryImpl.DeletePlayerProfile] Failed to delete player profile")
+	// The friend requests, friendships and blocks of the player go with the profile
+	err = p.Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.PlayerProfile{}, playerProfileID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where(SenderOrReceiverIDPlaceHolder, playerProfileID, playerProfileID).Delete(&models.FriendRequest{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where(PlayerOrFriendIDPlaceHolder, playerProfileID, playerProfileID).Delete(&models.Friendship{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Where(BlockerOrBlockedIDPlaceHolder, playerProfileID, playerProfileID).Delete(&models.PlayerBlock{}).Error
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[PlayerProfileRepositoryImpl.DeletePlayerProfile] Failed to delete player profile")
 		return helpers.ErrorDeletingUser
 	}
 
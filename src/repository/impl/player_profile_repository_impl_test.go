@@ -1,7 +1,9 @@
 package impl
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
@@ -41,79 +43,6 @@ func TestNewPlayerProfileRepositoryImpl(t *testing.T) {
 	require.NotNil(t, playerRepo, "PlayerProfileRepositoryImpl is nil")
 }
 
-func TestPlayerProfileRespositoryImpl_CreatePlayerProfile(t *testing.T) {
-
-	t.Run("CreatePlayerProfile_Success", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
-		defer func() {
-			sqlDB, _ := db.DB()
-			err := sqlDB.Close()
-			if err != nil {
-				t.Errorf("Error closing database connection: %v", err)
-			}
-		}()
-
-		playerRepo := NewPlayerProfileRepositoryImpl(db)
-		userRepo := NewUserRepositoryImpl(db)
-
-		resultUser := userRepo.CreateUser(testUser)
-		resultPlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
-
-		// Attempt to create user profile
-		require.NoError(t, resultUser, "Error creating user")
-		require.Nil(t, resultUser, "Error creating user")
-		// Verify user was created
-		require.NotZero(t, testUser.ID, "User ID is zero")
-
-		// Attempt to create Player
-		require.NoError(t, resultPlayer, "Error creating player profile")
-		require.Nil(t, resultPlayer, "Error creating player profile")
-		// Varify Player was created
-		require.NotZero(t, testPlayerProfile.ID, "Player Profile ID is zero")
-
-		var dbPlayer models.PlayerProfile
-		require.NoError(t, db.Preload("User").First(&dbPlayer, "nickname = ?", testPlayerProfile.Nickname).Error, "Error getting player profile")
-
-		// Assertion
-		require.Equal(t, testPlayerProfile.Nickname, dbPlayer.Nickname, "Nicknames do not match")
-
-	})
-
-	t.Run("CreatePlayerProfile_Duplicate", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
-		defer func() {
-			sqlDB, _ := db.DB()
-			err := sqlDB.Close()
-			if err != nil {
-				t.Errorf("Error closing database connection: %v", err)
-			}
-		}()
-
-		playerRepo := NewPlayerProfileRepositoryImpl(db)
-		userRepo := NewUserRepositoryImpl(db)
-
-		resultUser := userRepo.CreateUser(testUser)
-		resultPlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
-
-		// Attempt to create user profile
-		require.NoError(t, resultUser, "Error creating user")
-		require.Nil(t, resultUser, "Error creating user")
-		// Verify user was created
-		require.NotZero(t, testUser.ID, "User ID is zero")
-
-		// Attempt to create Player
-		require.NoError(t, resultPlayer, "Error creating player profile")
-		require.Nil(t, resultPlayer, "Error creating player profile")
-
-		// Varify Player was created
-		require.NotZero(t, testPlayerProfile.ID, "Player Profile ID is zero")
-
-		// Attempt to create Player again
-		err := playerRepo.CreatePlayerProfile(testPlayerProfile)
-		require.Error(t, err, "Expected error creating duplicate player profile")
-	})
-}
-
 func TestPlayerProfileRespositoryImpl_GetPlayerProfile(t *testing.T) {
 
 	t.Run("GetPlayerProfile_Success", func(t *testing.T) {
@@ -130,7 +59,7 @@ func TestPlayerProfileRespositoryImpl_GetPlayerProfile(t *testing.T) {
 		userRepo := NewUserRepositoryImpl(db)
 
 		resultUser := userRepo.CreateUser(testUser)
-		resultPlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
+		resultPlayer := db.Create(testPlayerProfile).Error
 
 		// Attempt to create user profile
 		require.NoError(t, resultUser, "Error creating user")
@@ -189,7 +118,7 @@ func TestPlayerProfileRespositoryImpl_GetAll(t *testing.T) {
 		userRepo := NewUserRepositoryImpl(db)
 
 		resultUser := userRepo.CreateUser(testUser)
-		resultPlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
+		resultPlayer := db.Create(testPlayerProfile).Error
 
 		// Attempt to create user profile
 		require.NoError(t, resultUser, "Error creating user")
@@ -206,7 +135,7 @@ func TestPlayerProfileRespositoryImpl_GetAll(t *testing.T) {
 		require.NotZero(t, testPlayerProfile.ID, "Player Profile ID is zero")
 
 		// Attempt to get all player profiles
-		playerProfiles, err := playerRepo.GetAllPlayerProfiles(0, 10)
+		playerProfiles, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, models.ListQuery{}, 0)
 		require.NoError(t, err, "Error getting all player profiles")
 		require.NotZero(t, len(playerProfiles), "Player profiles length is zero")
 	})
@@ -224,7 +153,7 @@ func TestPlayerProfileRespositoryImpl_GetAll(t *testing.T) {
 		playerRepo := NewPlayerProfileRepositoryImpl(db)
 
 		// Attempt to get all player profiles
-		playerProfiles, err := playerRepo.GetAllPlayerProfiles(0, 10)
+		playerProfiles, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, models.ListQuery{}, 0)
 		require.NoError(t, err, "Error getting all player profiles")
 		require.Zero(t, len(playerProfiles), "Player profiles length is not zero")
 	})
@@ -246,7 +175,7 @@ func TestPlayerProfileRespositoryImpl_UpdatePlayerProfile(t *testing.T) {
 		userRepo := NewUserRepositoryImpl(db)
 
 		resultUser := userRepo.CreateUser(testUser)
-		resultPlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
+		resultPlayer := db.Create(testPlayerProfile).Error
 
 		// Attempt to create user profile
 		require.NoError(t, resultUser, "Error creating user")
@@ -295,10 +224,88 @@ func TestPlayerProfileRespositoryImpl_UpdatePlayerProfile(t *testing.T) {
 
 }
 
+func TestPlayerProfileRespositoryImpl_UpdatePlayerProgress(t *testing.T) {
+
+	t.Run("UpdatePlayerProgress_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		playerRepo := NewPlayerProfileRepositoryImpl(db)
+		player := &models.PlayerProfile{Nickname: "progress", Avatar: "progress.png", Level: 3, Experience: 300, Points: 50, UserID: 1}
+		require.NoError(t, db.Create(player).Error, "Error creating player profile")
+
+		// Zero values must be written too
+		err := playerRepo.UpdatePlayerProgress(player.ID, &models.PlayerProfile{Nickname: "ignored", Level: 4})
+		require.NoError(t, err, "Error updating player progress")
+
+		dbPlayer, err := playerRepo.GetPlayerProfile(player.ID)
+		require.NoError(t, err, "Error getting player profile")
+
+		// Assertion
+		require.Equal(t, 4, dbPlayer.Level, "Level not updated")
+		require.Zero(t, dbPlayer.Experience, "Experience not updated")
+		require.Zero(t, dbPlayer.Points, "Points not updated")
+		require.Equal(t, "progress", dbPlayer.Nickname, "Nickname should not change")
+	})
+
+	t.Run("UpdatePlayerProgress_SeasonPoints", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		playerRepo := NewPlayerProfileRepositoryImpl(db)
+		player := &models.PlayerProfile{Nickname: "season", Avatar: "season.png", Level: 1, Points: 500, SeasonPoints: 20, UserID: 1}
+		require.NoError(t, db.Create(player).Error, "Error creating player profile")
+
+		// Earned points count for the season too
+		require.NoError(t, playerRepo.UpdatePlayerProgress(player.ID, &models.PlayerProfile{Level: 1, Points: 530}))
+
+		dbPlayer, err := playerRepo.GetPlayerProfile(player.ID)
+		require.NoError(t, err, "Error getting player profile")
+		require.Equal(t, 530, dbPlayer.Points)
+		require.Equal(t, 50, dbPlayer.SeasonPoints)
+
+		// Lost points don't take the season points below zero
+		require.NoError(t, playerRepo.UpdatePlayerProgress(player.ID, &models.PlayerProfile{Level: 1, Points: 400}))
+
+		dbPlayer, err = playerRepo.GetPlayerProfile(player.ID)
+		require.NoError(t, err, "Error getting player profile")
+		require.Equal(t, 400, dbPlayer.Points)
+		require.Zero(t, dbPlayer.SeasonPoints)
+	})
+
+	t.Run("UpdatePlayerProgress_NotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+
+		playerRepo := NewPlayerProfileRepositoryImpl(db)
+
+		err := playerRepo.UpdatePlayerProgress(99, &models.PlayerProfile{Level: 2})
+		require.EqualError(t, err, helpers.ErrorPlayerProfileNotFound.Error(), "Error messages do not match")
+	})
+}
+
 func TestPlayerProfileRespositoryImpl_DeletePlayerProfile(t *testing.T) {
 
 	t.Run("DeletePlayerProfile_Success", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{}, &models.FriendRequest{}, &models.Friendship{}, &models.PlayerBlock{})
 		defer func() {
 			sqlDB, _ := db.DB()
 			err := sqlDB.Close()
@@ -311,7 +318,7 @@ func TestPlayerProfileRespositoryImpl_DeletePlayerProfile(t *testing.T) {
 		userRepo := NewUserRepositoryImpl(db)
 
 		resultCreateUser := userRepo.CreateUser(testUser)
-		resultCreatePlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
+		resultCreatePlayer := db.Create(testPlayerProfile).Error
 
 		// Attempt to create user profile
 		require.NoError(t, resultCreateUser, "Error creating user")
@@ -337,6 +344,31 @@ func TestPlayerProfileRespositoryImpl_DeletePlayerProfile(t *testing.T) {
 		require.Error(t, err, "Expected error getting player profile")
 	})
 
+	t.Run("DeletePlayerProfile_DeletesFriendships", func(t *testing.T) {
+		db, players := setupFriendshipDB(t)
+		playerRepo := NewPlayerProfileRepositoryImpl(db)
+		friendshipRepo := &FriendshipRepositoryImpl{Db: db}
+
+		befriend(t, friendshipRepo, players[0].ID, players[1].ID)
+		befriend(t, friendshipRepo, players[1].ID, players[2].ID)
+		require.NoError(t, friendshipRepo.CreateFriendRequest(&models.FriendRequest{SenderID: players[0].ID, ReceiverID: players[3].ID}))
+		require.NoError(t, friendshipRepo.BlockPlayer(&models.PlayerBlock{BlockerID: players[2].ID, BlockedID: players[0].ID}))
+
+		require.NoError(t, playerRepo.DeletePlayerProfile(players[0].ID), "Error deleting player profile")
+
+		var count int64
+		require.NoError(t, db.Model(&models.FriendRequest{}).Count(&count).Error)
+		require.Zero(t, count, "Deleting the player should delete the pending requests")
+
+		require.NoError(t, db.Model(&models.PlayerBlock{}).Count(&count).Error)
+		require.Zero(t, count, "Deleting the player should delete the blocks")
+
+		friends, err := friendshipRepo.GetFriends(players[1].ID, models.Page{Limit: 10})
+		require.NoError(t, err, "Error getting friends")
+		require.Len(t, friends, 1, "Only the friendships of the player should be deleted")
+		require.Equal(t, players[2].ID, friends[0].ID)
+	})
+
 	t.Run("DeletePlayerProfile_NotFound", func(t *testing.T) {
 		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
 		defer func() {
@@ -357,6 +389,62 @@ func TestPlayerProfileRespositoryImpl_DeletePlayerProfile(t *testing.T) {
 
 }
 
+func TestPlayerProfileRespositoryImpl_UpdatePlayerExperience(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+
+	playerRepo := NewPlayerProfileRepositoryImpl(db)
+	player := &models.PlayerProfile{Nickname: "leveling", Avatar: "leveling.png", Level: 1, Experience: 50, UserID: 1}
+	require.NoError(t, db.Create(player).Error)
+
+	updated, err := playerRepo.UpdatePlayerExperience(player, 2, 150)
+	require.NoError(t, err, "Error updating player experience")
+	require.True(t, updated, "Experience should be updated")
+
+	// The profile read before the first update is stale now
+	updated, err = playerRepo.UpdatePlayerExperience(player, 3, 250)
+	require.NoError(t, err, "Error updating player experience")
+	require.False(t, updated, "Stale profiles must not overwrite the experience")
+
+	dbPlayer, err := playerRepo.GetPlayerProfile(player.ID)
+	require.NoError(t, err, "Error getting player profile")
+	require.Equal(t, 2, dbPlayer.Level)
+	require.Equal(t, 150, dbPlayer.Experience)
+}
+
+func TestPlayerProfileRespositoryImpl_CreatePlayerProfileWithinLimit(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+
+	require.NoError(t, db.Create(&models.User{UserName: "limited", PassWord: "password", Email: "limited@example.com", Age: 20, Role: "user"}).Error)
+	playerRepo := NewPlayerProfileRepositoryImpl(db)
+
+	require.NoError(t, playerRepo.CreatePlayerProfileWithinLimit(&models.PlayerProfile{Nickname: "first", Avatar: "first.png", Level: 1, UserID: 1}, 2))
+	require.NoError(t, playerRepo.CreatePlayerProfileWithinLimit(&models.PlayerProfile{Nickname: "second", Avatar: "second.png", Level: 1, UserID: 1}, 2))
+
+	err := playerRepo.CreatePlayerProfileWithinLimit(&models.PlayerProfile{Nickname: "third", Avatar: "third.png", Level: 1, UserID: 1}, 2)
+	require.ErrorIs(t, err, helpers.ErrPlayerProfileLimitReached, "Expected limit reached error")
+
+	err = playerRepo.CreatePlayerProfileWithinLimit(&models.PlayerProfile{Nickname: "orphan", Avatar: "orphan.png", Level: 1, UserID: 99}, 2)
+	require.ErrorIs(t, err, helpers.ErrorUserNotFound, "Expected user not found error")
+
+	var count int64
+	require.NoError(t, db.Model(&models.PlayerProfile{}).Count(&count).Error)
+	require.Equal(t, int64(2), count, "Only the profiles within the limit are created")
+}
+
 func TestPlayerProfileRespositoryImpl_CheckPlayerProfileExists(t *testing.T) {
 	t.Run("CheckPlayerProfileExists_Success", func(t *testing.T) {
 		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{})
@@ -372,7 +460,7 @@ func TestPlayerProfileRespositoryImpl_CheckPlayerProfileExists(t *testing.T) {
 		userRepo := NewUserRepositoryImpl(db)
 
 		resultCreateUser := userRepo.CreateUser(testUser)
-		resultCreatePlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
+		resultCreatePlayer := db.Create(testPlayerProfile).Error
 
 		// Attempt to create user profile
 		require.NoError(t, resultCreateUser, "Error creating user")
@@ -413,9 +501,9 @@ func TestPlayerProfileRespositoryImpl_CheckPlayerProfileExists(t *testing.T) {
 	})
 }
 
-func TestPlayerProfileRepository_GetPlayerWithAchievements(t *testing.T) {
-	t.Run("GetPlayerWithAchievements_Success", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{}, &models.Achievement{})
+func TestPlayerProfileRepository_GetPlayerAchievements(t *testing.T) {
+	t.Run("GetPlayerAchievements_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
 		defer func() {
 			sqlDB, _ := db.DB()
 			err := sqlDB.Close()
@@ -426,51 +514,60 @@ func TestPlayerProfileRepository_GetPlayerWithAchievements(t *testing.T) {
 		playerRepo := NewPlayerProfileRepositoryImpl(db)
 		userRepo := NewUserRepositoryImpl(db)
 
-		resultCreateUser := userRepo.CreateUser(testUser)
-		resultCreatePlayer := playerRepo.CreatePlayerProfile(testPlayerProfile)
-
-		// Attempt to create user profile
-		require.NoError(t, resultCreateUser, "Error creating user")
-		require.Nil(t, resultCreateUser, "Error creating user")
-
-		// Verify user was created
-		require.NotZero(t, testUser.ID, "User ID is zero")
-
-		// Attempt to create Player
-		require.NoError(t, resultCreatePlayer, "Error creating player profile")
-		require.Nil(t, resultCreatePlayer, "Error creating player profile")
-
-		// Varify Player was created
-		require.NotZero(t, testPlayerProfile.ID, "Player Profile ID is zero")
-
-		// Create achievements
-		achievement1 := &models.Achievement{
-			Name:        "Achievement 1",
-			Description: "Description 1",
-			PlayerProfiles: []models.PlayerProfile{
-				*testPlayerProfile,
-			},
+		user := &models.User{
+			UserName: "test",
+			PassWord: "test",
+			Email:    "test@test.com",
+			Age:      20,
 		}
-		achievement2 := &models.Achievement{
-			Name:        "Achievement 2",
-			Description: "Description 2",
-			PlayerProfiles: []models.PlayerProfile{
-				*testPlayerProfile,
-			},
+		err := userRepo.CreateUser(user)
+		require.NoError(t, err, "Error creating user")
+
+		player := &models.PlayerProfile{
+			Nickname:   "test",
+			Avatar:     "test.png",
+			Level:      1,
+			Experience: 10,
+			Points:     100,
+			UserID:     user.ID,
 		}
+		err = db.Create(player).Error
+		require.NoError(t, err, "Error creating player profile")
+
+		// Create achievements unlocked at different dates
+		unlockedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+		for i := 1; i <= 3; i++ {
+			achievement := &models.Achievement{
+				Name:        fmt.Sprintf("Achievement %d", i),
+				Description: fmt.Sprintf("Description %d", i),
+			}
+			require.NoError(t, db.Create(achievement).Error, "Error creating achievement")
 
-		db.Create(achievement1)
-		db.Create(achievement2)
+			playerAchievement := &models.PlayerProfileAchievement{
+				PlayerProfileID: player.ID,
+				AchievementID:   achievement.ID,
+				UnlockedAt:      unlockedAt.Add(time.Duration(i) * time.Hour),
+			}
+			require.NoError(t, db.Create(playerAchievement).Error, "Error awarding achievement")
+		}
 
-		// Get player with achievements
-		player, err := playerRepo.GetPlayerWithAchievements(testPlayerProfile.ID)
-		require.NoError(t, err, "Error getting player with achievements")
-		require.NotNil(t, player, "Player is nil")
-		require.NotZero(t, len(player.Achievements), "Achievements length is zero")
+		// Newest first
+		playerAchievements, err := playerRepo.GetPlayerAchievements(player.ID, 0, 2, "desc")
+		require.NoError(t, err, "Error getting player achievements")
+		require.Len(t, playerAchievements, 2, "Expected a page of 2 achievements")
+		require.Equal(t, "Achievement 3", playerAchievements[0].Achievement.Name)
+		require.Equal(t, "Achievement 2", playerAchievements[1].Achievement.Name)
+
+		// Oldest first, second page
+		playerAchievements, err = playerRepo.GetPlayerAchievements(player.ID, 2, 2, "asc")
+		require.NoError(t, err, "Error getting player achievements")
+		require.Len(t, playerAchievements, 1, "Expected the last achievement")
+		require.Equal(t, "Achievement 3", playerAchievements[0].Achievement.Name)
+		require.True(t, unlockedAt.Add(3*time.Hour).Equal(playerAchievements[0].UnlockedAt), "Unlock dates do not match")
 	})
 
-	t.Run("GetPlayerWithAchievements_NotFound", func(t *testing.T) {
-		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{}, &models.Achievement{})
+	t.Run("GetPlayerAchievements_NotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.User{}, &models.Achievement{}, &models.PlayerProfileAchievement{})
 		defer func() {
 			sqlDB, _ := db.DB()
 			err := sqlDB.Close()
@@ -480,10 +577,192 @@ func TestPlayerProfileRepository_GetPlayerWithAchievements(t *testing.T) {
 		}()
 		playerRepo := NewPlayerProfileRepositoryImpl(db)
 
-		// Get player with achievements
-		player, err := playerRepo.GetPlayerWithAchievements(1)
-		require.Error(t, err, "Expected error getting player with achievements")
-		require.Nil(t, player, "Player is not nil")
+		// Get player achievements
+		playerAchievements, err := playerRepo.GetPlayerAchievements(1, 0, 10, "desc")
+		require.Error(t, err, "Expected error getting player achievements")
+		require.Nil(t, playerAchievements, "Player achievements are not nil")
 		require.EqualError(t, err, helpers.ErrorPlayerProfileNotFound.Error(), "Error messages do not match")
 	})
 }
+
+func TestPlayerProfileRespositoryImpl_GetAllPlayerProfiles_ListQuery(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	playerRepo := NewPlayerProfileRepositoryImpl(db)
+
+	players := []models.PlayerProfile{
+		{Nickname: "NoobMaster", Avatar: "avatar.png", Level: 12, Points: 100, UserID: 1},
+		{Nickname: "noob_slayer", Avatar: "avatar.png", Level: 3, Points: 300, UserID: 1},
+		{Nickname: "noobXslayer", Avatar: "avatar.png", Level: 20, Points: 300, UserID: 2},
+		{Nickname: "pro", Avatar: "avatar.png", Level: 40, Points: 900, UserID: 2},
+	}
+	require.NoError(t, db.Create(&players).Error, "Error creating players")
+
+	t.Run("Prefix_CaseInsensitive", func(t *testing.T) {
+		query := models.ListQuery{Filters: []models.Filter{{Field: "nickname", Operator: models.FilterPrefix, Value: "NOOB"}}}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, query, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 3)
+	})
+
+	t.Run("Prefix_EscapesWildcards", func(t *testing.T) {
+		query := models.ListQuery{Filters: []models.Filter{{Field: "nickname", Operator: models.FilterPrefix, Value: "noob_"}}}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, query, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 1)
+		require.Equal(t, "noob_slayer", result[0].Nickname)
+	})
+
+	t.Run("Compare_AndSort", func(t *testing.T) {
+		query := models.ListQuery{
+			Filters: []models.Filter{{Field: "level", Operator: models.FilterGreaterOrEqual, Value: "10"}},
+			Sort:    []models.SortField{{Field: "points", Descending: true}},
+		}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, query, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 3)
+		require.Equal(t, players[3].ID, result[0].ID)
+		require.Equal(t, players[2].ID, result[1].ID)
+		require.Equal(t, players[0].ID, result[2].ID)
+	})
+
+	t.Run("Sort_TiesByID", func(t *testing.T) {
+		query := models.ListQuery{
+			Filters: []models.Filter{{Field: "points", Operator: models.FilterEqual, Value: "300"}},
+			Sort:    []models.SortField{{Field: "points", Descending: true}},
+		}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, query, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 2)
+		require.Equal(t, players[1].ID, result[0].ID)
+		require.Equal(t, players[2].ID, result[1].ID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		queries := map[string]models.ListQuery{
+			"UnknownField":    {Filters: []models.Filter{{Field: "avatar", Operator: models.FilterEqual, Value: "avatar.png"}}},
+			"NotSearchable":   {Filters: []models.Filter{{Field: "level", Operator: models.FilterPrefix, Value: "1"}}},
+			"NotNumber":       {Filters: []models.Filter{{Field: "level", Operator: models.FilterGreater, Value: "ten"}}},
+			"NotComparable":   {Filters: []models.Filter{{Field: "nickname", Operator: models.FilterGreater, Value: "a"}}},
+			"UnknownSort":     {Sort: []models.SortField{{Field: "deleted_at"}}},
+			"UnknownOperator": {Filters: []models.Filter{{Field: "level", Operator: "=~", Value: "1"}}},
+		}
+
+		for name, query := range queries {
+			t.Run(name, func(t *testing.T) {
+				result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, query, 0)
+				require.ErrorIs(t, err, helpers.ErrInvalidListQuery)
+				require.Nil(t, result)
+			})
+		}
+	})
+}
+
+func TestPlayerProfileRespositoryImpl_GetAllPlayerProfiles_Cursor(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	playerRepo := NewPlayerProfileRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 10, 20, 30, 40, 50)
+
+	t.Run("After", func(t *testing.T) {
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 2, AfterID: players[1].ID}, models.ListQuery{}, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 2)
+		require.Equal(t, players[2].ID, result[0].ID)
+		require.Equal(t, players[3].ID, result[1].ID)
+	})
+
+	t.Run("Before_InOrder", func(t *testing.T) {
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 2, BeforeID: players[4].ID}, models.ListQuery{}, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 2)
+		require.Equal(t, players[2].ID, result[0].ID)
+		require.Equal(t, players[3].ID, result[1].ID)
+	})
+
+	t.Run("After_Filtered", func(t *testing.T) {
+		query := models.ListQuery{Filters: []models.Filter{{Field: "points", Operator: models.FilterGreater, Value: "30"}}}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10, AfterID: players[0].ID}, query, 0)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, 2)
+		require.Equal(t, players[3].ID, result[0].ID)
+	})
+
+	t.Run("Cursor_WithSort", func(t *testing.T) {
+		query := models.ListQuery{Sort: []models.SortField{{Field: "points"}}}
+
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 2, AfterID: players[1].ID}, query, 0)
+		require.ErrorIs(t, err, helpers.ErrInvalidListQuery)
+		require.Nil(t, result)
+	})
+
+	t.Run("Count_Filtered", func(t *testing.T) {
+		query := models.ListQuery{Filters: []models.Filter{{Field: "points", Operator: models.FilterGreaterOrEqual, Value: "30"}}}
+
+		count, err := playerRepo.CountPlayerProfiles(query, 0)
+		require.NoError(t, err, "Error counting player profiles")
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("Count_InvalidQuery", func(t *testing.T) {
+		query := models.ListQuery{Filters: []models.Filter{{Field: "avatar", Operator: models.FilterEqual, Value: "avatar.png"}}}
+
+		_, err := playerRepo.CountPlayerProfiles(query, 0)
+		require.ErrorIs(t, err, helpers.ErrInvalidListQuery)
+	})
+}
+
+func TestPlayerProfileRespositoryImpl_GetAllPlayerProfiles_HidesBlockers(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.PlayerBlock{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	playerRepo := NewPlayerProfileRepositoryImpl(db)
+
+	players := []models.PlayerProfile{
+		{Nickname: "victim", Avatar: "avatar.png", Level: 1, UserID: 1},
+		{Nickname: "blocker", Avatar: "avatar.png", Level: 1, UserID: 2},
+		{Nickname: "bystander", Avatar: "avatar.png", Level: 1, UserID: 3},
+	}
+	require.NoError(t, db.Create(&players).Error, "Error creating players")
+	require.NoError(t, db.Create(&models.PlayerBlock{BlockerID: players[1].ID, BlockedID: players[0].ID}).Error, "Error creating block")
+
+	visible := map[uint]int{1: 2, 2: 3, 3: 3, 0: 3}
+	for viewerUserID, expected := range visible {
+		result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, models.ListQuery{}, viewerUserID)
+		require.NoError(t, err, "Error getting player profiles")
+		require.Len(t, result, expected, "Players visible to user %d", viewerUserID)
+
+		count, err := playerRepo.CountPlayerProfiles(models.ListQuery{}, viewerUserID)
+		require.NoError(t, err, "Error counting player profiles")
+		require.Equal(t, int64(expected), count, "Players counted for user %d", viewerUserID)
+	}
+
+	result, err := playerRepo.GetAllPlayerProfiles(models.Page{Limit: 10}, models.ListQuery{}, 1)
+	require.NoError(t, err, "Error getting player profiles")
+	for _, player := range result {
+		require.NotEqual(t, players[1].ID, player.ID, "The blocker should be hidden")
+	}
+}
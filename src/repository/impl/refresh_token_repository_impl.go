@@ -0,0 +1,92 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateRefreshToken implements repository.RefreshTokenRepository.
+func (rt *RefreshTokenRepositoryImpl) CreateRefreshToken(refreshToken *models.RefreshToken) error {
+	result := rt.Db.Create(refreshToken)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RefreshTokenRepositoryImpl.CreateRefreshToken] Failed to create refresh token")
+		return result.Error
+	}
+
+	return nil
+}
+
+// FindByTokenHash implements repository.RefreshTokenRepository.
+func (rt *RefreshTokenRepositoryImpl) FindByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+
+	result := rt.Db.Where(TokenHashPlaceHolder, tokenHash).First(&refreshToken)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorRefreshTokenNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RefreshTokenRepositoryImpl.FindByTokenHash] Failed to find refresh token")
+		return nil, result.Error
+	}
+
+	return &refreshToken, nil
+}
+
+// MarkAsUsed implements repository.RefreshTokenRepository.
+// The update only matches unused tokens, so when two requests race with the
+// same token only one of them gets true.
+func (rt *RefreshTokenRepositoryImpl) MarkAsUsed(refreshTokenID uint) (bool, error) {
+	result := rt.Db.Model(&models.RefreshToken{}).
+		Where(IDPlaceHolder, refreshTokenID).
+		Where("used_at IS NULL").
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RefreshTokenRepositoryImpl.MarkAsUsed] Failed to mark refresh token as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeFamily implements repository.RefreshTokenRepository.
+func (rt *RefreshTokenRepositoryImpl) RevokeFamily(familyID string) error {
+	result := rt.Db.Model(&models.RefreshToken{}).
+		Where(FamilyIDPlaceHolder, familyID).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RefreshTokenRepositoryImpl.RevokeFamily] Failed to revoke refresh token family")
+		return result.Error
+	}
+
+	return nil
+}
+
+// RevokeAllForUser implements repository.RefreshTokenRepository.
+func (rt *RefreshTokenRepositoryImpl) RevokeAllForUser(userID uint) error {
+	result := rt.Db.Model(&models.RefreshToken{}).
+		Where(UserIDPlaceHolder, userID).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RefreshTokenRepositoryImpl.RevokeAllForUser] Failed to revoke user refresh tokens")
+		return result.Error
+	}
+
+	return nil
+}
+
+func NewRefreshTokenRepositoryImpl(db *gorm.DB) r.RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{Db: db}
+}
@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepositoryImpl_FindByTokenHash(t *testing.T) {
+	t.Run("FindByTokenHash_Success", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.RefreshToken{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+		repo := NewRefreshTokenRepositoryImpl(db)
+
+		refreshToken := &models.RefreshToken{
+			UserID:    1,
+			TokenHash: helpers.HashToken("token"),
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		require.NoError(t, repo.CreateRefreshToken(refreshToken), "Error creating refresh token")
+
+		found, err := repo.FindByTokenHash(helpers.HashToken("token"))
+		require.NoError(t, err, "Error finding refresh token")
+		require.Equal(t, refreshToken.ID, found.ID, "Refresh token IDs do not match")
+		require.Nil(t, found.UsedAt, "Expected refresh token to be unused")
+	})
+
+	t.Run("FindByTokenHash_NotFound", func(t *testing.T) {
+		db := testutils.SetupTestDB(&models.RefreshToken{})
+		defer func() {
+			sqlDB, _ := db.DB()
+			err := sqlDB.Close()
+			if err != nil {
+				t.Errorf("Error closing database connection: %v", err)
+			}
+		}()
+		repo := NewRefreshTokenRepositoryImpl(db)
+
+		found, err := repo.FindByTokenHash(helpers.HashToken("unknown"))
+		require.Nil(t, found, "Expected no refresh token")
+		require.Equal(t, helpers.ErrorRefreshTokenNotFound, err, "Expected refresh token not found error")
+	})
+}
+
+func TestRefreshTokenRepositoryImpl_MarkAsUsed(t *testing.T) {
+	db := testutils.SetupTestDB(&models.RefreshToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRefreshTokenRepositoryImpl(db)
+
+	refreshToken := &models.RefreshToken{
+		UserID:    1,
+		TokenHash: helpers.HashToken("token"),
+		FamilyID:  "family",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, repo.CreateRefreshToken(refreshToken), "Error creating refresh token")
+
+	// Only the first call marks the token
+	marked, err := repo.MarkAsUsed(refreshToken.ID)
+	require.NoError(t, err, "Error marking refresh token as used")
+	require.True(t, marked, "Expected refresh token to be marked")
+
+	marked, err = repo.MarkAsUsed(refreshToken.ID)
+	require.NoError(t, err, "Error marking refresh token as used")
+	require.False(t, marked, "Expected used refresh token not to be marked again")
+}
+
+func TestRefreshTokenRepositoryImpl_RevokeFamily(t *testing.T) {
+	db := testutils.SetupTestDB(&models.RefreshToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRefreshTokenRepositoryImpl(db)
+
+	for _, refreshToken := range []*models.RefreshToken{
+		{UserID: 1, TokenHash: helpers.HashToken("first"), FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: 1, TokenHash: helpers.HashToken("second"), FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: 1, TokenHash: helpers.HashToken("other"), FamilyID: "other-family", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		require.NoError(t, repo.CreateRefreshToken(refreshToken), "Error creating refresh token")
+	}
+
+	require.NoError(t, repo.RevokeFamily("family"), "Error revoking refresh token family")
+
+	for _, token := range []string{"first", "second"} {
+		found, err := repo.FindByTokenHash(helpers.HashToken(token))
+		require.NoError(t, err, "Error finding refresh token")
+		require.NotNil(t, found.RevokedAt, "Expected refresh token to be revoked")
+	}
+
+	found, err := repo.FindByTokenHash(helpers.HashToken("other"))
+	require.NoError(t, err, "Error finding refresh token")
+	require.Nil(t, found.RevokedAt, "Expected refresh token of other family not to be revoked")
+}
@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateRevokedToken implements repository.RevokedTokenRepository.
+// Revoking an already revoked token is a no-op.
+func (rt *RevokedTokenRepositoryImpl) CreateRevokedToken(revokedToken *models.RevokedToken) error {
+	result := rt.Db.Clauses(clause.OnConflict{DoNothing: true}).Create(revokedToken)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RevokedTokenRepositoryImpl.CreateRevokedToken] Failed to create revoked token")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetActiveRevokedTokens implements repository.RevokedTokenRepository.
+func (rt *RevokedTokenRepositoryImpl) GetActiveRevokedTokens(now time.Time) ([]models.RevokedToken, error) {
+	var revokedTokens []models.RevokedToken
+
+	result := rt.Db.Where(ExpiresAtAfterPlaceHolder, now).Find(&revokedTokens)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RevokedTokenRepositoryImpl.GetActiveRevokedTokens] Failed to get revoked tokens")
+		return nil, result.Error
+	}
+
+	return revokedTokens, nil
+}
+
+// DeleteExpiredRevokedTokens implements repository.RevokedTokenRepository.
+func (rt *RevokedTokenRepositoryImpl) DeleteExpiredRevokedTokens(now time.Time) error {
+	result := rt.Db.Where(ExpiresAtBeforePlaceHolder, now).Delete(&models.RevokedToken{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RevokedTokenRepositoryImpl.DeleteExpiredRevokedTokens] Failed to delete expired revoked tokens")
+		return result.Error
+	}
+
+	return nil
+}
+
+// SaveUserRevocation implements repository.RevokedTokenRepository.
+func (rt *RevokedTokenRepositoryImpl) SaveUserRevocation(userRevocation *models.UserRevocation) error {
+	result := rt.Db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_at"}),
+	}).Create(userRevocation)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RevokedTokenRepositoryImpl.SaveUserRevocation] Failed to save user revocation")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetAllUserRevocations implements repository.RevokedTokenRepository.
+func (rt *RevokedTokenRepositoryImpl) GetAllUserRevocations() ([]models.UserRevocation, error) {
+	var userRevocations []models.UserRevocation
+
+	result := rt.Db.Find(&userRevocations)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RevokedTokenRepositoryImpl.GetAllUserRevocations] Failed to get user revocations")
+		return nil, result.Error
+	}
+
+	return userRevocations, nil
+}
+
+func NewRevokedTokenRepositoryImpl(db *gorm.DB) r.RevokedTokenRepository {
+	return &RevokedTokenRepositoryImpl{Db: db}
+}
@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedTokenRepositoryImpl_RevokedTokens(t *testing.T) {
+	db := testutils.SetupTestDB(&models.RevokedToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRevokedTokenRepositoryImpl(db)
+
+	now := time.Now()
+	require.NoError(t, repo.CreateRevokedToken(&models.RevokedToken{JTI: "active", UserID: 1, ExpiresAt: now.Add(time.Hour)}), "Error creating revoked token")
+	require.NoError(t, repo.CreateRevokedToken(&models.RevokedToken{JTI: "expired", UserID: 1, ExpiresAt: now.Add(-time.Hour)}), "Error creating revoked token")
+
+	// Revoking the same token twice is not an error
+	require.NoError(t, repo.CreateRevokedToken(&models.RevokedToken{JTI: "active", UserID: 1, ExpiresAt: now.Add(time.Hour)}), "Error creating duplicated revoked token")
+
+	revokedTokens, err := repo.GetActiveRevokedTokens(now)
+	require.NoError(t, err, "Error getting active revoked tokens")
+	require.Len(t, revokedTokens, 1, "Expected only the active revoked token")
+	require.Equal(t, "active", revokedTokens[0].JTI, "Revoked token IDs do not match")
+
+	require.NoError(t, repo.DeleteExpiredRevokedTokens(now), "Error deleting expired revoked tokens")
+
+	var count int64
+	require.NoError(t, db.Model(&models.RevokedToken{}).Count(&count).Error, "Error counting revoked tokens")
+	require.Equal(t, int64(1), count, "Expected expired revoked token to be deleted")
+}
+
+func TestRevokedTokenRepositoryImpl_UserRevocations(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserRevocation{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRevokedTokenRepositoryImpl(db)
+
+	firstRevocation := time.Now().Add(-time.Hour).Truncate(time.Second)
+	secondRevocation := time.Now().Truncate(time.Second)
+
+	require.NoError(t, repo.SaveUserRevocation(&models.UserRevocation{UserID: 1, RevokedAt: firstRevocation}), "Error saving user revocation")
+	require.NoError(t, repo.SaveUserRevocation(&models.UserRevocation{UserID: 1, RevokedAt: secondRevocation}), "Error updating user revocation")
+
+	userRevocations, err := repo.GetAllUserRevocations()
+	require.NoError(t, err, "Error getting user revocations")
+	require.Len(t, userRevocations, 1, "Expected one revocation per user")
+	require.True(t, secondRevocation.Equal(userRevocations[0].RevokedAt), "Expected the latest revocation date")
+}
@@ -0,0 +1,141 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RoleRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateRole implements repository.RoleRepository.
+// The permissions of the role are created with it.
+func (ro *RoleRepositoryImpl) CreateRole(role *models.Role) error {
+	result := ro.Db.Create(role)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RoleRepositoryImpl.CreateRole] Failed to create role")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetRole implements repository.RoleRepository.
+func (ro *RoleRepositoryImpl) GetRole(roleID uint) (*models.Role, error) {
+	var role models.Role
+
+	result := ro.Db.Preload("Permissions").Where(IDPlaceHolder, roleID).First(&role)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorRoleNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RoleRepositoryImpl.GetRole] Failed to get role")
+		return nil, result.Error
+	}
+
+	return &role, nil
+}
+
+// FindByName implements repository.RoleRepository.
+func (ro *RoleRepositoryImpl) FindByName(name string) (*models.Role, error) {
+	var role models.Role
+
+	result := ro.Db.Preload("Permissions").Where(NamePlaceHolder, name).First(&role)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorRoleNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RoleRepositoryImpl.FindByName] Failed to find role")
+		return nil, result.Error
+	}
+
+	return &role, nil
+}
+
+// GetAllRoles implements repository.RoleRepository.
+func (ro *RoleRepositoryImpl) GetAllRoles() ([]models.Role, error) {
+	var roles []models.Role
+
+	result := ro.Db.Preload("Permissions").Order("name").Find(&roles)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[RoleRepositoryImpl.GetAllRoles] Failed to get roles")
+		return nil, result.Error
+	}
+
+	return roles, nil
+}
+
+// UpdateRole implements repository.RoleRepository.
+// The description is updated and the permissions are replaced by the ones of
+// the role, in a single transaction.
+func (ro *RoleRepositoryImpl) UpdateRole(role *models.Role) error {
+	err := ro.Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Role{}).Where(IDPlaceHolder, role.ID).Update("description", role.Description)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return helpers.ErrorRoleNotFound
+		}
+
+		err := tx.Where(RoleIDPlaceHolder, role.ID).Delete(&models.RolePermission{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+
+		for i := range role.Permissions {
+			role.Permissions[i].RoleID = role.ID
+		}
+
+		return tx.Create(&role.Permissions).Error
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorRoleNotFound) {
+		logrus.WithError(err).Error("[RoleRepositoryImpl.UpdateRole] Failed to update role")
+	}
+
+	return err
+}
+
+// DeleteRole implements repository.RoleRepository.
+// The role is removed for good so its name can be used again.
+func (ro *RoleRepositoryImpl) DeleteRole(roleID uint) error {
+	err := ro.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where(RoleIDPlaceHolder, roleID).Delete(&models.RolePermission{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where(IDPlaceHolder, roleID).Delete(&models.Role{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return helpers.ErrorRoleNotFound
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorRoleNotFound) {
+		logrus.WithError(err).Error("[RoleRepositoryImpl.DeleteRole] Failed to delete role")
+	}
+
+	return err
+}
+
+func NewRoleRepositoryImpl(db *gorm.DB) r.RoleRepository {
+	return &RoleRepositoryImpl{Db: db}
+}
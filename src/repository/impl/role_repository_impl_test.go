@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleRepositoryImpl_CreateAndFind(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Role{}, &models.RolePermission{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRoleRepositoryImpl(db)
+
+	role := &models.Role{
+		Name:        "moderator",
+		Description: "Community moderator",
+		Permissions: []models.RolePermission{{Permission: models.PermissionPlayersModerate}, {Permission: models.PermissionUsersRead}},
+	}
+	require.NoError(t, repo.CreateRole(role), "Error creating role")
+	require.NoError(t, repo.CreateRole(&models.Role{Name: "user"}), "Error creating role")
+
+	found, err := repo.FindByName("moderator")
+	require.NoError(t, err, "Error finding role")
+	require.ElementsMatch(t, []string{models.PermissionPlayersModerate, models.PermissionUsersRead}, found.PermissionNames())
+
+	_, err = repo.FindByName("owner")
+	require.Equal(t, helpers.ErrorRoleNotFound, err, "Expected role not found error")
+
+	// Role names are unique
+	require.Error(t, repo.CreateRole(&models.Role{Name: "moderator"}), "Expected duplicated role error")
+
+	roles, err := repo.GetAllRoles()
+	require.NoError(t, err, "Error getting roles")
+	require.Len(t, roles, 2)
+	require.Equal(t, "moderator", roles[0].Name)
+	require.Len(t, roles[0].Permissions, 2)
+}
+
+func TestRoleRepositoryImpl_UpdateAndDelete(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Role{}, &models.RolePermission{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewRoleRepositoryImpl(db)
+
+	role := &models.Role{Name: "moderator", Permissions: []models.RolePermission{{Permission: models.PermissionPlayersModerate}}}
+	require.NoError(t, repo.CreateRole(role), "Error creating role")
+
+	// The permissions are replaced
+	err := repo.UpdateRole(&models.Role{
+		Model:       role.Model,
+		Description: "Moderates achievements",
+		Permissions: []models.RolePermission{{Permission: models.PermissionAchievementsWrite}},
+	})
+	require.NoError(t, err, "Error updating role")
+
+	updated, err := repo.GetRole(role.ID)
+	require.NoError(t, err, "Error getting role")
+	require.Equal(t, "Moderates achievements", updated.Description)
+	require.Equal(t, []string{models.PermissionAchievementsWrite}, updated.PermissionNames())
+
+	// A role can lose every permission
+	updated.Permissions = nil
+	require.NoError(t, repo.UpdateRole(updated), "Error updating role")
+	updated, err = repo.GetRole(role.ID)
+	require.NoError(t, err, "Error getting role")
+	require.Empty(t, updated.Permissions)
+
+	require.NoError(t, repo.DeleteRole(role.ID), "Error deleting role")
+	_, err = repo.GetRole(role.ID)
+	require.Equal(t, helpers.ErrorRoleNotFound, err, "Expected role not found error")
+	require.Equal(t, helpers.ErrorRoleNotFound, repo.DeleteRole(role.ID))
+
+	var permissionCount int64
+	db.Model(&models.RolePermission{}).Count(&permissionCount)
+	require.Zero(t, permissionCount, "Expected the permissions to be deleted with the role")
+
+	// The name is free again
+	require.NoError(t, repo.CreateRole(&models.Role{Name: "moderator"}), "Error creating role again")
+}
@@ -0,0 +1,182 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// standingsBatchSize is how many players are archived per insert when a season ends.
+const standingsBatchSize = 500
+
+type SeasonRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// GetCurrentSeason implements repository.SeasonRepository.
+func (s *SeasonRepositoryImpl) GetCurrentSeason() (*models.Season, error) {
+	var season models.Season
+
+	result := s.Db.Where("ended_at IS NULL").Order("started_at DESC").First(&season)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorSeasonNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SeasonRepositoryImpl.GetCurrentSeason] Failed to get current season")
+		return nil, result.Error
+	}
+
+	return &season, nil
+}
+
+// GetSeason implements repository.SeasonRepository.
+func (s *SeasonRepositoryImpl) GetSeason(seasonID uint) (*models.Season, error) {
+	var season models.Season
+
+	result := s.Db.Where(IDPlaceHolder, seasonID).First(&season)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorSeasonNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SeasonRepositoryImpl.GetSeason] Failed to get season")
+		return nil, result.Error
+	}
+
+	return &season, nil
+}
+
+// GetAllSeasons implements repository.SeasonRepository.
+func (s *SeasonRepositoryImpl) GetAllSeasons() ([]models.Season, error) {
+	var seasons []models.Season
+
+	result := s.Db.Order("started_at DESC").Find(&seasons)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SeasonRepositoryImpl.GetAllSeasons] Failed to get seasons")
+		return nil, result.Error
+	}
+
+	return seasons, nil
+}
+
+// StartSeason implements repository.SeasonRepository.
+// Ending the running season locks its row, so two requests can't archive it twice.
+func (s *SeasonRepositoryImpl) StartSeason(season *models.Season) error {
+	err := s.Db.Transaction(func(tx *gorm.DB) error {
+		var sameName int64
+
+		result := tx.Model(&models.Season{}).Where(NamePlaceHolder, season.Name).Count(&sameName)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if sameName > 0 {
+			return helpers.ErrorSeasonAlreadyExists
+		}
+
+		var currentSeasons []models.Season
+
+		result = tx.Where("ended_at IS NULL").Find(&currentSeasons)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, currentSeason := range currentSeasons {
+			result = tx.Model(&models.Season{}).
+				Where(IDPlaceHolder, currentSeason.ID).
+				Where("ended_at IS NULL").
+				Update("ended_at", season.StartedAt)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return helpers.ErrorSeasonAlreadyEnded
+			}
+
+			err := archiveStandings(tx, currentSeason.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Lifetime points are kept, only the season board starts over
+		result = tx.Model(&models.PlayerProfile{}).Where("season_points <> ?", 0).Update("season_points", 0)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Create(season).Error
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorSeasonAlreadyExists) && !errors.Is(err, helpers.ErrorSeasonAlreadyEnded) {
+		logrus.WithError(err).Error("[SeasonRepositoryImpl.StartSeason] Failed to start season")
+	}
+
+	return err
+}
+
+// archiveStandings copies the season points ranking of the players into the season,
+// with competitive ranks. The standings are inserted in rank order.
+func archiveStandings(tx *gorm.DB, seasonID uint) error {
+	position, rank, previousPoints := 0, 0, 0
+
+	for offset := 0; ; offset += standingsBatchSize {
+		var players []models.PlayerProfile
+
+		result := tx.Order("season_points DESC").Order("id").Offset(offset).Limit(standingsBatchSize).Find(&players)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(players) == 0 {
+			return nil
+		}
+
+		standings := make([]models.SeasonStanding, len(players))
+		for i, player := range players {
+			position++
+			if position == 1 || player.SeasonPoints != previousPoints {
+				rank = position
+			}
+			previousPoints = player.SeasonPoints
+
+			standings[i] = models.SeasonStanding{
+				SeasonID:        seasonID,
+				PlayerProfileID: player.ID,
+				Nickname:        player.Nickname,
+				Rank:            rank,
+				Points:          player.SeasonPoints,
+				Level:           player.Level,
+				Experience:      player.Experience,
+			}
+		}
+
+		result = tx.Create(&standings)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+}
+
+// GetStandings implements repository.SeasonRepository.
+func (s *SeasonRepositoryImpl) GetStandings(seasonID uint, offset int, pageSize int) ([]models.SeasonStanding, error) {
+	var standings []models.SeasonStanding
+
+	// Standings are inserted in rank order
+	result := s.Db.Where(SeasonIDPlaceHolder, seasonID).Order("id").Offset(offset).Limit(pageSize).Find(&standings)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SeasonRepositoryImpl.GetStandings] Failed to get standings")
+		return nil, result.Error
+	}
+
+	return standings, nil
+}
+
+func NewSeasonRepositoryImpl(db *gorm.DB) r.SeasonRepository {
+	return &SeasonRepositoryImpl{Db: db}
+}
@@ -0,0 +1,108 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonRepositoryImpl_StartSeason(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.Season{}, &models.SeasonStanding{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSeasonRepositoryImpl(db)
+
+	players := seedLeaderboard(t, db, 1000, 50, 50, 500)
+
+	// The first season has nothing to archive
+	first := &models.Season{Name: "Season 1", StartedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, repo.StartSeason(first), "Error starting season")
+
+	current, err := repo.GetCurrentSeason()
+	require.NoError(t, err, "Error getting current season")
+	require.Equal(t, first.ID, current.ID)
+
+	for i, seasonPoints := range []int{100, 300, 300, 0} {
+		require.NoError(t, db.Model(&models.PlayerProfile{}).Where(IDPlaceHolder, players[i].ID).Update("season_points", seasonPoints).Error)
+	}
+
+	second := &models.Season{Name: "Season 2", StartedAt: time.Now()}
+	require.NoError(t, repo.StartSeason(second), "Error starting season")
+
+	ended, err := repo.GetSeason(first.ID)
+	require.NoError(t, err, "Error getting season")
+	require.NotNil(t, ended.EndedAt, "Expected the first season to end")
+
+	standings, err := repo.GetStandings(first.ID, 0, 10)
+	require.NoError(t, err, "Error getting standings")
+	require.Len(t, standings, 4)
+	require.Equal(t, []int{1, 1, 3, 4}, []int{standings[0].Rank, standings[1].Rank, standings[2].Rank, standings[3].Rank}, "Expected competitive ranks")
+	require.Equal(t, players[1].ID, standings[0].PlayerProfileID)
+	require.Equal(t, 300, standings[0].Points)
+
+	var total int64
+	require.NoError(t, db.Model(&models.PlayerProfile{}).Where("season_points <> ?", 0).Count(&total).Error)
+	require.Zero(t, total, "Expected every player back to zero season points")
+
+	var points int
+	require.NoError(t, db.Model(&models.PlayerProfile{}).Where(IDPlaceHolder, players[0].ID).Pluck("points", &points).Error)
+	require.Equal(t, 1000, points, "Expected the lifetime points to be kept")
+
+	current, err = repo.GetCurrentSeason()
+	require.NoError(t, err, "Error getting current season")
+	require.Equal(t, second.ID, current.ID)
+
+	seasons, err := repo.GetAllSeasons()
+	require.NoError(t, err, "Error getting seasons")
+	require.Len(t, seasons, 2)
+	require.Equal(t, second.ID, seasons[0].ID, "Expected the latest season first")
+}
+
+func TestSeasonRepositoryImpl_StartSeason_AlreadyExists(t *testing.T) {
+	db := testutils.SetupTestDB(&models.PlayerProfile{}, &models.Season{}, &models.SeasonStanding{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSeasonRepositoryImpl(db)
+
+	require.NoError(t, repo.StartSeason(&models.Season{Name: "Season 1", StartedAt: time.Now()}), "Error starting season")
+
+	err := repo.StartSeason(&models.Season{Name: "Season 1", StartedAt: time.Now()})
+	require.Equal(t, helpers.ErrorSeasonAlreadyExists, err, "Expected season already exists error")
+
+	seasons, err := repo.GetAllSeasons()
+	require.NoError(t, err, "Error getting seasons")
+	require.Len(t, seasons, 1, "Expected the running season to be kept")
+	require.Nil(t, seasons[0].EndedAt)
+}
+
+func TestSeasonRepositoryImpl_GetSeason_NotFound(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Season{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSeasonRepositoryImpl(db)
+
+	_, err := repo.GetSeason(1)
+	require.Equal(t, helpers.ErrorSeasonNotFound, err, "Expected season not found error")
+
+	_, err = repo.GetCurrentSeason()
+	require.Equal(t, helpers.ErrorSeasonNotFound, err, "Expected season not found error")
+}
@@ -0,0 +1,138 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateSession implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) CreateSession(session *models.Session) error {
+	result := s.Db.Create(session)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.CreateSession] Failed to create session")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetSession implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) GetSession(sessionID uint) (*models.Session, error) {
+	var session models.Session
+
+	result := s.Db.Where(IDPlaceHolder, sessionID).First(&session)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorSessionNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.GetSession] Failed to get session")
+		return nil, result.Error
+	}
+
+	return &session, nil
+}
+
+// FindByFamilyID implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) FindByFamilyID(familyID string) (*models.Session, error) {
+	var session models.Session
+
+	result := s.Db.Where(FamilyIDPlaceHolder, familyID).First(&session)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorSessionNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.FindByFamilyID] Failed to find session")
+		return nil, result.Error
+	}
+
+	return &session, nil
+}
+
+// GetActiveSessions implements repository.SessionRepository.
+// Sessions not seen since seenSince have an expired refresh token and are left out.
+func (s *SessionRepositoryImpl) GetActiveSessions(userID uint, seenSince time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+
+	result := s.Db.Where(UserIDPlaceHolder, userID).
+		Where("ended_at IS NULL").
+		Where(LastSeenAtAfterPlaceHolder, seenSince).
+		Order("last_seen_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.GetActiveSessions] Failed to get sessions")
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+// UpdateLastSeen implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) UpdateLastSeen(sessionID uint, ip string, lastSeenAt time.Time) error {
+	result := s.Db.Model(&models.Session{}).
+		Where(IDPlaceHolder, sessionID).
+		Updates(map[string]interface{}{"ip": ip, "last_seen_at": lastSeenAt})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.UpdateLastSeen] Failed to update session")
+		return result.Error
+	}
+
+	return nil
+}
+
+// EndSession implements repository.SessionRepository.
+// It returns false when the session had already ended.
+func (s *SessionRepositoryImpl) EndSession(sessionID uint, endedAt time.Time) (bool, error) {
+	result := s.Db.Model(&models.Session{}).
+		Where(IDPlaceHolder, sessionID).
+		Where("ended_at IS NULL").
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.EndSession] Failed to end session")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// EndAllForUser implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) EndAllForUser(userID uint, endedAt time.Time) error {
+	result := s.Db.Model(&models.Session{}).
+		Where(UserIDPlaceHolder, userID).
+		Where("ended_at IS NULL").
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.EndAllForUser] Failed to end sessions")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetEndedSessions implements repository.SessionRepository.
+func (s *SessionRepositoryImpl) GetEndedSessions(endedSince time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+
+	result := s.Db.Select("id", "ended_at").Where(EndedAtAfterPlaceHolder, endedSince).Find(&sessions)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[SessionRepositoryImpl.GetEndedSessions] Failed to get ended sessions")
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+func NewSessionRepositoryImpl(db *gorm.DB) r.SessionRepository {
+	return &SessionRepositoryImpl{Db: db}
+}
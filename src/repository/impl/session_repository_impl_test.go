@@ -0,0 +1,100 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRepositoryImpl_FindByFamilyID(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Session{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSessionRepositoryImpl(db)
+
+	session := &models.Session{UserID: 1, FamilyID: "family", DeviceName: "PC", LastSeenAt: time.Now()}
+	require.NoError(t, repo.CreateSession(session), "Error creating session")
+
+	found, err := repo.FindByFamilyID("family")
+	require.NoError(t, err, "Error finding session")
+	require.Equal(t, session.ID, found.ID, "Session IDs do not match")
+
+	_, err = repo.FindByFamilyID("unknown")
+	require.Equal(t, helpers.ErrorSessionNotFound, err, "Expected session not found error")
+}
+
+func TestSessionRepositoryImpl_GetActiveSessions(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Session{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSessionRepositoryImpl(db)
+
+	now := time.Now()
+	console := &models.Session{UserID: 1, FamilyID: "console", LastSeenAt: now.Add(-time.Hour)}
+	pc := &models.Session{UserID: 1, FamilyID: "pc", LastSeenAt: now}
+	ended := &models.Session{UserID: 1, FamilyID: "ended", LastSeenAt: now}
+	stale := &models.Session{UserID: 1, FamilyID: "stale", LastSeenAt: now.Add(-48 * time.Hour)}
+	other := &models.Session{UserID: 2, FamilyID: "other", LastSeenAt: now}
+	for _, session := range []*models.Session{console, pc, ended, stale, other} {
+		require.NoError(t, repo.CreateSession(session), "Error creating session")
+	}
+
+	endedNow, err := repo.EndSession(ended.ID, now)
+	require.NoError(t, err, "Error ending session")
+	require.True(t, endedNow, "Expected the session to end")
+
+	endedAgain, err := repo.EndSession(ended.ID, now)
+	require.NoError(t, err, "Error ending session")
+	require.False(t, endedAgain, "Expected the session to be already ended")
+
+	sessions, err := repo.GetActiveSessions(1, now.Add(-24*time.Hour))
+	require.NoError(t, err, "Error getting sessions")
+	require.Len(t, sessions, 2, "Expected the ended, stale and other user sessions to be left out")
+	require.Equal(t, pc.ID, sessions[0].ID, "Expected the last seen session first")
+
+	endedSessions, err := repo.GetEndedSessions(now.Add(-time.Minute))
+	require.NoError(t, err, "Error getting ended sessions")
+	require.Len(t, endedSessions, 1)
+	require.Equal(t, ended.ID, endedSessions[0].ID)
+}
+
+func TestSessionRepositoryImpl_EndAllForUser(t *testing.T) {
+	db := testutils.SetupTestDB(&models.Session{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewSessionRepositoryImpl(db)
+
+	now := time.Now()
+	require.NoError(t, repo.CreateSession(&models.Session{UserID: 1, FamilyID: "pc", LastSeenAt: now}))
+	require.NoError(t, repo.CreateSession(&models.Session{UserID: 1, FamilyID: "console", LastSeenAt: now}))
+	require.NoError(t, repo.CreateSession(&models.Session{UserID: 2, FamilyID: "other", LastSeenAt: now}))
+
+	require.NoError(t, repo.EndAllForUser(1, now), "Error ending sessions")
+
+	sessions, err := repo.GetActiveSessions(1, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, sessions, "Expected every session of the user to end")
+
+	sessions, err = repo.GetActiveSessions(2, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, sessions, 1, "Expected the sessions of other users to stay")
+}
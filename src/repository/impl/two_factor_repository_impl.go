@@ -0,0 +1,149 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// SaveTwoFactor implements repository.TwoFactorRepository.
+// A new enrollment replaces the previous one of the user.
+func (tf *TwoFactorRepositoryImpl) SaveTwoFactor(twoFactor *models.TwoFactor) error {
+	result := tf.Db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_step", "updated_at"}),
+	}).Create(twoFactor)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[TwoFactorRepositoryImpl.SaveTwoFactor] Failed to save two factor")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetTwoFactor implements repository.TwoFactorRepository.
+func (tf *TwoFactorRepositoryImpl) GetTwoFactor(userID uint) (*models.TwoFactor, error) {
+	var twoFactor models.TwoFactor
+
+	result := tf.Db.Where(UserIDPlaceHolder, userID).First(&twoFactor)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorTwoFactorNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[TwoFactorRepositoryImpl.GetTwoFactor] Failed to get two factor")
+		return nil, result.Error
+	}
+
+	return &twoFactor, nil
+}
+
+// ConfirmTwoFactor implements repository.TwoFactorRepository.
+func (tf *TwoFactorRepositoryImpl) ConfirmTwoFactor(userID uint, confirmedAt time.Time) error {
+	result := tf.Db.Model(&models.TwoFactor{}).Where(UserIDPlaceHolder, userID).Update("confirmed_at", confirmedAt)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[TwoFactorRepositoryImpl.ConfirmTwoFactor] Failed to confirm two factor")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorTwoFactorNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsedStep implements repository.TwoFactorRepository.
+// It returns false when a code of the same or a later step was already used.
+func (tf *TwoFactorRepositoryImpl) UpdateLastUsedStep(userID uint, step int64) (bool, error) {
+	result := tf.Db.Model(&models.TwoFactor{}).
+		Where(UserIDPlaceHolder, userID).
+		Where("last_used_step < ?", step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[TwoFactorRepositoryImpl.UpdateLastUsedStep] Failed to update last used step")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteTwoFactor implements repository.TwoFactorRepository.
+// The recovery codes of the user are deleted too.
+func (tf *TwoFactorRepositoryImpl) DeleteTwoFactor(userID uint) error {
+	err := tf.Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(UserIDPlaceHolder, userID).Delete(&models.RecoveryCode{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where(UserIDPlaceHolder, userID).Delete(&models.TwoFactor{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return helpers.ErrorTwoFactorNotFound
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, helpers.ErrorTwoFactorNotFound) {
+		logrus.WithError(err).Error("[TwoFactorRepositoryImpl.DeleteTwoFactor] Failed to delete two factor")
+	}
+
+	return err
+}
+
+// ReplaceRecoveryCodes implements repository.TwoFactorRepository.
+func (tf *TwoFactorRepositoryImpl) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	err := tf.Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(UserIDPlaceHolder, userID).Delete(&models.RecoveryCode{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		recoveryCodes := make([]models.RecoveryCode, len(codeHashes))
+		for i, codeHash := range codeHashes {
+			recoveryCodes[i] = models.RecoveryCode{UserID: userID, CodeHash: codeHash}
+		}
+
+		return tx.Create(&recoveryCodes).Error
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorRepositoryImpl.ReplaceRecoveryCodes] Failed to replace recovery codes")
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode implements repository.TwoFactorRepository.
+// Only one of two concurrent requests using the same code gets true.
+func (tf *TwoFactorRepositoryImpl) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := tf.Db.Model(&models.RecoveryCode{}).
+		Where(UserIDPlaceHolder, userID).
+		Where(CodeHashPlaceHolder, codeHash).
+		Where("used_at IS NULL").
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[TwoFactorRepositoryImpl.UseRecoveryCode] Failed to use recovery code")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func NewTwoFactorRepositoryImpl(db *gorm.DB) r.TwoFactorRepository {
+	return &TwoFactorRepositoryImpl{Db: db}
+}
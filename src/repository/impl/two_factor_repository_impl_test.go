@@ -0,0 +1,108 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorRepositoryImpl_SaveAndConfirm(t *testing.T) {
+	db := testutils.SetupTestDB(&models.TwoFactor{}, &models.RecoveryCode{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewTwoFactorRepositoryImpl(db)
+
+	_, err := repo.GetTwoFactor(1)
+	require.Equal(t, helpers.ErrorTwoFactorNotFound, err, "Expected two factor not found error")
+
+	require.NoError(t, repo.SaveTwoFactor(&models.TwoFactor{UserID: 1, Secret: "FIRST"}), "Error saving two factor")
+	// A new enrollment replaces the pending one
+	require.NoError(t, repo.SaveTwoFactor(&models.TwoFactor{UserID: 1, Secret: "SECOND"}), "Error saving two factor")
+
+	twoFactor, err := repo.GetTwoFactor(1)
+	require.NoError(t, err, "Error getting two factor")
+	require.Equal(t, "SECOND", twoFactor.Secret)
+	require.Nil(t, twoFactor.ConfirmedAt)
+
+	require.NoError(t, repo.ConfirmTwoFactor(1, time.Now()), "Error confirming two factor")
+	twoFactor, err = repo.GetTwoFactor(1)
+	require.NoError(t, err, "Error getting two factor")
+	require.NotNil(t, twoFactor.ConfirmedAt)
+
+	require.Equal(t, helpers.ErrorTwoFactorNotFound, repo.ConfirmTwoFactor(2, time.Now()))
+}
+
+func TestTwoFactorRepositoryImpl_UpdateLastUsedStep(t *testing.T) {
+	db := testutils.SetupTestDB(&models.TwoFactor{}, &models.RecoveryCode{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewTwoFactorRepositoryImpl(db)
+
+	require.NoError(t, repo.SaveTwoFactor(&models.TwoFactor{UserID: 1, Secret: "SECRET"}), "Error saving two factor")
+
+	updated, err := repo.UpdateLastUsedStep(1, 100)
+	require.NoError(t, err)
+	require.True(t, updated, "Expected the first use of the step to be accepted")
+
+	updated, err = repo.UpdateLastUsedStep(1, 100)
+	require.NoError(t, err)
+	require.False(t, updated, "Expected the step to be refused a second time")
+
+	updated, err = repo.UpdateLastUsedStep(1, 99)
+	require.NoError(t, err)
+	require.False(t, updated, "Expected an older step to be refused")
+}
+
+func TestTwoFactorRepositoryImpl_RecoveryCodes(t *testing.T) {
+	db := testutils.SetupTestDB(&models.TwoFactor{}, &models.RecoveryCode{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewTwoFactorRepositoryImpl(db)
+
+	require.NoError(t, repo.SaveTwoFactor(&models.TwoFactor{UserID: 1, Secret: "SECRET"}), "Error saving two factor")
+	require.NoError(t, repo.ReplaceRecoveryCodes(1, []string{"old"}), "Error storing recovery codes")
+	require.NoError(t, repo.ReplaceRecoveryCodes(1, []string{"first", "second"}), "Error replacing recovery codes")
+
+	used, err := repo.UseRecoveryCode(1, "old")
+	require.NoError(t, err)
+	require.False(t, used, "Expected replaced codes to stop working")
+
+	used, err = repo.UseRecoveryCode(1, "first")
+	require.NoError(t, err)
+	require.True(t, used, "Expected the code to be accepted")
+
+	used, err = repo.UseRecoveryCode(1, "first")
+	require.NoError(t, err)
+	require.False(t, used, "Expected the code to be single use")
+
+	used, err = repo.UseRecoveryCode(2, "second")
+	require.NoError(t, err)
+	require.False(t, used, "Expected codes of other users to be refused")
+
+	require.NoError(t, repo.DeleteTwoFactor(1), "Error deleting two factor")
+	_, err = repo.GetTwoFactor(1)
+	require.Equal(t, helpers.ErrorTwoFactorNotFound, err)
+
+	used, err = repo.UseRecoveryCode(1, "second")
+	require.NoError(t, err)
+	require.False(t, used, "Expected recovery codes to be deleted with the two factor")
+}
@@ -0,0 +1,95 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateUserIdentity implements repository.UserIdentityRepository.
+func (ui *UserIdentityRepositoryImpl) CreateUserIdentity(userIdentity *models.UserIdentity) error {
+	result := ui.Db.Create(userIdentity)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserIdentityRepositoryImpl.CreateUserIdentity] Failed to create user identity")
+		return result.Error
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity implements repository.UserIdentityRepository.
+func (ui *UserIdentityRepositoryImpl) CreateUserWithIdentity(user *models.User, userIdentity *models.UserIdentity) error {
+	err := ui.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(user).Error
+		if err != nil {
+			return err
+		}
+
+		userIdentity.UserID = user.ID
+		return tx.Create(userIdentity).Error
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[UserIdentityRepositoryImpl.CreateUserWithIdentity] Failed to create user with identity")
+		return err
+	}
+
+	return nil
+}
+
+// FindByProviderSubject implements repository.UserIdentityRepository.
+func (ui *UserIdentityRepositoryImpl) FindByProviderSubject(provider string, subject string) (*models.UserIdentity, error) {
+	var userIdentity models.UserIdentity
+
+	result := ui.Db.Where(ProviderAndSubjectPlaceHolder, provider, subject).First(&userIdentity)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorUserIdentityNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserIdentityRepositoryImpl.FindByProviderSubject] Failed to find user identity")
+		return nil, result.Error
+	}
+
+	return &userIdentity, nil
+}
+
+// GetUserIdentities implements repository.UserIdentityRepository.
+func (ui *UserIdentityRepositoryImpl) GetUserIdentities(userID uint) ([]models.UserIdentity, error) {
+	var userIdentities []models.UserIdentity
+
+	result := ui.Db.Where(UserIDPlaceHolder, userID).Order("provider").Find(&userIdentities)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserIdentityRepositoryImpl.GetUserIdentities] Failed to get user identities")
+		return nil, result.Error
+	}
+
+	return userIdentities, nil
+}
+
+// DeleteUserIdentity implements repository.UserIdentityRepository.
+// The row is removed for good so the identity can be linked again later.
+func (ui *UserIdentityRepositoryImpl) DeleteUserIdentity(userID uint, provider string) error {
+	result := ui.Db.Unscoped().Where(UserIDAndProviderPlaceHolder, userID, provider).Delete(&models.UserIdentity{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserIdentityRepositoryImpl.DeleteUserIdentity] Failed to delete user identity")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorUserIdentityNotFound
+	}
+
+	return nil
+}
+
+func NewUserIdentityRepositoryImpl(db *gorm.DB) r.UserIdentityRepository {
+	return &UserIdentityRepositoryImpl{Db: db}
+}
@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIdentityRepositoryImpl_FindByProviderSubject(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserIdentity{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserIdentityRepositoryImpl(db)
+
+	require.NoError(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 1, Provider: "google", Subject: "123", Email: "test@test.com"}), "Error creating user identity")
+
+	found, err := repo.FindByProviderSubject("google", "123")
+	require.NoError(t, err, "Error finding user identity")
+	require.Equal(t, uint(1), found.UserID)
+
+	// The same subject in another provider is another identity
+	_, err = repo.FindByProviderSubject("discord", "123")
+	require.Equal(t, helpers.ErrorUserIdentityNotFound, err, "Expected user identity not found error")
+
+	// A provider subject can only be linked once
+	require.Error(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 2, Provider: "google", Subject: "123"}), "Expected duplicated identity error")
+}
+
+func TestUserIdentityRepositoryImpl_GetAndDelete(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserIdentity{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserIdentityRepositoryImpl(db)
+
+	require.NoError(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 1, Provider: "google", Subject: "123"}), "Error creating user identity")
+	require.NoError(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 1, Provider: "discord", Subject: "456"}), "Error creating user identity")
+	require.NoError(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 2, Provider: "google", Subject: "789"}), "Error creating user identity")
+
+	userIdentities, err := repo.GetUserIdentities(1)
+	require.NoError(t, err, "Error getting user identities")
+	require.Len(t, userIdentities, 2)
+	require.Equal(t, "discord", userIdentities[0].Provider)
+
+	require.Equal(t, helpers.ErrorUserIdentityNotFound, repo.DeleteUserIdentity(2, "discord"))
+	require.NoError(t, repo.DeleteUserIdentity(1, "google"), "Error deleting user identity")
+
+	// The identity can be linked again after unlinking it
+	require.NoError(t, repo.CreateUserIdentity(&models.UserIdentity{UserID: 3, Provider: "google", Subject: "123"}), "Error linking identity again")
+}
+
+func TestUserIdentityRepositoryImpl_CreateUserWithIdentity(t *testing.T) {
+	db := testutils.SetupTestDB(&models.User{}, &models.UserIdentity{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserIdentityRepositoryImpl(db)
+
+	user := &models.User{UserName: "player", PassWord: "hashed", Email: "player@test.com"}
+	require.NoError(t, repo.CreateUserWithIdentity(user, &models.UserIdentity{Provider: "google", Subject: "123", Email: "player@test.com"}), "Error creating user with identity")
+
+	found, err := repo.FindByProviderSubject("google", "123")
+	require.NoError(t, err, "Error finding user identity")
+	require.Equal(t, user.ID, found.UserID)
+
+	// The identity is already linked, so the user isn't created either
+	err = repo.CreateUserWithIdentity(&models.User{UserName: "other", PassWord: "hashed", Email: "other@test.com"}, &models.UserIdentity{Provider: "google", Subject: "123"})
+	require.Error(t, err, "Expected duplicated identity error")
+
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&models.User{}).Where("email = ?", "other@test.com").Count(&count).Error)
+	require.Equal(t, int64(0), count, "Expected the user to be rolled back")
+}
@@ -2,6 +2,7 @@ package impl
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
@@ -41,19 +42,55 @@ func (u *UserRepositoryImpl) CreateUser(user *models.User) error {
 	return nil
 }
 
+// userListFields are the fields users can be filtered and sorted by, the
+// email stays out so the list can't be used to probe addresses.
+var userListFields = map[string]listField{
+	"id":        {column: "id", numeric: true},
+	"user_name": {column: "user_name"},
+	"age":       {column: "age", numeric: true},
+	"role":      {column: "role"},
+}
+
 // GetAllUsers implements repository.UserRepository with pagination.
-func (u *UserRepositoryImpl) GetAllUsers(offset int, pageSize int) ([]models.User, error) {
+func (u *UserRepositoryImpl) GetAllUsers(page models.Page, listQuery models.ListQuery) ([]models.User, error) {
 	var users []models.User
 
-	result := u.Db.Offset(offset).Limit(pageSize).Find(&users)
+	scope, err := listQueryScope(listQuery, page, userListFields)
+	if err != nil {
+		return nil, err
+	}
+
+	result := u.Db.Scopes(scope).Find(&users)
 	if result.Error != nil {
 		logrus.WithError(result.Error).Error("[UserRepositoryImpl.GetAllUsers] Failed to get all users")
 		return nil, helpers.ErrorGetAllUsers
 	}
 
+	if page.BeforeID != 0 {
+		slices.Reverse(users)
+	}
+
 	return users, nil
 }
 
+// CountUsers implements repository.UserRepository.
+func (u *UserRepositoryImpl) CountUsers(listQuery models.ListQuery) (int64, error) {
+	var count int64
+
+	scope, err := listFilterScope(listQuery.Filters, userListFields)
+	if err != nil {
+		return 0, err
+	}
+
+	result := u.Db.Model(&models.User{}).Scopes(scope).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserRepositoryImpl.CountUsers] Failed to count users")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
 // GetUser implements repository.UserRepository.
 func (u *UserRepositoryImpl) GetUser(userID uint) (*models.User, error) {
 
@@ -102,6 +139,21 @@ func (u *UserRepositoryImpl) UpdateUser(userID uint, user *models.User) error {
 	return nil
 }
 
+// ClearEmailVerification implements repository.UserRepository.
+func (u *UserRepositoryImpl) ClearEmailVerification(userID uint) error {
+	result := u.Db.Model(&models.User{}).Where(IDPlaceHolder, userID).Update("email_verified_at", nil)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserRepositoryImpl.ClearEmailVerification] Failed to clear email verification")
+		return helpers.ErrorUpdateUser
+	}
+
+	if result.RowsAffected == 0 {
+		return helpers.ErrorUserNotFound
+	}
+
+	return nil
+}
+
 func (u *UserRepositoryImpl) DeleteUser(userID uint) error {
 	exists, err := u.CheckUserExists(userID)
 	if err != nil {
@@ -139,3 +191,16 @@ func (u *UserRepositoryImpl) CheckUserExists(userID uint) (bool, error) {
 
 	return exists > 0, nil
 }
+
+// CountUsersByRole implements repository.UserRepository.
+func (u *UserRepositoryImpl) CountUsersByRole(role string) (int64, error) {
+	var count int64
+
+	result := u.Db.Model(&models.User{}).Where(RolePlaceHolder, role).Count(&count)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserRepositoryImpl.CountUsersByRole] Failed to count users")
+		return 0, result.Error
+	}
+
+	return count, nil
+}
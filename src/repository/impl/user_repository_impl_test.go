@@ -2,7 +2,9 @@ package impl
 
 import (
 	"testing"
+	"time"
 
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils"
 	"github.com/stretchr/testify/require"
@@ -163,7 +165,7 @@ func TestUserRepositoryImpl_GetAllUsers(t *testing.T) {
 		require.NoError(t, repo.CreateUser(user2), "Error creating user")
 
 		// Attempt to get all users
-		users, err := repo.GetAllUsers(0, 10)
+		users, err := repo.GetAllUsers(models.Page{Limit: 10}, models.ListQuery{})
 
 		// Assertions
 		require.NoError(t, err, "Error getting all users")
@@ -186,7 +188,7 @@ func TestUserRepositoryImpl_GetAllUsers(t *testing.T) {
 		repo := NewUserRepositoryImpl(db)
 
 		// Attempt to get all users when there are none
-		users, err := repo.GetAllUsers(0, 10)
+		users, err := repo.GetAllUsers(models.Page{Limit: 10}, models.ListQuery{})
 
 		// Assertions
 		require.NoError(t, err, "Error getting all users")
@@ -264,6 +266,38 @@ func TestUserRepositoryImpl_UpdateUser(t *testing.T) {
 	})
 }
 
+func TestUserRepositoryImpl_ClearEmailVerification(t *testing.T) {
+	// Setup
+	db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+
+	repo := NewUserRepositoryImpl(db)
+
+	verifiedAt := time.Now()
+	user := &models.User{
+		UserName:        "test",
+		PassWord:        "test",
+		Email:           "test@test.com",
+		Age:             20,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	require.NoError(t, repo.CreateUser(user), "Error creating user")
+
+	require.NoError(t, repo.ClearEmailVerification(user.ID), "Error clearing email verification")
+
+	var dbUser models.User
+	require.NoError(t, db.First(&dbUser, user.ID).Error, "Error getting user")
+	require.Nil(t, dbUser.EmailVerifiedAt, "Expected email not to be verified")
+
+	require.ErrorIs(t, repo.ClearEmailVerification(999), helpers.ErrorUserNotFound)
+}
+
 func TestUserRepositoryImpl_DeleteUser(t *testing.T) {
 
 	t.Run("DeleteUser_Success", func(t *testing.T) {
@@ -380,3 +414,30 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 		require.Error(t, err, "Expected error when finding non-existent user by email")
 	})
 }
+
+func TestUserRepositoryImpl_CountUsersByRole(t *testing.T) {
+	// Setup
+	db := testutils.SetupTestDB(&models.User{}, &models.PlayerProfile{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+
+	repo := NewUserRepositoryImpl(db)
+
+	require.NoError(t, repo.CreateUser(&models.User{UserName: "mod1", PassWord: "test", Email: "mod1@test.com", Age: 20, Role: "moderator"}), "Error creating user")
+	require.NoError(t, repo.CreateUser(&models.User{UserName: "mod2", PassWord: "test", Email: "mod2@test.com", Age: 20, Role: "moderator"}), "Error creating user")
+	require.NoError(t, repo.CreateUser(&models.User{UserName: "player", PassWord: "test", Email: "player@test.com", Age: 20, Role: "user"}), "Error creating user")
+
+	// Assertions
+	count, err := repo.CountUsersByRole("moderator")
+	require.NoError(t, err, "Error counting users")
+	require.Equal(t, int64(2), count)
+
+	count, err = repo.CountUsersByRole("admin")
+	require.NoError(t, err, "Error counting users")
+	require.Zero(t, count)
+}
@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	r "github.com/dieg0code/player-profile/src/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepositoryImpl struct {
+	Db *gorm.DB
+}
+
+// CreateUserToken implements repository.UserTokenRepository.
+func (ut *UserTokenRepositoryImpl) CreateUserToken(userToken *models.UserToken) error {
+	result := ut.Db.Create(userToken)
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserTokenRepositoryImpl.CreateUserToken] Failed to create user token")
+		return result.Error
+	}
+
+	return nil
+}
+
+// FindByTokenHash implements repository.UserTokenRepository.
+func (ut *UserTokenRepositoryImpl) FindByTokenHash(purpose string, tokenHash string) (*models.UserToken, error) {
+	var userToken models.UserToken
+
+	result := ut.Db.Where(PurposeAndTokenHashPlaceHolder, purpose, tokenHash).First(&userToken)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorUserTokenNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserTokenRepositoryImpl.FindByTokenHash] Failed to find user token")
+		return nil, result.Error
+	}
+
+	return &userToken, nil
+}
+
+// MarkAsUsed implements repository.UserTokenRepository.
+// Only one of two concurrent requests using the same token gets true.
+func (ut *UserTokenRepositoryImpl) MarkAsUsed(userTokenID uint) (bool, error) {
+	result := ut.Db.Model(&models.UserToken{}).
+		Where(IDPlaceHolder, userTokenID).
+		Where("used_at IS NULL").
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserTokenRepositoryImpl.MarkAsUsed] Failed to mark user token as used")
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteUserTokens implements repository.UserTokenRepository.
+func (ut *UserTokenRepositoryImpl) DeleteUserTokens(userID uint, purpose string) error {
+	result := ut.Db.Where(UserIDAndPurposePlaceHolder, userID, purpose).Delete(&models.UserToken{})
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserTokenRepositoryImpl.DeleteUserTokens] Failed to delete user tokens")
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetLatestUserToken implements repository.UserTokenRepository.
+func (ut *UserTokenRepositoryImpl) GetLatestUserToken(userID uint, purpose string) (*models.UserToken, error) {
+	var userToken models.UserToken
+
+	result := ut.Db.Where(UserIDAndPurposePlaceHolder, userID, purpose).Order("created_at desc").First(&userToken)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, helpers.ErrorUserTokenNotFound
+	}
+
+	if result.Error != nil {
+		logrus.WithError(result.Error).Error("[UserTokenRepositoryImpl.GetLatestUserToken] Failed to get latest user token")
+		return nil, result.Error
+	}
+
+	return &userToken, nil
+}
+
+func NewUserTokenRepositoryImpl(db *gorm.DB) r.UserTokenRepository {
+	return &UserTokenRepositoryImpl{Db: db}
+}
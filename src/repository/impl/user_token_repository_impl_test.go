@@ -0,0 +1,123 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTokenRepositoryImpl_FindByTokenHash(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserTokenRepositoryImpl(db)
+
+	userToken := &models.UserToken{
+		UserID:    1,
+		Purpose:   models.PasswordResetToken,
+		TokenHash: helpers.HashToken("token"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, repo.CreateUserToken(userToken), "Error creating user token")
+
+	found, err := repo.FindByTokenHash(models.PasswordResetToken, helpers.HashToken("token"))
+	require.NoError(t, err, "Error finding user token")
+	require.Equal(t, userToken.ID, found.ID, "User token IDs do not match")
+
+	// A token can't be used for another purpose
+	_, err = repo.FindByTokenHash("other_purpose", helpers.HashToken("token"))
+	require.Equal(t, helpers.ErrorUserTokenNotFound, err, "Expected user token not found error")
+}
+
+func TestUserTokenRepositoryImpl_MarkAsUsed(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserTokenRepositoryImpl(db)
+
+	userToken := &models.UserToken{
+		UserID:    1,
+		Purpose:   models.PasswordResetToken,
+		TokenHash: helpers.HashToken("token"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, repo.CreateUserToken(userToken), "Error creating user token")
+
+	marked, err := repo.MarkAsUsed(userToken.ID)
+	require.NoError(t, err, "Error marking user token as used")
+	require.True(t, marked, "Expected user token to be marked")
+
+	marked, err = repo.MarkAsUsed(userToken.ID)
+	require.NoError(t, err, "Error marking user token as used")
+	require.False(t, marked, "Expected used user token not to be marked again")
+}
+
+func TestUserTokenRepositoryImpl_DeleteUserTokens(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserTokenRepositoryImpl(db)
+
+	for _, userToken := range []*models.UserToken{
+		{UserID: 1, Purpose: models.PasswordResetToken, TokenHash: helpers.HashToken("first"), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: 2, Purpose: models.PasswordResetToken, TokenHash: helpers.HashToken("second"), ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		require.NoError(t, repo.CreateUserToken(userToken), "Error creating user token")
+	}
+
+	require.NoError(t, repo.DeleteUserTokens(1, models.PasswordResetToken), "Error deleting user tokens")
+
+	_, err := repo.FindByTokenHash(models.PasswordResetToken, helpers.HashToken("first"))
+	require.Equal(t, helpers.ErrorUserTokenNotFound, err, "Expected deleted user token not to be found")
+
+	_, err = repo.FindByTokenHash(models.PasswordResetToken, helpers.HashToken("second"))
+	require.NoError(t, err, "Expected token of other user to be kept")
+}
+
+func TestUserTokenRepositoryImpl_GetLatestUserToken(t *testing.T) {
+	db := testutils.SetupTestDB(&models.UserToken{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+	repo := NewUserTokenRepositoryImpl(db)
+
+	_, err := repo.GetLatestUserToken(1, models.EmailVerificationToken)
+	require.Equal(t, helpers.ErrorUserTokenNotFound, err, "Expected user token not found error")
+
+	older := &models.UserToken{UserID: 1, Purpose: models.EmailVerificationToken, TokenHash: helpers.HashToken("older"), ExpiresAt: time.Now().Add(time.Hour)}
+	older.CreatedAt = time.Now().Add(-time.Hour)
+	newer := &models.UserToken{UserID: 1, Purpose: models.EmailVerificationToken, TokenHash: helpers.HashToken("newer"), ExpiresAt: time.Now().Add(time.Hour)}
+	otherPurpose := &models.UserToken{UserID: 1, Purpose: models.PasswordResetToken, TokenHash: helpers.HashToken("other"), ExpiresAt: time.Now().Add(time.Hour)}
+	otherPurpose.CreatedAt = time.Now().Add(time.Minute)
+
+	for _, userToken := range []*models.UserToken{older, newer, otherPurpose} {
+		require.NoError(t, repo.CreateUserToken(userToken), "Error creating user token")
+	}
+
+	latest, err := repo.GetLatestUserToken(1, models.EmailVerificationToken)
+	require.NoError(t, err, "Error getting latest user token")
+	require.Equal(t, newer.ID, latest.ID, "Expected the newest token of the purpose")
+}
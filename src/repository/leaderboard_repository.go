@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+// LeaderboardRepository ranks the player profiles by one of the leaderboard
+// metrics, highest first. Ties are ordered by ID so the order is stable.
+type LeaderboardRepository interface {
+	GetRankedPlayers(metric string, offset int, limit int) ([]models.PlayerProfile, error)
+	// CountPlayersAbove counts the players with a higher value than the given
+	// one or, when distinct, the different higher values.
+	CountPlayersAbove(metric string, value int, distinct bool) (int64, error)
+	// GetPlayerPosition returns the zero-based position of the player in the ranking.
+	GetPlayerPosition(metric string, playerProfile *models.PlayerProfile) (int64, error)
+}
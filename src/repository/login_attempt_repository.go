@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+type LoginAttemptRepository interface {
+	GetLoginAttempt(key string) (*models.LoginAttempt, error)
+	RecordFailure(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error)
+	LockUntil(key string, lockedUntil time.Time) error
+	DeleteLoginAttempt(key string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type MatchRepository interface {
+	GetPlayerRatings(playerProfileIDs []uint) ([]models.PlayerRating, error)
+	// CreateMatch saves the match, its participants and the new ratings in one
+	// transaction. Ratings without ID are created, the others are only updated
+	// if no other match rated the player since they were read, otherwise
+	// nothing is saved and ErrorPlayerRatingConflict is returned.
+	CreateMatch(match *models.Match, ratings []models.PlayerRating) error
+	// GetPlayerMatches returns the participations of the player with their
+	// match, in the order they were rated, latest first.
+	GetPlayerMatches(playerProfileID uint, offset int, pageSize int) ([]models.MatchParticipant, error)
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type OIDCStateRepository interface {
+	CreateOIDCState(oidcState *models.OIDCState) error
+	FindByStateHash(stateHash string) (*models.OIDCState, error)
+	MarkAsUsed(oidcStateID uint) (bool, error)
+}
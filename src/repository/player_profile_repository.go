@@ -3,11 +3,20 @@ package repository
 import "github.com/dieg0code/player-profile/src/models"
 
 type PlayerProfileRepository interface {
-	CreatePlayerProfile(playerProfile *models.PlayerProfile) error
+	// CreatePlayerProfileWithinLimit creates the profile unless its user already
+	// has maxProfiles, counting and creating while the user row is locked.
+	CreatePlayerProfileWithinLimit(playerProfile *models.PlayerProfile, maxProfiles int) error
 	GetPlayerProfile(playerProfileID uint) (*models.PlayerProfile, error)
 	UpdatePlayerProfile(playerProfileID uint, playerProfile *models.PlayerProfile) error
+	UpdatePlayerProgress(playerProfileID uint, playerProfile *models.PlayerProfile) error
+	UpdatePlayerExperience(playerProfile *models.PlayerProfile, level int, experience int) (bool, error)
+	// DeletePlayerProfile deletes the profile with its friend requests,
+	// friendships and blocks in one transaction.
 	DeletePlayerProfile(playerProfileID uint) error
 	CheckPlayerProfileExists(playerProfileID uint) (bool, error)
-	GetAllPlayerProfiles(offset int, pageSize int) ([]models.PlayerProfile, error)
-	GetPlayerWithAchievements(playerProfileID uint) (*models.PlayerProfile, error)
+	// GetAllPlayerProfiles and CountPlayerProfiles leave out the players who
+	// blocked a player of the viewer, a user ID or zero for API keys.
+	GetAllPlayerProfiles(page models.Page, listQuery models.ListQuery, viewerUserID uint) ([]models.PlayerProfile, error)
+	CountPlayerProfiles(listQuery models.ListQuery, viewerUserID uint) (int64, error)
+	GetPlayerAchievements(playerProfileID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error)
 }
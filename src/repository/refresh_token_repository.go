@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(refreshToken *models.RefreshToken) error
+	FindByTokenHash(tokenHash string) (*models.RefreshToken, error)
+	MarkAsUsed(refreshTokenID uint) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint) error
+}
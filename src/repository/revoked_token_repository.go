@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+type RevokedTokenRepository interface {
+	CreateRevokedToken(revokedToken *models.RevokedToken) error
+	GetActiveRevokedTokens(now time.Time) ([]models.RevokedToken, error)
+	DeleteExpiredRevokedTokens(now time.Time) error
+	SaveUserRevocation(userRevocation *models.UserRevocation) error
+	GetAllUserRevocations() ([]models.UserRevocation, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type RoleRepository interface {
+	CreateRole(role *models.Role) error
+	GetRole(roleID uint) (*models.Role, error)
+	FindByName(name string) (*models.Role, error)
+	GetAllRoles() ([]models.Role, error)
+	UpdateRole(role *models.Role) error
+	DeleteRole(roleID uint) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type SeasonRepository interface {
+	GetCurrentSeason() (*models.Season, error)
+	GetSeason(seasonID uint) (*models.Season, error)
+	GetAllSeasons() ([]models.Season, error)
+	// StartSeason ends the running season archiving its standings, resets the
+	// season points of every player and creates the new season in one transaction.
+	StartSeason(season *models.Season) error
+	GetStandings(seasonID uint, offset int, pageSize int) ([]models.SeasonStanding, error)
+}
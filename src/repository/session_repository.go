@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+type SessionRepository interface {
+	CreateSession(session *models.Session) error
+	GetSession(sessionID uint) (*models.Session, error)
+	FindByFamilyID(familyID string) (*models.Session, error)
+	GetActiveSessions(userID uint, seenSince time.Time) ([]models.Session, error)
+	UpdateLastSeen(sessionID uint, ip string, lastSeenAt time.Time) error
+	EndSession(sessionID uint, endedAt time.Time) (bool, error)
+	EndAllForUser(userID uint, endedAt time.Time) error
+	GetEndedSessions(endedSince time.Time) ([]models.Session, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+type TwoFactorRepository interface {
+	SaveTwoFactor(twoFactor *models.TwoFactor) error
+	GetTwoFactor(userID uint) (*models.TwoFactor, error)
+	ConfirmTwoFactor(userID uint, confirmedAt time.Time) error
+	UpdateLastUsedStep(userID uint, step int64) (bool, error)
+	DeleteTwoFactor(userID uint) error
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+}
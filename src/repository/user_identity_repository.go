@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type UserIdentityRepository interface {
+	CreateUserIdentity(userIdentity *models.UserIdentity) error
+	// CreateUserWithIdentity creates a user and links the identity to it, or neither.
+	CreateUserWithIdentity(user *models.User, userIdentity *models.UserIdentity) error
+	FindByProviderSubject(provider string, subject string) (*models.UserIdentity, error)
+	GetUserIdentities(userID uint) ([]models.UserIdentity, error)
+	DeleteUserIdentity(userID uint, provider string) error
+}
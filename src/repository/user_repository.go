@@ -7,6 +7,11 @@ type UserRepository interface {
 	GetUser(userID uint) (*models.User, error)
 	FindByEmail(email string) (*models.User, error)
 	UpdateUser(userID uint, user *models.User) error
+	// ClearEmailVerification marks the email of the user as not verified,
+	// UpdateUser can't since it skips the fields left empty.
+	ClearEmailVerification(userID uint) error
 	DeleteUser(userID uint) error
-	GetAllUsers(offset int, pageSize int) ([]models.User, error)
+	GetAllUsers(page models.Page, listQuery models.ListQuery) ([]models.User, error)
+	CountUsers(listQuery models.ListQuery) (int64, error)
+	CountUsersByRole(role string) (int64, error)
 }
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/dieg0code/player-profile/src/models"
+
+type UserTokenRepository interface {
+	CreateUserToken(userToken *models.UserToken) error
+	FindByTokenHash(purpose string, tokenHash string) (*models.UserToken, error)
+	MarkAsUsed(userTokenID uint) (bool, error)
+	DeleteUserTokens(userID uint, purpose string) error
+	GetLatestUserToken(userID uint, purpose string) (*models.UserToken, error)
+}
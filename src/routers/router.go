@@ -1,51 +1,154 @@
 package routers
 
 import (
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/dieg0code/player-profile/src/controllers"
 	"github.com/dieg0code/player-profile/src/middleware"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(authController *controllers.AuthController, userController *controllers.UserController, playerController *controllers.PlayerProfileController, achievementController *controllers.AchievementController) *gin.Engine {
+func NewRouter(trustedProxies []string, authUtils auth.AuthUtils, revocationStore auth.RevocationStore, sessionStore auth.SessionStore, permissionStore auth.PermissionStore, authController *controllers.AuthController, passwordController *controllers.PasswordController, emailVerificationController *controllers.EmailVerificationController, twoFactorController *controllers.TwoFactorController, oidcController *controllers.OIDCController, apiKeyController *controllers.APIKeyController, roleController *controllers.RoleController, impersonationController *controllers.ImpersonationController, sessionController *controllers.SessionController, userController *controllers.UserController, playerController *controllers.PlayerProfileController, levelingController *controllers.LevelingController, leaderboardController *controllers.LeaderboardController, seasonController *controllers.SeasonController, matchController *controllers.MatchController, achievementController *controllers.AchievementController, friendshipController *controllers.FriendshipController) *gin.Engine {
 	router := gin.Default()
 
+	// The client IP throttles logins and is stored with each session, so only
+	// the configured proxies can set it through X-Forwarded-For
+	err := router.SetTrustedProxies(trustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
 	router.GET("", func(ctx *gin.Context) {
 		ctx.JSON(200, "API is running")
 	})
+	router.GET("/.well-known/jwks.json", authController.JWKS)
 
 	baseRouter := router.Group("/api/v1")
 	userRouter := baseRouter.Group("/users")
 	playerRouter := baseRouter.Group("/players")
 	achievementRouter := baseRouter.Group("/achievements")
+	apiKeyRouter := baseRouter.Group("/api-keys")
+	roleRouter := baseRouter.Group("/roles")
+	leaderboardRouter := baseRouter.Group("/leaderboards")
+	seasonRouter := baseRouter.Group("/seasons")
+	matchRouter := baseRouter.Group("/matches")
+
+	authMiddleware := middleware.JWTAuthMiddleware(authUtils, revocationStore, sessionStore, permissionStore)
+	// Game servers and integrations can use an API key on the player and achievement routes
+	apiKeyOrAuthMiddleware := middleware.APIKeyOrJWTAuthMiddleware(middleware.APIKeyAuthMiddleware(apiKeyController.AuthenticateAPIKeyFromService), authMiddleware)
 
 	// Public routes
 	userRouter.POST("", userController.CreateUser)
 	baseRouter.POST("/login", authController.Login)
+	baseRouter.POST("/auth/refresh", authController.Refresh)
+	baseRouter.POST("/auth/forgot-password", passwordController.ForgotPassword)
+	baseRouter.POST("/auth/reset-password", passwordController.ResetPassword)
+	baseRouter.GET("/auth/verify", emailVerificationController.VerifyEmail)
+	baseRouter.POST("/auth/verify/resend", emailVerificationController.ResendVerificationEmail)
+	baseRouter.POST("/auth/2fa/verify", authController.VerifyTwoFactor)
+	baseRouter.GET("/auth/oidc/providers", oidcController.Providers)
+	baseRouter.GET("/auth/oidc/:provider/login", oidcController.Login)
+	baseRouter.GET("/auth/oidc/:provider/callback", oidcController.Callback)
+
+	// Admins impersonating a user can't delete data nor change how the account signs in
+	notImpersonating := middleware.DenyImpersonation()
+
+	// Auth routes
+	baseRouter.POST("/auth/logout", authMiddleware, authController.Logout)
+	baseRouter.POST("/auth/2fa/enroll", authMiddleware, notImpersonating, twoFactorController.Enroll)
+	baseRouter.POST("/auth/2fa/confirm", authMiddleware, notImpersonating, twoFactorController.Confirm)
+	baseRouter.POST("/auth/2fa/disable", authMiddleware, notImpersonating, twoFactorController.Disable)
+	baseRouter.POST("/auth/oidc/:provider/link", authMiddleware, notImpersonating, oidcController.Link)
+	baseRouter.POST("/auth/impersonate/:userID", authMiddleware, notImpersonating, middleware.RequirePermission(models.PermissionUsersImpersonate), impersonationController.Impersonate)
 
 	// Apply JWTAuthMiddleware to routes that require authentication
-	userRouter.Use(middleware.JWTAuthMiddleware())
-	playerRouter.Use(middleware.JWTAuthMiddleware())
-	achievementRouter.Use(middleware.JWTAuthMiddleware())
+	userRouter.Use(authMiddleware)
+	playerRouter.Use(apiKeyOrAuthMiddleware)
+	achievementRouter.Use(apiKeyOrAuthMiddleware)
+	leaderboardRouter.Use(apiKeyOrAuthMiddleware)
+	seasonRouter.Use(apiKeyOrAuthMiddleware)
+	matchRouter.Use(apiKeyOrAuthMiddleware)
+	apiKeyRouter.Use(authMiddleware, notImpersonating, middleware.RequirePermission(models.PermissionAPIKeysManage))
+	roleRouter.Use(authMiddleware, notImpersonating, middleware.RequirePermission(models.PermissionRolesManage))
+
+	userOwner := middleware.UserOwner()
+	// Managing users doesn't extend to the users with more permissions than the caller
+	notPrivilegedUser := middleware.DenyPrivilegeEscalation(roleController.GetUserPermissionsFromService)
+	playerOwner := middleware.PlayerOwner(playerController.GetPlayerByIDFromService)
 
 	// User routes
 	userRouter.GET("", userController.GetAllUsers)
 	userRouter.GET("/:userID", userController.GetUserByID)
-	userRouter.PUT("/:userID", middleware.RoleCheckUsersMiddleware(), userController.UpdateUser)
-	userRouter.DELETE("/:userID", middleware.RoleCheckUsersMiddleware(), userController.DeleteUser)
+	userRouter.PUT("/:userID", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, userController.UpdateUser)
+	userRouter.DELETE("/:userID", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, userController.DeleteUser)
+	userRouter.PUT("/:userID/password", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, passwordController.ChangePassword)
+	userRouter.POST("/:userID/logout", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, authController.LogoutAll)
+	userRouter.GET("/:userID/sessions", middleware.RequirePermission(models.PermissionUsersRead, userOwner), sessionController.GetSessions)
+	userRouter.DELETE("/:userID/sessions/:sessionID", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, sessionController.EndSession)
+	userRouter.GET("/:userID/identities", middleware.RequirePermission(models.PermissionUsersRead, userOwner), oidcController.GetIdentities)
+	userRouter.DELETE("/:userID/identities/:provider", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite, userOwner), notPrivilegedUser, oidcController.Unlink)
+	userRouter.POST("/:userID/unlock", notImpersonating, middleware.RequirePermission(models.PermissionUsersWrite), authController.UnlockAccount)
+	userRouter.PUT("/:userID/role", notImpersonating, middleware.RequirePermission(models.PermissionRolesManage), roleController.AssignRole)
 
 	// Player routes
 	playerRouter.POST("", playerController.CreatePlayerProfile)
 	playerRouter.GET("", playerController.GetAllPlayers)
 	playerRouter.GET("/:playerID", playerController.GetPlayerByID)
-	playerRouter.PUT("/:playerID", middleware.RoleCheckPlayersMiddleware(playerController.GetPlayerByIDFromService), playerController.UpdatePlayer)
-	playerRouter.DELETE("/:playerID", middleware.RoleCheckPlayersMiddleware(playerController.GetPlayerByIDFromService), playerController.DeletePlayer)
+	playerRouter.PUT("/:playerID", middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), playerController.UpdatePlayer)
+	playerRouter.PUT("/:playerID/progress", middleware.RequirePermission(models.PermissionPlayersProgress), playerController.UpdatePlayerProgress)
+	playerRouter.POST("/:playerID/experience", middleware.RequirePermission(models.PermissionPlayersProgress), levelingController.GrantExperience)
+	playerRouter.DELETE("/:playerID", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), playerController.DeletePlayer)
+	playerRouter.GET("/:playerID/achievements", playerController.GetPlayerWithAchievements)
+	playerRouter.GET("/:playerID/matches", matchController.GetPlayerMatches)
+	playerRouter.POST("/:playerID/achievements/:achievementID", middleware.RequirePermission(models.PermissionAchievementsAward), achievementController.AwardAchievement)
+	playerRouter.DELETE("/:playerID/achievements/:achievementID", notImpersonating, middleware.RequirePermission(models.PermissionAchievementsAward), achievementController.RevokeAchievement)
+
+	// Friend routes, the friends of a player are public but its requests and blocks aren't
+	playerRouter.POST("/:playerID/friend-requests", middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.SendFriendRequest)
+	playerRouter.GET("/:playerID/friend-requests", middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.GetFriendRequests)
+	playerRouter.POST("/:playerID/friend-requests/:requestID/accept", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.AcceptFriendRequest)
+	playerRouter.POST("/:playerID/friend-requests/:requestID/decline", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.DeclineFriendRequest)
+	playerRouter.DELETE("/:playerID/friend-requests/:requestID", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.CancelFriendRequest)
+	playerRouter.GET("/:playerID/friends", friendshipController.GetFriends)
+	playerRouter.DELETE("/:playerID/friends/:friendID", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.Unfriend)
+	playerRouter.GET("/:playerID/mutual-friends/:otherPlayerID", friendshipController.GetMutualFriends)
+	playerRouter.GET("/:playerID/blocks", middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.GetBlockedPlayers)
+	playerRouter.POST("/:playerID/blocks/:blockedID", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.BlockPlayer)
+	playerRouter.DELETE("/:playerID/blocks/:blockedID", notImpersonating, middleware.RequirePermission(models.PermissionPlayersModerate, playerOwner), friendshipController.UnblockPlayer)
 
 	// Achievement routes
-	achievementRouter.POST("", middleware.AuthorizationAchievementMiddleware(), achievementController.CreateAchievement)
+	achievementRouter.POST("", middleware.RequirePermission(models.PermissionAchievementsWrite), achievementController.CreateAchievement)
 	achievementRouter.GET("", achievementController.GetAllAchievements)
 	achievementRouter.GET("/:achievementID", achievementController.GetAchievementByID)
-	achievementRouter.PUT("/:achievementID", middleware.AuthorizationAchievementMiddleware(), achievementController.UpdateAchievement)
-	achievementRouter.DELETE("/:achievementID", middleware.AuthorizationAchievementMiddleware(), achievementController.DeleteAchievement)
+	achievementRouter.GET("/:achievementID/players", achievementController.GetAchievementWithPlayers)
+	achievementRouter.PUT("/:achievementID", middleware.RequirePermission(models.PermissionAchievementsWrite), achievementController.UpdateAchievement)
+	achievementRouter.DELETE("/:achievementID", notImpersonating, middleware.RequirePermission(models.PermissionAchievementsWrite), achievementController.DeleteAchievement)
+
+	// Leaderboard routes
+	leaderboardRouter.GET("/:metric", leaderboardController.GetLeaderboard)
+	leaderboardRouter.GET("/:metric/players/:playerID", leaderboardController.GetPlayerNeighbourhood)
+
+	// Season routes
+	seasonRouter.POST("", notImpersonating, middleware.RequirePermission(models.PermissionSeasonsManage), seasonController.StartSeason)
+	seasonRouter.GET("", seasonController.GetAllSeasons)
+	seasonRouter.GET("/current", seasonController.GetCurrentSeason)
+	seasonRouter.GET("/:seasonID/standings", seasonController.GetStandings)
+
+	// Match routes
+	matchRouter.POST("", middleware.RequirePermission(models.PermissionMatchesSubmit), matchController.SubmitMatch)
+
+	// API key routes
+	apiKeyRouter.POST("", apiKeyController.CreateAPIKey)
+	apiKeyRouter.GET("", apiKeyController.GetAllAPIKeys)
+	apiKeyRouter.DELETE("/:apiKeyID", apiKeyController.RevokeAPIKey)
+
+	// Role routes
+	baseRouter.GET("/permissions", authMiddleware, notImpersonating, middleware.RequirePermission(models.PermissionRolesManage), roleController.GetPermissions)
+	roleRouter.GET("", roleController.GetAllRoles)
+	roleRouter.GET("/:roleID", roleController.GetRole)
+	roleRouter.POST("", roleController.CreateRole)
+	roleRouter.PUT("/:roleID", roleController.UpdateRole)
+	roleRouter.DELETE("/:roleID", roleController.DeleteRole)
 
 	return router
 }
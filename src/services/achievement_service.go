@@ -3,13 +3,16 @@ package services
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 )
 
 type AchievementService interface {
 	Create(achievement request.CreateAchievementRequest) error
 	Delete(achievementID uint) error
 	GetByID(achievementID uint) (*response.AchievementResponse, error)
-	GetAll(page int, pageSize int) ([]response.AchievementResponse, error)
+	GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.AchievementResponse, *response.Pagination, error)
 	Update(achievementID uint, achievement request.UpdateAchievementRequest) error
-	GetAchievementWithPlayers(achievementID uint) (*response.AchievementWithPlayers, error)
+	GetAchievementWithPlayers(achievementID uint, page int, pageSize int, sortOrder string) (*response.AchievementWithPlayers, error)
+	AwardToPlayer(playerProfileID uint, achievementID uint) (*response.PlayerAchievementResponse, error)
+	RevokeFromPlayer(playerProfileID uint, achievementID uint) error
 }
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type APIKeyService interface {
+	CreateAPIKey(createdBy uint, createRequest request.CreateAPIKeyRequest) (*response.CreatedAPIKeyResponse, error)
+	GetAllAPIKeys() ([]response.APIKeyResponse, error)
+	RevokeAPIKey(apiKeyID uint) error
+	Authenticate(key string) (*response.APIKeyResponse, error)
+}
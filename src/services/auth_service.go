@@ -1,10 +1,21 @@
 package services
 
 import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 )
 
 type AuthService interface {
-	Login(loginRequest request.LoginRequest) (*response.LoginResponse, error)
+	Login(loginRequest request.LoginRequest, client request.ClientInfo) (*response.LoginResponse, error)
+	VerifyTwoFactor(verifyRequest request.TwoFactorLoginRequest, client request.ClientInfo) (*response.LoginResponse, error)
+	// LoginWithIdentity signs in a user already authenticated by an identity provider.
+	LoginWithIdentity(userID uint, client request.ClientInfo) (*response.LoginResponse, error)
+	Refresh(refreshRequest request.RefreshTokenRequest, client request.ClientInfo) (*response.LoginResponse, error)
+	Logout(userID uint, sessionID uint, tokenID string, expiresAt time.Time, logoutRequest request.LogoutRequest) error
+	LogoutAll(userID uint) error
+	UnlockAccount(userID uint) error
+	JWKS() auth.JWKS
 }
@@ -3,4 +3,7 @@ package services
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	ComparePassword(hashedPassword string, password string) error
+	// NeedsRehash reports whether the hash was made with another algorithm or
+	// other parameters than the ones configured.
+	NeedsRehash(hashedPassword string) bool
 }
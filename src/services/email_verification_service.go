@@ -0,0 +1,9 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/data/request"
+
+type EmailVerificationService interface {
+	SendVerificationEmail(userID uint) error
+	VerifyEmail(token string) error
+	ResendVerificationEmail(resendRequest request.ResendVerificationRequest) error
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type FriendshipService interface {
+	SendFriendRequest(playerProfileID uint, friendRequest request.SendFriendRequestRequest) (*response.FriendRequestResponse, error)
+	// GetFriendRequests returns the pending requests of the player, incoming or outgoing.
+	GetFriendRequests(playerProfileID uint, direction string, pageRequest request.PageRequest) ([]response.FriendRequestResponse, *response.Pagination, error)
+	// AcceptFriendRequest, DeclineFriendRequest and CancelFriendRequest act on
+	// the requests received or, for CancelFriendRequest, sent by the player.
+	AcceptFriendRequest(playerProfileID uint, friendRequestID uint) error
+	DeclineFriendRequest(playerProfileID uint, friendRequestID uint) error
+	CancelFriendRequest(playerProfileID uint, friendRequestID uint) error
+	Unfriend(playerProfileID uint, friendID uint) error
+	GetFriends(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error)
+	GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error)
+	BlockPlayer(playerProfileID uint, blockedID uint) error
+	UnblockPlayer(playerProfileID uint, blockedID uint) error
+	GetBlockedPlayers(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error)
+}
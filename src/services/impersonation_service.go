@@ -0,0 +1,8 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/data/response"
+
+type ImpersonationService interface {
+	// Impersonate issues a token acting as the user on behalf of the admin actorID.
+	Impersonate(actorID uint, userID uint) (*response.ImpersonationResponse, error)
+}
@@ -1,6 +1,8 @@
 package impl
 
 import (
+	"errors"
+
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/dieg0code/player-profile/src/helpers"
@@ -17,24 +19,47 @@ type AchievementServiceImpl struct {
 }
 
 // GetAchievementWithPlayers implements services.AchievementService.
-func (a *AchievementServiceImpl) GetAchievementWithPlayers(achievementID uint) (*response.AchievementWithPlayers, error) {
+func (a *AchievementServiceImpl) GetAchievementWithPlayers(achievementID uint, page int, pageSize int, sortOrder string) (*response.AchievementWithPlayers, error) {
+	if achievementID == 0 {
+		return nil, helpers.ErrInvalidAchievementID
+	}
+
+	if page <= 0 || pageSize <= 0 {
+		return nil, helpers.ErrInvalidPagination
+	}
+
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return nil, helpers.ErrInvalidSortOrder
+	}
+
+	achievement, err := a.AchievementRepository.GetAchievement(achievementID)
+	if err != nil {
+		logrus.WithError(err).Error("[AchievementServiceImpl.GetAchievementWithPlayers] Failed to get achievement")
+		if errors.Is(err, helpers.ErrorAchievementNotFound) {
+			return nil, err
+		}
+		return nil, helpers.ErrAchievementRepository
+	}
 
-	achievement, err := a.AchievementRepository.GetAchievementWithPlayers(achievementID)
+	offset := (page - 1) * pageSize
 
+	playerAchievements, err := a.AchievementRepository.GetAchievementPlayers(achievementID, offset, pageSize, sortOrder)
 	if err != nil {
-		logrus.WithError(err).Error("[AchievementServiceImpl.GetAchievementWithPlayers] Failed to get achievement with players")
+		logrus.WithError(err).Error("[AchievementServiceImpl.GetAchievementWithPlayers] Failed to get achievement players")
 		return nil, helpers.ErrAchievementRepository
 	}
 
 	achievementWithPlayers := response.AchievementWithPlayers{
-		ID:   achievement.ID,
-		Name: achievement.Name,
+		ID:      achievement.ID,
+		Name:    achievement.Name,
+		Players: []response.PlayerSumary{},
 	}
 
-	for _, player := range achievement.PlayerProfiles {
+	for _, playerAchievement := range playerAchievements {
 		achievementWithPlayers.Players = append(achievementWithPlayers.Players, response.PlayerSumary{
-			ID:       player.ID,
-			Nickname: player.Nickname,
+			ID:         playerAchievement.PlayerProfile.ID,
+			Nickname:   playerAchievement.PlayerProfile.Nickname,
+			UnlockedAt: playerAchievement.UnlockedAt,
 		})
 	}
 
@@ -80,17 +105,31 @@ func (a *AchievementServiceImpl) Delete(achievementID uint) error {
 }
 
 // GetAll implements services.AchievementService.
-func (a *AchievementServiceImpl) GetAll(page int, pageSize int) ([]response.AchievementResponse, error) {
-	if page <= 0 || pageSize <= 0 {
-		return nil, helpers.ErrInvalidPagination
+func (a *AchievementServiceImpl) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.AchievementResponse, *response.Pagination, error) {
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	offset := (page - 1) * pageSize
-
-	achievements, err := a.AchievementRepository.GetAllAchievements(offset, pageSize)
+	achievements, err := a.AchievementRepository.GetAllAchievements(page, listQuery)
 	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidListQuery) {
+			return nil, nil, err
+		}
+
 		logrus.WithError(err).Error("[AchievementServiceImpl.GetAll] Failed to get all achievements")
-		return nil, helpers.ErrAchievementRepository
+		return nil, nil, helpers.ErrAchievementRepository
+	}
+
+	achievements, pagination := pageItems(achievements, page, listQuery, func(achievement models.Achievement) uint { return achievement.ID })
+
+	if pageRequest.Total {
+		total, err := a.AchievementRepository.CountAchievements(listQuery)
+		if err != nil {
+			logrus.WithError(err).Error("[AchievementServiceImpl.GetAll] Failed to count achievements")
+			return nil, nil, helpers.ErrAchievementRepository
+		}
+		pagination.Total = &total
 	}
 
 	var achievementResponses []response.AchievementResponse
@@ -104,13 +143,13 @@ func (a *AchievementServiceImpl) GetAll(page int, pageSize int) ([]response.Achi
 		err = a.Validate.Struct(achievementResponse)
 		if err != nil {
 			logrus.WithError(err).Error("[AchievementServiceImpl.GetAll] Failed to validate achievement data")
-			return nil, helpers.ErrAchievementDataValidation
+			return nil, nil, helpers.ErrAchievementDataValidation
 		}
 
 		achievementResponses = append(achievementResponses, achievementResponse)
 	}
 
-	return achievementResponses, nil
+	return achievementResponses, pagination, nil
 }
 
 // GetByID implements services.AchievementService.
@@ -178,6 +217,57 @@ func (a *AchievementServiceImpl) Update(achievementID uint, achievement request.
 	return nil
 }
 
+// AwardToPlayer implements services.AchievementService.
+func (a *AchievementServiceImpl) AwardToPlayer(playerProfileID uint, achievementID uint) (*response.PlayerAchievementResponse, error) {
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if achievementID == 0 {
+		return nil, helpers.ErrInvalidAchievementID
+	}
+
+	playerAchievement, newlyUnlocked, err := a.AchievementRepository.AwardAchievement(playerProfileID, achievementID)
+	if err != nil {
+		logrus.WithError(err).Error("[AchievementServiceImpl.AwardToPlayer] Failed to award achievement")
+		if errors.Is(err, helpers.ErrorPlayerProfileNotFound) || errors.Is(err, helpers.ErrorAchievementNotFound) {
+			return nil, err
+		}
+		return nil, helpers.ErrAchievementRepository
+	}
+
+	playerAchievementResponse := response.PlayerAchievementResponse{
+		PlayerID:      playerAchievement.PlayerProfileID,
+		AchievementID: playerAchievement.AchievementID,
+		UnlockedAt:    playerAchievement.UnlockedAt,
+		NewlyUnlocked: newlyUnlocked,
+	}
+
+	return &playerAchievementResponse, nil
+}
+
+// RevokeFromPlayer implements services.AchievementService.
+func (a *AchievementServiceImpl) RevokeFromPlayer(playerProfileID uint, achievementID uint) error {
+	if playerProfileID == 0 {
+		return helpers.ErrInvalidPlayerProfileID
+	}
+
+	if achievementID == 0 {
+		return helpers.ErrInvalidAchievementID
+	}
+
+	err := a.AchievementRepository.RevokeAchievement(playerProfileID, achievementID)
+	if err != nil {
+		logrus.WithError(err).Error("[AchievementServiceImpl.RevokeFromPlayer] Failed to revoke achievement")
+		if errors.Is(err, helpers.ErrorPlayerAchievementNotFound) {
+			return err
+		}
+		return helpers.ErrAchievementRepository
+	}
+
+	return nil
+}
+
 func NewAchievementServiceImpl(achievementRepository repository.AchievementRepository, validate *validator.Validate) services.AchievementService {
 	return &AchievementServiceImpl{
 		AchievementRepository: achievementRepository,
@@ -2,6 +2,7 @@ package impl
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/helpers"
@@ -215,10 +216,10 @@ func TestAchievementServiceImpl_GetAll(t *testing.T) {
 		respnseMock = append(respnseMock, achievement, achivement1)
 
 		// Expectations
-		mockAchievementRepo.On("GetAllAchievements", 0, 10).Return(respnseMock, nil)
+		mockAchievementRepo.On("GetAllAchievements", models.Page{Limit: 11}, models.ListQuery{}).Return(respnseMock, nil)
 
 		// Execution
-		result, err := achievementService.GetAll(1, 10)
+		result, _, err := achievementService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		// Assertions
 		require.NoError(t, err, "Error getting all achievements")
@@ -234,10 +235,10 @@ func TestAchievementServiceImpl_GetAll(t *testing.T) {
 		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
 
 		// Expectations
-		mockAchievementRepo.On("GetAllAchievements", 0, 10).Return([]models.Achievement{}, helpers.ErrAchievementRepository)
+		mockAchievementRepo.On("GetAllAchievements", models.Page{Limit: 11}, models.ListQuery{}).Return([]models.Achievement{}, helpers.ErrAchievementRepository)
 
 		// Execution
-		result, err := achievementService.GetAll(1, 10)
+		result, _, err := achievementService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		// Assertions
 		require.Error(t, err, "Expected error getting all achievements")
@@ -252,10 +253,10 @@ func TestAchievementServiceImpl_GetAll(t *testing.T) {
 		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
 
 		// Expectations
-		mockAchievementRepo.On("GetAllAchievements", 0, 10).Return([]models.Achievement{}, nil)
+		mockAchievementRepo.On("GetAllAchievements", models.Page{Limit: 11}, models.ListQuery{}).Return([]models.Achievement{}, nil)
 
 		// Execution
-		result, err := achievementService.GetAll(1, 10)
+		result, _, err := achievementService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		// Assertions
 		require.NoError(t, err, "Error getting all achievements")
@@ -271,10 +272,10 @@ func TestAchievementServiceImpl_GetAll(t *testing.T) {
 		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
 
 		// Expectations
-		mockAchievementRepo.On("GetAllAchievements", 0, 10).Return([]models.Achievement{}, helpers.ErrAchievementRepository)
+		mockAchievementRepo.On("GetAllAchievements", models.Page{Limit: 11}, models.ListQuery{}).Return([]models.Achievement{}, helpers.ErrAchievementRepository)
 
 		// Execution
-		result, err := achievementService.GetAll(1, 10)
+		result, _, err := achievementService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		// Assertions
 		require.Error(t, err, "Expected error getting all achievements")
@@ -289,7 +290,7 @@ func TestAchievementServiceImpl_GetAll(t *testing.T) {
 		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
 
 		// Execution
-		result, err := achievementService.GetAll(0, 0)
+		result, _, err := achievementService.GetAll(request.PageRequest{Page: 0, PageSize: 0}, models.ListQuery{})
 
 		// Assertions
 		require.Error(t, err, "Expected error getting all achievements")
@@ -375,7 +376,7 @@ func TestAchievementServiceImpl_Update(t *testing.T) {
 	})
 }
 
-func TetAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
+func TestAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
 	t.Run("GetAchievementWithPlayers_Success", func(t *testing.T) {
 		// Mocks
 		mockAchievementRepo := new(mocks.AchievementRepository)
@@ -388,8 +389,14 @@ func TetAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
 			Model:       gorm.Model{ID: achievementID},
 			Name:        "Test",
 			Description: "Test Description",
-			PlayerProfiles: []models.PlayerProfile{
-				{
+		}
+		unlockedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+		playerAchievements := []models.PlayerProfileAchievement{
+			{
+				PlayerProfileID: 1,
+				AchievementID:   achievementID,
+				UnlockedAt:      unlockedAt,
+				PlayerProfile: models.PlayerProfile{
 					Model:      gorm.Model{ID: 1},
 					Nickname:   "test nickaname",
 					Avatar:     "test.png",
@@ -401,15 +408,18 @@ func TetAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
 		}
 
 		// Expectations
-		mockAchievementRepo.On("GetAchievementWithPlayers", achievementID).Return(&achievement, nil)
+		mockAchievementRepo.On("GetAchievement", achievementID).Return(&achievement, nil)
+		mockAchievementRepo.On("GetAchievementPlayers", achievementID, 0, 10, "desc").Return(playerAchievements, nil)
 
 		// Execution
-		result, err := achievementService.GetAchievementWithPlayers(achievementID)
+		result, err := achievementService.GetAchievementWithPlayers(achievementID, 1, 10, "desc")
 
 		// Assertions
 		require.NoError(t, err, "Error getting achievement with players")
 		require.NotNil(t, result, "Expected achievement get nil")
 		require.Equal(t, achievementID, result.ID, "Expected achievement ID")
+		require.Len(t, result.Players, 1, "Expected 1 player")
+		require.Equal(t, unlockedAt, result.Players[0].UnlockedAt, "Unlock dates do not match")
 		mockAchievementRepo.AssertExpectations(t)
 	})
 
@@ -419,18 +429,50 @@ func TetAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
 		mockValidator := validator.New()
 		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
 
+		// Execution
+		result, err := achievementService.GetAchievementWithPlayers(0, 1, 10, "desc")
+
+		// Assertions
+		require.Error(t, err, "Expected error getting achievement with players with invalid ID")
+		require.Nil(t, result, "Expected achievement get nil")
+		require.EqualError(t, err, helpers.ErrInvalidAchievementID.Error(), "Expected error getting achievement with players with invalid ID")
+		mockAchievementRepo.AssertNotCalled(t, "GetAchievement")
+	})
+
+	t.Run("GetAchievementWithPlayers_InvalidPagination", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		// Execution
+		result, err := achievementService.GetAchievementWithPlayers(1, 0, 10, "desc")
+
+		// Assertions
+		require.Error(t, err, "Expected error getting achievement with players with invalid pagination")
+		require.Nil(t, result, "Expected achievement get nil")
+		require.Equal(t, helpers.ErrInvalidPagination, err, "Expected invalid pagination error")
+		mockAchievementRepo.AssertNotCalled(t, "GetAchievement")
+	})
+
+	t.Run("GetAchievementWithPlayers_NotFound", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
 		// Test data
-		achievementID := uint(0)
+		achievementID := uint(1)
 
-		mockAchievementRepo.On("GetAchievementWithPlayers", achievementID).Return(nil, helpers.ErrInvalidAchievementID)
+		mockAchievementRepo.On("GetAchievement", achievementID).Return(nil, helpers.ErrorAchievementNotFound)
 
 		// Execution
-		result, err := achievementService.GetAchievementWithPlayers(achievementID)
+		result, err := achievementService.GetAchievementWithPlayers(achievementID, 1, 10, "asc")
 
 		// Assertions
-		require.Error(t, err, "Expected error getting achievement with players with invalid ID")
+		require.Error(t, err, "Expected error getting non-existent achievement with players")
 		require.Nil(t, result, "Expected achievement get nil")
-		require.EqualError(t, err, helpers.ErrInvalidAchievementID.Error(), "Expected error getting achievement with players with invalid ID")
+		require.Equal(t, helpers.ErrorAchievementNotFound, err, "Expected achievement not found error")
 		mockAchievementRepo.AssertExpectations(t)
 	})
 
@@ -442,15 +484,133 @@ func TetAchievementServiceImpl_GetAchievementWithPlayers(t *testing.T) {
 
 		// Test data
 		achievementID := uint(1)
+		achievement := models.Achievement{
+			Model: gorm.Model{ID: achievementID},
+			Name:  "Test",
+		}
 
-		mockAchievementRepo.On("GetAchievementWithPlayers", achievementID).Return(nil, helpers.ErrAchievementRepository)
+		mockAchievementRepo.On("GetAchievement", achievementID).Return(&achievement, nil)
+		mockAchievementRepo.On("GetAchievementPlayers", achievementID, 0, 10, "desc").Return(nil, helpers.ErrAchievementRepository)
 
 		// Execution
-		result, err := achievementService.GetAchievementWithPlayers(achievementID)
+		result, err := achievementService.GetAchievementWithPlayers(achievementID, 1, 10, "desc")
 
 		// Assertions
 		require.Error(t, err, "Expected error getting achievement with players")
 		require.Nil(t, result, "Expected achievement get nil")
+		require.Equal(t, helpers.ErrAchievementRepository, err, "Expected achievement repository error")
+		mockAchievementRepo.AssertExpectations(t)
+	})
+}
+
+func TestAchievementServiceImpl_AwardToPlayer(t *testing.T) {
+	t.Run("AwardToPlayer_Success", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		// Test data
+		unlockedAt := time.Now()
+		mockAchievementRepo.On("AwardAchievement", uint(1), uint(2)).Return(&models.PlayerProfileAchievement{
+			PlayerProfileID: 1,
+			AchievementID:   2,
+			UnlockedAt:      unlockedAt,
+		}, true, nil)
+
+		// Execution
+		result, err := achievementService.AwardToPlayer(1, 2)
+
+		// Assertions
+		require.NoError(t, err, "Error awarding achievement")
+		require.Equal(t, uint(1), result.PlayerID)
+		require.Equal(t, uint(2), result.AchievementID)
+		require.Equal(t, unlockedAt, result.UnlockedAt)
+		require.True(t, result.NewlyUnlocked)
+		mockAchievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("AwardToPlayer_InvalidID", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		// Execution
+		_, err := achievementService.AwardToPlayer(0, 2)
+		require.Equal(t, helpers.ErrInvalidPlayerProfileID, err)
+
+		_, err = achievementService.AwardToPlayer(1, 0)
+		require.Equal(t, helpers.ErrInvalidAchievementID, err)
+
+		mockAchievementRepo.AssertNotCalled(t, "AwardAchievement")
+	})
+
+	t.Run("AwardToPlayer_NotFound", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		mockAchievementRepo.On("AwardAchievement", uint(1), uint(2)).Return(nil, false, helpers.ErrorAchievementNotFound)
+
+		// Execution
+		result, err := achievementService.AwardToPlayer(1, 2)
+
+		// Assertions
+		require.Nil(t, result)
+		require.ErrorIs(t, err, helpers.ErrorAchievementNotFound)
+		mockAchievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("AwardToPlayer_RepositoryError", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		mockAchievementRepo.On("AwardAchievement", uint(1), uint(2)).Return(nil, false, gorm.ErrInvalidDB)
+
+		// Execution
+		result, err := achievementService.AwardToPlayer(1, 2)
+
+		// Assertions
+		require.Nil(t, result)
+		require.Equal(t, helpers.ErrAchievementRepository, err)
+		mockAchievementRepo.AssertExpectations(t)
+	})
+}
+
+func TestAchievementServiceImpl_RevokeFromPlayer(t *testing.T) {
+	t.Run("RevokeFromPlayer_Success", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		mockAchievementRepo.On("RevokeAchievement", uint(1), uint(2)).Return(nil)
+
+		// Execution
+		err := achievementService.RevokeFromPlayer(1, 2)
+
+		// Assertions
+		require.NoError(t, err, "Error revoking achievement")
+		mockAchievementRepo.AssertExpectations(t)
+	})
+
+	t.Run("RevokeFromPlayer_NotAwarded", func(t *testing.T) {
+		// Mocks
+		mockAchievementRepo := new(mocks.AchievementRepository)
+		mockValidator := validator.New()
+		achievementService := NewAchievementServiceImpl(mockAchievementRepo, mockValidator)
+
+		mockAchievementRepo.On("RevokeAchievement", uint(1), uint(2)).Return(helpers.ErrorPlayerAchievementNotFound)
+
+		// Execution
+		err := achievementService.RevokeFromPlayer(1, 2)
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrorPlayerAchievementNotFound)
 		mockAchievementRepo.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,202 @@
+package impl
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// API keys look like pp_<prefix>.<secret>, the prefix finds the key and the
+// secret proves it.
+const apiKeyPrefix = "pp_"
+const apiKeyPrefixBytes = 6
+
+// The last use is written at most once per interval, not on every request.
+const apiKeyLastUsedInterval = time.Minute
+
+type APIKeyServiceImpl struct {
+	APIKeyRepository repository.APIKeyRepository
+	Validate         *validator.Validate
+}
+
+// CreateAPIKey implements services.APIKeyService.
+// The key is only returned here, afterwards only the prefix can be seen.
+func (a *APIKeyServiceImpl) CreateAPIKey(createdBy uint, createRequest request.CreateAPIKeyRequest) (*response.CreatedAPIKeyResponse, error) {
+	err := a.Validate.Struct(createRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.CreateAPIKey] Failed to validate api key request")
+		return nil, helpers.ErrAPIKeyDataValidation
+	}
+
+	if createRequest.ExpiresAt != nil && !createRequest.ExpiresAt.After(time.Now()) {
+		return nil, helpers.ErrAPIKeyDataValidation
+	}
+
+	for _, scope := range createRequest.Scopes {
+		if !models.IsAPIKeyScope(scope) {
+			return nil, helpers.ErrAPIKeyDataValidation
+		}
+	}
+
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	_, err = rand.Read(prefixBytes)
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.CreateAPIKey] Failed to generate prefix")
+		return nil, err
+	}
+	prefix := apiKeyPrefix + hex.EncodeToString(prefixBytes)
+
+	secret, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.CreateAPIKey] Failed to generate secret")
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		Name:       createRequest.Name,
+		Prefix:     prefix,
+		SecretHash: helpers.HashToken(secret),
+		Scopes:     strings.Join(uniqueScopes(createRequest.Scopes), " "),
+		CreatedBy:  createdBy,
+		ExpiresAt:  createRequest.ExpiresAt,
+	}
+
+	err = a.APIKeyRepository.CreateAPIKey(apiKey)
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.CreateAPIKey] Failed to create api key")
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{"apiKeyID": apiKey.ID, "createdBy": createdBy}).Info("[APIKeyServiceImpl.CreateAPIKey] API key created")
+
+	return &response.CreatedAPIKeyResponse{
+		Key:    prefix + "." + secret,
+		APIKey: toAPIKeyResponse(apiKey),
+	}, nil
+}
+
+// GetAllAPIKeys implements services.APIKeyService.
+func (a *APIKeyServiceImpl) GetAllAPIKeys() ([]response.APIKeyResponse, error) {
+	apiKeys, err := a.APIKeyRepository.GetAllAPIKeys()
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.GetAllAPIKeys] Failed to get api keys")
+		return nil, err
+	}
+
+	apiKeyResponses := make([]response.APIKeyResponse, 0, len(apiKeys))
+	for i := range apiKeys {
+		apiKeyResponses = append(apiKeyResponses, toAPIKeyResponse(&apiKeys[i]))
+	}
+
+	return apiKeyResponses, nil
+}
+
+// RevokeAPIKey implements services.APIKeyService.
+func (a *APIKeyServiceImpl) RevokeAPIKey(apiKeyID uint) error {
+	if apiKeyID == 0 {
+		return helpers.ErrInvalidAPIKeyID
+	}
+
+	_, err := a.APIKeyRepository.GetAPIKey(apiKeyID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorAPIKeyNotFound) {
+			logrus.WithError(err).Error("[APIKeyServiceImpl.RevokeAPIKey] Failed to get api key")
+		}
+		return err
+	}
+
+	err = a.APIKeyRepository.RevokeAPIKey(apiKeyID)
+	if err != nil {
+		logrus.WithError(err).Error("[APIKeyServiceImpl.RevokeAPIKey] Failed to revoke api key")
+		return err
+	}
+
+	logrus.WithField("apiKeyID", apiKeyID).Info("[APIKeyServiceImpl.RevokeAPIKey] API key revoked")
+	return nil
+}
+
+// Authenticate implements services.APIKeyService.
+// Every failure is reported as ErrInvalidAPIKey so callers can't tell an
+// unknown prefix from a wrong secret.
+func (a *APIKeyServiceImpl) Authenticate(key string) (*response.APIKeyResponse, error) {
+	prefix, secret, found := strings.Cut(key, ".")
+	if !found || !strings.HasPrefix(prefix, apiKeyPrefix) || secret == "" {
+		return nil, helpers.ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.APIKeyRepository.FindByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorAPIKeyNotFound) {
+			return nil, helpers.ErrInvalidAPIKey
+		}
+		logrus.WithError(err).Error("[APIKeyServiceImpl.Authenticate] Failed to find api key")
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(helpers.HashToken(secret)), []byte(apiKey.SecretHash)) != 1 {
+		return nil, helpers.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt)) {
+		return nil, helpers.ErrInvalidAPIKey
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		// The request goes on if the write fails, the last use is informative
+		err = a.APIKeyRepository.UpdateLastUsed(apiKey.ID, now)
+		if err != nil {
+			logrus.WithError(err).Warn("[APIKeyServiceImpl.Authenticate] Failed to update last use")
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	apiKeyResponse := toAPIKeyResponse(apiKey)
+	return &apiKeyResponse, nil
+}
+
+func toAPIKeyResponse(apiKey *models.APIKey) response.APIKeyResponse {
+	return response.APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     strings.Fields(apiKey.Scopes),
+		CreatedAt:  apiKey.CreatedAt,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+	}
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	return unique
+}
+
+func NewAPIKeyServiceImpl(apiKeyRepository repository.APIKeyRepository, validate *validator.Validate) services.APIKeyService {
+	return &APIKeyServiceImpl{
+		APIKeyRepository: apiKeyRepository,
+		Validate:         validate,
+	}
+}
@@ -0,0 +1,210 @@
+package impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyServiceImpl_CreateAPIKey(t *testing.T) {
+	t.Run("CreateAPIKey_Success", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Test data
+		createRequest := request.CreateAPIKeyRequest{
+			Name:   "eu-west game server",
+			Scopes: []string{models.PermissionAchievementsAward, models.PermissionPlayersModerate, models.PermissionAchievementsAward},
+		}
+
+		var saved *models.APIKey
+		mockAPIKeyRepo.On("CreateAPIKey", mock.MatchedBy(func(apiKey *models.APIKey) bool {
+			saved = apiKey
+			return apiKey.Name == "eu-west game server" && apiKey.CreatedBy == 1
+		})).Return(nil)
+
+		// Execution
+		created, err := apiKeyService.CreateAPIKey(1, createRequest)
+
+		// Assertions
+		require.NoError(t, err)
+		prefix, secret, found := strings.Cut(created.Key, ".")
+		assert.True(t, found)
+		assert.True(t, strings.HasPrefix(prefix, "pp_"))
+		assert.Equal(t, saved.Prefix, prefix)
+		assert.Equal(t, helpers.HashToken(secret), saved.SecretHash, "Only the hash of the secret is stored")
+		assert.Equal(t, "achievements:award players:moderate", saved.Scopes)
+		assert.Equal(t, []string{"achievements:award", "players:moderate"}, created.APIKey.Scopes)
+	})
+
+	t.Run("CreateAPIKey_UnknownScope", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Execution
+		_, err := apiKeyService.CreateAPIKey(1, request.CreateAPIKeyRequest{Name: "eu-west", Scopes: []string{"users:delete"}})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrAPIKeyDataValidation, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("CreateAPIKey_AdminScope", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Execution
+		_, err := apiKeyService.CreateAPIKey(1, request.CreateAPIKeyRequest{Name: "eu-west", Scopes: []string{models.PermissionAPIKeysManage}})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrAPIKeyDataValidation, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("CreateAPIKey_ExpiredDate", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Test data
+		expiresAt := time.Now().Add(-time.Hour)
+
+		// Execution
+		_, err := apiKeyService.CreateAPIKey(1, request.CreateAPIKeyRequest{Name: "eu-west", Scopes: []string{models.PermissionPlayersModerate}, ExpiresAt: &expiresAt})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrAPIKeyDataValidation, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+}
+
+func TestAPIKeyServiceImpl_RevokeAPIKey(t *testing.T) {
+	t.Run("RevokeAPIKey_Success", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		mockAPIKeyRepo.On("GetAPIKey", uint(1)).Return(&models.APIKey{Model: gorm.Model{ID: 1}}, nil)
+		mockAPIKeyRepo.On("RevokeAPIKey", uint(1)).Return(nil)
+
+		// Execution
+		err := apiKeyService.RevokeAPIKey(1)
+
+		// Assertions
+		require.NoError(t, err)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("RevokeAPIKey_NotFound", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		mockAPIKeyRepo.On("GetAPIKey", uint(2)).Return(nil, helpers.ErrorAPIKeyNotFound)
+
+		// Execution
+		err := apiKeyService.RevokeAPIKey(2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorAPIKeyNotFound, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "RevokeAPIKey", mock.Anything)
+	})
+}
+
+func TestAPIKeyServiceImpl_Authenticate(t *testing.T) {
+	secretHash := helpers.HashToken("secret")
+
+	t.Run("Authenticate_Success", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Test data
+		mockAPIKeyRepo.On("FindByPrefix", "pp_abc").Return(&models.APIKey{Model: gorm.Model{ID: 1}, Prefix: "pp_abc", SecretHash: secretHash, Scopes: "achievements:award"}, nil)
+		mockAPIKeyRepo.On("UpdateLastUsed", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+		// Execution
+		apiKey, err := apiKeyService.Authenticate("pp_abc.secret")
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), apiKey.ID)
+		assert.Equal(t, []string{"achievements:award"}, apiKey.Scopes)
+		assert.NotNil(t, apiKey.LastUsedAt)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Authenticate_RecentlyUsed", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		// Test data
+		lastUsedAt := time.Now().Add(-time.Second)
+		mockAPIKeyRepo.On("FindByPrefix", "pp_abc").Return(&models.APIKey{Model: gorm.Model{ID: 1}, Prefix: "pp_abc", SecretHash: secretHash, LastUsedAt: &lastUsedAt}, nil)
+
+		// Execution
+		_, err := apiKeyService.Authenticate("pp_abc.secret")
+
+		// Assertions
+		require.NoError(t, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything)
+	})
+
+	revokedAt := time.Now().Add(-time.Hour)
+	expiresAt := time.Now().Add(-time.Minute)
+
+	invalidCases := map[string]struct {
+		key    string
+		apiKey *models.APIKey
+	}{
+		"Malformed":   {"secret", nil},
+		"WrongSecret": {"pp_abc.other", &models.APIKey{Model: gorm.Model{ID: 1}, SecretHash: secretHash}},
+		"Revoked":     {"pp_abc.secret", &models.APIKey{Model: gorm.Model{ID: 1}, SecretHash: secretHash, RevokedAt: &revokedAt}},
+		"Expired":     {"pp_abc.secret", &models.APIKey{Model: gorm.Model{ID: 1}, SecretHash: secretHash, ExpiresAt: &expiresAt}},
+	}
+
+	for name, tc := range invalidCases {
+		t.Run("Authenticate_"+name, func(t *testing.T) {
+			// Mocks
+			mockAPIKeyRepo := new(mocks.APIKeyRepository)
+			apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+			mockAPIKeyRepo.On("FindByPrefix", "pp_abc").Return(tc.apiKey, nil)
+
+			// Execution
+			_, err := apiKeyService.Authenticate(tc.key)
+
+			// Assertions
+			assert.Equal(t, helpers.ErrInvalidAPIKey, err)
+			mockAPIKeyRepo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Authenticate_UnknownPrefix", func(t *testing.T) {
+		// Mocks
+		mockAPIKeyRepo := new(mocks.APIKeyRepository)
+		apiKeyService := NewAPIKeyServiceImpl(mockAPIKeyRepo, validator.New())
+
+		mockAPIKeyRepo.On("FindByPrefix", "pp_def").Return(nil, helpers.ErrorAPIKeyNotFound)
+
+		// Execution
+		_, err := apiKeyService.Authenticate("pp_def.secret")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidAPIKey, err)
+	})
+}
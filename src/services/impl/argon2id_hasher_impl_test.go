@@ -0,0 +1,94 @@
+package impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	// Small parameters keep the tests fast
+	hasher := NewArgon2idHasher(64, 1, 1, 2)
+	password := "password"
+
+	t.Run("HashPassword_Encoding", func(t *testing.T) {
+		hashedPassword, err := hasher.HashPassword(password)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=64,t=1,p=1$"), "Expected the parameters in the hash")
+
+		otherHash, err := hasher.HashPassword(password)
+		require.NoError(t, err)
+		assert.NotEqual(t, hashedPassword, otherHash, "Expected a random salt")
+	})
+
+	t.Run("ComparePassword_Success", func(t *testing.T) {
+		hashedPassword, err := hasher.HashPassword(password)
+		require.NoError(t, err)
+
+		assert.NoError(t, hasher.ComparePassword(hashedPassword, password))
+		assert.Equal(t, helpers.ErrPasswordMismatch, hasher.ComparePassword(hashedPassword, "invalid"))
+	})
+
+	t.Run("ComparePassword_OtherParameters", func(t *testing.T) {
+		hashedPassword, err := NewArgon2idHasher(128, 2, 1, 2).HashPassword(password)
+		require.NoError(t, err)
+
+		assert.NoError(t, hasher.ComparePassword(hashedPassword, password), "Expected the parameters to be read from the hash")
+		assert.True(t, hasher.NeedsRehash(hashedPassword))
+	})
+
+	t.Run("ComparePassword_LongPassword", func(t *testing.T) {
+		longPassword := strings.Repeat("a", 100)
+		hashedPassword, err := hasher.HashPassword(longPassword)
+		require.NoError(t, err)
+
+		assert.Equal(t, helpers.ErrPasswordMismatch, hasher.ComparePassword(hashedPassword, longPassword[:72]), "Expected every byte to count")
+	})
+
+	t.Run("ComparePassword_Bcrypt", func(t *testing.T) {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		assert.NoError(t, hasher.ComparePassword(string(hashedPassword), password), "Expected bcrypt hashes to keep working")
+		assert.Equal(t, helpers.ErrPasswordMismatch, hasher.ComparePassword(string(hashedPassword), "invalid"))
+		assert.True(t, hasher.NeedsRehash(string(hashedPassword)))
+	})
+
+	t.Run("ComparePassword_Malformed", func(t *testing.T) {
+		assert.Equal(t, helpers.ErrUnknownPasswordHash, hasher.ComparePassword("$argon2id$v=19$m=64$salt", password))
+		assert.Equal(t, helpers.ErrUnknownPasswordHash, hasher.ComparePassword("plain", password))
+	})
+}
+
+func TestArgon2idHasher_MaxConcurrency(t *testing.T) {
+	hasher := NewArgon2idHasher(64, 1, 1, 1).(*Argon2idHasher)
+
+	// Another hash holds the only slot
+	hasher.slots <- struct{}{}
+
+	hashed := make(chan struct{})
+	go func() {
+		_, _ = hasher.HashPassword("password")
+		close(hashed)
+	}()
+
+	select {
+	case <-hashed:
+		t.Fatal("Expected the hash to wait for a free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-hasher.slots
+
+	select {
+	case <-hashed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the hash to run once the slot is free")
+	}
+}
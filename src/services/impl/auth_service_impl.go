@@ -2,10 +2,15 @@ package impl
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/repository"
 	"github.com/dieg0code/player-profile/src/services"
 	"github.com/go-playground/validator/v10"
@@ -13,14 +18,27 @@ import (
 )
 
 type AuthServiceImpl struct {
-	UserRepository repository.UserRepository
-	PasswordHasher services.PasswordHasher
-	Validate       *validator.Validate
-	AuthUtils      auth.AuthUtils
+	UserRepository         repository.UserRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	UserTokenRepository    repository.UserTokenRepository
+	SessionRepository      repository.SessionRepository
+	TwoFactorService       services.TwoFactorService
+	PasswordHasher         services.PasswordHasher
+	Validate               *validator.Validate
+	AuthUtils              auth.AuthUtils
+	RevocationStore        auth.RevocationStore
+	SessionStore           auth.SessionStore
+	LoginLimiter           auth.LoginLimiter
+	AuthConfig             config.AuthConfig
+
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
 // Login implements services.AuthService.
-func (a *AuthServiceImpl) Login(loginRequest request.LoginRequest) (*response.LoginResponse, error) {
+// Unknown emails and wrong passwords fail the same way and take the same time,
+// and every failure counts against both the account and the client IP.
+func (a *AuthServiceImpl) Login(loginRequest request.LoginRequest, client request.ClientInfo) (*response.LoginResponse, error) {
 	// Validación de la solicitud
 	err := a.Validate.Struct(loginRequest)
 	if err != nil {
@@ -28,40 +46,544 @@ func (a *AuthServiceImpl) Login(loginRequest request.LoginRequest) (*response.Lo
 		return nil, errors.New("invalid request body")
 	}
 
+	err = a.checkLoginLimit(loginRequest.Email, client.IP)
+	if err != nil {
+		return nil, err
+	}
+
 	// Búsqueda del usuario por correo electrónico
 	user, err := a.UserRepository.FindByEmail(loginRequest.Email)
 	if err != nil {
-		logrus.WithError(err).Error("[AuthServiceImpl.Login] Failed to find user by email")
-		return nil, errors.New("user not found")
+		if !errors.Is(err, helpers.ErrorUserNotFound) {
+			logrus.WithError(err).Error("[AuthServiceImpl.Login] Failed to find user by email")
+			return nil, err
+		}
+
+		// Se compara igual contra un hash para no revelar que el correo no existe
+		_ = a.PasswordHasher.ComparePassword(a.getDummyHash(), loginRequest.Password)
+		return nil, a.recordLoginFailure(loginRequest.Email, client.IP)
 	}
 
 	// Comparación de la contraseña
 	err = a.PasswordHasher.ComparePassword(user.PassWord, loginRequest.Password)
 	if err != nil {
-		logrus.WithError(err).Error("[AuthServiceImpl.Login] Failed to compare password")
-		return nil, errors.New("invalid credentials")
+		logrus.WithField("userID", user.ID).Warn("[AuthServiceImpl.Login] Invalid password")
+		return nil, a.recordLoginFailure(loginRequest.Email, client.IP)
+	}
+
+	if a.PasswordHasher.NeedsRehash(user.PassWord) {
+		a.rehashPassword(user.ID, loginRequest.Password)
+	}
+
+	if a.AuthConfig.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		logrus.WithField("userID", user.ID).Warn("[AuthServiceImpl.Login] Login refused, email not verified")
+		return nil, helpers.ErrEmailNotVerified
+	}
+
+	// Con 2FA la contraseña solo abre un challenge que se canjea con el código,
+	// los intentos fallidos se reinician recién cuando el código es válido
+	twoFactorEnabled, err := a.TwoFactorService.IsEnabled(user.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Login] Failed to check two factor")
+		return nil, err
+	}
+
+	if twoFactorEnabled {
+		return a.issueTwoFactorChallenge(user)
+	}
+
+	a.recordLoginSuccess(user.Email)
+
+	return a.startSession(user, client, loginRequest.DeviceName)
+}
+
+// VerifyTwoFactor implements services.AuthService.
+// The challenge token is single use: a wrong code ends the challenge and the
+// login has to start again with the password.
+func (a *AuthServiceImpl) VerifyTwoFactor(verifyRequest request.TwoFactorLoginRequest, client request.ClientInfo) (*response.LoginResponse, error) {
+	err := a.Validate.Struct(verifyRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.VerifyTwoFactor] Failed to validate two factor request")
+		return nil, helpers.ErrTwoFactorDataValidation
+	}
+
+	challenge, err := a.UserTokenRepository.FindByTokenHash(models.TwoFactorChallengeToken, helpers.HashToken(verifyRequest.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserTokenNotFound) {
+			return nil, helpers.ErrInvalidTwoFactorChallenge
+		}
+		logrus.WithError(err).Error("[AuthServiceImpl.VerifyTwoFactor] Failed to find challenge")
+		return nil, err
+	}
+
+	if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, helpers.ErrInvalidTwoFactorChallenge
+	}
+
+	marked, err := a.UserTokenRepository.MarkAsUsed(challenge.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.VerifyTwoFactor] Failed to mark challenge as used")
+		return nil, err
+	}
+
+	if !marked {
+		return nil, helpers.ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := a.UserRepository.GetUser(challenge.UserID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.VerifyTwoFactor] Failed to get user")
+		return nil, err
+	}
+
+	err = a.TwoFactorService.VerifyCode(challenge.UserID, verifyRequest.Code)
+	if err != nil {
+		logrus.WithError(err).Warn("[AuthServiceImpl.VerifyTwoFactor] Two factor code refused")
+		if errors.Is(err, helpers.ErrInvalidTwoFactorCode) {
+			if recordErr := a.LoginLimiter.RecordFailure(user.Email, client.IP); recordErr != nil {
+				logrus.WithError(recordErr).Error("[AuthServiceImpl.VerifyTwoFactor] Failed to record login failure")
+			}
+		}
+		return nil, err
+	}
+
+	a.recordLoginSuccess(user.Email)
+
+	return a.startSession(user, client, verifyRequest.DeviceName)
+}
+
+// LoginWithIdentity implements services.AuthService.
+// The provider replaces the password, the email verification and two factor
+// checks still apply.
+func (a *AuthServiceImpl) LoginWithIdentity(userID uint, client request.ClientInfo) (*response.LoginResponse, error) {
+	user, err := a.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LoginWithIdentity] Failed to get user")
+		return nil, err
+	}
+
+	if a.AuthConfig.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		logrus.WithField("userID", user.ID).Warn("[AuthServiceImpl.LoginWithIdentity] Login refused, email not verified")
+		return nil, helpers.ErrEmailNotVerified
+	}
+
+	twoFactorEnabled, err := a.TwoFactorService.IsEnabled(user.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LoginWithIdentity] Failed to check two factor")
+		return nil, err
+	}
+
+	if twoFactorEnabled {
+		return a.issueTwoFactorChallenge(user)
+	}
+
+	return a.startSession(user, client, "")
+}
+
+// Refresh implements services.AuthService.
+// The presented refresh token is single use: it is exchanged for a new access
+// token and a new refresh token of the same family. Presenting a token that was
+// already used means it leaked, so the whole family is revoked.
+func (a *AuthServiceImpl) Refresh(refreshRequest request.RefreshTokenRequest, client request.ClientInfo) (*response.LoginResponse, error) {
+	err := a.Validate.Struct(refreshRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to validate refresh request")
+		return nil, helpers.ErrInvalidRefreshToken
+	}
+
+	refreshToken, err := a.RefreshTokenRepository.FindByTokenHash(helpers.HashToken(refreshRequest.RefreshToken))
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to find refresh token")
+		if errors.Is(err, helpers.ErrorRefreshTokenNotFound) {
+			return nil, helpers.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if refreshToken.RevokedAt != nil || time.Now().After(refreshToken.ExpiresAt) {
+		return nil, helpers.ErrInvalidRefreshToken
+	}
+
+	if refreshToken.UsedAt != nil {
+		return nil, a.revokeReusedFamily(refreshToken)
+	}
+
+	marked, err := a.RefreshTokenRepository.MarkAsUsed(refreshToken.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to mark refresh token as used")
+		return nil, err
+	}
+
+	// Another request used the token between the lookup and the update
+	if !marked {
+		return nil, a.revokeReusedFamily(refreshToken)
+	}
+
+	user, err := a.UserRepository.GetUser(refreshToken.UserID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to get user")
+		if errors.Is(err, helpers.ErrorUserNotFound) {
+			return nil, helpers.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	session, err := a.SessionRepository.FindByFamilyID(refreshToken.FamilyID)
+	if err != nil && !errors.Is(err, helpers.ErrorSessionNotFound) {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to find session")
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// Families started before sessions were recorded get one on their next refresh
+	if session == nil {
+		session = newSession(user.ID, refreshToken.FamilyID, client, "", now)
+
+		err = a.SessionRepository.CreateSession(session)
+		if err != nil {
+			logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to create session")
+			return nil, errors.New("failed to generate token")
+		}
+	} else {
+		err = a.SessionRepository.UpdateLastSeen(session.ID, client.IP, now)
+		if err != nil {
+			logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to update session")
+		}
+	}
+
+	return a.issueTokens(user, session)
+}
+
+// Logout implements services.AuthService.
+// The access token is revoked until it expires and its session ends, which
+// revokes the refresh token family. Tokens issued before sessions were
+// recorded have no session, their family is revoked when the client sends its
+// refresh token.
+func (a *AuthServiceImpl) Logout(userID uint, sessionID uint, tokenID string, expiresAt time.Time, logoutRequest request.LogoutRequest) error {
+	err := a.RevocationStore.RevokeToken(tokenID, userID, expiresAt)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Logout] Failed to revoke access token")
+		return err
+	}
+
+	if sessionID != 0 {
+		session, err := a.SessionRepository.GetSession(sessionID)
+		if err != nil && !errors.Is(err, helpers.ErrorSessionNotFound) {
+			logrus.WithError(err).Error("[AuthServiceImpl.Logout] Failed to get session")
+			return err
+		}
+
+		if session != nil && session.UserID == userID {
+			err = a.endSession(session)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if logoutRequest.RefreshToken == "" {
+		return nil
+	}
+
+	refreshToken, err := a.RefreshTokenRepository.FindByTokenHash(helpers.HashToken(logoutRequest.RefreshToken))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorRefreshTokenNotFound) {
+			return nil
+		}
+		logrus.WithError(err).Error("[AuthServiceImpl.Logout] Failed to find refresh token")
+		return err
+	}
+
+	// A user can't revoke somebody else's session with a leaked refresh token
+	if refreshToken.UserID != userID {
+		return nil
+	}
+
+	err = a.RefreshTokenRepository.RevokeFamily(refreshToken.FamilyID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Logout] Failed to revoke refresh token family")
+		return err
+	}
+
+	return nil
+}
+
+// LogoutAll implements services.AuthService.
+func (a *AuthServiceImpl) LogoutAll(userID uint) error {
+	if userID == 0 {
+		return helpers.ErrInvalidUserID
+	}
+
+	_, err := a.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LogoutAll] Failed to get user")
+		return err
+	}
+
+	err = a.RefreshTokenRepository.RevokeAllForUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LogoutAll] Failed to revoke refresh tokens")
+		return err
+	}
+
+	err = a.RevocationStore.RevokeAllForUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LogoutAll] Failed to revoke access tokens")
+		return err
+	}
+
+	err = a.SessionRepository.EndAllForUser(userID, time.Now())
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.LogoutAll] Failed to end sessions")
+		return err
+	}
+
+	return nil
+}
+
+// UnlockAccount implements services.AuthService.
+func (a *AuthServiceImpl) UnlockAccount(userID uint) error {
+	if userID == 0 {
+		return helpers.ErrInvalidUserID
+	}
+
+	user, err := a.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.UnlockAccount] Failed to get user")
+		return err
+	}
+
+	err = a.LoginLimiter.Unlock(user.Email)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.UnlockAccount] Failed to unlock account")
+		return err
+	}
+
+	return nil
+}
+
+// JWKS implements services.AuthService.
+func (a *AuthServiceImpl) JWKS() auth.JWKS {
+	return a.AuthUtils.JWKS()
+}
+
+func (a *AuthServiceImpl) revokeReusedFamily(refreshToken *models.RefreshToken) error {
+	logrus.WithFields(logrus.Fields{
+		"userID":   refreshToken.UserID,
+		"familyID": refreshToken.FamilyID,
+	}).Warn("[AuthServiceImpl.Refresh] Refresh token reuse detected, revoking family")
+
+	err := a.RefreshTokenRepository.RevokeFamily(refreshToken.FamilyID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to revoke refresh token family")
+		return err
+	}
+
+	// The access tokens of the stolen session stop working too
+	session, err := a.SessionRepository.FindByFamilyID(refreshToken.FamilyID)
+	if err == nil {
+		err = a.SessionStore.EndSession(session.ID)
+	}
+	if err != nil && !errors.Is(err, helpers.ErrorSessionNotFound) {
+		logrus.WithError(err).Error("[AuthServiceImpl.Refresh] Failed to end session")
+		return err
+	}
+
+	return helpers.ErrRefreshTokenReused
+}
+
+// checkLoginLimit refuses the attempt while the account or the client IP is backing off.
+func (a *AuthServiceImpl) checkLoginLimit(email string, clientIP string) error {
+	retryAfter, err := a.LoginLimiter.Check(email, clientIP)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.checkLoginLimit] Failed to check login attempts")
+		return err
+	}
+
+	if retryAfter > 0 {
+		logrus.WithField("retryAfter", retryAfter).Warn("[AuthServiceImpl.checkLoginLimit] Login attempt throttled")
+		return &helpers.RetryAfterError{Err: helpers.ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts the failed attempt and returns the uniform credentials error.
+func (a *AuthServiceImpl) recordLoginFailure(email string, clientIP string) error {
+	err := a.LoginLimiter.RecordFailure(email, clientIP)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.recordLoginFailure] Failed to record login failure")
+		return err
+	}
+
+	return helpers.ErrInvalidCredentials
+}
+
+func (a *AuthServiceImpl) recordLoginSuccess(email string) {
+	err := a.LoginLimiter.RecordSuccess(email)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.recordLoginSuccess] Failed to reset login attempts")
+	}
+}
+
+// rehashPassword replaces a hash made with an old algorithm or old parameters,
+// the login goes on when it fails and the next one tries again.
+func (a *AuthServiceImpl) rehashPassword(userID uint, password string) {
+	hashedPassword, err := a.PasswordHasher.HashPassword(password)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.rehashPassword] Failed to hash password")
+		return
+	}
+
+	err = a.UserRepository.UpdateUser(userID, &models.User{PassWord: hashedPassword})
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.rehashPassword] Failed to update password")
+		return
+	}
+
+	logrus.WithField("userID", userID).Info("[AuthServiceImpl.rehashPassword] Password rehashed")
+}
+
+// getDummyHash returns a hash made with the current hasher, so a login for an
+// unknown email costs the same as a wrong password.
+func (a *AuthServiceImpl) getDummyHash() string {
+	a.dummyHashOnce.Do(func() {
+		dummyHash, err := a.PasswordHasher.HashPassword("dummy-password-for-timing")
+		if err != nil {
+			logrus.WithError(err).Error("[AuthServiceImpl.getDummyHash] Failed to hash dummy password")
+			return
+		}
+		a.dummyHash = dummyHash
+	})
+
+	return a.dummyHash
+}
+
+func (a *AuthServiceImpl) issueTwoFactorChallenge(user *models.User) (*response.LoginResponse, error) {
+	challengeToken, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.issueTwoFactorChallenge] Failed to generate challenge")
+		return nil, errors.New("failed to generate token")
+	}
+
+	err = a.UserTokenRepository.CreateUserToken(&models.UserToken{
+		UserID:    user.ID,
+		Purpose:   models.TwoFactorChallengeToken,
+		TokenHash: helpers.HashToken(challengeToken),
+		ExpiresAt: time.Now().Add(a.AuthConfig.TwoFactorChallengeTTL),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.issueTwoFactorChallenge] Failed to store challenge")
+		return nil, errors.New("failed to generate token")
+	}
+
+	return &response.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// startSession records the signed in device, every session opens a new
+// refresh token family.
+func (a *AuthServiceImpl) startSession(user *models.User, client request.ClientInfo, deviceName string) (*response.LoginResponse, error) {
+	familyID, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.startSession] Failed to generate refresh token family")
+		return nil, errors.New("failed to generate token")
+	}
+
+	session := newSession(user.ID, familyID, client, deviceName, time.Now())
+
+	err = a.SessionRepository.CreateSession(session)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.startSession] Failed to create session")
+		return nil, errors.New("failed to generate token")
+	}
+
+	return a.issueTokens(user, session)
+}
+
+// endSession revokes the refresh token family of the session and rejects its access tokens.
+func (a *AuthServiceImpl) endSession(session *models.Session) error {
+	err := a.RefreshTokenRepository.RevokeFamily(session.FamilyID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.endSession] Failed to revoke refresh token family")
+		return err
+	}
+
+	err = a.SessionStore.EndSession(session.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.endSession] Failed to end session")
+		return err
+	}
+
+	return nil
+}
+
+func newSession(userID uint, familyID string, client request.ClientInfo, deviceName string, now time.Time) *models.Session {
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	return &models.Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		DeviceName: deviceName,
+		UserAgent:  userAgent,
+		IP:         client.IP,
+		LastSeenAt: now,
+	}
+}
+
+// issueTokens generates an access token and stores a new refresh token in the family of the session.
+func (a *AuthServiceImpl) issueTokens(user *models.User, session *models.Session) (*response.LoginResponse, error) {
+	token, err := a.AuthUtils.GenerateToken(user.ID, user.Role, session.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.issueTokens] Failed to generate token")
+		return nil, errors.New("failed to generate token")
+	}
+
+	refreshToken, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[AuthServiceImpl.issueTokens] Failed to generate refresh token")
+		return nil, errors.New("failed to generate token")
 	}
 
-	// Generación del token
-	token, err := a.AuthUtils.GenerateToken(user.ID, user.Role)
+	err = a.RefreshTokenRepository.CreateRefreshToken(&models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: helpers.HashToken(refreshToken),
+		FamilyID:  session.FamilyID,
+		ExpiresAt: time.Now().Add(a.AuthConfig.RefreshTokenTTL),
+	})
 	if err != nil {
-		logrus.WithError(err).Error("[AuthServiceImpl.Login] Failed to generate token")
+		logrus.WithError(err).Error("[AuthServiceImpl.issueTokens] Failed to store refresh token")
 		return nil, errors.New("failed to generate token")
 	}
 
-	// Creación de la respuesta de inicio de sesión
 	loginResponse := &response.LoginResponse{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(a.AuthConfig.AccessTokenTTL.Seconds()),
 	}
 
 	return loginResponse, nil
 }
 
-func NewAuthService(userRepository repository.UserRepository, passwordHasher services.PasswordHasher, validate *validator.Validate, auth auth.AuthUtils) services.AuthService {
+func NewAuthService(userRepository repository.UserRepository, refreshTokenRepository repository.RefreshTokenRepository, userTokenRepository repository.UserTokenRepository, sessionRepository repository.SessionRepository, sessionStore auth.SessionStore, passwordHasher services.PasswordHasher, validate *validator.Validate, auth auth.AuthUtils, revocationStore auth.RevocationStore, loginLimiter auth.LoginLimiter, twoFactorService services.TwoFactorService, authConfig config.AuthConfig) services.AuthService {
 	return &AuthServiceImpl{
-		UserRepository: userRepository,
-		PasswordHasher: passwordHasher,
-		Validate:       validate,
-		AuthUtils:      auth,
+		UserRepository:         userRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		UserTokenRepository:    userTokenRepository,
+		SessionRepository:      sessionRepository,
+		TwoFactorService:       twoFactorService,
+		PasswordHasher:         passwordHasher,
+		Validate:               validate,
+		AuthUtils:              auth,
+		RevocationStore:        revocationStore,
+		SessionStore:           sessionStore,
+		LoginLimiter:           loginLimiter,
+		AuthConfig:             authConfig,
 	}
 }
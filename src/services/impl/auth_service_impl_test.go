@@ -4,15 +4,60 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
 	"github.com/dieg0code/player-profile/src/models"
 	"github.com/dieg0code/player-profile/src/testutils/mocks"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
+var testAuthConfig = config.AuthConfig{
+	AccessTokenTTL:        15 * time.Minute,
+	RefreshTokenTTL:       24 * time.Hour,
+	TwoFactorChallengeTTL: 5 * time.Minute,
+}
+
+// newTwoFactorDisabledMock is the two factor service of a user without 2FA.
+func newTwoFactorDisabledMock() *mocks.MockTwoFactorService {
+	twoFactorService := new(mocks.MockTwoFactorService)
+	twoFactorService.On("IsEnabled", mock.Anything).Return(false, nil).Maybe()
+	return twoFactorService
+}
+
+// newLoginLimiterMock is a login limiter that never throttles.
+func newLoginLimiterMock() *mocks.MockLoginLimiter {
+	loginLimiter := new(mocks.MockLoginLimiter)
+	loginLimiter.On("Check", mock.Anything, mock.Anything).Return(time.Duration(0), nil).Maybe()
+	loginLimiter.On("RecordFailure", mock.Anything, mock.Anything).Return(nil).Maybe()
+	loginLimiter.On("RecordSuccess", mock.Anything).Return(nil).Maybe()
+	return loginLimiter
+}
+
+// newSessionRepositoryMock is a session repository that stores every session with ID 1.
+func newSessionRepositoryMock() *mocks.SessionRepository {
+	sessionRepo := new(mocks.SessionRepository)
+	sessionRepo.On("CreateSession", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Session).ID = 1
+	}).Return(nil).Maybe()
+	sessionRepo.On("FindByFamilyID", mock.Anything).Return(&models.Session{Model: gorm.Model{ID: 1}, UserID: 1}, nil).Maybe()
+	sessionRepo.On("UpdateLastSeen", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	sessionRepo.On("EndAllForUser", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return sessionRepo
+}
+
+// newSessionStoreMock is a session store where every session can be ended.
+func newSessionStoreMock() *mocks.MockSessionStore {
+	sessionStore := new(mocks.MockSessionStore)
+	sessionStore.On("EndSession", mock.Anything).Return(nil).Maybe()
+	return sessionStore
+}
+
 func TestAuthServiceImpl(t *testing.T) {
 	t.Run("Login_Success", func(t *testing.T) {
 		// Mocks
@@ -20,7 +65,8 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		loginRequest := request.LoginRequest{
@@ -38,30 +84,77 @@ func TestAuthServiceImpl(t *testing.T) {
 		}, nil)
 
 		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
 
-		mockAuthUtils.On("GenerateToken", uint(1), "admin").Return("token", nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "admin", uint(1)).Return("token", nil)
+
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.MatchedBy(func(refreshToken *models.RefreshToken) bool {
+			return refreshToken.UserID == 1 && refreshToken.FamilyID != "" && refreshToken.ExpiresAt.After(time.Now())
+		})).Return(nil)
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
 		assert.Nil(t, err)
 		assert.NotNil(t, loginResponse)
 		assert.Equal(t, "token", loginResponse.Token)
+		assert.NotEmpty(t, loginResponse.RefreshToken)
+		assert.Equal(t, int64(testAuthConfig.AccessTokenTTL.Seconds()), loginResponse.ExpiresIn)
 
 		mockUserRepo.AssertExpectations(t)
 		mockPasswordHasher.AssertExpectations(t)
 		mockAuthUtils.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertExpectations(t)
 
 	})
 
+	t.Run("Login_RehashOutdatedPassword", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "test@test.com",
+			Password: "password",
+		}
+
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{
+			Model:    gorm.Model{ID: 1},
+			Email:    "test@test.com",
+			PassWord: "$2a$10$oldbcrypthash",
+			Role:     "user",
+		}, nil)
+
+		mockPasswordHasher.On("ComparePassword", "$2a$10$oldbcrypthash", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "$2a$10$oldbcrypthash").Return(true)
+		mockPasswordHasher.On("HashPassword", loginRequest.Password).Return("$argon2id$newhash", nil)
+		mockUserRepo.On("UpdateUser", uint(1), &models.User{PassWord: "$argon2id$newhash"}).Return(nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(1)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Nil(t, err)
+		assert.Equal(t, "token", loginResponse.Token)
+		mockUserRepo.AssertExpectations(t)
+		mockPasswordHasher.AssertExpectations(t)
+	})
+
 	t.Run("Login_Fail_InvalidRequest", func(t *testing.T) {
 		// Mocks
 		mockUserRepo := new(mocks.UserRepository)
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		loginRequest := request.LoginRequest{
@@ -70,7 +163,7 @@ func TestAuthServiceImpl(t *testing.T) {
 		}
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
 		assert.NotNil(t, err)
@@ -87,7 +180,8 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		loginRequest := request.LoginRequest{
@@ -95,13 +189,15 @@ func TestAuthServiceImpl(t *testing.T) {
 			Password: "password",
 		}
 
-		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(nil, assert.AnError)
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(nil, helpers.ErrorUserNotFound)
+		mockPasswordHasher.On("HashPassword", mock.Anything).Return("dummyhash", nil).Once()
+		mockPasswordHasher.On("ComparePassword", "dummyhash", loginRequest.Password).Return(errors.New("password mismatch"))
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
-		assert.NotNil(t, err, "Expected error finding nonexistent user")
+		assert.Equal(t, helpers.ErrInvalidCredentials, err, "Expected the same error as a wrong password")
 		assert.Nil(t, loginResponse, "Expected nil in login response")
 
 		mockUserRepo.AssertExpectations(t)
@@ -115,7 +211,8 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		loginRequest := request.LoginRequest{
@@ -135,10 +232,10 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockPasswordHasher.On("ComparePassword", "correctpassword", loginRequest.Password).Return(errors.New("password mismatch"))
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
-		assert.NotNil(t, err, "Expected error for invalid password")
+		assert.Equal(t, helpers.ErrInvalidCredentials, err, "Expected error for invalid password")
 		assert.Nil(t, loginResponse, "Expected nil in login response")
 
 		mockUserRepo.AssertExpectations(t)
@@ -146,13 +243,182 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockAuthUtils.AssertExpectations(t)
 	})
 
+	t.Run("Login_Fail_RecordsFailure", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), mockLoginLimiter, newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "test@test.com",
+			Password: "wrongpassword",
+		}
+
+		mockLoginLimiter.On("Check", loginRequest.Email, "10.0.0.1").Return(time.Duration(0), nil)
+		mockLoginLimiter.On("RecordFailure", loginRequest.Email, "10.0.0.1").Return(nil)
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com", PassWord: "correctpassword"}, nil)
+		mockPasswordHasher.On("ComparePassword", "correctpassword", loginRequest.Password).Return(errors.New("password mismatch"))
+
+		// Execution
+		_, err := authService.Login(loginRequest, request.ClientInfo{IP: "10.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidCredentials, err)
+		mockLoginLimiter.AssertExpectations(t)
+		mockLoginLimiter.AssertNotCalled(t, "RecordSuccess", mock.Anything)
+	})
+
+	t.Run("Login_Fail_Throttled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), mockLoginLimiter, newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "test@test.com",
+			Password: "password",
+		}
+
+		mockLoginLimiter.On("Check", loginRequest.Email, "10.0.0.1").Return(30*time.Second, nil)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "10.0.0.1"})
+
+		// Validation
+		assert.ErrorIs(t, err, helpers.ErrTooManyLoginAttempts)
+		assert.Nil(t, loginResponse)
+
+		var retryAfterErr *helpers.RetryAfterError
+		assert.True(t, errors.As(err, &retryAfterErr))
+		assert.Equal(t, 30*time.Second, retryAfterErr.RetryAfter)
+
+		mockUserRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+		mockPasswordHasher.AssertNotCalled(t, "ComparePassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Login_Success_ResetsFailures", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), mockLoginLimiter, newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "Test@test.com",
+			Password: "password",
+		}
+
+		mockLoginLimiter.On("Check", loginRequest.Email, "10.0.0.1").Return(time.Duration(0), nil)
+		mockLoginLimiter.On("RecordSuccess", "test@test.com").Return(nil)
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com", PassWord: "password", Role: "user"}, nil)
+		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(1)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		_, err := authService.Login(loginRequest, request.ClientInfo{IP: "10.0.0.1"})
+
+		// Validation
+		assert.NoError(t, err)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("Login_Fail_EmailNotVerified", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authConfig := testAuthConfig
+		authConfig.RequireEmailVerification = true
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), authConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "test@test.com",
+			Password: "password",
+		}
+
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{
+			Model:    gorm.Model{ID: 1},
+			UserName: "test",
+			Email:    "test@test.com",
+			PassWord: "password",
+			Age:      20,
+			Role:     "user",
+		}, nil)
+
+		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrEmailNotVerified, err)
+		assert.Nil(t, loginResponse, "Expected nil in login response")
+
+		mockAuthUtils.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Login_Success_EmailVerified", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authConfig := testAuthConfig
+		authConfig.RequireEmailVerification = true
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), authConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{
+			Email:    "test@test.com",
+			Password: "password",
+		}
+
+		verifiedAt := time.Now().Add(-time.Hour)
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{
+			Model:           gorm.Model{ID: 1},
+			UserName:        "test",
+			Email:           "test@test.com",
+			PassWord:        "password",
+			Age:             20,
+			Role:            "user",
+			EmailVerifiedAt: &verifiedAt,
+		}, nil)
+
+		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(1)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.NoError(t, err)
+		assert.Equal(t, "token", loginResponse.Token)
+	})
+
 	t.Run("Login_Fail_TokenGeneration", func(t *testing.T) {
 		// Mocks
 		mockUserRepo := new(mocks.UserRepository)
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		loginRequest := request.LoginRequest{
@@ -170,11 +436,12 @@ func TestAuthServiceImpl(t *testing.T) {
 		}, nil)
 
 		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
 
-		mockAuthUtils.On("GenerateToken", uint(1), "admin").Return("", errors.New("token generation failed"))
+		mockAuthUtils.On("GenerateToken", uint(1), "admin", uint(1)).Return("", errors.New("token generation failed"))
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
 		assert.NotNil(t, err, "Expected error for token generation failure")
@@ -191,7 +458,8 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 		validate := validator.New()
 		mockAuthUtils := new(mocks.MockAuthUtils)
-		authService := NewAuthService(mockUserRepo, mockPasswordHasher, validate, mockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
 
 		// Test data
 		longPassword := "a" + strings.Repeat("b", 4096) // assuming a password length limit
@@ -203,7 +471,7 @@ func TestAuthServiceImpl(t *testing.T) {
 		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(nil, assert.AnError)
 
 		// Execution
-		loginResponse, err := authService.Login(loginRequest)
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
 
 		// Validation
 		assert.NotNil(t, err, "Expected validation error for long password")
@@ -215,3 +483,727 @@ func TestAuthServiceImpl(t *testing.T) {
 	})
 
 }
+
+func TestAuthServiceImpl_Sessions(t *testing.T) {
+	t.Run("Login_StartsSession", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, newSessionStoreMock(), mockPasswordHasher, validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{Email: "test@test.com", Password: "password", DeviceName: "Living room PS5"}
+		client := request.ClientInfo{IP: "203.0.113.7", UserAgent: strings.Repeat("a", 300)}
+
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(&models.User{Model: gorm.Model{ID: 1}, Role: "user", PassWord: "hashedPassword"}, nil)
+		mockPasswordHasher.On("ComparePassword", "hashedPassword", "password").Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "hashedPassword").Return(false)
+		mockSessionRepo.On("CreateSession", mock.MatchedBy(func(session *models.Session) bool {
+			return session.UserID == 1 && session.FamilyID != "" && session.DeviceName == "Living room PS5" &&
+				session.IP == "203.0.113.7" && len(session.UserAgent) == 255 && !session.LastSeenAt.IsZero()
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Session).ID = 4
+		}).Return(nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(4)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, client)
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging in")
+		assert.Equal(t, "token", loginResponse.Token)
+
+		mockSessionRepo.AssertExpectations(t)
+		mockAuthUtils.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_ReusedToken_EndsSession", func(t *testing.T) {
+		// Mocks
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionStore := new(mocks.MockSessionStore)
+		authService := NewAuthService(new(mocks.UserRepository), mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, mockSessionStore, new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		usedAt := time.Now().Add(-time.Minute)
+		mockRefreshTokenRepo.On("FindByTokenHash", helpers.HashToken("refresh-token")).Return(&models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+		mockSessionRepo.On("FindByFamilyID", "family").Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 1, FamilyID: "family"}, nil)
+		mockSessionStore.On("EndSession", uint(2)).Return(nil)
+
+		// Execution
+		_, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: "refresh-token"}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrRefreshTokenReused, err, "Expected refresh token reuse error")
+		mockSessionStore.AssertExpectations(t)
+	})
+
+	t.Run("Logout_EndsSession", func(t *testing.T) {
+		// Mocks
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionStore := new(mocks.MockSessionStore)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(new(mocks.UserRepository), mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, mockSessionStore, new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		expiresAt := time.Now().Add(time.Hour)
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(nil)
+		mockSessionRepo.On("GetSession", uint(2)).Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 1, FamilyID: "family"}, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+		mockSessionStore.On("EndSession", uint(2)).Return(nil)
+
+		// Execution
+		err := authService.Logout(1, 2, "token-id", expiresAt, request.LogoutRequest{})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out")
+		mockRefreshTokenRepo.AssertExpectations(t)
+		mockSessionStore.AssertExpectations(t)
+	})
+
+	t.Run("Logout_SessionOfOtherUser", func(t *testing.T) {
+		// Mocks
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockSessionStore := new(mocks.MockSessionStore)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(new(mocks.UserRepository), mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, mockSessionStore, new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		expiresAt := time.Now().Add(time.Hour)
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(nil)
+		mockSessionRepo.On("GetSession", uint(2)).Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 5, FamilyID: "family"}, nil)
+
+		// Execution
+		err := authService.Logout(1, 2, "token-id", expiresAt, request.LogoutRequest{})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out")
+		mockRefreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+		mockSessionStore.AssertNotCalled(t, "EndSession", mock.Anything)
+	})
+}
+
+func TestAuthServiceImpl_Refresh(t *testing.T) {
+	refreshTokenValue := "refresh-token"
+	refreshTokenHash := helpers.HashToken(refreshTokenValue)
+
+	t.Run("Refresh_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		storedToken := &models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			TokenHash: refreshTokenHash,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(storedToken, nil)
+		mockRefreshTokenRepo.On("MarkAsUsed", uint(10)).Return(true, nil)
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{
+			Model: gorm.Model{ID: 1},
+			Role:  "user",
+		}, nil)
+		mockSessionRepo.On("FindByFamilyID", "family").Return(&models.Session{Model: gorm.Model{ID: 1}, UserID: 1, FamilyID: "family"}, nil)
+		mockSessionRepo.On("UpdateLastSeen", uint(1), "10.0.0.2", mock.AnythingOfType("time.Time")).Return(nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(1)).Return("new-token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.MatchedBy(func(refreshToken *models.RefreshToken) bool {
+			return refreshToken.FamilyID == "family" && refreshToken.TokenHash != refreshTokenHash
+		})).Return(nil)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{IP: "10.0.0.2"})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error refreshing token")
+		assert.Equal(t, "new-token", refreshResponse.Token)
+		assert.NotEmpty(t, refreshResponse.RefreshToken)
+		assert.NotEqual(t, refreshTokenValue, refreshResponse.RefreshToken, "Expected refresh token to be rotated")
+
+		mockUserRepo.AssertExpectations(t)
+		mockAuthUtils.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Success_LegacyFamily", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionRepo := new(mocks.SessionRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), mockSessionRepo, newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Test data
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(&models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			TokenHash: refreshTokenHash,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockRefreshTokenRepo.On("MarkAsUsed", uint(10)).Return(true, nil)
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Role: "user"}, nil)
+		mockSessionRepo.On("FindByFamilyID", "family").Return(nil, helpers.ErrorSessionNotFound)
+		mockSessionRepo.On("CreateSession", mock.MatchedBy(func(session *models.Session) bool {
+			return session.UserID == 1 && session.FamilyID == "family" && session.UserAgent == "Xbox"
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Session).ID = 3
+		}).Return(nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(3)).Return("new-token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{IP: "10.0.0.2", UserAgent: "Xbox"})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error refreshing token")
+		assert.Equal(t, "new-token", refreshResponse.Token)
+
+		mockAuthUtils.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Fail_UnknownToken", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(nil, helpers.ErrorRefreshTokenNotFound)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidRefreshToken, err, "Expected invalid refresh token error")
+		assert.Nil(t, refreshResponse)
+
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Fail_Expired", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(&models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidRefreshToken, err, "Expected invalid refresh token error")
+		assert.Nil(t, refreshResponse)
+
+		mockRefreshTokenRepo.AssertNotCalled(t, "MarkAsUsed", mock.Anything)
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Fail_ReusedToken", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		usedAt := time.Now().Add(-time.Minute)
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(&models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrRefreshTokenReused, err, "Expected refresh token reuse error")
+		assert.Nil(t, refreshResponse)
+
+		mockAuthUtils.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Fail_ConcurrentUse", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRefreshTokenRepo.On("FindByTokenHash", refreshTokenHash).Return(&models.RefreshToken{
+			Model:     gorm.Model{ID: 10},
+			UserID:    1,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockRefreshTokenRepo.On("MarkAsUsed", uint(10)).Return(false, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{RefreshToken: refreshTokenValue}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrRefreshTokenReused, err, "Expected refresh token reuse error")
+		assert.Nil(t, refreshResponse)
+
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh_Fail_EmptyToken", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		validate := validator.New()
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validate, mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Execution
+		refreshResponse, err := authService.Refresh(request.RefreshTokenRequest{}, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidRefreshToken, err, "Expected invalid refresh token error")
+		assert.Nil(t, refreshResponse)
+
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthServiceImpl_Logout(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	t.Run("Logout_AccessTokenOnly", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(nil)
+
+		// Execution
+		err := authService.Logout(1, 0, "token-id", expiresAt, request.LogoutRequest{})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out")
+		mockRevocationStore.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertNotCalled(t, "FindByTokenHash", mock.Anything)
+	})
+
+	t.Run("Logout_WithRefreshToken", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(nil)
+		mockRefreshTokenRepo.On("FindByTokenHash", helpers.HashToken("refresh-token")).Return(&models.RefreshToken{
+			UserID:   1,
+			FamilyID: "family",
+		}, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+
+		// Execution
+		err := authService.Logout(1, 0, "token-id", expiresAt, request.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out")
+		mockRevocationStore.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Logout_RefreshTokenOfOtherUser", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(nil)
+		mockRefreshTokenRepo.On("FindByTokenHash", helpers.HashToken("refresh-token")).Return(&models.RefreshToken{
+			UserID:   2,
+			FamilyID: "family",
+		}, nil)
+
+		// Execution
+		err := authService.Logout(1, 0, "token-id", expiresAt, request.LogoutRequest{RefreshToken: "refresh-token"})
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out")
+		mockRefreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+	})
+
+	t.Run("Logout_RevocationError", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockRevocationStore.On("RevokeToken", "token-id", uint(1), expiresAt).Return(assert.AnError)
+
+		// Execution
+		err := authService.Logout(1, 0, "token-id", expiresAt, request.LogoutRequest{})
+
+		// Validation
+		assert.NotNil(t, err, "Expected error logging out")
+		mockRevocationStore.AssertExpectations(t)
+	})
+}
+
+func TestAuthServiceImpl_LogoutAll(t *testing.T) {
+	t.Run("LogoutAll_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+		mockRefreshTokenRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+		mockRevocationStore.On("RevokeAllForUser", uint(1)).Return(nil)
+
+		// Execution
+		err := authService.LogoutAll(1)
+
+		// Validation
+		assert.Nil(t, err, "Expected no error logging out all sessions")
+		mockUserRepo.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertExpectations(t)
+		mockRevocationStore.AssertExpectations(t)
+	})
+
+	t.Run("LogoutAll_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), mockRevocationStore, newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := authService.LogoutAll(1)
+
+		// Validation
+		assert.Equal(t, helpers.ErrorUserNotFound, err, "Expected user not found error")
+		mockRevocationStore.AssertNotCalled(t, "RevokeAllForUser", mock.Anything)
+	})
+
+	t.Run("LogoutAll_InvalidUserID", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Execution
+		err := authService.LogoutAll(0)
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidUserID, err, "Expected invalid user ID error")
+		mockUserRepo.AssertNotCalled(t, "GetUser", mock.Anything)
+	})
+}
+
+func TestAuthServiceImpl_TwoFactorLogin(t *testing.T) {
+	user := &models.User{
+		Model:    gorm.Model{ID: 1},
+		UserName: "test",
+		Email:    "test@test.com",
+		PassWord: "password",
+		Age:      20,
+		Role:     "admin",
+	}
+
+	t.Run("Login_TwoFactorChallenge", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), mockPasswordHasher, validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), mockTwoFactorService, testAuthConfig)
+
+		// Test data
+		loginRequest := request.LoginRequest{Email: "test@test.com", Password: "password"}
+
+		mockUserRepo.On("FindByEmail", loginRequest.Email).Return(user, nil)
+		mockPasswordHasher.On("ComparePassword", "password", loginRequest.Password).Return(nil)
+		mockPasswordHasher.On("NeedsRehash", "password").Return(false)
+		mockTwoFactorService.On("IsEnabled", uint(1)).Return(true, nil)
+
+		var challengeHash string
+		mockUserTokenRepo.On("CreateUserToken", mock.MatchedBy(func(userToken *models.UserToken) bool {
+			challengeHash = userToken.TokenHash
+			return userToken.UserID == 1 && userToken.Purpose == models.TwoFactorChallengeToken && userToken.ExpiresAt.Before(time.Now().Add(testAuthConfig.TwoFactorChallengeTTL+time.Second))
+		})).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.Login(loginRequest, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.NoError(t, err)
+		assert.True(t, loginResponse.TwoFactorRequired)
+		assert.Empty(t, loginResponse.Token, "Expected no access token before the second step")
+		assert.Empty(t, loginResponse.RefreshToken, "Expected no refresh token before the second step")
+		assert.Equal(t, helpers.HashToken(loginResponse.ChallengeToken), challengeHash)
+
+		mockAuthUtils.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyTwoFactor_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), mockTwoFactorService, testAuthConfig)
+
+		// Test data
+		challenge := &models.UserToken{Model: gorm.Model{ID: 5}, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+		mockUserTokenRepo.On("FindByTokenHash", models.TwoFactorChallengeToken, helpers.HashToken("challenge")).Return(challenge, nil)
+		mockUserTokenRepo.On("MarkAsUsed", uint(5)).Return(true, nil)
+		mockTwoFactorService.On("VerifyCode", uint(1), "123456").Return(nil)
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "admin", uint(1)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.VerifyTwoFactor(request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.NoError(t, err)
+		assert.Equal(t, "token", loginResponse.Token)
+		assert.NotEmpty(t, loginResponse.RefreshToken)
+		assert.False(t, loginResponse.TwoFactorRequired)
+
+		mockUserTokenRepo.AssertExpectations(t)
+		mockTwoFactorService.AssertExpectations(t)
+		mockRefreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("VerifyTwoFactor_WrongCode", func(t *testing.T) {
+		// Mocks
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		mockUserRepo := new(mocks.UserRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), mockLoginLimiter, mockTwoFactorService, testAuthConfig)
+
+		challenge := &models.UserToken{Model: gorm.Model{ID: 5}, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+		mockUserTokenRepo.On("FindByTokenHash", models.TwoFactorChallengeToken, helpers.HashToken("challenge")).Return(challenge, nil)
+		mockUserTokenRepo.On("MarkAsUsed", uint(5)).Return(true, nil)
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockTwoFactorService.On("VerifyCode", uint(1), "000000").Return(helpers.ErrInvalidTwoFactorCode)
+		mockLoginLimiter.On("RecordFailure", "test@test.com", "127.0.0.1").Return(nil)
+
+		// Execution
+		loginResponse, err := authService.VerifyTwoFactor(request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "000000"}, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidTwoFactorCode, err)
+		assert.Nil(t, loginResponse)
+		mockAuthUtils.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("VerifyTwoFactor_ExpiredChallenge", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		authService := NewAuthService(new(mocks.UserRepository), new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), mockTwoFactorService, testAuthConfig)
+
+		challenge := &models.UserToken{Model: gorm.Model{ID: 5}, UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}
+		mockUserTokenRepo.On("FindByTokenHash", models.TwoFactorChallengeToken, helpers.HashToken("challenge")).Return(challenge, nil)
+
+		// Execution
+		_, err := authService.VerifyTwoFactor(request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidTwoFactorChallenge, err)
+		mockTwoFactorService.AssertNotCalled(t, "VerifyCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyTwoFactor_ChallengeAlreadyUsed", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		authService := NewAuthService(new(mocks.UserRepository), new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), mockTwoFactorService, testAuthConfig)
+
+		challenge := &models.UserToken{Model: gorm.Model{ID: 5}, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+		mockUserTokenRepo.On("FindByTokenHash", models.TwoFactorChallengeToken, helpers.HashToken("challenge")).Return(challenge, nil)
+		mockUserTokenRepo.On("MarkAsUsed", uint(5)).Return(false, nil)
+
+		// Execution
+		_, err := authService.VerifyTwoFactor(request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidTwoFactorChallenge, err)
+		mockTwoFactorService.AssertNotCalled(t, "VerifyCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyTwoFactor_UnknownChallenge", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		authService := NewAuthService(new(mocks.UserRepository), new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), new(mocks.MockTwoFactorService), testAuthConfig)
+
+		mockUserTokenRepo.On("FindByTokenHash", models.TwoFactorChallengeToken, helpers.HashToken("challenge")).Return(nil, helpers.ErrorUserTokenNotFound)
+
+		// Execution
+		_, err := authService.VerifyTwoFactor(request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"}, request.ClientInfo{IP: "127.0.0.1"})
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidTwoFactorChallenge, err)
+	})
+}
+
+func TestAuthServiceImpl_UnlockAccount(t *testing.T) {
+	t.Run("UnlockAccount_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), mockLoginLimiter, newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}, nil)
+		mockLoginLimiter.On("Unlock", "test@test.com").Return(nil)
+
+		// Execution
+		err := authService.UnlockAccount(1)
+
+		// Validation
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("UnlockAccount_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), mockLoginLimiter, newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(2)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := authService.UnlockAccount(2)
+
+		// Validation
+		assert.Equal(t, helpers.ErrorUserNotFound, err)
+		mockLoginLimiter.AssertNotCalled(t, "Unlock", mock.Anything)
+	})
+
+	t.Run("UnlockAccount_InvalidUserID", func(t *testing.T) {
+		authService := NewAuthService(new(mocks.UserRepository), new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		// Execution
+		err := authService.UnlockAccount(0)
+
+		// Validation
+		assert.Equal(t, helpers.ErrInvalidUserID, err)
+	})
+}
+
+func TestAuthServiceImpl_LoginWithIdentity(t *testing.T) {
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com", Role: "user"}
+
+	t.Run("LoginWithIdentity_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		authService := NewAuthService(mockUserRepo, mockRefreshTokenRepo, new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockAuthUtils.On("GenerateToken", uint(1), "user", uint(1)).Return("token", nil)
+		mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.LoginWithIdentity(1, request.ClientInfo{})
+
+		// Validation
+		assert.NoError(t, err)
+		assert.Equal(t, "token", loginResponse.Token)
+		assert.NotEmpty(t, loginResponse.RefreshToken)
+	})
+
+	t.Run("LoginWithIdentity_TwoFactorChallenge", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockTwoFactorService := new(mocks.MockTwoFactorService)
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), mockUserTokenRepo, newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), mockAuthUtils, new(mocks.MockRevocationStore), newLoginLimiterMock(), mockTwoFactorService, testAuthConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockTwoFactorService.On("IsEnabled", uint(1)).Return(true, nil)
+		mockUserTokenRepo.On("CreateUserToken", mock.Anything).Return(nil)
+
+		// Execution
+		loginResponse, err := authService.LoginWithIdentity(1, request.ClientInfo{})
+
+		// Validation
+		assert.NoError(t, err)
+		assert.True(t, loginResponse.TwoFactorRequired)
+		mockAuthUtils.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("LoginWithIdentity_EmailNotVerified", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		authConfig := testAuthConfig
+		authConfig.RequireEmailVerification = true
+		authService := NewAuthService(mockUserRepo, new(mocks.RefreshTokenRepository), new(mocks.UserTokenRepository), newSessionRepositoryMock(), newSessionStoreMock(), new(mocks.MockPasswordHasher), validator.New(), new(mocks.MockAuthUtils), new(mocks.MockRevocationStore), newLoginLimiterMock(), newTwoFactorDisabledMock(), authConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+
+		// Execution
+		_, err := authService.LoginWithIdentity(1, request.ClientInfo{})
+
+		// Validation
+		assert.Equal(t, helpers.ErrEmailNotVerified, err)
+	})
+}
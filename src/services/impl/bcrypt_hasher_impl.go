@@ -6,14 +6,18 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-type BcryptHasher struct{}
+// BcryptHasher hashes with bcrypt, which only uses the first 72 bytes of the
+// password, longer passwords are refused.
+type BcryptHasher struct {
+	Cost int
+}
 
-func NewPassWordHasher() services.PasswordHasher {
-	return &BcryptHasher{}
+func NewBcryptHasher(cost int) services.PasswordHasher {
+	return &BcryptHasher{Cost: cost}
 }
 
 func (h *BcryptHasher) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
 	if err != nil {
 		logrus.WithError(err).Error("[BcryptHasher.HashPassword] Failed to hash password")
 		return "", err
@@ -23,5 +27,10 @@ func (h *BcryptHasher) HashPassword(password string) (string, error) {
 }
 
 func (h *BcryptHasher) ComparePassword(hashedPassword string, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return comparePassword(hashedPassword, password)
+}
+
+func (h *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != h.Cost
 }
@@ -1,13 +1,15 @@
 package impl
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestBcryptHasher(t *testing.T) {
-	hasher := NewPassWordHasher()
+	hasher := NewBcryptHasher(bcrypt.MinCost)
 	password := "password"
 
 	t.Run("HashPassword_Success", func(t *testing.T) {
@@ -34,4 +36,17 @@ func TestBcryptHasher(t *testing.T) {
 		assert.NotNil(t, err, "Expected error comparing password")
 	})
 
+	t.Run("NeedsRehash", func(t *testing.T) {
+		hashedPassword, err := hasher.HashPassword(password)
+		assert.Nil(t, err, "Expected no error hashing password")
+
+		assert.False(t, hasher.NeedsRehash(hashedPassword), "Expected no rehash with the same cost")
+		assert.True(t, NewBcryptHasher(bcrypt.MinCost+1).NeedsRehash(hashedPassword), "Expected rehash with another cost")
+		assert.True(t, hasher.NeedsRehash("$argon2id$v=19$m=8,t=1,p=1$c2FsdA$a2V5"), "Expected rehash of other algorithms")
+	})
+
+	t.Run("HashPassword_TooLong", func(t *testing.T) {
+		_, err := hasher.HashPassword(strings.Repeat("a", 73))
+		assert.NotNil(t, err, "Expected error hashing a password longer than 72 bytes")
+	})
 }
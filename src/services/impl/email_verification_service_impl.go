@@ -0,0 +1,168 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type EmailVerificationServiceImpl struct {
+	UserRepository      repository.UserRepository
+	UserTokenRepository repository.UserTokenRepository
+	Mailer              mailer.Mailer
+	Validate            *validator.Validate
+	AuthConfig          config.AuthConfig
+}
+
+// SendVerificationEmail implements services.EmailVerificationService.
+// Previous verification links of the user stop working.
+func (e *EmailVerificationServiceImpl) SendVerificationEmail(userID uint) error {
+	user, err := e.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.SendVerificationEmail] Failed to get user")
+		return err
+	}
+
+	return e.sendVerificationEmail(user)
+}
+
+// VerifyEmail implements services.EmailVerificationService.
+func (e *EmailVerificationServiceImpl) VerifyEmail(token string) error {
+	if token == "" {
+		return helpers.ErrInvalidVerificationToken
+	}
+
+	userToken, err := e.UserTokenRepository.FindByTokenHash(models.EmailVerificationToken, helpers.HashToken(token))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserTokenNotFound) {
+			return helpers.ErrInvalidVerificationToken
+		}
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.VerifyEmail] Failed to find verification token")
+		return err
+	}
+
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		return helpers.ErrInvalidVerificationToken
+	}
+
+	marked, err := e.UserTokenRepository.MarkAsUsed(userToken.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.VerifyEmail] Failed to mark verification token as used")
+		return err
+	}
+
+	if !marked {
+		return helpers.ErrInvalidVerificationToken
+	}
+
+	verifiedAt := time.Now()
+	err = e.UserRepository.UpdateUser(userToken.UserID, &models.User{EmailVerifiedAt: &verifiedAt})
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.VerifyEmail] Failed to mark email as verified")
+		return err
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail implements services.EmailVerificationService.
+// Like ForgotPassword it doesn't report unknown or already verified emails, and
+// requests arriving before VerificationResendInterval since the last email are
+// dropped silently for the same reason.
+func (e *EmailVerificationServiceImpl) ResendVerificationEmail(resendRequest request.ResendVerificationRequest) error {
+	err := e.Validate.Struct(resendRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.ResendVerificationEmail] Failed to validate resend request")
+		return helpers.ErrVerificationDataValidation
+	}
+
+	user, err := e.UserRepository.FindByEmail(resendRequest.Email)
+	if err != nil {
+		logrus.WithError(err).Warn("[EmailVerificationServiceImpl.ResendVerificationEmail] Verification requested for unknown email")
+		return nil
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	lastToken, err := e.UserTokenRepository.GetLatestUserToken(user.ID, models.EmailVerificationToken)
+	if err != nil && !errors.Is(err, helpers.ErrorUserTokenNotFound) {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.ResendVerificationEmail] Failed to get last verification token")
+		return err
+	}
+
+	if lastToken != nil && time.Since(lastToken.CreatedAt) < e.AuthConfig.VerificationResendInterval {
+		logrus.WithField("userID", user.ID).Warn("[EmailVerificationServiceImpl.ResendVerificationEmail] Verification email throttled")
+		return nil
+	}
+
+	return e.sendVerificationEmail(user)
+}
+
+func (e *EmailVerificationServiceImpl) sendVerificationEmail(user *models.User) error {
+	err := e.UserTokenRepository.DeleteUserTokens(user.ID, models.EmailVerificationToken)
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.sendVerificationEmail] Failed to delete previous verification tokens")
+		return err
+	}
+
+	token, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.sendVerificationEmail] Failed to generate verification token")
+		return err
+	}
+
+	err = e.UserTokenRepository.CreateUserToken(&models.UserToken{
+		UserID:    user.ID,
+		Purpose:   models.EmailVerificationToken,
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: time.Now().Add(e.AuthConfig.EmailVerificationTTL),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.sendVerificationEmail] Failed to store verification token")
+		return err
+	}
+
+	err = e.Mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    e.verificationEmailBody(user.UserName, token),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[EmailVerificationServiceImpl.sendVerificationEmail] Failed to send verification email")
+		return helpers.ErrSendEmail
+	}
+
+	return nil
+}
+
+func (e *EmailVerificationServiceImpl) verificationEmailBody(userName string, token string) string {
+	link := token
+	if e.AuthConfig.EmailVerificationURL != "" {
+		link = e.AuthConfig.EmailVerificationURL + "?token=" + url.QueryEscape(token)
+	}
+
+	return fmt.Sprintf("Hi %s,\n\nUse the following link to verify your email, it expires in %s:\n\n%s\n\nIf you didn't create an account you can ignore this email.\n", userName, e.AuthConfig.EmailVerificationTTL, link)
+}
+
+func NewEmailVerificationServiceImpl(userRepository repository.UserRepository, userTokenRepository repository.UserTokenRepository, mailer mailer.Mailer, validate *validator.Validate, authConfig config.AuthConfig) services.EmailVerificationService {
+	return &EmailVerificationServiceImpl{
+		UserRepository:      userRepository,
+		UserTokenRepository: userTokenRepository,
+		Mailer:              mailer,
+		Validate:            validate,
+		AuthConfig:          authConfig,
+	}
+}
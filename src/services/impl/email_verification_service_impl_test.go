@@ -0,0 +1,263 @@
+package impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testVerificationConfig = config.AuthConfig{
+	EmailVerificationTTL:       24 * time.Hour,
+	EmailVerificationURL:       "https://example.com/verify",
+	VerificationResendInterval: time.Minute,
+}
+
+func TestEmailVerificationServiceImpl_SendVerificationEmail(t *testing.T) {
+	t.Run("SendVerificationEmail_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, mockMailer, validator.New(), testVerificationConfig)
+
+		// Test data
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, UserName: "test", Email: "test@test.com"}, nil)
+		mockUserTokenRepo.On("DeleteUserTokens", uint(1), models.EmailVerificationToken).Return(nil)
+
+		var tokenHash string
+		mockUserTokenRepo.On("CreateUserToken", mock.MatchedBy(func(userToken *models.UserToken) bool {
+			tokenHash = userToken.TokenHash
+			return userToken.UserID == 1 && userToken.Purpose == models.EmailVerificationToken && userToken.ExpiresAt.After(time.Now().Add(23*time.Hour))
+		})).Return(nil)
+
+		var sent mailer.Message
+		mockMailer.On("Send", mock.MatchedBy(func(message mailer.Message) bool {
+			sent = message
+			return message.To == "test@test.com"
+		})).Return(nil)
+
+		// Execution
+		err := verificationService.SendVerificationEmail(1)
+
+		// Assertions
+		assert.NoError(t, err)
+
+		token := sent.Body[strings.Index(sent.Body, "?token=")+len("?token="):]
+		token = strings.Fields(token)[0]
+		assert.Equal(t, helpers.HashToken(token), tokenHash)
+
+		mockUserRepo.AssertExpectations(t)
+		mockUserTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("SendVerificationEmail_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, mockMailer, validator.New(), testVerificationConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := verificationService.SendVerificationEmail(1)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorUserNotFound, err)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestEmailVerificationServiceImpl_VerifyEmail(t *testing.T) {
+	t.Run("VerifyEmail_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		// Test data
+		userToken := &models.UserToken{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+		mockUserTokenRepo.On("FindByTokenHash", models.EmailVerificationToken, helpers.HashToken("token")).Return(userToken, nil)
+		mockUserTokenRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockUserRepo.On("UpdateUser", uint(1), mock.MatchedBy(func(user *models.User) bool {
+			return user.EmailVerifiedAt != nil && user.PassWord == ""
+		})).Return(nil)
+
+		// Execution
+		err := verificationService.VerifyEmail("token")
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserTokenRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("VerifyEmail_EmptyToken", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		verificationService := NewEmailVerificationServiceImpl(new(mocks.UserRepository), mockUserTokenRepo, new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		// Execution
+		err := verificationService.VerifyEmail("")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidVerificationToken, err)
+		mockUserTokenRepo.AssertNotCalled(t, "FindByTokenHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyEmail_TokenNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		verificationService := NewEmailVerificationServiceImpl(new(mocks.UserRepository), mockUserTokenRepo, new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		mockUserTokenRepo.On("FindByTokenHash", models.EmailVerificationToken, helpers.HashToken("token")).Return(nil, helpers.ErrorUserTokenNotFound)
+
+		// Execution
+		err := verificationService.VerifyEmail("token")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidVerificationToken, err)
+	})
+
+	t.Run("VerifyEmail_TokenExpired", func(t *testing.T) {
+		// Mocks
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		verificationService := NewEmailVerificationServiceImpl(new(mocks.UserRepository), mockUserTokenRepo, new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		userToken := &models.UserToken{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}
+		mockUserTokenRepo.On("FindByTokenHash", models.EmailVerificationToken, helpers.HashToken("token")).Return(userToken, nil)
+
+		// Execution
+		err := verificationService.VerifyEmail("token")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidVerificationToken, err)
+		mockUserTokenRepo.AssertNotCalled(t, "MarkAsUsed", mock.Anything)
+	})
+
+	t.Run("VerifyEmail_ConcurrentUse", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		userToken := &models.UserToken{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+		mockUserTokenRepo.On("FindByTokenHash", models.EmailVerificationToken, helpers.HashToken("token")).Return(userToken, nil)
+		mockUserTokenRepo.On("MarkAsUsed", uint(3)).Return(false, nil)
+
+		// Execution
+		err := verificationService.VerifyEmail("token")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidVerificationToken, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailVerificationServiceImpl_ResendVerificationEmail(t *testing.T) {
+	resendRequest := request.ResendVerificationRequest{Email: "test@test.com"}
+
+	t.Run("ResendVerificationEmail_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, mockMailer, validator.New(), testVerificationConfig)
+
+		// Test data
+		mockUserRepo.On("FindByEmail", "test@test.com").Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}, nil)
+		lastToken := &models.UserToken{Model: gorm.Model{ID: 3, CreatedAt: time.Now().Add(-2 * time.Minute)}, UserID: 1}
+		mockUserTokenRepo.On("GetLatestUserToken", uint(1), models.EmailVerificationToken).Return(lastToken, nil)
+		mockUserTokenRepo.On("DeleteUserTokens", uint(1), models.EmailVerificationToken).Return(nil)
+		mockUserTokenRepo.On("CreateUserToken", mock.Anything).Return(nil)
+		mockMailer.On("Send", mock.Anything).Return(nil)
+
+		// Execution
+		err := verificationService.ResendVerificationEmail(resendRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("ResendVerificationEmail_Throttled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, mockMailer, validator.New(), testVerificationConfig)
+
+		mockUserRepo.On("FindByEmail", "test@test.com").Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}, nil)
+		lastToken := &models.UserToken{Model: gorm.Model{ID: 3, CreatedAt: time.Now().Add(-10 * time.Second)}, UserID: 1}
+		mockUserTokenRepo.On("GetLatestUserToken", uint(1), models.EmailVerificationToken).Return(lastToken, nil)
+
+		// Execution
+		err := verificationService.ResendVerificationEmail(resendRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserTokenRepo.AssertNotCalled(t, "CreateUserToken", mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("ResendVerificationEmail_AlreadyVerified", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockUserTokenRepo := new(mocks.UserTokenRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, mockUserTokenRepo, mockMailer, validator.New(), testVerificationConfig)
+
+		verifiedAt := time.Now()
+		mockUserRepo.On("FindByEmail", "test@test.com").Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com", EmailVerifiedAt: &verifiedAt}, nil)
+
+		// Execution
+		err := verificationService.ResendVerificationEmail(resendRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserTokenRepo.AssertNotCalled(t, "GetLatestUserToken", mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("ResendVerificationEmail_UnknownEmail", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockMailer := new(mocks.MockMailer)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, new(mocks.UserTokenRepository), mockMailer, validator.New(), testVerificationConfig)
+
+		mockUserRepo.On("FindByEmail", "test@test.com").Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := verificationService.ResendVerificationEmail(resendRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("ResendVerificationEmail_InvalidEmail", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		verificationService := NewEmailVerificationServiceImpl(mockUserRepo, new(mocks.UserTokenRepository), new(mocks.MockMailer), validator.New(), testVerificationConfig)
+
+		// Execution
+		err := verificationService.ResendVerificationEmail(request.ResendVerificationRequest{Email: "invalid"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrVerificationDataValidation, err)
+		mockUserRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+	})
+}
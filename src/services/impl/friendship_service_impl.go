@@ -0,0 +1,374 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type FriendshipServiceImpl struct {
+	FriendshipRepository repository.FriendshipRepository
+	Validate             *validator.Validate
+}
+
+// SendFriendRequest implements services.FriendshipService.
+func (f *FriendshipServiceImpl) SendFriendRequest(playerProfileID uint, friendRequest request.SendFriendRequestRequest) (*response.FriendRequestResponse, error) {
+	err := f.Validate.Struct(friendRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.SendFriendRequest] Failed to validate friend request data")
+		return nil, helpers.ErrFriendRequestDataValidation
+	}
+
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if friendRequest.PlayerID == playerProfileID {
+		return nil, helpers.ErrSelfFriendship
+	}
+
+	created := &models.FriendRequest{SenderID: playerProfileID, ReceiverID: friendRequest.PlayerID}
+
+	err = f.FriendshipRepository.CreateFriendRequest(created)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorPlayerProfileNotFound) || errors.Is(err, helpers.ErrorPlayerBlocked) ||
+			errors.Is(err, helpers.ErrorAlreadyFriends) || errors.Is(err, helpers.ErrorFriendRequestPending) {
+			return nil, err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.SendFriendRequest] Failed to create friend request")
+		return nil, helpers.ErrRepository
+	}
+
+	// Read back with the nicknames of both players
+	stored, err := f.FriendshipRepository.GetFriendRequest(created.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.SendFriendRequest] Failed to get friend request")
+		return nil, helpers.ErrRepository
+	}
+
+	friendRequestResponse := toFriendRequestResponse(stored)
+	return &friendRequestResponse, nil
+}
+
+// GetFriendRequests implements services.FriendshipService.
+func (f *FriendshipServiceImpl) GetFriendRequests(playerProfileID uint, direction string, pageRequest request.PageRequest) ([]response.FriendRequestResponse, *response.Pagination, error) {
+	if playerProfileID == 0 {
+		return nil, nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if direction != models.FriendRequestsIncoming && direction != models.FriendRequestsOutgoing {
+		return nil, nil, helpers.ErrInvalidFriendRequestDirection
+	}
+
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	incoming := direction == models.FriendRequestsIncoming
+
+	friendRequests, err := f.FriendshipRepository.GetFriendRequests(playerProfileID, incoming, page)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.GetFriendRequests] Failed to get friend requests")
+		return nil, nil, helpers.ErrRepository
+	}
+
+	friendRequests, pagination := pageItems(friendRequests, page, models.ListQuery{}, func(friendRequest models.FriendRequest) uint { return friendRequest.ID })
+
+	if pageRequest.Total {
+		total, err := f.FriendshipRepository.CountFriendRequests(playerProfileID, incoming)
+		if err != nil {
+			logrus.WithError(err).Error("[FriendshipServiceImpl.GetFriendRequests] Failed to count friend requests")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
+	}
+
+	friendRequestResponses := make([]response.FriendRequestResponse, 0, len(friendRequests))
+	for i := range friendRequests {
+		friendRequestResponses = append(friendRequestResponses, toFriendRequestResponse(&friendRequests[i]))
+	}
+
+	return friendRequestResponses, pagination, nil
+}
+
+// AcceptFriendRequest implements services.FriendshipService.
+func (f *FriendshipServiceImpl) AcceptFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	friendRequest, err := f.getFriendRequest(friendRequestID, func(friendRequest *models.FriendRequest) bool {
+		return friendRequest.ReceiverID == playerProfileID
+	})
+	if err != nil {
+		return err
+	}
+
+	err = f.FriendshipRepository.AcceptFriendRequest(friendRequest)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorFriendRequestNotFound) {
+			return err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.AcceptFriendRequest] Failed to accept friend request")
+		return helpers.ErrRepository
+	}
+
+	return nil
+}
+
+// DeclineFriendRequest implements services.FriendshipService.
+func (f *FriendshipServiceImpl) DeclineFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	friendRequest, err := f.getFriendRequest(friendRequestID, func(friendRequest *models.FriendRequest) bool {
+		return friendRequest.ReceiverID == playerProfileID
+	})
+	if err != nil {
+		return err
+	}
+
+	return f.deleteFriendRequest(friendRequest.ID)
+}
+
+// CancelFriendRequest implements services.FriendshipService.
+func (f *FriendshipServiceImpl) CancelFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	friendRequest, err := f.getFriendRequest(friendRequestID, func(friendRequest *models.FriendRequest) bool {
+		return friendRequest.SenderID == playerProfileID
+	})
+	if err != nil {
+		return err
+	}
+
+	return f.deleteFriendRequest(friendRequest.ID)
+}
+
+// Unfriend implements services.FriendshipService.
+func (f *FriendshipServiceImpl) Unfriend(playerProfileID uint, friendID uint) error {
+	if playerProfileID == 0 || friendID == 0 {
+		return helpers.ErrInvalidPlayerProfileID
+	}
+
+	err := f.FriendshipRepository.DeleteFriendship(playerProfileID, friendID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorFriendshipNotFound) {
+			return err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.Unfriend] Failed to delete friendship")
+		return helpers.ErrRepository
+	}
+
+	return nil
+}
+
+// GetFriends implements services.FriendshipService.
+func (f *FriendshipServiceImpl) GetFriends(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	if playerProfileID == 0 {
+		return nil, nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	friends, err := f.FriendshipRepository.GetFriends(playerProfileID, page)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.GetFriends] Failed to get friends")
+		return nil, nil, helpers.ErrRepository
+	}
+
+	friends, pagination := pageItems(friends, page, models.ListQuery{}, func(friend models.PlayerProfile) uint { return friend.ID })
+
+	if pageRequest.Total {
+		total, err := f.FriendshipRepository.CountFriends(playerProfileID)
+		if err != nil {
+			logrus.WithError(err).Error("[FriendshipServiceImpl.GetFriends] Failed to count friends")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
+	}
+
+	return toPlayerProfileResponses(friends), pagination, nil
+}
+
+// GetMutualFriends implements services.FriendshipService.
+func (f *FriendshipServiceImpl) GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	if playerProfileID == 0 || otherPlayerProfileID == 0 {
+		return nil, nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	friends, err := f.FriendshipRepository.GetMutualFriends(playerProfileID, otherPlayerProfileID, page)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.GetMutualFriends] Failed to get mutual friends")
+		return nil, nil, helpers.ErrRepository
+	}
+
+	friends, pagination := pageItems(friends, page, models.ListQuery{}, func(friend models.PlayerProfile) uint { return friend.ID })
+
+	if pageRequest.Total {
+		total, err := f.FriendshipRepository.CountMutualFriends(playerProfileID, otherPlayerProfileID)
+		if err != nil {
+			logrus.WithError(err).Error("[FriendshipServiceImpl.GetMutualFriends] Failed to count mutual friends")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
+	}
+
+	return toPlayerProfileResponses(friends), pagination, nil
+}
+
+// BlockPlayer implements services.FriendshipService.
+func (f *FriendshipServiceImpl) BlockPlayer(playerProfileID uint, blockedID uint) error {
+	if playerProfileID == 0 || blockedID == 0 {
+		return helpers.ErrInvalidPlayerProfileID
+	}
+
+	if playerProfileID == blockedID {
+		return helpers.ErrSelfFriendship
+	}
+
+	err := f.FriendshipRepository.BlockPlayer(&models.PlayerBlock{BlockerID: playerProfileID, BlockedID: blockedID})
+	if err != nil {
+		if errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+			return err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.BlockPlayer] Failed to block player")
+		return helpers.ErrRepository
+	}
+
+	return nil
+}
+
+// UnblockPlayer implements services.FriendshipService.
+func (f *FriendshipServiceImpl) UnblockPlayer(playerProfileID uint, blockedID uint) error {
+	if playerProfileID == 0 || blockedID == 0 {
+		return helpers.ErrInvalidPlayerProfileID
+	}
+
+	err := f.FriendshipRepository.UnblockPlayer(playerProfileID, blockedID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorPlayerBlockNotFound) {
+			return err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.UnblockPlayer] Failed to unblock player")
+		return helpers.ErrRepository
+	}
+
+	return nil
+}
+
+// GetBlockedPlayers implements services.FriendshipService.
+func (f *FriendshipServiceImpl) GetBlockedPlayers(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	if playerProfileID == 0 {
+		return nil, nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players, err := f.FriendshipRepository.GetBlockedPlayers(playerProfileID, page)
+	if err != nil {
+		logrus.WithError(err).Error("[FriendshipServiceImpl.GetBlockedPlayers] Failed to get blocked players")
+		return nil, nil, helpers.ErrRepository
+	}
+
+	players, pagination := pageItems(players, page, models.ListQuery{}, func(player models.PlayerProfile) uint { return player.ID })
+
+	if pageRequest.Total {
+		total, err := f.FriendshipRepository.CountBlockedPlayers(playerProfileID)
+		if err != nil {
+			logrus.WithError(err).Error("[FriendshipServiceImpl.GetBlockedPlayers] Failed to count blocked players")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
+	}
+
+	return toPlayerProfileResponses(players), pagination, nil
+}
+
+// getFriendRequest returns the friend request if it belongs to the player,
+// requests of other players are reported as not found.
+func (f *FriendshipServiceImpl) getFriendRequest(friendRequestID uint, belongsToPlayer func(*models.FriendRequest) bool) (*models.FriendRequest, error) {
+	if friendRequestID == 0 {
+		return nil, helpers.ErrInvalidFriendRequestID
+	}
+
+	friendRequest, err := f.FriendshipRepository.GetFriendRequest(friendRequestID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorFriendRequestNotFound) {
+			return nil, err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.getFriendRequest] Failed to get friend request")
+		return nil, helpers.ErrRepository
+	}
+
+	if !belongsToPlayer(friendRequest) {
+		return nil, helpers.ErrorFriendRequestNotFound
+	}
+
+	return friendRequest, nil
+}
+
+func (f *FriendshipServiceImpl) deleteFriendRequest(friendRequestID uint) error {
+	err := f.FriendshipRepository.DeleteFriendRequest(friendRequestID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorFriendRequestNotFound) {
+			return err
+		}
+
+		logrus.WithError(err).Error("[FriendshipServiceImpl.deleteFriendRequest] Failed to delete friend request")
+		return helpers.ErrRepository
+	}
+
+	return nil
+}
+
+func toFriendRequestResponse(friendRequest *models.FriendRequest) response.FriendRequestResponse {
+	return response.FriendRequestResponse{
+		ID:               friendRequest.ID,
+		SenderID:         friendRequest.SenderID,
+		SenderNickname:   friendRequest.Sender.Nickname,
+		ReceiverID:       friendRequest.ReceiverID,
+		ReceiverNickname: friendRequest.Receiver.Nickname,
+		CreatedAt:        friendRequest.CreatedAt,
+	}
+}
+
+func toPlayerProfileResponses(players []models.PlayerProfile) []response.PlayerProfileResponse {
+	playerProfileResponses := make([]response.PlayerProfileResponse, 0, len(players))
+	for _, player := range players {
+		playerProfileResponses = append(playerProfileResponses, response.PlayerProfileResponse{
+			ID:         player.ID,
+			Nickname:   player.Nickname,
+			Avatar:     player.Avatar,
+			Level:      player.Level,
+			Experience: player.Experience,
+			Points:     player.Points,
+			UserID:     player.UserID,
+		})
+	}
+
+	return playerProfileResponses
+}
+
+func NewFriendshipServiceImpl(friendshipRepository repository.FriendshipRepository, validate *validator.Validate) services.FriendshipService {
+	return &FriendshipServiceImpl{
+		FriendshipRepository: friendshipRepository,
+		Validate:             validate,
+	}
+}
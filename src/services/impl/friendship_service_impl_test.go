@@ -0,0 +1,381 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFriendshipServiceImpl_SendFriendRequest(t *testing.T) {
+	t.Run("SendFriendRequest_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Test data
+		stored := &models.FriendRequest{
+			ID:         5,
+			SenderID:   1,
+			ReceiverID: 2,
+			Sender:     models.PlayerProfile{Nickname: "sender"},
+			Receiver:   models.PlayerProfile{Nickname: "receiver"},
+		}
+
+		// Expectations
+		mockFriendshipRepo.On("CreateFriendRequest", mock.MatchedBy(func(friendRequest *models.FriendRequest) bool {
+			return friendRequest.SenderID == 1 && friendRequest.ReceiverID == 2
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.FriendRequest).ID = 5
+		}).Return(nil)
+		mockFriendshipRepo.On("GetFriendRequest", uint(5)).Return(stored, nil)
+
+		// Execution
+		friendRequest, err := friendshipService.SendFriendRequest(1, request.SendFriendRequestRequest{PlayerID: 2})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, uint(5), friendRequest.ID)
+		assert.Equal(t, "sender", friendRequest.SenderNickname)
+		assert.Equal(t, "receiver", friendRequest.ReceiverNickname)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("SendFriendRequest_Invalid", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		_, validationErr := friendshipService.SendFriendRequest(1, request.SendFriendRequestRequest{})
+		_, selfErr := friendshipService.SendFriendRequest(1, request.SendFriendRequestRequest{PlayerID: 1})
+
+		// Assertions
+		assert.ErrorIs(t, validationErr, helpers.ErrFriendRequestDataValidation)
+		assert.ErrorIs(t, selfErr, helpers.ErrSelfFriendship)
+		mockFriendshipRepo.AssertNotCalled(t, "CreateFriendRequest", mock.Anything)
+	})
+
+	t.Run("SendFriendRequest_Rejected", func(t *testing.T) {
+		rejections := []error{helpers.ErrorPlayerProfileNotFound, helpers.ErrorPlayerBlocked, helpers.ErrorAlreadyFriends, helpers.ErrorFriendRequestPending}
+
+		for _, rejection := range rejections {
+			// Mocks
+			mockFriendshipRepo := new(mocks.FriendshipRepository)
+			friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+			// Expectations
+			mockFriendshipRepo.On("CreateFriendRequest", mock.Anything).Return(rejection)
+
+			// Execution
+			friendRequest, err := friendshipService.SendFriendRequest(1, request.SendFriendRequestRequest{PlayerID: 2})
+
+			// Assertions
+			assert.ErrorIs(t, err, rejection)
+			assert.Nil(t, friendRequest)
+		}
+	})
+
+	t.Run("SendFriendRequest_RepositoryError", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("CreateFriendRequest", mock.Anything).Return(gorm.ErrInvalidDB)
+
+		// Execution
+		_, err := friendshipService.SendFriendRequest(1, request.SendFriendRequestRequest{PlayerID: 2})
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrRepository)
+	})
+}
+
+func TestFriendshipServiceImpl_AnswerFriendRequest(t *testing.T) {
+	friendRequest := &models.FriendRequest{ID: 5, SenderID: 1, ReceiverID: 2}
+
+	t.Run("AcceptFriendRequest_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequest", uint(5)).Return(friendRequest, nil)
+		mockFriendshipRepo.On("AcceptFriendRequest", friendRequest).Return(nil)
+
+		// Execution
+		err := friendshipService.AcceptFriendRequest(2, 5)
+
+		// Assertions
+		require.NoError(t, err)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("DeclineFriendRequest_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequest", uint(5)).Return(friendRequest, nil)
+		mockFriendshipRepo.On("DeleteFriendRequest", uint(5)).Return(nil)
+
+		// Execution
+		err := friendshipService.DeclineFriendRequest(2, 5)
+
+		// Assertions
+		require.NoError(t, err)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("CancelFriendRequest_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequest", uint(5)).Return(friendRequest, nil)
+		mockFriendshipRepo.On("DeleteFriendRequest", uint(5)).Return(nil)
+
+		// Execution
+		err := friendshipService.CancelFriendRequest(1, 5)
+
+		// Assertions
+		require.NoError(t, err)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherPlayersRequest_NotFound", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequest", uint(5)).Return(friendRequest, nil)
+
+		// Execution
+		// The sender can't accept its own request and the receiver can't cancel it
+		acceptErr := friendshipService.AcceptFriendRequest(1, 5)
+		declineErr := friendshipService.DeclineFriendRequest(3, 5)
+		cancelErr := friendshipService.CancelFriendRequest(2, 5)
+
+		// Assertions
+		assert.ErrorIs(t, acceptErr, helpers.ErrorFriendRequestNotFound)
+		assert.ErrorIs(t, declineErr, helpers.ErrorFriendRequestNotFound)
+		assert.ErrorIs(t, cancelErr, helpers.ErrorFriendRequestNotFound)
+		mockFriendshipRepo.AssertNotCalled(t, "AcceptFriendRequest", mock.Anything)
+		mockFriendshipRepo.AssertNotCalled(t, "DeleteFriendRequest", mock.Anything)
+	})
+
+	t.Run("AcceptFriendRequest_InvalidID", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		err := friendshipService.AcceptFriendRequest(2, 0)
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrInvalidFriendRequestID)
+	})
+}
+
+func TestFriendshipServiceImpl_GetFriendRequests(t *testing.T) {
+	t.Run("GetFriendRequests_Incoming", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequests", uint(2), true, models.Page{Offset: 10, Limit: 11}).Return([]models.FriendRequest{{ID: 5, SenderID: 1, ReceiverID: 2}}, nil)
+
+		// Execution
+		friendRequests, pagination, err := friendshipService.GetFriendRequests(2, models.FriendRequestsIncoming, request.PageRequest{Page: 2, PageSize: 10})
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, friendRequests, 1)
+		assert.Equal(t, uint(1), friendRequests[0].SenderID)
+		assert.False(t, pagination.HasMore)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetFriendRequests_OutgoingWithTotal", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Test data
+		friendRequests := []models.FriendRequest{{ID: 5, SenderID: 1, ReceiverID: 2}, {ID: 8, SenderID: 1, ReceiverID: 3}}
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriendRequests", uint(1), false, models.Page{Limit: 2, AfterID: 4}).Return(friendRequests, nil)
+		mockFriendshipRepo.On("CountFriendRequests", uint(1), false).Return(int64(5), nil)
+
+		// Execution
+		result, pagination, err := friendshipService.GetFriendRequests(1, models.FriendRequestsOutgoing, request.PageRequest{Page: 1, PageSize: 1, After: helpers.EncodeCursor(4), Total: true})
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.True(t, pagination.HasMore)
+		assert.Equal(t, helpers.EncodeCursor(5), pagination.NextCursor)
+		assert.Equal(t, int64(5), *pagination.Total)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetFriendRequests_InvalidDirection", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		_, _, err := friendshipService.GetFriendRequests(2, "sideways", request.PageRequest{Page: 1, PageSize: 10})
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrInvalidFriendRequestDirection)
+	})
+}
+
+func TestFriendshipServiceImpl_GetFriends(t *testing.T) {
+	t.Run("GetFriends_WithTotal", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Test data
+		friends := []models.PlayerProfile{{Model: gorm.Model{ID: 3}}, {Model: gorm.Model{ID: 4}}, {Model: gorm.Model{ID: 6}}}
+
+		// Expectations
+		mockFriendshipRepo.On("GetFriends", uint(1), models.Page{Limit: 3}).Return(friends, nil)
+		mockFriendshipRepo.On("CountFriends", uint(1)).Return(int64(7), nil)
+
+		// Execution
+		result, pagination, err := friendshipService.GetFriends(1, request.PageRequest{Page: 1, PageSize: 2, Total: true})
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.True(t, pagination.HasMore)
+		assert.Equal(t, helpers.EncodeCursor(4), pagination.NextCursor)
+		assert.Equal(t, int64(7), *pagination.Total)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetMutualFriends_Cursor", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetMutualFriends", uint(1), uint(2), models.Page{Limit: 11, AfterID: 3}).Return([]models.PlayerProfile{{Model: gorm.Model{ID: 4}}}, nil)
+
+		// Execution
+		result, pagination, err := friendshipService.GetMutualFriends(1, 2, request.PageRequest{Page: 1, PageSize: 10, After: helpers.EncodeCursor(3)})
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.False(t, pagination.HasMore)
+		assert.Nil(t, pagination.Total)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetFriends_InvalidCursor", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		_, _, err := friendshipService.GetFriends(1, request.PageRequest{PageSize: 10, After: "nope"})
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrInvalidCursor)
+	})
+}
+
+func TestFriendshipServiceImpl_GetBlockedPlayers(t *testing.T) {
+	t.Run("GetBlockedPlayers_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("GetBlockedPlayers", uint(1), models.Page{Limit: 11, BeforeID: 9}).Return([]models.PlayerProfile{{Model: gorm.Model{ID: 2}}}, nil)
+
+		// Execution
+		result, pagination, err := friendshipService.GetBlockedPlayers(1, request.PageRequest{Page: 1, PageSize: 10, Before: helpers.EncodeCursor(9)})
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, uint(2), result[0].ID)
+		assert.Nil(t, pagination.Total)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetBlockedPlayers_InvalidPlayerID", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		_, _, err := friendshipService.GetBlockedPlayers(0, request.PageRequest{Page: 1, PageSize: 10})
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrInvalidPlayerProfileID)
+	})
+}
+
+func TestFriendshipServiceImpl_BlockPlayer(t *testing.T) {
+	t.Run("BlockPlayer_Success", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("BlockPlayer", &models.PlayerBlock{BlockerID: 1, BlockedID: 2}).Return(nil)
+
+		// Execution
+		err := friendshipService.BlockPlayer(1, 2)
+
+		// Assertions
+		require.NoError(t, err)
+		mockFriendshipRepo.AssertExpectations(t)
+	})
+
+	t.Run("BlockPlayer_Self", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Execution
+		err := friendshipService.BlockPlayer(1, 1)
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrSelfFriendship)
+	})
+
+	t.Run("UnblockPlayer_NotBlocked", func(t *testing.T) {
+		// Mocks
+		mockFriendshipRepo := new(mocks.FriendshipRepository)
+		friendshipService := NewFriendshipServiceImpl(mockFriendshipRepo, validator.New())
+
+		// Expectations
+		mockFriendshipRepo.On("UnblockPlayer", uint(1), uint(2)).Return(helpers.ErrorPlayerBlockNotFound)
+
+		// Execution
+		err := friendshipService.UnblockPlayer(1, 2)
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrorPlayerBlockNotFound)
+	})
+}
@@ -0,0 +1,66 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/sirupsen/logrus"
+)
+
+type ImpersonationServiceImpl struct {
+	UserRepository  repository.UserRepository
+	AuthUtils       auth.AuthUtils
+	PermissionStore auth.PermissionStore
+	AuthConfig      config.AuthConfig
+}
+
+// Impersonate implements services.ImpersonationService.
+// Only users whose role grants no permission can be impersonated, so the token
+// never gives the admin more than a player sees.
+func (i *ImpersonationServiceImpl) Impersonate(actorID uint, userID uint) (*response.ImpersonationResponse, error) {
+	if userID == 0 {
+		return nil, helpers.ErrInvalidUserID
+	}
+
+	user, err := i.UserRepository.GetUser(userID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorUserNotFound) {
+			logrus.WithError(err).Error("[ImpersonationServiceImpl.Impersonate] Failed to get user")
+		}
+		return nil, err
+	}
+
+	permissions, ok := i.PermissionStore.Permissions(user.Role)
+	if !ok || len(permissions) > 0 || user.ID == actorID {
+		return nil, helpers.ErrCannotImpersonate
+	}
+
+	token, err := i.AuthUtils.GenerateImpersonationToken(user.ID, user.Role, actorID, i.AuthConfig.ImpersonationTokenTTL)
+	if err != nil {
+		logrus.WithError(err).Error("[ImpersonationServiceImpl.Impersonate] Failed to generate token")
+		return nil, errors.New("failed to generate token")
+	}
+
+	logrus.WithFields(logrus.Fields{"impersonatorID": actorID, "userID": user.ID}).Warn("[ImpersonationServiceImpl.Impersonate] Impersonation started")
+
+	return &response.ImpersonationResponse{
+		Token:          token,
+		ExpiresIn:      int64(i.AuthConfig.ImpersonationTokenTTL.Seconds()),
+		UserID:         user.ID,
+		ImpersonatorID: actorID,
+	}, nil
+}
+
+func NewImpersonationServiceImpl(userRepository repository.UserRepository, authUtils auth.AuthUtils, permissionStore auth.PermissionStore, authConfig config.AuthConfig) services.ImpersonationService {
+	return &ImpersonationServiceImpl{
+		UserRepository:  userRepository,
+		AuthUtils:       authUtils,
+		PermissionStore: permissionStore,
+		AuthConfig:      authConfig,
+	}
+}
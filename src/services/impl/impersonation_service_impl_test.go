@@ -0,0 +1,104 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestImpersonationServiceImpl_Impersonate(t *testing.T) {
+	impersonationConfig := testAuthConfig
+	impersonationConfig.ImpersonationTokenTTL = 10 * time.Minute
+
+	newPermissionStore := func() *mocks.MockPermissionStore {
+		permissionStore := new(mocks.MockPermissionStore)
+		permissionStore.On("Permissions", models.UserRole).Return([]string{}, true)
+		permissionStore.On("Permissions", models.AdminRole).Return([]string{models.PermissionUsersImpersonate}, true)
+		permissionStore.On("Permissions", mock.Anything).Return(nil, false)
+		return permissionStore
+	}
+
+	t.Run("Impersonate_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		impersonationService := NewImpersonationServiceImpl(mockUserRepo, mockAuthUtils, newPermissionStore(), impersonationConfig)
+
+		// Test data
+		mockUserRepo.On("GetUser", uint(42)).Return(&models.User{Model: gorm.Model{ID: 42}, Role: models.UserRole}, nil)
+		mockAuthUtils.On("GenerateImpersonationToken", uint(42), models.UserRole, uint(1), 10*time.Minute).Return("token", nil)
+
+		// Execution
+		impersonation, err := impersonationService.Impersonate(1, 42)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, "token", impersonation.Token)
+		assert.Equal(t, int64(600), impersonation.ExpiresIn)
+		assert.Equal(t, uint(42), impersonation.UserID)
+		assert.Equal(t, uint(1), impersonation.ImpersonatorID)
+		mockAuthUtils.AssertExpectations(t)
+	})
+
+	t.Run("Impersonate_PrivilegedUser", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthUtils := new(mocks.MockAuthUtils)
+		impersonationService := NewImpersonationServiceImpl(mockUserRepo, mockAuthUtils, newPermissionStore(), impersonationConfig)
+
+		mockUserRepo.On("GetUser", uint(2)).Return(&models.User{Model: gorm.Model{ID: 2}, Role: models.AdminRole}, nil)
+
+		// Execution
+		_, err := impersonationService.Impersonate(1, 2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrCannotImpersonate, err)
+		mockAuthUtils.AssertNotCalled(t, "GenerateImpersonationToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Impersonate_UnknownRole", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		impersonationService := NewImpersonationServiceImpl(mockUserRepo, new(mocks.MockAuthUtils), newPermissionStore(), impersonationConfig)
+
+		mockUserRepo.On("GetUser", uint(3)).Return(&models.User{Model: gorm.Model{ID: 3}, Role: "deleted"}, nil)
+
+		// Execution
+		_, err := impersonationService.Impersonate(1, 3)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrCannotImpersonate, err)
+	})
+
+	t.Run("Impersonate_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		impersonationService := NewImpersonationServiceImpl(mockUserRepo, new(mocks.MockAuthUtils), newPermissionStore(), impersonationConfig)
+
+		mockUserRepo.On("GetUser", uint(42)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		_, err := impersonationService.Impersonate(1, 42)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorUserNotFound, err)
+	})
+
+	t.Run("Impersonate_InvalidUserID", func(t *testing.T) {
+		// Mocks
+		impersonationService := NewImpersonationServiceImpl(new(mocks.UserRepository), new(mocks.MockAuthUtils), newPermissionStore(), impersonationConfig)
+
+		// Execution
+		_, err := impersonationService.Impersonate(1, 0)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidUserID, err)
+	})
+}
@@ -0,0 +1,68 @@
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+)
+
+type cachedLeaderboard struct {
+	players  []models.PlayerProfile
+	loadedAt time.Time
+}
+
+// LeaderboardCacheImpl serves the top of the leaderboards from memory, so
+// the busiest pages don't hit the database on every request. Boards can be
+// up to TTL old.
+type LeaderboardCacheImpl struct {
+	LeaderboardRepository repository.LeaderboardRepository
+	CacheSize             int
+	TTL                   time.Duration
+
+	mutex  sync.Mutex
+	boards map[string]cachedLeaderboard
+}
+
+// Top implements services.LeaderboardCache.
+func (c *LeaderboardCacheImpl) Top(metric string) ([]models.PlayerProfile, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	board, ok := c.boards[metric]
+	if ok && time.Since(board.loadedAt) < c.TTL {
+		return board.players, nil
+	}
+
+	players, err := c.LeaderboardRepository.GetRankedPlayers(metric, 0, c.CacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	c.boards[metric] = cachedLeaderboard{players: players, loadedAt: time.Now()}
+	return players, nil
+}
+
+// Size implements services.LeaderboardCache.
+func (c *LeaderboardCacheImpl) Size() int {
+	return c.CacheSize
+}
+
+// Invalidate implements services.LeaderboardCache.
+func (c *LeaderboardCacheImpl) Invalidate() {
+	c.mutex.Lock()
+	c.boards = make(map[string]cachedLeaderboard)
+	c.mutex.Unlock()
+}
+
+func NewLeaderboardCacheImpl(leaderboardRepository repository.LeaderboardRepository, leaderboardConfig config.LeaderboardConfig) services.LeaderboardCache {
+	return &LeaderboardCacheImpl{
+		LeaderboardRepository: leaderboardRepository,
+		CacheSize:             leaderboardConfig.CacheSize,
+		TTL:                   leaderboardConfig.CacheTTL,
+		boards:                make(map[string]cachedLeaderboard),
+	}
+}
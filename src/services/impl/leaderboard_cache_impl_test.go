@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboardCacheImpl_Top(t *testing.T) {
+	t.Run("Top_ServedFromMemory", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		leaderboardCache := NewLeaderboardCacheImpl(mockLeaderboardRepo, config.LeaderboardConfig{CacheSize: 3, CacheTTL: time.Minute})
+
+		// Expectations
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricPoints, 0, 3).Return(rankedPlayers(1, 300, 200, 100), nil).Once()
+
+		// Execution
+		first, err := leaderboardCache.Top(models.LeaderboardMetricPoints)
+		require.NoError(t, err)
+		second, err := leaderboardCache.Top(models.LeaderboardMetricPoints)
+		require.NoError(t, err)
+
+		// Assertions
+		assert.Equal(t, first, second)
+		assert.Equal(t, 3, leaderboardCache.Size())
+		mockLeaderboardRepo.AssertExpectations(t)
+	})
+
+	t.Run("Top_ReloadedAfterInvalidate", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		leaderboardCache := NewLeaderboardCacheImpl(mockLeaderboardRepo, config.LeaderboardConfig{CacheSize: 3, CacheTTL: time.Minute})
+
+		// Expectations
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricPoints, 0, 3).Return(rankedPlayers(1, 300), nil).Twice()
+
+		// Execution
+		_, err := leaderboardCache.Top(models.LeaderboardMetricPoints)
+		require.NoError(t, err)
+		leaderboardCache.Invalidate()
+		_, err = leaderboardCache.Top(models.LeaderboardMetricPoints)
+		require.NoError(t, err)
+
+		// Assertions
+		mockLeaderboardRepo.AssertExpectations(t)
+	})
+
+	t.Run("Top_RepositoryErrorNotCached", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		leaderboardCache := NewLeaderboardCacheImpl(mockLeaderboardRepo, config.LeaderboardConfig{CacheSize: 3, CacheTTL: time.Minute})
+
+		// Expectations
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricLevel, 0, 3).Return(nil, assert.AnError).Once()
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricLevel, 0, 3).Return(rankedPlayers(1, 10), nil).Once()
+
+		// Execution
+		_, err := leaderboardCache.Top(models.LeaderboardMetricLevel)
+		assert.Equal(t, assert.AnError, err)
+		players, err := leaderboardCache.Top(models.LeaderboardMetricLevel)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Len(t, players, 1)
+		mockLeaderboardRepo.AssertExpectations(t)
+	})
+}
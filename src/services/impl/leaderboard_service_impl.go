@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/sirupsen/logrus"
+)
+
+type LeaderboardServiceImpl struct {
+	LeaderboardRepository   repository.LeaderboardRepository
+	PlayerProfileRepository repository.PlayerProfileRepository
+	LeaderboardCache        services.LeaderboardCache
+	LeaderboardConfig       config.LeaderboardConfig
+}
+
+// GetLeaderboard implements services.LeaderboardService.
+func (l *LeaderboardServiceImpl) GetLeaderboard(metric string, ranking string, page int, pageSize int) (*response.LeaderboardResponse, error) {
+	err := validateLeaderboard(metric, ranking)
+	if err != nil {
+		return nil, err
+	}
+
+	if page <= 0 || pageSize <= 0 {
+		return nil, helpers.ErrInvalidPagination
+	}
+
+	entries, err := l.rankedEntries(metric, ranking, (page-1)*pageSize, pageSize)
+	if err != nil {
+		logrus.WithError(err).Error("[LeaderboardServiceImpl.GetLeaderboard] Failed to rank players")
+		return nil, helpers.ErrRepository
+	}
+
+	return &response.LeaderboardResponse{Metric: metric, Ranking: ranking, Entries: entries}, nil
+}
+
+// GetPlayerNeighbourhood implements services.LeaderboardService.
+// Players near the top get fewer neighbours above them. The cache is skipped
+// so the window always matches the current position of the player.
+func (l *LeaderboardServiceImpl) GetPlayerNeighbourhood(metric string, ranking string, playerProfileID uint, neighbours int) (*response.LeaderboardResponse, error) {
+	err := validateLeaderboard(metric, ranking)
+	if err != nil {
+		return nil, err
+	}
+
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if neighbours < 0 || neighbours > l.LeaderboardConfig.MaxNeighbours {
+		return nil, helpers.ErrInvalidNeighbours
+	}
+
+	playerProfile, err := l.PlayerProfileRepository.GetPlayerProfile(playerProfileID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+			logrus.WithError(err).Error("[LeaderboardServiceImpl.GetPlayerNeighbourhood] Failed to get player profile")
+		}
+		return nil, err
+	}
+
+	position, err := l.LeaderboardRepository.GetPlayerPosition(metric, playerProfile)
+	if err != nil {
+		logrus.WithError(err).Error("[LeaderboardServiceImpl.GetPlayerNeighbourhood] Failed to get player position")
+		return nil, helpers.ErrRepository
+	}
+
+	offset := max(int(position)-neighbours, 0)
+	limit := int(position) - offset + neighbours + 1
+
+	entries, err := l.rankedEntriesFromDatabase(metric, ranking, offset, limit)
+	if err != nil {
+		logrus.WithError(err).Error("[LeaderboardServiceImpl.GetPlayerNeighbourhood] Failed to rank players")
+		return nil, helpers.ErrRepository
+	}
+
+	return &response.LeaderboardResponse{Metric: metric, Ranking: ranking, Entries: entries}, nil
+}
+
+// rankedEntries ranks the players between offset and offset+limit, from
+// memory when the page is inside the cached top.
+func (l *LeaderboardServiceImpl) rankedEntries(metric string, ranking string, offset int, limit int) ([]response.LeaderboardEntryResponse, error) {
+	if offset+limit <= l.LeaderboardCache.Size() {
+		top, err := l.LeaderboardCache.Top(metric)
+		if err != nil {
+			return nil, err
+		}
+
+		end := min(offset+limit, len(top))
+		entries := rankPlayers(top[:end], metric, ranking, 1, 0)
+		return entries[min(offset, end):], nil
+	}
+
+	return l.rankedEntriesFromDatabase(metric, ranking, offset, limit)
+}
+
+// rankedEntriesFromDatabase ranks the players between offset and
+// offset+limit, counting the players above the first one to know its rank.
+func (l *LeaderboardServiceImpl) rankedEntriesFromDatabase(metric string, ranking string, offset int, limit int) ([]response.LeaderboardEntryResponse, error) {
+	players, err := l.LeaderboardRepository.GetRankedPlayers(metric, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(players) == 0 {
+		return []response.LeaderboardEntryResponse{}, nil
+	}
+
+	above, err := l.LeaderboardRepository.CountPlayersAbove(metric, players[0].LeaderboardValue(metric), ranking == models.RankingDense)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankPlayers(players, metric, ranking, int(above)+1, offset), nil
+}
+
+// rankPlayers ranks ordered players, the first one has firstRank and is at
+// firstPosition (zero-based) of the whole leaderboard.
+func rankPlayers(players []models.PlayerProfile, metric string, ranking string, firstRank int, firstPosition int) []response.LeaderboardEntryResponse {
+	entries := make([]response.LeaderboardEntryResponse, len(players))
+
+	rank := firstRank
+	for i := range players {
+		value := players[i].LeaderboardValue(metric)
+
+		if i > 0 && value != entries[i-1].Value {
+			if ranking == models.RankingDense {
+				rank++
+			} else {
+				rank = firstPosition + i + 1
+			}
+		}
+
+		entries[i] = response.LeaderboardEntryResponse{
+			Rank:     rank,
+			PlayerID: players[i].ID,
+			Nickname: players[i].Nickname,
+			Value:    value,
+		}
+	}
+
+	return entries
+}
+
+func validateLeaderboard(metric string, ranking string) error {
+	if !models.IsLeaderboardMetric(metric) {
+		return helpers.ErrInvalidLeaderboardMetric
+	}
+
+	if ranking != models.RankingCompetitive && ranking != models.RankingDense {
+		return helpers.ErrInvalidRanking
+	}
+
+	return nil
+}
+
+func NewLeaderboardServiceImpl(leaderboardRepository repository.LeaderboardRepository, playerProfileRepository repository.PlayerProfileRepository, leaderboardCache services.LeaderboardCache, leaderboardConfig config.LeaderboardConfig) services.LeaderboardService {
+	return &LeaderboardServiceImpl{
+		LeaderboardRepository:   leaderboardRepository,
+		PlayerProfileRepository: playerProfileRepository,
+		LeaderboardCache:        leaderboardCache,
+		LeaderboardConfig:       leaderboardConfig,
+	}
+}
@@ -0,0 +1,223 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testLeaderboardConfig = config.LeaderboardConfig{CacheSize: 5, MaxNeighbours: 3}
+
+// rankedPlayers returns players with the given points and ids starting at firstID.
+func rankedPlayers(firstID uint, points ...int) []models.PlayerProfile {
+	players := make([]models.PlayerProfile, len(points))
+	for i, p := range points {
+		players[i] = models.PlayerProfile{Model: gorm.Model{ID: firstID + uint(i)}, Points: p}
+	}
+	return players
+}
+
+func ranksOf(entries []response.LeaderboardEntryResponse) []int {
+	ranks := make([]int, len(entries))
+	for i, entry := range entries {
+		ranks[i] = entry.Rank
+	}
+	return ranks
+}
+
+func TestLeaderboardServiceImpl_GetLeaderboard(t *testing.T) {
+	t.Run("GetLeaderboard_CompetitiveFromCache", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, mockPlayerRepo, mockCache, testLeaderboardConfig)
+
+		// Expectations
+		mockCache.On("Size").Return(5)
+		mockCache.On("Top", models.LeaderboardMetricPoints).Return(rankedPlayers(1, 300, 200, 200, 100), nil)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetLeaderboard(models.LeaderboardMetricPoints, models.RankingCompetitive, 1, 4)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, leaderboard.Entries, 4)
+		assert.Equal(t, []int{1, 2, 2, 4}, ranksOf(leaderboard.Entries))
+		assert.Equal(t, 300, leaderboard.Entries[0].Value)
+		mockCache.AssertExpectations(t)
+		mockLeaderboardRepo.AssertNotCalled(t, "GetRankedPlayers")
+	})
+
+	t.Run("GetLeaderboard_DenseFromCache", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, mockPlayerRepo, mockCache, testLeaderboardConfig)
+
+		// Expectations
+		mockCache.On("Size").Return(5)
+		mockCache.On("Top", models.LeaderboardMetricPoints).Return(rankedPlayers(1, 300, 200, 200, 100), nil)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetLeaderboard(models.LeaderboardMetricPoints, models.RankingDense, 2, 2)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, ranksOf(leaderboard.Entries), "Expected the page to keep the ranks of the whole board")
+		assert.Equal(t, uint(3), leaderboard.Entries[0].PlayerID)
+	})
+
+	t.Run("GetLeaderboard_FromDatabase", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, mockPlayerRepo, mockCache, testLeaderboardConfig)
+
+		// Expectations
+		// Page 2 of 4 ends past the cached top 5
+		mockCache.On("Size").Return(5)
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricPoints, 4, 4).Return(rankedPlayers(5, 100, 100, 50), nil)
+		// Three players are above the first one, tied with the player at position 4
+		mockLeaderboardRepo.On("CountPlayersAbove", models.LeaderboardMetricPoints, 100, false).Return(int64(3), nil)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetLeaderboard(models.LeaderboardMetricPoints, models.RankingCompetitive, 2, 4)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []int{4, 4, 7}, ranksOf(leaderboard.Entries))
+		mockLeaderboardRepo.AssertExpectations(t)
+		mockCache.AssertNotCalled(t, "Top", models.LeaderboardMetricPoints)
+	})
+
+	testCases := []struct {
+		name        string
+		metric      string
+		ranking     string
+		page        int
+		pageSize    int
+		expectedErr error
+	}{
+		{name: "GetLeaderboard_InvalidMetric", metric: "nickname", ranking: models.RankingCompetitive, page: 1, pageSize: 10, expectedErr: helpers.ErrInvalidLeaderboardMetric},
+		{name: "GetLeaderboard_InvalidRanking", metric: models.LeaderboardMetricLevel, ranking: "olympic", page: 1, pageSize: 10, expectedErr: helpers.ErrInvalidRanking},
+		{name: "GetLeaderboard_InvalidPagination", metric: models.LeaderboardMetricLevel, ranking: models.RankingDense, page: 0, pageSize: 10, expectedErr: helpers.ErrInvalidPagination},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			leaderboardService := NewLeaderboardServiceImpl(new(mocks.LeaderboardRepository), new(mocks.PlayerProfileRepository), new(mocks.MockLeaderboardCache), testLeaderboardConfig)
+
+			leaderboard, err := leaderboardService.GetLeaderboard(tc.metric, tc.ranking, tc.page, tc.pageSize)
+
+			assert.Nil(t, leaderboard)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+
+	t.Run("GetLeaderboard_RepositoryError", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, new(mocks.PlayerProfileRepository), mockCache, testLeaderboardConfig)
+
+		// Expectations
+		mockCache.On("Size").Return(5)
+		mockCache.On("Top", models.LeaderboardMetricPoints).Return(nil, assert.AnError)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetLeaderboard(models.LeaderboardMetricPoints, models.RankingCompetitive, 1, 5)
+
+		// Assertions
+		assert.Nil(t, leaderboard)
+		assert.Equal(t, helpers.ErrRepository, err)
+	})
+}
+
+func TestLeaderboardServiceImpl_GetPlayerNeighbourhood(t *testing.T) {
+	t.Run("GetPlayerNeighbourhood_Success", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, mockPlayerRepo, mockCache, testLeaderboardConfig)
+
+		// Test data
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 7}, Points: 150}
+
+		// Expectations
+		mockPlayerRepo.On("GetPlayerProfile", uint(7)).Return(playerProfile, nil)
+		mockLeaderboardRepo.On("GetPlayerPosition", models.LeaderboardMetricPoints, playerProfile).Return(int64(5), nil)
+		// Positions 3 to 7, the player is in the middle
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricPoints, 3, 5).Return(rankedPlayers(5, 200, 150, 150, 100, 90), nil)
+		mockLeaderboardRepo.On("CountPlayersAbove", models.LeaderboardMetricPoints, 200, true).Return(int64(2), nil)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetPlayerNeighbourhood(models.LeaderboardMetricPoints, models.RankingDense, 7, 2)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 4, 4, 5, 6}, ranksOf(leaderboard.Entries))
+		assert.Equal(t, uint(7), leaderboard.Entries[2].PlayerID)
+		mockPlayerRepo.AssertExpectations(t)
+		mockLeaderboardRepo.AssertExpectations(t)
+		mockCache.AssertNotCalled(t, "Top", models.LeaderboardMetricPoints)
+	})
+
+	t.Run("GetPlayerNeighbourhood_NearTheTop", func(t *testing.T) {
+		// Mocks
+		mockLeaderboardRepo := new(mocks.LeaderboardRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		leaderboardService := NewLeaderboardServiceImpl(mockLeaderboardRepo, mockPlayerRepo, new(mocks.MockLeaderboardCache), testLeaderboardConfig)
+
+		// Test data
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Points: 300}
+
+		// Expectations
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(playerProfile, nil)
+		mockLeaderboardRepo.On("GetPlayerPosition", models.LeaderboardMetricPoints, playerProfile).Return(int64(0), nil)
+		mockLeaderboardRepo.On("GetRankedPlayers", models.LeaderboardMetricPoints, 0, 3).Return(rankedPlayers(1, 300, 200, 100), nil)
+		mockLeaderboardRepo.On("CountPlayersAbove", models.LeaderboardMetricPoints, 300, false).Return(int64(0), nil)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetPlayerNeighbourhood(models.LeaderboardMetricPoints, models.RankingCompetitive, 1, 2)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ranksOf(leaderboard.Entries))
+	})
+
+	t.Run("GetPlayerNeighbourhood_PlayerNotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		leaderboardService := NewLeaderboardServiceImpl(new(mocks.LeaderboardRepository), mockPlayerRepo, new(mocks.MockLeaderboardCache), testLeaderboardConfig)
+
+		// Expectations
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		leaderboard, err := leaderboardService.GetPlayerNeighbourhood(models.LeaderboardMetricLevel, models.RankingCompetitive, 1, 2)
+
+		// Assertions
+		assert.Nil(t, leaderboard)
+		assert.Equal(t, helpers.ErrorPlayerProfileNotFound, err)
+	})
+
+	t.Run("GetPlayerNeighbourhood_TooManyNeighbours", func(t *testing.T) {
+		leaderboardService := NewLeaderboardServiceImpl(new(mocks.LeaderboardRepository), new(mocks.PlayerProfileRepository), new(mocks.MockLeaderboardCache), testLeaderboardConfig)
+
+		leaderboard, err := leaderboardService.GetPlayerNeighbourhood(models.LeaderboardMetricLevel, models.RankingCompetitive, 1, 4)
+
+		assert.Nil(t, leaderboard)
+		assert.Equal(t, helpers.ErrInvalidNeighbours, err)
+	})
+}
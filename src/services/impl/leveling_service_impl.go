@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// maxExperienceGrantAttempts bounds the retries when other grants update the player first.
+const maxExperienceGrantAttempts = 3
+
+type LevelingServiceImpl struct {
+	PlayerProfileRepository repository.PlayerProfileRepository
+	XPCurve                 services.XPCurve
+	Validate                *validator.Validate
+}
+
+// GrantExperience implements services.LevelingService.
+// Experience is capped at the total of the max level and players never lose
+// levels, even when their level was set above the curve.
+func (l *LevelingServiceImpl) GrantExperience(playerProfileID uint, grant request.GrantExperienceRequest) (*response.ExperienceGrantResponse, error) {
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	err := l.Validate.Struct(grant)
+	if err != nil {
+		logrus.WithError(err).Error("[LevelingServiceImpl.GrantExperience] Failed to validate experience data")
+		return nil, helpers.ErrExperienceDataValidation
+	}
+
+	maxLevel := l.XPCurve.MaxLevel()
+	maxExperience := l.XPCurve.ExperienceForLevel(maxLevel)
+
+	for attempt := 0; attempt < maxExperienceGrantAttempts; attempt++ {
+		playerProfile, err := l.PlayerProfileRepository.GetPlayerProfile(playerProfileID)
+		if err != nil {
+			if !errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+				logrus.WithError(err).Error("[LevelingServiceImpl.GrantExperience] Failed to get player profile")
+			}
+			return nil, err
+		}
+
+		experience := min(playerProfile.Experience+grant.Amount, max(maxExperience, playerProfile.Experience))
+		level := max(playerProfile.Level, l.XPCurve.LevelForExperience(experience))
+
+		updated, err := l.PlayerProfileRepository.UpdatePlayerExperience(playerProfile, level, experience)
+		if err != nil {
+			logrus.WithError(err).Error("[LevelingServiceImpl.GrantExperience] Failed to update player experience")
+			return nil, helpers.ErrRepository
+		}
+
+		if !updated {
+			continue
+		}
+
+		experienceToNextLevel := 0
+		if level < maxLevel {
+			experienceToNextLevel = max(l.XPCurve.ExperienceForLevel(level+1)-experience, 0)
+		}
+
+		return &response.ExperienceGrantResponse{
+			PlayerID:              playerProfile.ID,
+			ExperienceGained:      experience - playerProfile.Experience,
+			Experience:            experience,
+			Level:                 level,
+			LevelsGained:          level - playerProfile.Level,
+			ExperienceToNextLevel: experienceToNextLevel,
+			MaxLevel:              maxLevel,
+		}, nil
+	}
+
+	logrus.WithField("playerProfileID", playerProfileID).Warn("[LevelingServiceImpl.GrantExperience] Player updated by other requests on every attempt")
+	return nil, helpers.ErrExperienceGrantConflict
+}
+
+func NewLevelingServiceImpl(playerProfileRepository repository.PlayerProfileRepository, xpCurve services.XPCurve, validate *validator.Validate) services.LevelingService {
+	return &LevelingServiceImpl{
+		PlayerProfileRepository: playerProfileRepository,
+		XPCurve:                 xpCurve,
+		Validate:                validate,
+	}
+}
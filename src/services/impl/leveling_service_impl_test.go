@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// testXPCurve costs 100 experience per level up to level 10.
+var testXPCurve = NewXPCurve(config.XPCurveConfig{Type: config.XPCurveLinear, Base: 100, MaxLevel: 10})
+
+func TestLevelingServiceImpl_GrantExperience(t *testing.T) {
+	t.Run("GrantExperience_MultiLevelUp", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Test data
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 2, Experience: 150}
+
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(playerProfile, nil)
+		mockPlayerRepo.On("UpdatePlayerExperience", playerProfile, 4, 380).Return(true, nil)
+
+		// Execution
+		experienceGrant, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: 230})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, 4, experienceGrant.Level)
+		assert.Equal(t, 2, experienceGrant.LevelsGained)
+		assert.Equal(t, 230, experienceGrant.ExperienceGained)
+		assert.Equal(t, 380, experienceGrant.Experience)
+		assert.Equal(t, 20, experienceGrant.ExperienceToNextLevel)
+		assert.Equal(t, 10, experienceGrant.MaxLevel)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GrantExperience_MaxLevel", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Test data
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 9, Experience: 850}
+
+		// Experience stops at the total of the max level
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(playerProfile, nil)
+		mockPlayerRepo.On("UpdatePlayerExperience", playerProfile, 10, 900).Return(true, nil)
+
+		// Execution
+		experienceGrant, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: 500})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, 10, experienceGrant.Level)
+		assert.Equal(t, 50, experienceGrant.ExperienceGained)
+		assert.Zero(t, experienceGrant.ExperienceToNextLevel)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GrantExperience_KeepsHigherLevel", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Test data, the level was set above the curve by a progress update
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 5, Experience: 0}
+
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(playerProfile, nil)
+		mockPlayerRepo.On("UpdatePlayerExperience", playerProfile, 5, 120).Return(true, nil)
+
+		// Execution
+		experienceGrant, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: 120})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, 5, experienceGrant.Level)
+		assert.Zero(t, experienceGrant.LevelsGained)
+		assert.Equal(t, 380, experienceGrant.ExperienceToNextLevel)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GrantExperience_RetriesConcurrentUpdate", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Test data
+		stale := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 1, Experience: 0}
+		fresh := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 1, Experience: 50}
+
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(stale, nil).Once()
+		mockPlayerRepo.On("UpdatePlayerExperience", stale, 1, 60).Return(false, nil).Once()
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(fresh, nil).Once()
+		mockPlayerRepo.On("UpdatePlayerExperience", fresh, 2, 110).Return(true, nil).Once()
+
+		// Execution
+		experienceGrant, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: 60})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, 110, experienceGrant.Experience)
+		assert.Equal(t, 1, experienceGrant.LevelsGained)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GrantExperience_Conflict", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Test data
+		playerProfile := &models.PlayerProfile{Model: gorm.Model{ID: 1}, Level: 1}
+
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(playerProfile, nil)
+		mockPlayerRepo.On("UpdatePlayerExperience", playerProfile, mock.Anything, mock.Anything).Return(false, nil)
+
+		// Execution
+		_, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: 60})
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrExperienceGrantConflict)
+		mockPlayerRepo.AssertNumberOfCalls(t, "UpdatePlayerExperience", maxExperienceGrantAttempts)
+	})
+
+	t.Run("GrantExperience_InvalidAmount", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Execution
+		_, err := levelingService.GrantExperience(1, request.GrantExperienceRequest{Amount: -5})
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrExperienceDataValidation)
+		mockPlayerRepo.AssertNotCalled(t, "GetPlayerProfile", mock.Anything)
+	})
+
+	t.Run("GrantExperience_InvalidID", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		// Execution
+		_, err := levelingService.GrantExperience(0, request.GrantExperienceRequest{Amount: 5})
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrInvalidPlayerProfileID)
+	})
+
+	t.Run("GrantExperience_PlayerNotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		levelingService := NewLevelingServiceImpl(mockPlayerRepo, testXPCurve, validator.New())
+
+		mockPlayerRepo.On("GetPlayerProfile", uint(99)).Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		_, err := levelingService.GrantExperience(99, request.GrantExperienceRequest{Amount: 5})
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrorPlayerProfileNotFound)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+)
+
+// listPage translates the page request of a list endpoint into the page the
+// repository reads, one item longer than asked to know if there are more.
+func listPage(pageRequest request.PageRequest) (models.Page, error) {
+	if pageRequest.PageSize <= 0 {
+		return models.Page{}, helpers.ErrInvalidPagination
+	}
+
+	page := models.Page{Limit: pageRequest.PageSize + 1}
+
+	switch {
+	case pageRequest.After != "" && pageRequest.Before != "":
+		return models.Page{}, helpers.ErrConflictingCursors
+	case pageRequest.After != "":
+		id, err := helpers.DecodeCursor(pageRequest.After)
+		if err != nil {
+			return models.Page{}, err
+		}
+		page.AfterID = id
+	case pageRequest.Before != "":
+		id, err := helpers.DecodeCursor(pageRequest.Before)
+		if err != nil {
+			return models.Page{}, err
+		}
+		page.BeforeID = id
+	default:
+		if pageRequest.Page <= 0 {
+			return models.Page{}, helpers.ErrInvalidPagination
+		}
+		page.Offset = (pageRequest.Page - 1) * pageRequest.PageSize
+	}
+
+	return page, nil
+}
+
+// pageItems drops the extra item read by listPage and describes the page.
+// Lists sorted by id get the cursors of the pages around, next when there
+// are items after the page and previous when there are items before it.
+func pageItems[T any](items []T, page models.Page, listQuery models.ListQuery, id func(T) uint) ([]T, *response.Pagination) {
+	pagination := &response.Pagination{HasMore: len(items) >= page.Limit}
+
+	if pagination.HasMore {
+		if page.BeforeID != 0 {
+			items = items[len(items)-page.Limit+1:]
+		} else {
+			items = items[:page.Limit-1]
+		}
+	}
+
+	if len(items) == 0 || len(listQuery.Sort) > 0 {
+		return items, pagination
+	}
+
+	hasNext, hasPrev := pagination.HasMore, page.AfterID != 0 || page.Offset > 0
+	if page.BeforeID != 0 {
+		hasNext, hasPrev = true, pagination.HasMore
+	}
+
+	if hasNext {
+		pagination.NextCursor = helpers.EncodeCursor(id(items[len(items)-1]))
+	}
+	if hasPrev {
+		pagination.PrevCursor = helpers.EncodeCursor(id(items[0]))
+	}
+
+	return items, pagination
+}
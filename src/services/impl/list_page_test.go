@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPage(t *testing.T) {
+	t.Run("PageNumber", func(t *testing.T) {
+		page, err := listPage(request.PageRequest{Page: 3, PageSize: 10})
+
+		require.NoError(t, err)
+		require.Equal(t, models.Page{Offset: 20, Limit: 11}, page)
+	})
+
+	t.Run("Cursors", func(t *testing.T) {
+		page, err := listPage(request.PageRequest{Page: 3, PageSize: 10, After: helpers.EncodeCursor(7)})
+		require.NoError(t, err)
+		require.Equal(t, models.Page{Limit: 11, AfterID: 7}, page)
+
+		page, err = listPage(request.PageRequest{PageSize: 10, Before: helpers.EncodeCursor(7)})
+		require.NoError(t, err)
+		require.Equal(t, models.Page{Limit: 11, BeforeID: 7}, page)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := listPage(request.PageRequest{Page: 0, PageSize: 10})
+		require.ErrorIs(t, err, helpers.ErrInvalidPagination)
+
+		_, err = listPage(request.PageRequest{Page: 1, PageSize: 0})
+		require.ErrorIs(t, err, helpers.ErrInvalidPagination)
+
+		_, err = listPage(request.PageRequest{PageSize: 10, After: "nope"})
+		require.ErrorIs(t, err, helpers.ErrInvalidCursor)
+
+		_, err = listPage(request.PageRequest{PageSize: 10, After: helpers.EncodeCursor(1), Before: helpers.EncodeCursor(9)})
+		require.ErrorIs(t, err, helpers.ErrConflictingCursors)
+	})
+}
+
+func TestPageItems(t *testing.T) {
+	id := func(item uint) uint { return item }
+
+	t.Run("FirstPage", func(t *testing.T) {
+		items, pagination := pageItems([]uint{1, 2, 3}, models.Page{Limit: 3}, models.ListQuery{}, id)
+
+		require.Equal(t, []uint{1, 2}, items)
+		require.True(t, pagination.HasMore)
+		require.Equal(t, helpers.EncodeCursor(2), pagination.NextCursor)
+		require.Empty(t, pagination.PrevCursor)
+	})
+
+	t.Run("LastPage", func(t *testing.T) {
+		items, pagination := pageItems([]uint{5, 6}, models.Page{Limit: 3, AfterID: 4}, models.ListQuery{}, id)
+
+		require.Equal(t, []uint{5, 6}, items)
+		require.False(t, pagination.HasMore)
+		require.Empty(t, pagination.NextCursor)
+		require.Equal(t, helpers.EncodeCursor(5), pagination.PrevCursor)
+	})
+
+	t.Run("Before", func(t *testing.T) {
+		items, pagination := pageItems([]uint{2, 3, 4}, models.Page{Limit: 3, BeforeID: 5}, models.ListQuery{}, id)
+
+		require.Equal(t, []uint{3, 4}, items)
+		require.True(t, pagination.HasMore)
+		require.Equal(t, helpers.EncodeCursor(4), pagination.NextCursor)
+		require.Equal(t, helpers.EncodeCursor(3), pagination.PrevCursor)
+	})
+
+	t.Run("Sorted_NoCursors", func(t *testing.T) {
+		query := models.ListQuery{Sort: []models.SortField{{Field: "points"}}}
+
+		items, pagination := pageItems([]uint{3, 1, 2}, models.Page{Offset: 2, Limit: 3}, query, id)
+
+		require.Equal(t, []uint{3, 1}, items)
+		require.True(t, pagination.HasMore)
+		require.Empty(t, pagination.NextCursor)
+		require.Empty(t, pagination.PrevCursor)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		items, pagination := pageItems([]uint{}, models.Page{Limit: 3, AfterID: 9}, models.ListQuery{}, id)
+
+		require.Empty(t, items)
+		require.False(t, pagination.HasMore)
+		require.Empty(t, pagination.NextCursor)
+	})
+}
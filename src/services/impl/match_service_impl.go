@@ -0,0 +1,252 @@
+package impl
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// maxMatchRatingAttempts bounds the retries when other matches rate the same players first.
+const maxMatchRatingAttempts = 3
+
+type MatchServiceImpl struct {
+	MatchRepository         repository.MatchRepository
+	PlayerProfileRepository repository.PlayerProfileRepository
+	RatingEngine            services.RatingEngine
+	Validate                *validator.Validate
+}
+
+// SubmitMatch implements services.MatchService.
+// The ratings are read again on every attempt, so concurrent matches of the
+// same players are rated one after the other.
+func (m *MatchServiceImpl) SubmitMatch(matchRequest request.SubmitMatchRequest) (*response.MatchResponse, error) {
+	err := m.Validate.Struct(matchRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[MatchServiceImpl.SubmitMatch] Failed to validate match data")
+		return nil, helpers.ErrMatchDataValidation
+	}
+
+	teamIndexes, err := matchTeams(matchRequest.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	playerProfileIDs := make([]uint, len(matchRequest.Participants))
+	for i, participant := range matchRequest.Participants {
+		playerProfileIDs[i] = participant.PlayerID
+	}
+
+	playedAt := time.Now()
+	if matchRequest.PlayedAt != nil {
+		playedAt = *matchRequest.PlayedAt
+	}
+
+	for attempt := 0; attempt < maxMatchRatingAttempts; attempt++ {
+		storedRatings, err := m.MatchRepository.GetPlayerRatings(playerProfileIDs)
+		if err != nil {
+			logrus.WithError(err).Error("[MatchServiceImpl.SubmitMatch] Failed to get player ratings")
+			return nil, helpers.ErrRepository
+		}
+
+		match, ratings := m.rateMatch(matchRequest.Participants, teamIndexes, storedRatings)
+		match.PlayedAt = playedAt
+
+		err = m.MatchRepository.CreateMatch(match, ratings)
+		if errors.Is(err, helpers.ErrorPlayerRatingConflict) {
+			continue
+		}
+
+		if err != nil {
+			if errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+				return nil, err
+			}
+
+			logrus.WithError(err).Error("[MatchServiceImpl.SubmitMatch] Failed to create match")
+			return nil, helpers.ErrRepository
+		}
+
+		return toMatchResponse(match), nil
+	}
+
+	logrus.WithField("players", playerProfileIDs).Warn("[MatchServiceImpl.SubmitMatch] Players rated by other matches on every attempt")
+	return nil, helpers.ErrorPlayerRatingConflict
+}
+
+// rateMatch builds the match and the new ratings of its players. Players
+// without a stored rating start with the initial one.
+func (m *MatchServiceImpl) rateMatch(participants []request.MatchParticipantRequest, teamIndexes map[int]int, storedRatings []models.PlayerRating) (*models.Match, []models.PlayerRating) {
+	ratingsByPlayer := make(map[uint]models.PlayerRating, len(storedRatings))
+	for _, rating := range storedRatings {
+		ratingsByPlayer[rating.PlayerProfileID] = rating
+	}
+
+	initial := m.RatingEngine.Initial()
+	teams := make([]services.RatedTeam, len(teamIndexes))
+	// Position of each participant inside its team
+	positions := make([]int, len(participants))
+
+	for i, participant := range participants {
+		rating := initial
+		if stored, ok := ratingsByPlayer[participant.PlayerID]; ok {
+			rating = services.Rating{Rating: stored.Rating, Deviation: stored.Deviation, Volatility: stored.Volatility}
+		}
+
+		team := &teams[teamIndexes[participant.Team]]
+		team.Placement = participant.Placement
+		positions[i] = len(team.Players)
+		team.Players = append(team.Players, rating)
+	}
+
+	newRatings := m.RatingEngine.Rate(teams)
+
+	match := &models.Match{
+		RatingSystem: m.RatingEngine.System(),
+		Participants: make([]models.MatchParticipant, len(participants)),
+	}
+	ratings := make([]models.PlayerRating, len(participants))
+
+	for i, participant := range participants {
+		teamIndex := teamIndexes[participant.Team]
+		before := teams[teamIndex].Players[positions[i]]
+		after := newRatings[teamIndex][positions[i]]
+
+		match.Participants[i] = models.MatchParticipant{
+			PlayerProfileID: participant.PlayerID,
+			Team:            participant.Team,
+			Placement:       participant.Placement,
+			RatingBefore:    before.Rating,
+			RatingAfter:     after.Rating,
+			DeviationBefore: before.Deviation,
+			DeviationAfter:  after.Deviation,
+		}
+
+		rating := ratingsByPlayer[participant.PlayerID]
+		rating.PlayerProfileID = participant.PlayerID
+		rating.Rating = after.Rating
+		rating.Deviation = after.Deviation
+		rating.Volatility = after.Volatility
+		rating.MatchesPlayed++
+		ratings[i] = rating
+	}
+
+	return match, ratings
+}
+
+// matchTeams checks the participants and indexes the teams in the order they
+// appear.
+func matchTeams(participants []request.MatchParticipantRequest) (map[int]int, error) {
+	players := make(map[uint]bool, len(participants))
+	teamIndexes := make(map[int]int)
+	placements := make(map[int]int)
+
+	for _, participant := range participants {
+		if players[participant.PlayerID] {
+			return nil, helpers.ErrDuplicateMatchParticipant
+		}
+		players[participant.PlayerID] = true
+
+		placement, ok := placements[participant.Team]
+		if !ok {
+			teamIndexes[participant.Team] = len(teamIndexes)
+			placements[participant.Team] = participant.Placement
+			continue
+		}
+
+		if placement != participant.Placement {
+			return nil, helpers.ErrInvalidMatchTeams
+		}
+	}
+
+	if len(teamIndexes) < 2 {
+		return nil, helpers.ErrInvalidMatchTeams
+	}
+
+	return teamIndexes, nil
+}
+
+// GetPlayerMatches implements services.MatchService.
+func (m *MatchServiceImpl) GetPlayerMatches(playerProfileID uint, page int, pageSize int) ([]response.PlayerMatchResponse, error) {
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if page <= 0 || pageSize <= 0 {
+		return nil, helpers.ErrInvalidPagination
+	}
+
+	_, err := m.PlayerProfileRepository.GetPlayerProfile(playerProfileID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorPlayerProfileNotFound) {
+			logrus.WithError(err).Error("[MatchServiceImpl.GetPlayerMatches] Failed to get player profile")
+		}
+		return nil, err
+	}
+
+	participants, err := m.MatchRepository.GetPlayerMatches(playerProfileID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		logrus.WithError(err).Error("[MatchServiceImpl.GetPlayerMatches] Failed to get player matches")
+		return nil, helpers.ErrRepository
+	}
+
+	matches := make([]response.PlayerMatchResponse, len(participants))
+	for i, participant := range participants {
+		matches[i] = response.PlayerMatchResponse{
+			MatchID:      participant.MatchID,
+			RatingSystem: participant.Match.RatingSystem,
+			PlayedAt:     participant.Match.PlayedAt,
+			Team:         participant.Team,
+			Placement:    participant.Placement,
+			RatingBefore: roundRating(participant.RatingBefore),
+			RatingAfter:  roundRating(participant.RatingAfter),
+			RatingDelta:  roundRating(participant.RatingAfter - participant.RatingBefore),
+			Deviation:    roundRating(participant.DeviationAfter),
+		}
+	}
+
+	return matches, nil
+}
+
+func toMatchResponse(match *models.Match) *response.MatchResponse {
+	participants := make([]response.MatchParticipantResponse, len(match.Participants))
+	for i, participant := range match.Participants {
+		participants[i] = response.MatchParticipantResponse{
+			PlayerID:     participant.PlayerProfileID,
+			Team:         participant.Team,
+			Placement:    participant.Placement,
+			RatingBefore: roundRating(participant.RatingBefore),
+			RatingAfter:  roundRating(participant.RatingAfter),
+			RatingDelta:  roundRating(participant.RatingAfter - participant.RatingBefore),
+			Deviation:    roundRating(participant.DeviationAfter),
+		}
+	}
+
+	return &response.MatchResponse{
+		ID:           match.ID,
+		RatingSystem: match.RatingSystem,
+		PlayedAt:     match.PlayedAt,
+		Participants: participants,
+	}
+}
+
+// roundRating keeps two decimals, ratings are stored unrounded.
+func roundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
+
+func NewMatchServiceImpl(matchRepository repository.MatchRepository, playerProfileRepository repository.PlayerProfileRepository, ratingEngine services.RatingEngine, validate *validator.Validate) services.MatchService {
+	return &MatchServiceImpl{
+		MatchRepository:         matchRepository,
+		PlayerProfileRepository: playerProfileRepository,
+		RatingEngine:            ratingEngine,
+		Validate:                validate,
+	}
+}
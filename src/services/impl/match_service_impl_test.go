@@ -0,0 +1,213 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testMatchRequest = request.SubmitMatchRequest{
+	Participants: []request.MatchParticipantRequest{
+		{PlayerID: 1, Team: 1, Placement: 2},
+		{PlayerID: 2, Team: 2, Placement: 1},
+	},
+}
+
+func TestMatchServiceImpl_SubmitMatch(t *testing.T) {
+	t.Run("SubmitMatch_Success", func(t *testing.T) {
+		// Mocks
+		mockMatchRepo := new(mocks.MatchRepository)
+		matchService := NewMatchServiceImpl(mockMatchRepo, new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+		// Test data
+		// Player 1 was rated before, player 2 plays their first rated match
+		storedRatings := []models.PlayerRating{{ID: 10, PlayerProfileID: 1, Rating: 1500, Deviation: 350, Volatility: 0.06, MatchesPlayed: 4}}
+
+		// Expectations
+		mockMatchRepo.On("GetPlayerRatings", []uint{1, 2}).Return(storedRatings, nil)
+		mockMatchRepo.On("CreateMatch", mock.AnythingOfType("*models.Match"), mock.MatchedBy(func(ratings []models.PlayerRating) bool {
+			return len(ratings) == 2 &&
+				ratings[0].ID == 10 && ratings[0].MatchesPlayed == 5 && ratings[0].Rating < 1500 &&
+				ratings[1].ID == 0 && ratings[1].PlayerProfileID == 2 && ratings[1].MatchesPlayed == 1 && ratings[1].Rating > 1500
+		})).Return(nil)
+
+		// Execution
+		match, err := matchService.SubmitMatch(testMatchRequest)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, models.RatingSystemElo, match.RatingSystem)
+		require.Len(t, match.Participants, 2)
+		assert.Equal(t, -16.0, match.Participants[0].RatingDelta)
+		assert.Equal(t, 16.0, match.Participants[1].RatingDelta)
+		assert.Equal(t, 1516.0, match.Participants[1].RatingAfter)
+		assert.False(t, match.PlayedAt.IsZero())
+		mockMatchRepo.AssertExpectations(t)
+	})
+
+	t.Run("SubmitMatch_RetriedAfterConflict", func(t *testing.T) {
+		// Mocks
+		mockMatchRepo := new(mocks.MatchRepository)
+		matchService := NewMatchServiceImpl(mockMatchRepo, new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+		// Test data
+		playedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+		matchRequest := testMatchRequest
+		matchRequest.PlayedAt = &playedAt
+
+		// Expectations
+		// Another match rates player 2 between the read and the write
+		mockMatchRepo.On("GetPlayerRatings", []uint{1, 2}).Return([]models.PlayerRating{}, nil).Once()
+		mockMatchRepo.On("CreateMatch", mock.Anything, mock.Anything).Return(helpers.ErrorPlayerRatingConflict).Once()
+		mockMatchRepo.On("GetPlayerRatings", []uint{1, 2}).Return([]models.PlayerRating{{ID: 11, PlayerProfileID: 2, Rating: 1600, Deviation: 350, Volatility: 0.06, MatchesPlayed: 1}}, nil).Once()
+		mockMatchRepo.On("CreateMatch", mock.Anything, mock.Anything).Return(nil).Once()
+
+		// Execution
+		match, err := matchService.SubmitMatch(matchRequest)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, 1600.0, match.Participants[1].RatingBefore, "Expected the rating read on the second attempt")
+		assert.Equal(t, playedAt, match.PlayedAt)
+		mockMatchRepo.AssertExpectations(t)
+	})
+
+	t.Run("SubmitMatch_Conflict", func(t *testing.T) {
+		// Mocks
+		mockMatchRepo := new(mocks.MatchRepository)
+		matchService := NewMatchServiceImpl(mockMatchRepo, new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+		// Expectations
+		mockMatchRepo.On("GetPlayerRatings", []uint{1, 2}).Return([]models.PlayerRating{}, nil).Times(maxMatchRatingAttempts)
+		mockMatchRepo.On("CreateMatch", mock.Anything, mock.Anything).Return(helpers.ErrorPlayerRatingConflict).Times(maxMatchRatingAttempts)
+
+		// Execution
+		match, err := matchService.SubmitMatch(testMatchRequest)
+
+		// Assertions
+		assert.Nil(t, match)
+		assert.Equal(t, helpers.ErrorPlayerRatingConflict, err)
+		mockMatchRepo.AssertExpectations(t)
+	})
+
+	t.Run("SubmitMatch_PlayerNotFound", func(t *testing.T) {
+		// Mocks
+		mockMatchRepo := new(mocks.MatchRepository)
+		matchService := NewMatchServiceImpl(mockMatchRepo, new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+		// Expectations
+		mockMatchRepo.On("GetPlayerRatings", []uint{1, 2}).Return([]models.PlayerRating{}, nil)
+		mockMatchRepo.On("CreateMatch", mock.Anything, mock.Anything).Return(helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		match, err := matchService.SubmitMatch(testMatchRequest)
+
+		// Assertions
+		assert.Nil(t, match)
+		assert.Equal(t, helpers.ErrorPlayerProfileNotFound, err)
+	})
+
+	testCases := []struct {
+		name         string
+		participants []request.MatchParticipantRequest
+		expectedErr  error
+	}{
+		{
+			name:         "SubmitMatch_TooFewParticipants",
+			participants: []request.MatchParticipantRequest{{PlayerID: 1, Team: 1, Placement: 1}},
+			expectedErr:  helpers.ErrMatchDataValidation,
+		},
+		{
+			name:         "SubmitMatch_DuplicateParticipant",
+			participants: []request.MatchParticipantRequest{{PlayerID: 1, Team: 1, Placement: 1}, {PlayerID: 1, Team: 2, Placement: 2}},
+			expectedErr:  helpers.ErrDuplicateMatchParticipant,
+		},
+		{
+			name:         "SubmitMatch_SingleTeam",
+			participants: []request.MatchParticipantRequest{{PlayerID: 1, Team: 1, Placement: 1}, {PlayerID: 2, Team: 1, Placement: 1}},
+			expectedErr:  helpers.ErrInvalidMatchTeams,
+		},
+		{
+			name:         "SubmitMatch_TeamWithTwoPlacements",
+			participants: []request.MatchParticipantRequest{{PlayerID: 1, Team: 1, Placement: 1}, {PlayerID: 2, Team: 1, Placement: 2}, {PlayerID: 3, Team: 2, Placement: 3}},
+			expectedErr:  helpers.ErrInvalidMatchTeams,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockMatchRepo := new(mocks.MatchRepository)
+			matchService := NewMatchServiceImpl(mockMatchRepo, new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+			match, err := matchService.SubmitMatch(request.SubmitMatchRequest{Participants: tc.participants})
+
+			assert.Nil(t, match)
+			assert.Equal(t, tc.expectedErr, err)
+			mockMatchRepo.AssertNotCalled(t, "GetPlayerRatings", mock.Anything)
+		})
+	}
+}
+
+func TestMatchServiceImpl_GetPlayerMatches(t *testing.T) {
+	t.Run("GetPlayerMatches_Success", func(t *testing.T) {
+		// Mocks
+		mockMatchRepo := new(mocks.MatchRepository)
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		matchService := NewMatchServiceImpl(mockMatchRepo, mockPlayerRepo, NewRatingEngine(testRatingConfig), validator.New())
+
+		// Test data
+		participants := []models.MatchParticipant{
+			{MatchID: 3, Match: models.Match{Model: gorm.Model{ID: 3}, RatingSystem: models.RatingSystemGlicko2}, PlayerProfileID: 1, Team: 2, Placement: 1, RatingBefore: 1500, RatingAfter: 1662.311, DeviationAfter: 290.319},
+		}
+
+		// Expectations
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(&models.PlayerProfile{Model: gorm.Model{ID: 1}}, nil)
+		mockMatchRepo.On("GetPlayerMatches", uint(1), 20, 10).Return(participants, nil)
+
+		// Execution
+		matches, err := matchService.GetPlayerMatches(1, 3, 10)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, uint(3), matches[0].MatchID)
+		assert.Equal(t, models.RatingSystemGlicko2, matches[0].RatingSystem)
+		assert.Equal(t, 162.31, matches[0].RatingDelta)
+		assert.Equal(t, 290.32, matches[0].Deviation)
+		mockMatchRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetPlayerMatches_PlayerNotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		matchService := NewMatchServiceImpl(new(mocks.MatchRepository), mockPlayerRepo, NewRatingEngine(testRatingConfig), validator.New())
+
+		// Expectations
+		mockPlayerRepo.On("GetPlayerProfile", uint(1)).Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		matches, err := matchService.GetPlayerMatches(1, 1, 10)
+
+		// Assertions
+		assert.Nil(t, matches)
+		assert.Equal(t, helpers.ErrorPlayerProfileNotFound, err)
+	})
+
+	t.Run("GetPlayerMatches_InvalidPagination", func(t *testing.T) {
+		matchService := NewMatchServiceImpl(new(mocks.MatchRepository), new(mocks.PlayerProfileRepository), NewRatingEngine(testRatingConfig), validator.New())
+
+		matches, err := matchService.GetPlayerMatches(1, 0, 10)
+
+		assert.Nil(t, matches)
+		assert.Equal(t, helpers.ErrInvalidPagination, err)
+	})
+}
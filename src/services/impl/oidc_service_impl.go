@@ -0,0 +1,283 @@
+package impl
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/sirupsen/logrus"
+)
+
+type OIDCServiceImpl struct {
+	UserRepository         repository.UserRepository
+	UserIdentityRepository repository.UserIdentityRepository
+	OIDCStateRepository    repository.OIDCStateRepository
+	OIDCClient             auth.OIDCClient
+	AuthService            services.AuthService
+	PasswordHasher         services.PasswordHasher
+	OIDCConfig             config.OIDCConfig
+}
+
+// Providers implements services.OIDCService.
+func (o *OIDCServiceImpl) Providers() []string {
+	return o.OIDCClient.Providers()
+}
+
+// Authorize implements services.OIDCService.
+// The state, nonce and PKCE verifier are kept server side until the callback.
+func (o *OIDCServiceImpl) Authorize(provider string, linkUserID uint) (*response.OIDCAuthorizationResponse, error) {
+	state, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Authorize] Failed to generate state")
+		return nil, err
+	}
+
+	nonce, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Authorize] Failed to generate nonce")
+		return nil, err
+	}
+
+	codeVerifier, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Authorize] Failed to generate code verifier")
+		return nil, err
+	}
+
+	authorizationURL, err := o.OIDCClient.AuthCodeURL(provider, state, nonce, helpers.PKCEChallenge(codeVerifier))
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Authorize] Failed to build authorization url")
+		return nil, err
+	}
+
+	oidcState := &models.OIDCState{
+		StateHash:    helpers.HashToken(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(o.OIDCConfig.StateTTL),
+	}
+	if linkUserID != 0 {
+		oidcState.LinkUserID = &linkUserID
+	}
+
+	err = o.OIDCStateRepository.CreateOIDCState(oidcState)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Authorize] Failed to store state")
+		return nil, err
+	}
+
+	return &response.OIDCAuthorizationResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+// Callback implements services.OIDCService.
+// A known identity signs its user in. An unknown one is linked to the user that
+// started the flow or, for a plain login, gets a new account. Identities are
+// never linked to an existing account by email, that has to be done by the
+// signed in user, otherwise whoever controls the email at the provider would
+// take over the account.
+func (o *OIDCServiceImpl) Callback(provider string, state string, code string, client request.ClientInfo) (*response.LoginResponse, error) {
+	oidcState, err := o.OIDCStateRepository.FindByStateHash(helpers.HashToken(state))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorOIDCStateNotFound) {
+			return nil, helpers.ErrInvalidOIDCState
+		}
+		logrus.WithError(err).Error("[OIDCServiceImpl.Callback] Failed to find state")
+		return nil, err
+	}
+
+	if oidcState.Provider != provider || oidcState.UsedAt != nil || time.Now().After(oidcState.ExpiresAt) {
+		return nil, helpers.ErrInvalidOIDCState
+	}
+
+	marked, err := o.OIDCStateRepository.MarkAsUsed(oidcState.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Callback] Failed to mark state as used")
+		return nil, err
+	}
+
+	if !marked {
+		return nil, helpers.ErrInvalidOIDCState
+	}
+
+	claims, err := o.OIDCClient.Exchange(provider, code, oidcState.CodeVerifier)
+	if err != nil {
+		logrus.WithError(err).Warn("[OIDCServiceImpl.Callback] Failed to exchange code")
+		if errors.Is(err, helpers.ErrUnknownOIDCProvider) {
+			return nil, err
+		}
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	// The nonce ties the ID token to this login, a token replayed from another one is refused
+	if claims.Nonce != oidcState.Nonce {
+		logrus.WithField("provider", provider).Warn("[OIDCServiceImpl.Callback] ID token nonce mismatch")
+		return nil, helpers.ErrOIDCLoginFailed
+	}
+
+	userID, err := o.resolveUser(provider, claims, oidcState.LinkUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.AuthService.LoginWithIdentity(userID, client)
+}
+
+// GetIdentities implements services.OIDCService.
+func (o *OIDCServiceImpl) GetIdentities(userID uint) ([]response.UserIdentityResponse, error) {
+	if userID == 0 {
+		return nil, helpers.ErrInvalidUserID
+	}
+
+	userIdentities, err := o.UserIdentityRepository.GetUserIdentities(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.GetIdentities] Failed to get identities")
+		return nil, err
+	}
+
+	identities := make([]response.UserIdentityResponse, 0, len(userIdentities))
+	for _, userIdentity := range userIdentities {
+		identities = append(identities, response.UserIdentityResponse{
+			Provider: userIdentity.Provider,
+			Email:    userIdentity.Email,
+			LinkedAt: userIdentity.CreatedAt,
+		})
+	}
+
+	return identities, nil
+}
+
+// Unlink implements services.OIDCService.
+func (o *OIDCServiceImpl) Unlink(userID uint, provider string) error {
+	if userID == 0 {
+		return helpers.ErrInvalidUserID
+	}
+
+	err := o.UserIdentityRepository.DeleteUserIdentity(userID, provider)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.Unlink] Failed to unlink identity")
+		return err
+	}
+
+	return nil
+}
+
+// resolveUser returns the user the identity belongs to, linking or registering it when it's new.
+func (o *OIDCServiceImpl) resolveUser(provider string, claims *auth.OIDCClaims, linkUserID *uint) (uint, error) {
+	userIdentity, err := o.UserIdentityRepository.FindByProviderSubject(provider, claims.Subject)
+	if err == nil {
+		if linkUserID != nil && *linkUserID != userIdentity.UserID {
+			return 0, helpers.ErrIdentityAlreadyLinked
+		}
+		return userIdentity.UserID, nil
+	}
+
+	if !errors.Is(err, helpers.ErrorUserIdentityNotFound) {
+		logrus.WithError(err).Error("[OIDCServiceImpl.resolveUser] Failed to find identity")
+		return 0, err
+	}
+
+	if linkUserID != nil {
+		err = o.UserIdentityRepository.CreateUserIdentity(&models.UserIdentity{
+			UserID:   *linkUserID,
+			Provider: provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		})
+		if err != nil {
+			logrus.WithError(err).Error("[OIDCServiceImpl.resolveUser] Failed to link identity")
+			return 0, err
+		}
+
+		logrus.WithFields(logrus.Fields{"userID": *linkUserID, "provider": provider}).Info("[OIDCServiceImpl.resolveUser] Identity linked")
+		return *linkUserID, nil
+	}
+
+	return o.registerUser(provider, claims)
+}
+
+// registerUser creates the account of a player signing in for the first time
+// with the provider. The password is random, it can be set with the password
+// reset flow.
+func (o *OIDCServiceImpl) registerUser(provider string, claims *auth.OIDCClaims) (uint, error) {
+	if claims.Email == "" {
+		return 0, helpers.ErrOIDCEmailRequired
+	}
+
+	_, err := o.UserRepository.FindByEmail(claims.Email)
+	if err == nil {
+		return 0, helpers.ErrOIDCEmailInUse
+	}
+
+	if !errors.Is(err, helpers.ErrorUserNotFound) {
+		logrus.WithError(err).Error("[OIDCServiceImpl.registerUser] Failed to find user by email")
+		return 0, err
+	}
+
+	password, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.registerUser] Failed to generate password")
+		return 0, err
+	}
+
+	hashedPassword, err := o.PasswordHasher.HashPassword(password)
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.registerUser] Failed to hash password")
+		return 0, errors.New("failed to hash password")
+	}
+
+	user := &models.User{
+		UserName: userNameFromClaims(claims),
+		PassWord: hashedPassword,
+		Email:    claims.Email,
+		Role:     config.DefaultRole(),
+	}
+	if claims.EmailVerified {
+		verifiedAt := time.Now()
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
+	// Both are created together, an account without its identity would block
+	// the next social login with its email
+	err = o.UserIdentityRepository.CreateUserWithIdentity(user, &models.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[OIDCServiceImpl.registerUser] Failed to create user with identity")
+		return 0, err
+	}
+
+	logrus.WithFields(logrus.Fields{"userID": user.ID, "provider": provider}).Info("[OIDCServiceImpl.registerUser] User registered with identity provider")
+	return user.ID, nil
+}
+
+func userNameFromClaims(claims *auth.OIDCClaims) string {
+	if claims.Name != "" {
+		return claims.Name
+	}
+
+	userName, _, _ := strings.Cut(claims.Email, "@")
+	return userName
+}
+
+func NewOIDCServiceImpl(userRepository repository.UserRepository, userIdentityRepository repository.UserIdentityRepository, oidcStateRepository repository.OIDCStateRepository, oidcClient auth.OIDCClient, authService services.AuthService, passwordHasher services.PasswordHasher, oidcConfig config.OIDCConfig) services.OIDCService {
+	return &OIDCServiceImpl{
+		UserRepository:         userRepository,
+		UserIdentityRepository: userIdentityRepository,
+		OIDCStateRepository:    oidcStateRepository,
+		OIDCClient:             oidcClient,
+		AuthService:            authService,
+		PasswordHasher:         passwordHasher,
+		OIDCConfig:             oidcConfig,
+	}
+}
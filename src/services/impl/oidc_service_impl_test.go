@@ -0,0 +1,417 @@
+package impl
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	authImpl "github.com/dieg0code/player-profile/src/auth/impl"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	repoImpl "github.com/dieg0code/player-profile/src/repository/impl"
+	"github.com/dieg0code/player-profile/src/testutils"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testOIDCConfig = config.OIDCConfig{StateTTL: 10 * time.Minute}
+
+func newPendingOIDCState(linkUserID *uint) *models.OIDCState {
+	return &models.OIDCState{
+		Model:        gorm.Model{ID: 3},
+		StateHash:    helpers.HashToken("state"),
+		Provider:     "google",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+}
+
+func TestOIDCServiceImpl_Authorize(t *testing.T) {
+	t.Run("Authorize_Success", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), new(mocks.UserIdentityRepository), mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		var codeChallenge string
+		mockOIDCClient.On("AuthCodeURL", "google", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			codeChallenge = args.String(3)
+		}).Return("https://accounts.example.com/authorize", nil)
+		mockOIDCStateRepo.On("CreateOIDCState", mock.MatchedBy(func(oidcState *models.OIDCState) bool {
+			return oidcState.Provider == "google" && oidcState.LinkUserID == nil &&
+				helpers.PKCEChallenge(oidcState.CodeVerifier) == codeChallenge &&
+				oidcState.ExpiresAt.After(time.Now().Add(9*time.Minute))
+		})).Return(nil)
+
+		// Execution
+		authorizationResponse, err := oidcService.Authorize("google", 0)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, "https://accounts.example.com/authorize", authorizationResponse.AuthorizationURL)
+		mockOIDCStateRepo.AssertExpectations(t)
+	})
+
+	t.Run("Authorize_Link", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), new(mocks.UserIdentityRepository), mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockOIDCClient.On("AuthCodeURL", "google", mock.Anything, mock.Anything, mock.Anything).Return("https://accounts.example.com/authorize", nil)
+		mockOIDCStateRepo.On("CreateOIDCState", mock.MatchedBy(func(oidcState *models.OIDCState) bool {
+			return oidcState.LinkUserID != nil && *oidcState.LinkUserID == 7
+		})).Return(nil)
+
+		// Execution
+		_, err := oidcService.Authorize("google", 7)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockOIDCStateRepo.AssertExpectations(t)
+	})
+
+	t.Run("Authorize_UnknownProvider", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), new(mocks.UserIdentityRepository), mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockOIDCClient.On("AuthCodeURL", "myspace", mock.Anything, mock.Anything, mock.Anything).Return("", helpers.ErrUnknownOIDCProvider)
+
+		// Execution
+		_, err := oidcService.Authorize("myspace", 0)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrUnknownOIDCProvider, err)
+		mockOIDCStateRepo.AssertNotCalled(t, "CreateOIDCState", mock.Anything)
+	})
+}
+
+func TestOIDCServiceImpl_Callback(t *testing.T) {
+	claims := &auth.OIDCClaims{Subject: "sub-1", Email: "player@test.com", EmailVerified: true, Name: "Player", Nonce: "nonce"}
+	loginResponse := &response.LoginResponse{Token: "token", RefreshToken: "refresh"}
+
+	t.Run("Callback_KnownIdentity", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		mockAuthService := new(mocks.MockAuthService)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, mockAuthService, new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(nil), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(claims, nil)
+		mockUserIdentityRepo.On("FindByProviderSubject", "google", "sub-1").Return(&models.UserIdentity{UserID: 5, Provider: "google", Subject: "sub-1"}, nil)
+		mockAuthService.On("LoginWithIdentity", uint(5), request.ClientInfo{}).Return(loginResponse, nil)
+
+		// Execution
+		result, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, loginResponse, result)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Callback_RegistersNewUser", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockAuthService := new(mocks.MockAuthService)
+		oidcService := NewOIDCServiceImpl(mockUserRepo, mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, mockAuthService, mockPasswordHasher, testOIDCConfig)
+
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(nil), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(claims, nil)
+		mockUserIdentityRepo.On("FindByProviderSubject", "google", "sub-1").Return(nil, helpers.ErrorUserIdentityNotFound)
+		mockUserRepo.On("FindByEmail", "player@test.com").Return(nil, helpers.ErrorUserNotFound)
+		mockPasswordHasher.On("HashPassword", mock.Anything).Return("hashed", nil)
+		mockUserIdentityRepo.On("CreateUserWithIdentity", mock.MatchedBy(func(user *models.User) bool {
+			return user.Email == "player@test.com" && user.UserName == "Player" && user.PassWord == "hashed" && user.EmailVerifiedAt != nil && user.Role == os.Getenv("DEFAULT_ROLE")
+		}), mock.MatchedBy(func(userIdentity *models.UserIdentity) bool {
+			return userIdentity.Provider == "google" && userIdentity.Subject == "sub-1"
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.User).ID = 9
+		}).Return(nil)
+		mockAuthService.On("LoginWithIdentity", uint(9), request.ClientInfo{}).Return(loginResponse, nil)
+
+		// Execution
+		result, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, loginResponse, result)
+		mockUserRepo.AssertExpectations(t)
+		mockUserIdentityRepo.AssertExpectations(t)
+	})
+
+	t.Run("Callback_EmailInUse", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockAuthService := new(mocks.MockAuthService)
+		oidcService := NewOIDCServiceImpl(mockUserRepo, mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, mockAuthService, new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(nil), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(claims, nil)
+		mockUserIdentityRepo.On("FindByProviderSubject", "google", "sub-1").Return(nil, helpers.ErrorUserIdentityNotFound)
+		mockUserRepo.On("FindByEmail", "player@test.com").Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+
+		// Execution
+		_, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrOIDCEmailInUse, err)
+		mockUserIdentityRepo.AssertNotCalled(t, "CreateUserIdentity", mock.Anything)
+		mockAuthService.AssertNotCalled(t, "LoginWithIdentity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback_LinksIdentity", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		mockAuthService := new(mocks.MockAuthService)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, mockAuthService, new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		linkUserID := uint(2)
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(&linkUserID), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(claims, nil)
+		mockUserIdentityRepo.On("FindByProviderSubject", "google", "sub-1").Return(nil, helpers.ErrorUserIdentityNotFound)
+		mockUserIdentityRepo.On("CreateUserIdentity", mock.MatchedBy(func(userIdentity *models.UserIdentity) bool {
+			return userIdentity.UserID == 2 && userIdentity.Subject == "sub-1"
+		})).Return(nil)
+		mockAuthService.On("LoginWithIdentity", uint(2), request.ClientInfo{}).Return(loginResponse, nil)
+
+		// Execution
+		_, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserIdentityRepo.AssertExpectations(t)
+	})
+
+	t.Run("Callback_IdentityLinkedToOtherUser", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		mockAuthService := new(mocks.MockAuthService)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, mockAuthService, new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		linkUserID := uint(2)
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(&linkUserID), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(claims, nil)
+		mockUserIdentityRepo.On("FindByProviderSubject", "google", "sub-1").Return(&models.UserIdentity{UserID: 5}, nil)
+
+		// Execution
+		_, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrIdentityAlreadyLinked, err)
+		mockAuthService.AssertNotCalled(t, "LoginWithIdentity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback_NonceMismatch", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		replayedClaims := *claims
+		replayedClaims.Nonce = "other-nonce"
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(nil), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(true, nil)
+		mockOIDCClient.On("Exchange", "google", "code", "verifier").Return(&replayedClaims, nil)
+
+		// Execution
+		_, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrOIDCLoginFailed, err)
+		mockUserIdentityRepo.AssertNotCalled(t, "FindByProviderSubject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback_InvalidState", func(t *testing.T) {
+		expiredState := newPendingOIDCState(nil)
+		expiredState.ExpiresAt = time.Now().Add(-time.Second)
+		usedAt := time.Now()
+		usedState := newPendingOIDCState(nil)
+		usedState.UsedAt = &usedAt
+
+		cases := map[string]struct {
+			provider  string
+			oidcState *models.OIDCState
+			findErr   error
+		}{
+			"Unknown":       {provider: "google", findErr: helpers.ErrorOIDCStateNotFound},
+			"Expired":       {provider: "google", oidcState: expiredState},
+			"AlreadyUsed":   {provider: "google", oidcState: usedState},
+			"OtherProvider": {provider: "discord", oidcState: newPendingOIDCState(nil)},
+		}
+
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Mocks
+				mockOIDCClient := new(mocks.MockOIDCClient)
+				mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+				oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), new(mocks.UserIdentityRepository), mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+				mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(tc.oidcState, tc.findErr)
+
+				// Execution
+				_, err := oidcService.Callback(tc.provider, "state", "code", request.ClientInfo{})
+
+				// Assertions
+				assert.Equal(t, helpers.ErrInvalidOIDCState, err)
+				mockOIDCClient.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Callback_ConcurrentUse", func(t *testing.T) {
+		// Mocks
+		mockOIDCClient := new(mocks.MockOIDCClient)
+		mockOIDCStateRepo := new(mocks.OIDCStateRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), new(mocks.UserIdentityRepository), mockOIDCStateRepo, mockOIDCClient, new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockOIDCStateRepo.On("FindByStateHash", helpers.HashToken("state")).Return(newPendingOIDCState(nil), nil)
+		mockOIDCStateRepo.On("MarkAsUsed", uint(3)).Return(false, nil)
+
+		// Execution
+		_, err := oidcService.Callback("google", "state", "code", request.ClientInfo{})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidOIDCState, err)
+		mockOIDCClient.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOIDCServiceImpl_Identities(t *testing.T) {
+	t.Run("GetIdentities_Success", func(t *testing.T) {
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, new(mocks.OIDCStateRepository), new(mocks.MockOIDCClient), new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		linkedAt := time.Now()
+		mockUserIdentityRepo.On("GetUserIdentities", uint(1)).Return([]models.UserIdentity{
+			{Model: gorm.Model{CreatedAt: linkedAt}, UserID: 1, Provider: "google", Email: "player@test.com"},
+		}, nil)
+
+		// Execution
+		identities, err := oidcService.GetIdentities(1)
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Equal(t, []response.UserIdentityResponse{{Provider: "google", Email: "player@test.com", LinkedAt: linkedAt}}, identities)
+	})
+
+	t.Run("Unlink_NotLinked", func(t *testing.T) {
+		mockUserIdentityRepo := new(mocks.UserIdentityRepository)
+		oidcService := NewOIDCServiceImpl(new(mocks.UserRepository), mockUserIdentityRepo, new(mocks.OIDCStateRepository), new(mocks.MockOIDCClient), new(mocks.MockAuthService), new(mocks.MockPasswordHasher), testOIDCConfig)
+
+		mockUserIdentityRepo.On("DeleteUserIdentity", uint(1), "google").Return(helpers.ErrorUserIdentityNotFound)
+
+		// Execution
+		err := oidcService.Unlink(1, "google")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorUserIdentityNotFound, err)
+	})
+}
+
+// TestOIDCServiceImpl_StandInProvider runs the whole flow against a local
+// provider: the first login registers the player, the second one finds it.
+func TestOIDCServiceImpl_StandInProvider(t *testing.T) {
+	provider := testutils.NewOIDCProvider("player-profile")
+	defer provider.Close()
+
+	db := testutils.SetupTestDB(&models.User{}, &models.UserIdentity{}, &models.OIDCState{})
+	defer func() {
+		sqlDB, _ := db.DB()
+		err := sqlDB.Close()
+		if err != nil {
+			t.Errorf("Error closing database connection: %v", err)
+		}
+	}()
+
+	oidcConfig := config.OIDCConfig{
+		Providers: map[string]config.OIDCProviderConfig{
+			"test": {
+				Name:        "test",
+				Issuer:      provider.Issuer(),
+				ClientID:    "player-profile",
+				RedirectURL: "http://localhost:8080/api/v1/auth/oidc/test/callback",
+				Scopes:      []string{"openid", "email"},
+			},
+		},
+		StateTTL: time.Minute,
+	}
+
+	mockPasswordHasher := new(mocks.MockPasswordHasher)
+	mockPasswordHasher.On("HashPassword", mock.Anything).Return("hashed", nil)
+	mockAuthService := new(mocks.MockAuthService)
+	mockAuthService.On("LoginWithIdentity", mock.Anything, request.ClientInfo{}).Return(&response.LoginResponse{Token: "token"}, nil)
+
+	oidcService := NewOIDCServiceImpl(
+		repoImpl.NewUserRepositoryImpl(db),
+		repoImpl.NewUserIdentityRepositoryImpl(db),
+		repoImpl.NewOIDCStateRepositoryImpl(db),
+		authImpl.NewOIDCClientImpl(oidcConfig, http.DefaultClient),
+		mockAuthService,
+		mockPasswordHasher,
+		oidcConfig,
+	)
+	player := testutils.OIDCUser{Subject: "steam-42", Email: "player@test.com", EmailVerified: true}
+
+	// First login registers the player
+	authorization, err := oidcService.Authorize("test", 0)
+	require.NoError(t, err)
+	code, state := provider.Authorize(authorization.AuthorizationURL, player)
+
+	_, err = oidcService.Callback("test", state, code, request.ClientInfo{})
+	require.NoError(t, err)
+
+	var user models.User
+	require.NoError(t, db.Where("email = ?", "player@test.com").First(&user).Error)
+	assert.Equal(t, "player", user.UserName)
+	assert.NotNil(t, user.EmailVerifiedAt)
+
+	// The state can't be replayed
+	_, err = oidcService.Callback("test", state, code, request.ClientInfo{})
+	assert.Equal(t, helpers.ErrInvalidOIDCState, err)
+
+	// Second login signs the same user in
+	authorization, err = oidcService.Authorize("test", 0)
+	require.NoError(t, err)
+	code, state = provider.Authorize(authorization.AuthorizationURL, player)
+
+	_, err = oidcService.Callback("test", state, code, request.ClientInfo{})
+	require.NoError(t, err)
+
+	var userCount int64
+	db.Model(&models.User{}).Count(&userCount)
+	assert.Equal(t, int64(1), userCount)
+	mockAuthService.AssertNumberOfCalls(t, "LoginWithIdentity", 2)
+	mockAuthService.AssertCalled(t, "LoginWithIdentity", user.ID, request.ClientInfo{})
+}
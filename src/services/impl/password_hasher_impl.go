@@ -0,0 +1,40 @@
+package impl
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/services"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// NewPassWordHasher returns the hasher of the configured algorithm.
+func NewPassWordHasher(hashConfig config.PasswordHashConfig) services.PasswordHasher {
+	if hashConfig.Algorithm == config.PasswordHashBcrypt {
+		return NewBcryptHasher(hashConfig.BcryptCost)
+	}
+
+	return NewArgon2idHasher(hashConfig.Argon2Memory, hashConfig.Argon2Iterations, hashConfig.Argon2Parallelism, hashConfig.Argon2MaxConcurrency)
+}
+
+// comparePassword checks a password against a hash of any supported
+// algorithm, so switching algorithms doesn't lock anybody out.
+func comparePassword(hashedPassword string, password string) error {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return compareArgon2id(hashedPassword, password)
+	}
+
+	_, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return helpers.ErrUnknownPasswordHash
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return helpers.ErrPasswordMismatch
+	}
+
+	return err
+}
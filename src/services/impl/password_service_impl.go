@@ -0,0 +1,219 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type PasswordServiceImpl struct {
+	UserRepository         repository.UserRepository
+	UserTokenRepository    repository.UserTokenRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	SessionRepository      repository.SessionRepository
+	RevocationStore        auth.RevocationStore
+	PasswordHasher         services.PasswordHasher
+	Mailer                 mailer.Mailer
+	Validate               *validator.Validate
+	AuthConfig             config.AuthConfig
+}
+
+// ForgotPassword implements services.PasswordService.
+// Unknown emails are not reported, so the endpoint can't be used to find out
+// which emails are registered.
+func (p *PasswordServiceImpl) ForgotPassword(forgotRequest request.ForgotPasswordRequest) error {
+	err := p.Validate.Struct(forgotRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ForgotPassword] Failed to validate forgot password request")
+		return helpers.ErrPasswordDataValidation
+	}
+
+	user, err := p.UserRepository.FindByEmail(forgotRequest.Email)
+	if err != nil {
+		logrus.WithError(err).Warn("[PasswordServiceImpl.ForgotPassword] Password reset requested for unknown email")
+		return nil
+	}
+
+	// Only the last requested link works
+	err = p.UserTokenRepository.DeleteUserTokens(user.ID, models.PasswordResetToken)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ForgotPassword] Failed to delete previous reset tokens")
+		return err
+	}
+
+	token, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ForgotPassword] Failed to generate reset token")
+		return err
+	}
+
+	err = p.UserTokenRepository.CreateUserToken(&models.UserToken{
+		UserID:    user.ID,
+		Purpose:   models.PasswordResetToken,
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: time.Now().Add(p.AuthConfig.PasswordResetTTL),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ForgotPassword] Failed to store reset token")
+		return err
+	}
+
+	err = p.Mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    p.resetEmailBody(user.UserName, token),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ForgotPassword] Failed to send reset email")
+		return helpers.ErrSendEmail
+	}
+
+	return nil
+}
+
+// ResetPassword implements services.PasswordService.
+// Every session of the user is revoked after the password changes.
+func (p *PasswordServiceImpl) ResetPassword(resetRequest request.ResetPasswordRequest) error {
+	err := p.Validate.Struct(resetRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ResetPassword] Failed to validate reset password request")
+		return helpers.ErrPasswordDataValidation
+	}
+
+	userToken, err := p.UserTokenRepository.FindByTokenHash(models.PasswordResetToken, helpers.HashToken(resetRequest.Token))
+	if err != nil {
+		if errors.Is(err, helpers.ErrorUserTokenNotFound) {
+			return helpers.ErrInvalidPasswordResetToken
+		}
+		logrus.WithError(err).Error("[PasswordServiceImpl.ResetPassword] Failed to find reset token")
+		return err
+	}
+
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		return helpers.ErrInvalidPasswordResetToken
+	}
+
+	marked, err := p.UserTokenRepository.MarkAsUsed(userToken.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ResetPassword] Failed to mark reset token as used")
+		return err
+	}
+
+	if !marked {
+		return helpers.ErrInvalidPasswordResetToken
+	}
+
+	hashedPassword, err := p.PasswordHasher.HashPassword(resetRequest.Password)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ResetPassword] Failed to hash password")
+		return errors.New("failed to hash password")
+	}
+
+	err = p.UserRepository.UpdateUser(userToken.UserID, &models.User{PassWord: hashedPassword})
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ResetPassword] Failed to update password")
+		return err
+	}
+
+	return p.revokeSessions(userToken.UserID)
+}
+
+// ChangePassword implements services.PasswordService.
+// requireCurrentPassword is false only when an admin forces the password of
+// another user. Every session of the user is revoked after the change.
+func (p *PasswordServiceImpl) ChangePassword(userID uint, requireCurrentPassword bool, changeRequest request.ChangePasswordRequest) error {
+	err := p.Validate.Struct(changeRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ChangePassword] Failed to validate change password request")
+		return helpers.ErrPasswordDataValidation
+	}
+
+	user, err := p.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ChangePassword] Failed to get user")
+		return err
+	}
+
+	if requireCurrentPassword {
+		if changeRequest.CurrentPassword == "" {
+			return helpers.ErrPasswordDataValidation
+		}
+
+		err = p.PasswordHasher.ComparePassword(user.PassWord, changeRequest.CurrentPassword)
+		if err != nil {
+			logrus.WithError(err).Warn("[PasswordServiceImpl.ChangePassword] Current password does not match")
+			return helpers.ErrInvalidCurrentPassword
+		}
+	}
+
+	hashedPassword, err := p.PasswordHasher.HashPassword(changeRequest.NewPassword)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ChangePassword] Failed to hash password")
+		return errors.New("failed to hash password")
+	}
+
+	err = p.UserRepository.UpdateUser(user.ID, &models.User{PassWord: hashedPassword})
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.ChangePassword] Failed to update password")
+		return err
+	}
+
+	return p.revokeSessions(user.ID)
+}
+
+func (p *PasswordServiceImpl) revokeSessions(userID uint) error {
+	err := p.RefreshTokenRepository.RevokeAllForUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.revokeSessions] Failed to revoke refresh tokens")
+		return err
+	}
+
+	err = p.RevocationStore.RevokeAllForUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.revokeSessions] Failed to revoke access tokens")
+		return err
+	}
+
+	err = p.SessionRepository.EndAllForUser(userID, time.Now())
+	if err != nil {
+		logrus.WithError(err).Error("[PasswordServiceImpl.revokeSessions] Failed to end sessions")
+		return err
+	}
+
+	return nil
+}
+
+func (p *PasswordServiceImpl) resetEmailBody(userName string, token string) string {
+	link := token
+	if p.AuthConfig.PasswordResetURL != "" {
+		link = p.AuthConfig.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	}
+
+	return fmt.Sprintf("Hi %s,\n\nUse the following link to choose a new password, it expires in %s:\n\n%s\n\nIf you didn't ask for a password reset you can ignore this email.\n", userName, p.AuthConfig.PasswordResetTTL, link)
+}
+
+func NewPasswordServiceImpl(userRepository repository.UserRepository, userTokenRepository repository.UserTokenRepository, refreshTokenRepository repository.RefreshTokenRepository, sessionRepository repository.SessionRepository, revocationStore auth.RevocationStore, passwordHasher services.PasswordHasher, mailer mailer.Mailer, validate *validator.Validate, authConfig config.AuthConfig) services.PasswordService {
+	return &PasswordServiceImpl{
+		UserRepository:         userRepository,
+		UserTokenRepository:    userTokenRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		SessionRepository:      sessionRepository,
+		RevocationStore:        revocationStore,
+		PasswordHasher:         passwordHasher,
+		Mailer:                 mailer,
+		Validate:               validate,
+		AuthConfig:             authConfig,
+	}
+}
@@ -0,0 +1,363 @@
+package impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var testPasswordConfig = config.AuthConfig{
+	PasswordResetTTL: time.Hour,
+	PasswordResetURL: "https://example.com/reset",
+}
+
+type passwordServiceMocks struct {
+	userRepo         *mocks.UserRepository
+	userTokenRepo    *mocks.UserTokenRepository
+	refreshTokenRepo *mocks.RefreshTokenRepository
+	sessionRepo      *mocks.SessionRepository
+	revocationStore  *mocks.MockRevocationStore
+	passwordHasher   *mocks.MockPasswordHasher
+	mailer           *mocks.MockMailer
+}
+
+func newPasswordServiceWithMocks() (services.PasswordService, *passwordServiceMocks) {
+	m := &passwordServiceMocks{
+		userRepo:         new(mocks.UserRepository),
+		userTokenRepo:    new(mocks.UserTokenRepository),
+		refreshTokenRepo: new(mocks.RefreshTokenRepository),
+		sessionRepo:      new(mocks.SessionRepository),
+		revocationStore:  new(mocks.MockRevocationStore),
+		passwordHasher:   new(mocks.MockPasswordHasher),
+		mailer:           new(mocks.MockMailer),
+	}
+
+	passwordService := NewPasswordServiceImpl(m.userRepo, m.userTokenRepo, m.refreshTokenRepo, m.sessionRepo, m.revocationStore, m.passwordHasher, m.mailer, validator.New(), testPasswordConfig)
+
+	return passwordService, m
+}
+
+func TestPasswordServiceImpl_ForgotPassword(t *testing.T) {
+	t.Run("ForgotPassword_Success", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Test data
+		user := &models.User{Model: gorm.Model{ID: 1}, UserName: "test", Email: "test@test.com"}
+		m.userRepo.On("FindByEmail", "test@test.com").Return(user, nil)
+		m.userTokenRepo.On("DeleteUserTokens", uint(1), models.PasswordResetToken).Return(nil)
+
+		var tokenHash string
+		m.userTokenRepo.On("CreateUserToken", mock.MatchedBy(func(userToken *models.UserToken) bool {
+			tokenHash = userToken.TokenHash
+			return userToken.UserID == 1 && userToken.Purpose == models.PasswordResetToken && userToken.ExpiresAt.After(time.Now())
+		})).Return(nil)
+
+		var sent mailer.Message
+		m.mailer.On("Send", mock.MatchedBy(func(message mailer.Message) bool {
+			sent = message
+			return message.To == "test@test.com"
+		})).Return(nil)
+
+		// Execution
+		err := passwordService.ForgotPassword(request.ForgotPasswordRequest{Email: "test@test.com"})
+
+		// Assertions
+		assert.NoError(t, err)
+		assert.Contains(t, sent.Body, testPasswordConfig.PasswordResetURL+"?token=")
+
+		// Only the hash is stored, the email carries the token itself
+		token := sent.Body[strings.Index(sent.Body, "?token=")+len("?token="):]
+		token = strings.Fields(token)[0]
+		assert.Equal(t, helpers.HashToken(token), tokenHash)
+
+		m.userRepo.AssertExpectations(t)
+		m.userTokenRepo.AssertExpectations(t)
+		m.mailer.AssertExpectations(t)
+	})
+
+	t.Run("ForgotPassword_UnknownEmail", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userRepo.On("FindByEmail", "unknown@test.com").Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := passwordService.ForgotPassword(request.ForgotPasswordRequest{Email: "unknown@test.com"})
+
+		// Assertions
+		assert.NoError(t, err)
+		m.userTokenRepo.AssertNotCalled(t, "CreateUserToken", mock.Anything)
+		m.mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("ForgotPassword_InvalidEmail", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Execution
+		err := passwordService.ForgotPassword(request.ForgotPasswordRequest{Email: "invalid"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrPasswordDataValidation, err)
+		m.userRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+	})
+
+	t.Run("ForgotPassword_SendFails", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		user := &models.User{Model: gorm.Model{ID: 1}, UserName: "test", Email: "test@test.com"}
+		m.userRepo.On("FindByEmail", "test@test.com").Return(user, nil)
+		m.userTokenRepo.On("DeleteUserTokens", uint(1), models.PasswordResetToken).Return(nil)
+		m.userTokenRepo.On("CreateUserToken", mock.Anything).Return(nil)
+		m.mailer.On("Send", mock.Anything).Return(assert.AnError)
+
+		// Execution
+		err := passwordService.ForgotPassword(request.ForgotPasswordRequest{Email: "test@test.com"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrSendEmail, err)
+	})
+}
+
+func TestPasswordServiceImpl_ResetPassword(t *testing.T) {
+	resetRequest := request.ResetPasswordRequest{
+		Token:    "token",
+		Password: "newpassword",
+	}
+
+	t.Run("ResetPassword_Success", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Test data
+		userToken := &models.UserToken{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			Purpose:   models.PasswordResetToken,
+			TokenHash: helpers.HashToken("token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		m.userTokenRepo.On("FindByTokenHash", models.PasswordResetToken, helpers.HashToken("token")).Return(userToken, nil)
+		m.userTokenRepo.On("MarkAsUsed", uint(7)).Return(true, nil)
+		m.passwordHasher.On("HashPassword", "newpassword").Return("hashed", nil)
+		m.userRepo.On("UpdateUser", uint(1), &models.User{PassWord: "hashed"}).Return(nil)
+		m.refreshTokenRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+		m.revocationStore.On("RevokeAllForUser", uint(1)).Return(nil)
+		m.sessionRepo.On("EndAllForUser", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+		// Execution
+		err := passwordService.ResetPassword(resetRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		m.userTokenRepo.AssertExpectations(t)
+		m.passwordHasher.AssertExpectations(t)
+		m.userRepo.AssertExpectations(t)
+		m.refreshTokenRepo.AssertExpectations(t)
+		m.revocationStore.AssertExpectations(t)
+	})
+
+	t.Run("ResetPassword_TokenNotFound", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userTokenRepo.On("FindByTokenHash", models.PasswordResetToken, helpers.HashToken("token")).Return(nil, helpers.ErrorUserTokenNotFound)
+
+		// Execution
+		err := passwordService.ResetPassword(resetRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidPasswordResetToken, err)
+	})
+
+	t.Run("ResetPassword_TokenExpired", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		userToken := &models.UserToken{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}
+		m.userTokenRepo.On("FindByTokenHash", models.PasswordResetToken, helpers.HashToken("token")).Return(userToken, nil)
+
+		// Execution
+		err := passwordService.ResetPassword(resetRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidPasswordResetToken, err)
+		m.userTokenRepo.AssertNotCalled(t, "MarkAsUsed", mock.Anything)
+	})
+
+	t.Run("ResetPassword_TokenAlreadyUsed", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		usedAt := time.Now().Add(-time.Minute)
+		userToken := &models.UserToken{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}
+		m.userTokenRepo.On("FindByTokenHash", models.PasswordResetToken, helpers.HashToken("token")).Return(userToken, nil)
+
+		// Execution
+		err := passwordService.ResetPassword(resetRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidPasswordResetToken, err)
+		m.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResetPassword_ConcurrentUse", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		userToken := &models.UserToken{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		m.userTokenRepo.On("FindByTokenHash", models.PasswordResetToken, helpers.HashToken("token")).Return(userToken, nil)
+		m.userTokenRepo.On("MarkAsUsed", uint(7)).Return(false, nil)
+
+		// Execution
+		err := passwordService.ResetPassword(resetRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidPasswordResetToken, err)
+		m.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResetPassword_ShortPassword", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Execution
+		err := passwordService.ResetPassword(request.ResetPasswordRequest{Token: "token", Password: "short"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrPasswordDataValidation, err)
+		m.userTokenRepo.AssertNotCalled(t, "FindByTokenHash", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordServiceImpl_ChangePassword(t *testing.T) {
+	changeRequest := request.ChangePasswordRequest{
+		CurrentPassword: "oldpassword",
+		NewPassword:     "newpassword",
+	}
+
+	t.Run("ChangePassword_Success", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Test data
+		m.userRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, PassWord: "oldhash"}, nil)
+		m.passwordHasher.On("ComparePassword", "oldhash", "oldpassword").Return(nil)
+		m.passwordHasher.On("HashPassword", "newpassword").Return("newhash", nil)
+		m.userRepo.On("UpdateUser", uint(1), &models.User{PassWord: "newhash"}).Return(nil)
+		m.refreshTokenRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+		m.revocationStore.On("RevokeAllForUser", uint(1)).Return(nil)
+		m.sessionRepo.On("EndAllForUser", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+		// Execution
+		err := passwordService.ChangePassword(1, true, changeRequest)
+
+		// Assertions
+		assert.NoError(t, err)
+		m.userRepo.AssertExpectations(t)
+		m.passwordHasher.AssertExpectations(t)
+		m.refreshTokenRepo.AssertExpectations(t)
+		m.revocationStore.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword_WrongCurrentPassword", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, PassWord: "oldhash"}, nil)
+		m.passwordHasher.On("ComparePassword", "oldhash", "oldpassword").Return(assert.AnError)
+
+		// Execution
+		err := passwordService.ChangePassword(1, true, changeRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidCurrentPassword, err)
+		m.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword_MissingCurrentPassword", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, PassWord: "oldhash"}, nil)
+
+		// Execution
+		err := passwordService.ChangePassword(1, true, request.ChangePasswordRequest{NewPassword: "newpassword"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrPasswordDataValidation, err)
+		m.passwordHasher.AssertNotCalled(t, "ComparePassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword_ForcedByAdmin", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userRepo.On("GetUser", uint(2)).Return(&models.User{Model: gorm.Model{ID: 2}, PassWord: "oldhash"}, nil)
+		m.passwordHasher.On("HashPassword", "newpassword").Return("newhash", nil)
+		m.userRepo.On("UpdateUser", uint(2), &models.User{PassWord: "newhash"}).Return(nil)
+		m.refreshTokenRepo.On("RevokeAllForUser", uint(2)).Return(nil)
+		m.revocationStore.On("RevokeAllForUser", uint(2)).Return(nil)
+		m.sessionRepo.On("EndAllForUser", uint(2), mock.AnythingOfType("time.Time")).Return(nil)
+
+		// Execution
+		err := passwordService.ChangePassword(2, false, request.ChangePasswordRequest{NewPassword: "newpassword"})
+
+		// Assertions
+		assert.NoError(t, err)
+		m.passwordHasher.AssertNotCalled(t, "ComparePassword", mock.Anything, mock.Anything)
+		m.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword_ShortPassword", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		// Execution
+		err := passwordService.ChangePassword(1, true, request.ChangePasswordRequest{CurrentPassword: "oldpassword", NewPassword: "short"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrPasswordDataValidation, err)
+		m.userRepo.AssertNotCalled(t, "GetUser", mock.Anything)
+	})
+
+	t.Run("ChangePassword_UserNotFound", func(t *testing.T) {
+		// Mocks
+		passwordService, m := newPasswordServiceWithMocks()
+
+		m.userRepo.On("GetUser", uint(1)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		err := passwordService.ChangePassword(1, true, changeRequest)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorUserNotFound, err)
+	})
+}
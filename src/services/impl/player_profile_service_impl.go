@@ -1,6 +1,9 @@
 package impl
 
 import (
+	"errors"
+
+	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/dieg0code/player-profile/src/helpers"
@@ -13,29 +16,51 @@ import (
 
 type PlayerProfileServiceImpl struct {
 	PlayerProfileRepository repository.PlayerProfileRepository
+	XPCurve                 services.XPCurve
 	Validate                *validator.Validate
-	PasswordHasher          services.PasswordHasher
+	PlayerProfileConfig     config.PlayerProfileConfig
 }
 
 // GetPlayerWithAchievements implements services.PlayerProfileService.
-func (p *PlayerProfileServiceImpl) GetPlayerWithAchievements(playerProfileID uint) (*response.PlayerWithAchievements, error) {
-	playerProfile, err := p.PlayerProfileRepository.GetPlayerWithAchievements(playerProfileID)
+func (p *PlayerProfileServiceImpl) GetPlayerWithAchievements(playerProfileID uint, page int, pageSize int, sortOrder string) (*response.PlayerWithAchievements, error) {
+	if playerProfileID == 0 {
+		return nil, helpers.ErrInvalidPlayerProfileID
+	}
+
+	if page <= 0 || pageSize <= 0 {
+		return nil, helpers.ErrInvalidPagination
+	}
+
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return nil, helpers.ErrInvalidSortOrder
+	}
+
+	playerProfile, err := p.PlayerProfileRepository.GetPlayerProfile(playerProfileID)
 	if err != nil {
-		logrus.WithError(err).Error("[PlayerProfileServiceImpl.GetPlayerWithAchievements] Failed to get player with achievements")
+		logrus.WithError(err).Error("[PlayerProfileServiceImpl.GetPlayerWithAchievements] Failed to get player profile")
+		return nil, err
+	}
+
+	offset := (page - 1) * pageSize
+
+	playerAchievements, err := p.PlayerProfileRepository.GetPlayerAchievements(playerProfileID, offset, pageSize, sortOrder)
+	if err != nil {
+		logrus.WithError(err).Error("[PlayerProfileServiceImpl.GetPlayerWithAchievements] Failed to get player achievements")
 		return nil, err
 	}
 
 	playerWithAchievementResponse := response.PlayerWithAchievements{
-		ID:       playerProfile.ID,
-		Nickname: playerProfile.Nickname,
+		ID:           playerProfile.ID,
+		Nickname:     playerProfile.Nickname,
+		Achievements: []response.AchievementsSumary{},
 	}
 
-	for _, achievement := range playerProfile.Achievements {
+	for _, playerAchievement := range playerAchievements {
 		playerWithAchievementResponse.Achievements = append(playerWithAchievementResponse.Achievements, response.AchievementsSumary{
-			ID:   achievement.ID,
-			Name: achievement.Name,
+			ID:         playerAchievement.Achievement.ID,
+			Name:       playerAchievement.Achievement.Name,
+			UnlockedAt: playerAchievement.UnlockedAt,
 		})
-
 	}
 
 	return &playerWithAchievementResponse, nil
@@ -52,14 +77,18 @@ func (p *PlayerProfileServiceImpl) Create(playerProfile request.CreatePlayerProf
 	playerProfileModel := models.PlayerProfile{
 		Nickname:   playerProfile.Nickname,
 		Avatar:     playerProfile.Avatar,
-		Level:      playerProfile.Level,
-		Experience: playerProfile.Experience,
-		Points:     playerProfile.Points,
+		Level:      p.PlayerProfileConfig.DefaultLevel,
+		Experience: p.PlayerProfileConfig.DefaultExperience,
+		Points:     p.PlayerProfileConfig.DefaultPoints,
 		UserID:     playerProfile.UserID,
 	}
 
-	err = p.PlayerProfileRepository.CreatePlayerProfile(&playerProfileModel)
+	err = p.PlayerProfileRepository.CreatePlayerProfileWithinLimit(&playerProfileModel, p.PlayerProfileConfig.MaxProfilesPerUser)
 	if err != nil {
+		if errors.Is(err, helpers.ErrPlayerProfileLimitReached) || errors.Is(err, helpers.ErrorUserNotFound) {
+			return err
+		}
+
 		logrus.WithError(err).Error("[PlayerProfileServiceImpl.Create] Failed to create player profile")
 		return helpers.ErrRepository
 	}
@@ -83,18 +112,31 @@ func (p *PlayerProfileServiceImpl) Delete(playerProfileID uint) error {
 }
 
 // GetAll implements services.PlayerProfileService.
-func (p *PlayerProfileServiceImpl) GetAll(page int, pageSize int) ([]response.PlayerProfileResponse, error) {
-
-	if page <= 0 || pageSize <= 0 {
-		return nil, helpers.ErrInvalidPagination
+func (p *PlayerProfileServiceImpl) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery, viewerUserID uint) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	offset := (page - 1) * pageSize
-
-	playerProfiles, err := p.PlayerProfileRepository.GetAllPlayerProfiles(offset, pageSize)
+	playerProfiles, err := p.PlayerProfileRepository.GetAllPlayerProfiles(page, listQuery, viewerUserID)
 	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidListQuery) {
+			return nil, nil, err
+		}
+
 		logrus.WithError(err).Error("[PlayerProfileServiceImpl.GetAll] Failed to get all player profiles")
-		return nil, helpers.ErrRepository
+		return nil, nil, helpers.ErrRepository
+	}
+
+	playerProfiles, pagination := pageItems(playerProfiles, page, listQuery, func(playerProfile models.PlayerProfile) uint { return playerProfile.ID })
+
+	if pageRequest.Total {
+		total, err := p.PlayerProfileRepository.CountPlayerProfiles(listQuery, viewerUserID)
+		if err != nil {
+			logrus.WithError(err).Error("[PlayerProfileServiceImpl.GetAll] Failed to count player profiles")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
 	}
 
 	var playerProfilesResponse []response.PlayerProfileResponse
@@ -113,7 +155,7 @@ func (p *PlayerProfileServiceImpl) GetAll(page int, pageSize int) ([]response.Pl
 		playerProfilesResponse = append(playerProfilesResponse, playerProfileResponse)
 	}
 
-	return playerProfilesResponse, nil
+	return playerProfilesResponse, pagination, nil
 }
 
 // GetByID implements services.PlayerProfileService.
@@ -162,13 +204,13 @@ func (p *PlayerProfileServiceImpl) Update(playerProfileID uint, playerProfile re
 		return helpers.ErrPlayerProfileDataValidation
 	}
 
-	playerData.Nickname = playerProfile.Nickname
-	playerData.Avatar = playerProfile.Avatar
-	playerData.Level = playerProfile.Level
-	playerData.Experience = playerProfile.Experience
-	playerData.Points = playerProfile.Points
+	// Only the cosmetic fields, the progression belongs to the game servers.
+	cosmeticData := models.PlayerProfile{
+		Nickname: playerProfile.Nickname,
+		Avatar:   playerProfile.Avatar,
+	}
 
-	err = p.PlayerProfileRepository.UpdatePlayerProfile(playerProfileID, playerData)
+	err = p.PlayerProfileRepository.UpdatePlayerProfile(playerProfileID, &cosmeticData)
 	if err != nil {
 		logrus.WithError(err).Error("[PlayerProfileServiceImpl.Update] Failed to update player profile")
 		return err
@@ -177,9 +219,43 @@ func (p *PlayerProfileServiceImpl) Update(playerProfileID uint, playerProfile re
 	return nil
 }
 
-func NewPlayerProfileServiceImpl(playerProfileRepository repository.PlayerProfileRepository, validate *validator.Validate) services.PlayerProfileService {
+// UpdateProgress implements services.PlayerProfileService.
+// The experience is capped like granted experience and the level follows from
+// it on the XP curve, so the two never disagree.
+func (p *PlayerProfileServiceImpl) UpdateProgress(playerProfileID uint, progress request.UpdatePlayerProgressRequest) error {
+	if playerProfileID == 0 {
+		return helpers.ErrInvalidPlayerProfileID
+	}
+
+	err := p.Validate.Struct(progress)
+	if err != nil {
+		logrus.WithError(err).Error("[PlayerProfileServiceImpl.UpdateProgress] Failed to validate player progress data")
+		return helpers.ErrPlayerProfileDataValidation
+	}
+
+	maxExperience := p.XPCurve.ExperienceForLevel(p.XPCurve.MaxLevel())
+	experience := min(progress.Experience, maxExperience)
+
+	progressData := models.PlayerProfile{
+		Level:      p.XPCurve.LevelForExperience(experience),
+		Experience: experience,
+		Points:     progress.Points,
+	}
+
+	err = p.PlayerProfileRepository.UpdatePlayerProgress(playerProfileID, &progressData)
+	if err != nil {
+		logrus.WithError(err).Error("[PlayerProfileServiceImpl.UpdateProgress] Failed to update player progress")
+		return err
+	}
+
+	return nil
+}
+
+func NewPlayerProfileServiceImpl(playerProfileRepository repository.PlayerProfileRepository, xpCurve services.XPCurve, validate *validator.Validate, playerProfileConfig config.PlayerProfileConfig) services.PlayerProfileService {
 	return &PlayerProfileServiceImpl{
 		PlayerProfileRepository: playerProfileRepository,
+		XPCurve:                 xpCurve,
 		Validate:                validate,
+		PlayerProfileConfig:     playerProfileConfig,
 	}
 }
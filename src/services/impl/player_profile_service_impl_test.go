@@ -1,8 +1,11 @@
 package impl
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/dieg0code/player-profile/src/helpers"
@@ -14,32 +17,29 @@ import (
 	"gorm.io/gorm"
 )
 
+var testPlayerProfileConfig = config.PlayerProfileConfig{DefaultLevel: 1, MaxProfilesPerUser: 3}
+
 func TestPlayerProfileServiceImpl_Create(t *testing.T) {
 	t.Run("CreatePlayer_Success", func(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfile := request.CreatePlayerProfileRequest{
-			Nickname:   "TestPlayer",
-			Avatar:     "http://example.com/avatar.png",
-			Level:      1,
-			Experience: 10,
-			Points:     5,
-			UserID:     1,
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
+			UserID:   1,
 		}
 
 		// Expectations
-		mockPlayerRepo.On("CreatePlayerProfile", &models.PlayerProfile{
-			Nickname:   playerProfile.Nickname,
-			Avatar:     playerProfile.Avatar,
-			Level:      playerProfile.Level,
-			Experience: playerProfile.Experience,
-			Points:     playerProfile.Points,
-			UserID:     playerProfile.UserID,
-		}).Return(nil)
+		mockPlayerRepo.On("CreatePlayerProfileWithinLimit", &models.PlayerProfile{
+			Nickname: playerProfile.Nickname,
+			Avatar:   playerProfile.Avatar,
+			Level:    1,
+			UserID:   playerProfile.UserID,
+		}, testPlayerProfileConfig.MaxProfilesPerUser).Return(nil)
 
 		// Execution
 		err := playerService.Create(playerProfile)
@@ -53,7 +53,7 @@ func TestPlayerProfileServiceImpl_Create(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfile := request.CreatePlayerProfileRequest{
@@ -73,16 +73,13 @@ func TestPlayerProfileServiceImpl_Create(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfile := request.CreatePlayerProfileRequest{
-			Nickname:   "TestPlayer",
-			Avatar:     "http://example.com/avatar.png",
-			Level:      0,
-			Experience: 0,
-			Points:     0,
-			UserID:     0,
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
+			UserID:   0,
 		}
 
 		// Execution
@@ -98,27 +95,22 @@ func TestPlayerProfileServiceImpl_Create(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfile := request.CreatePlayerProfileRequest{
-			Nickname:   "TestPlayer",
-			Avatar:     "http://example.com/avatar.png",
-			Level:      1,
-			Experience: 10,
-			Points:     5,
-			UserID:     1,
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
+			UserID:   1,
 		}
 
 		// Expectations
-		mockPlayerRepo.On("CreatePlayerProfile", &models.PlayerProfile{
-			Nickname:   playerProfile.Nickname,
-			Avatar:     playerProfile.Avatar,
-			Level:      playerProfile.Level,
-			Experience: playerProfile.Experience,
-			Points:     playerProfile.Points,
-			UserID:     playerProfile.UserID,
-		}).Return(helpers.ErrRepository)
+		mockPlayerRepo.On("CreatePlayerProfileWithinLimit", &models.PlayerProfile{
+			Nickname: playerProfile.Nickname,
+			Avatar:   playerProfile.Avatar,
+			Level:    1,
+			UserID:   playerProfile.UserID,
+		}, testPlayerProfileConfig.MaxProfilesPerUser).Return(helpers.ErrRepository)
 
 		// Execution
 		err := playerService.Create(playerProfile)
@@ -128,6 +120,56 @@ func TestPlayerProfileServiceImpl_Create(t *testing.T) {
 		require.Equal(t, helpers.ErrRepository, err, "Error creating player profile")
 		mockPlayerRepo.AssertExpectations(t)
 	})
+
+	t.Run("CreatePlayer_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Test data
+		playerProfile := request.CreatePlayerProfileRequest{
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
+			UserID:   99,
+		}
+
+		// Expectations
+		mockPlayerRepo.On("CreatePlayerProfileWithinLimit", mock.Anything, testPlayerProfileConfig.MaxProfilesPerUser).Return(helpers.ErrorUserNotFound)
+
+		// Execution
+		err := playerService.Create(playerProfile)
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrorUserNotFound, "Expected user not found error")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreatePlayer_LimitReached", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerProfileConfig := testPlayerProfileConfig
+		playerProfileConfig.MaxProfilesPerUser = 2
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, playerProfileConfig)
+
+		// Test data
+		playerProfile := request.CreatePlayerProfileRequest{
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
+			UserID:   1,
+		}
+
+		// Expectations
+		mockPlayerRepo.On("CreatePlayerProfileWithinLimit", mock.Anything, 2).Return(helpers.ErrPlayerProfileLimitReached)
+
+		// Execution
+		err := playerService.Create(playerProfile)
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrPlayerProfileLimitReached, "Expected limit reached error")
+		mockPlayerRepo.AssertExpectations(t)
+	})
 }
 
 func TestPlayerProfileServiceImpl_Delete(t *testing.T) {
@@ -135,7 +177,7 @@ func TestPlayerProfileServiceImpl_Delete(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
@@ -155,7 +197,7 @@ func TestPlayerProfileServiceImpl_Delete(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(0)
@@ -173,7 +215,7 @@ func TestPlayerProfileServiceImpl_Delete(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
@@ -196,7 +238,7 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 		// Test data
 		playerProfiles := []models.PlayerProfile{
 			{
@@ -219,7 +261,7 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 			},
 		}
 
-		mockPlayerRepo.On("GetAllPlayerProfiles", 0, 10).Return(playerProfiles, nil)
+		mockPlayerRepo.On("GetAllPlayerProfiles", models.Page{Limit: 11}, models.ListQuery{}, uint(0)).Return(playerProfiles, nil)
 		var responseMock []response.PlayerProfileResponse
 		for _, player := range playerProfiles {
 			responseMock = append(responseMock, response.PlayerProfileResponse{
@@ -233,7 +275,7 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 			})
 		}
 
-		players, err := playerService.GetAll(1, 10)
+		players, _, err := playerService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, 0)
 
 		require.NoError(t, err, "Error getting all players")
 		require.Equal(t, responseMock, players, "Error getting all players")
@@ -245,13 +287,13 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 		// Test data
 		playerProfiles := []models.PlayerProfile{}
 
-		mockPlayerRepo.On("GetAllPlayerProfiles", 0, 10).Return(playerProfiles, nil)
+		mockPlayerRepo.On("GetAllPlayerProfiles", models.Page{Limit: 11}, models.ListQuery{}, uint(0)).Return(playerProfiles, nil)
 
-		players, err := playerService.GetAll(1, 10)
+		players, _, err := playerService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, 0)
 
 		require.NoError(t, err, "Error getting all players")
 		require.Empty(t, players, "Error getting all players")
@@ -262,13 +304,13 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 		// Test data
 		playerProfiles := []models.PlayerProfile{}
 
-		mockPlayerRepo.On("GetAllPlayerProfiles", 0, 10).Return(playerProfiles, helpers.ErrRepository)
+		mockPlayerRepo.On("GetAllPlayerProfiles", models.Page{Limit: 11}, models.ListQuery{}, uint(0)).Return(playerProfiles, helpers.ErrRepository)
 
-		players, err := playerService.GetAll(1, 10)
+		players, _, err := playerService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{}, 0)
 
 		require.Error(t, err, "Error getting all players")
 		require.Nil(t, players, "Error getting all players")
@@ -280,22 +322,87 @@ func TestPlayerProfileServiceImpl_GetAll(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
-		players, err := playerService.GetAll(0, 0)
+		players, _, err := playerService.GetAll(request.PageRequest{Page: 0, PageSize: 0}, models.ListQuery{}, 0)
 
 		require.Error(t, err, "Error getting all players")
 		require.Nil(t, players, "Error getting all players")
 		require.EqualError(t, err, helpers.ErrInvalidPagination.Error(), "Error getting all players")
 		mockPlayerRepo.AssertExpectations(t)
 	})
+
+	t.Run("GetAllPlayers_InvalidListQuery", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+		// Test data
+		query := models.ListQuery{Filters: []models.Filter{{Field: "avatar", Operator: models.FilterEqual, Value: "a.png"}}}
+		invalidQuery := fmt.Errorf("%w: unknown field %q", helpers.ErrInvalidListQuery, "avatar")
+
+		mockPlayerRepo.On("GetAllPlayerProfiles", models.Page{Limit: 11}, query, uint(0)).Return([]models.PlayerProfile(nil), invalidQuery)
+
+		players, _, err := playerService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, query, 0)
+
+		require.ErrorIs(t, err, helpers.ErrInvalidListQuery, "Error getting all players")
+		require.Nil(t, players, "Error getting all players")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_CursorWithTotal", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+		// Test data
+		playerProfiles := []models.PlayerProfile{
+			{Model: gorm.Model{ID: 5}, Nickname: "TestPlayer5", Avatar: "http://example.com/avatar.png", Level: 1, UserID: 1},
+			{Model: gorm.Model{ID: 6}, Nickname: "TestPlayer6", Avatar: "http://example.com/avatar.png", Level: 1, UserID: 1},
+			{Model: gorm.Model{ID: 7}, Nickname: "TestPlayer7", Avatar: "http://example.com/avatar.png", Level: 1, UserID: 1},
+		}
+		pageRequest := request.PageRequest{Page: 1, PageSize: 2, After: helpers.EncodeCursor(4), Total: true}
+
+		// Expectations
+		mockPlayerRepo.On("GetAllPlayerProfiles", models.Page{Limit: 3, AfterID: 4}, models.ListQuery{}, uint(0)).Return(playerProfiles, nil)
+		mockPlayerRepo.On("CountPlayerProfiles", models.ListQuery{}, uint(0)).Return(int64(9), nil)
+
+		// Execution
+		players, pagination, err := playerService.GetAll(pageRequest, models.ListQuery{}, 0)
+
+		// Assertions
+		require.NoError(t, err, "Error getting all players")
+		require.Len(t, players, 2)
+		require.Equal(t, uint(6), players[1].ID)
+		require.True(t, pagination.HasMore)
+		require.Equal(t, helpers.EncodeCursor(6), pagination.NextCursor)
+		require.Equal(t, helpers.EncodeCursor(5), pagination.PrevCursor)
+		require.Equal(t, int64(9), *pagination.Total)
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetAllPlayers_InvalidCursor", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Execution
+		players, pagination, err := playerService.GetAll(request.PageRequest{PageSize: 10, Before: "nope"}, models.ListQuery{}, 0)
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrInvalidCursor, "Error getting all players")
+		require.Nil(t, players, "Error getting all players")
+		require.Nil(t, pagination, "Error getting all players")
+		mockPlayerRepo.AssertNotCalled(t, "GetAllPlayerProfiles")
+	})
 }
 func TestPlayerProfileServiceImpl_GetByID(t *testing.T) {
 	t.Run("GetPlayer_Success", func(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
@@ -325,7 +432,7 @@ func TestPlayerProfileServiceImpl_GetByID(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(0)
@@ -344,7 +451,7 @@ func TestPlayerProfileServiceImpl_GetByID(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
@@ -368,30 +475,30 @@ func TestPlayerProfileServiceImpl_Update(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
 		playerProfile := request.UpdatePlayerProfileRequest{
-			Nickname:   "TestPlayer",
-			Avatar:     "http://example.com/avatar.png",
-			Level:      1,
-			Experience: 10,
-			Points:     5,
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
 		}
 
 		playerData := &models.PlayerProfile{
 			Model:      gorm.Model{ID: playerProfileID},
-			Nickname:   playerProfile.Nickname,
-			Avatar:     playerProfile.Avatar,
-			Level:      playerProfile.Level,
-			Experience: playerProfile.Experience,
-			Points:     playerProfile.Points,
+			Nickname:   "OldNickname",
+			Avatar:     "http://example.com/old-avatar.png",
+			Level:      3,
+			Experience: 300,
+			Points:     50,
 		}
 
 		// Expectations
 		mockPlayerRepo.On("GetPlayerProfile", playerProfileID).Return(playerData, nil)
-		mockPlayerRepo.On("UpdatePlayerProfile", playerProfileID, playerData).Return(nil)
+		mockPlayerRepo.On("UpdatePlayerProfile", playerProfileID, &models.PlayerProfile{
+			Nickname: playerProfile.Nickname,
+			Avatar:   playerProfile.Avatar,
+		}).Return(nil)
 
 		// Execution
 		err := playerService.Update(playerProfileID, playerProfile)
@@ -405,7 +512,7 @@ func TestPlayerProfileServiceImpl_Update(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Mock expectation for GetPlayerProfile
 		mockPlayerRepo.On("GetPlayerProfile", mock.Anything).Return(nil, helpers.ErrorPlayerProfileNotFound)
@@ -413,11 +520,8 @@ func TestPlayerProfileServiceImpl_Update(t *testing.T) {
 		// Test data
 		playerProfileID := uint(0)
 		playerProfile := request.UpdatePlayerProfileRequest{
-			Nickname:   "TestPlayer",
-			Avatar:     "http://example.com/avatar.png",
-			Level:      1,
-			Experience: 10,
-			Points:     5,
+			Nickname: "TestPlayer",
+			Avatar:   "http://example.com/avatar.png",
 		}
 
 		// Execution
@@ -430,12 +534,119 @@ func TestPlayerProfileServiceImpl_Update(t *testing.T) {
 	})
 }
 
+func TestPlayerProfileServiceImpl_UpdateProgress(t *testing.T) {
+	t.Run("UpdateProgress_Success", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Test data
+		playerProfileID := uint(1)
+		progress := request.UpdatePlayerProgressRequest{
+			Experience: 250,
+			Points:     150,
+		}
+
+		// Expectations
+		mockPlayerRepo.On("UpdatePlayerProgress", playerProfileID, &models.PlayerProfile{
+			Level:      3,
+			Experience: 250,
+			Points:     150,
+		}).Return(nil)
+
+		// Execution
+		err := playerService.UpdateProgress(playerProfileID, progress)
+
+		// Assertions
+		require.NoError(t, err, "Error updating player progress")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateProgress_CapsExperience", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Test data
+		playerProfileID := uint(1)
+		maxExperience := testXPCurve.ExperienceForLevel(testXPCurve.MaxLevel())
+		progress := request.UpdatePlayerProgressRequest{
+			Experience: maxExperience + 500,
+		}
+
+		// Expectations
+		mockPlayerRepo.On("UpdatePlayerProgress", playerProfileID, &models.PlayerProfile{
+			Level:      testXPCurve.MaxLevel(),
+			Experience: maxExperience,
+		}).Return(nil)
+
+		// Execution
+		err := playerService.UpdateProgress(playerProfileID, progress)
+
+		// Assertions
+		require.NoError(t, err, "Error updating player progress")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateProgress_InvalidID", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Execution
+		err := playerService.UpdateProgress(0, request.UpdatePlayerProgressRequest{})
+
+		// Assertions
+		require.EqualError(t, err, helpers.ErrInvalidPlayerProfileID.Error(), "Expected error updating player progress with invalid ID")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateProgress_ValidationError", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Test data
+		progress := request.UpdatePlayerProgressRequest{
+			Experience: -10,
+		}
+
+		// Execution
+		err := playerService.UpdateProgress(1, progress)
+
+		// Assertions
+		require.EqualError(t, err, helpers.ErrPlayerProfileDataValidation.Error(), "Expected error updating player progress with invalid data")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateProgress_NotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Expectations
+		mockPlayerRepo.On("UpdatePlayerProgress", uint(99), mock.Anything).Return(helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		err := playerService.UpdateProgress(99, request.UpdatePlayerProgressRequest{})
+
+		// Assertions
+		require.ErrorIs(t, err, helpers.ErrorPlayerProfileNotFound, "Expected not found error")
+		mockPlayerRepo.AssertExpectations(t)
+	})
+}
+
 func TestPlayerProfileServiceImpl_GetPlayerWithAchievements(t *testing.T) {
 	t.Run("GetPlayerWithAchievements_Success", func(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
@@ -447,8 +658,14 @@ func TestPlayerProfileServiceImpl_GetPlayerWithAchievements(t *testing.T) {
 			Experience: 10,
 			Points:     5,
 			UserID:     1,
-			Achievements: []models.Achievement{
-				{
+		}
+		unlockedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+		playerAchievements := []models.PlayerProfileAchievement{
+			{
+				PlayerProfileID: playerProfileID,
+				AchievementID:   1,
+				UnlockedAt:      unlockedAt,
+				Achievement: models.Achievement{
 					Model:       gorm.Model{ID: 1},
 					Name:        "TestAchievement",
 					Description: "TestDescription",
@@ -457,15 +674,17 @@ func TestPlayerProfileServiceImpl_GetPlayerWithAchievements(t *testing.T) {
 		}
 
 		// Expectations
-		mockPlayerRepo.On("GetPlayerWithAchievements", playerProfileID).Return(&playerProfile, nil)
+		mockPlayerRepo.On("GetPlayerProfile", playerProfileID).Return(&playerProfile, nil)
+		mockPlayerRepo.On("GetPlayerAchievements", playerProfileID, 10, 10, "asc").Return(playerAchievements, nil)
 
 		// Execution
-		result, err := playerService.GetPlayerWithAchievements(playerProfileID)
+		result, err := playerService.GetPlayerWithAchievements(playerProfileID, 2, 10, "asc")
 
 		// Assertions
 		require.NoError(t, err, "Error getting player profile with achievements")
 		require.Equal(t, playerProfileID, result.ID, "Error getting player profile with achievements")
-		require.NotEmpty(t, result.Achievements, "Error getting player profile with achievements")
+		require.Len(t, result.Achievements, 1, "Error getting player profile with achievements")
+		require.Equal(t, unlockedAt, result.Achievements[0].UnlockedAt, "Unlock dates do not match")
 		mockPlayerRepo.AssertExpectations(t)
 	})
 
@@ -473,20 +692,53 @@ func TestPlayerProfileServiceImpl_GetPlayerWithAchievements(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
-
-		// Test data
-		playerProfileID := uint(0)
-
-		mockPlayerRepo.On("GetPlayerWithAchievements", playerProfileID).Return(nil, helpers.ErrInvalidPlayerProfileID)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Execution
-		result, err := playerService.GetPlayerWithAchievements(playerProfileID)
+		result, err := playerService.GetPlayerWithAchievements(0, 1, 10, "desc")
 
 		// Assertions
 		require.Error(t, err, "Expected error getting player profile with achievements with invalid ID")
 		require.Nil(t, result, "Expected nil result getting player profile with achievements with invalid ID")
 		require.EqualError(t, err, helpers.ErrInvalidPlayerProfileID.Error(), "Expected error getting player profile with achievements with invalid ID")
+		mockPlayerRepo.AssertNotCalled(t, "GetPlayerProfile")
+	})
+
+	t.Run("GetPlayerWithAchievements_InvalidSortOrder", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Execution
+		result, err := playerService.GetPlayerWithAchievements(1, 1, 10, "sideways")
+
+		// Assertions
+		require.Error(t, err, "Expected error getting player profile with achievements with invalid sort order")
+		require.Nil(t, result, "Expected nil result getting player profile with achievements with invalid sort order")
+		require.Equal(t, helpers.ErrInvalidSortOrder, err, "Expected invalid sort order error")
+		mockPlayerRepo.AssertNotCalled(t, "GetPlayerProfile")
+	})
+
+	t.Run("GetPlayerWithAchievements_NotFound", func(t *testing.T) {
+		// Mocks
+		mockPlayerRepo := new(mocks.PlayerProfileRepository)
+		mockValidator := validator.New()
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
+
+		// Test data
+		playerProfileID := uint(1)
+
+		mockPlayerRepo.On("GetPlayerProfile", playerProfileID).Return(nil, helpers.ErrorPlayerProfileNotFound)
+
+		// Execution
+		result, err := playerService.GetPlayerWithAchievements(playerProfileID, 1, 10, "desc")
+
+		// Assertions
+		require.Error(t, err, "Expected error getting non-existent player profile with achievements")
+		require.Nil(t, result, "Expected nil result getting non-existent player profile with achievements")
+		require.Equal(t, helpers.ErrorPlayerProfileNotFound, err, "Expected player profile not found error")
+		mockPlayerRepo.AssertNotCalled(t, "GetPlayerAchievements")
 		mockPlayerRepo.AssertExpectations(t)
 	})
 
@@ -494,15 +746,20 @@ func TestPlayerProfileServiceImpl_GetPlayerWithAchievements(t *testing.T) {
 		// Mocks
 		mockPlayerRepo := new(mocks.PlayerProfileRepository)
 		mockValidator := validator.New()
-		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, mockValidator)
+		playerService := NewPlayerProfileServiceImpl(mockPlayerRepo, testXPCurve, mockValidator, testPlayerProfileConfig)
 
 		// Test data
 		playerProfileID := uint(1)
+		playerProfile := models.PlayerProfile{
+			Model:    gorm.Model{ID: playerProfileID},
+			Nickname: "TestPlayer",
+		}
 
-		mockPlayerRepo.On("GetPlayerWithAchievements", playerProfileID).Return(nil, helpers.ErrRepository)
+		mockPlayerRepo.On("GetPlayerProfile", playerProfileID).Return(&playerProfile, nil)
+		mockPlayerRepo.On("GetPlayerAchievements", playerProfileID, 0, 10, "desc").Return(nil, helpers.ErrRepository)
 
 		// Execution
-		result, err := playerService.GetPlayerWithAchievements(playerProfileID)
+		result, err := playerService.GetPlayerWithAchievements(playerProfileID, 1, 10, "desc")
 
 		// Assertions
 		require.Error(t, err, "Error getting player profile with achievements")
@@ -0,0 +1,208 @@
+package impl
+
+import (
+	"math"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+)
+
+const (
+	// glicko2Scale converts ratings to the Glicko-2 scale and back.
+	glicko2Scale = 173.7178
+	// glicko2Convergence is the tolerance of the volatility iteration.
+	glicko2Convergence = 0.000001
+)
+
+// pairing is the result of a player against one opposing team, 1 for a win,
+// 0.5 for a draw and 0 for a loss.
+type pairing struct {
+	opponent services.Rating
+	score    float64
+}
+
+// RatingEngineImpl rates every player against each opposing team, seen as a
+// single player with the mean rating of the team. The better placement wins
+// each pairing and equal placements draw, so free-for-all, team and 1v1
+// matches are rated the same way. Every match is a Glicko-2 rating period.
+type RatingEngineImpl struct {
+	system     string
+	initial    services.Rating
+	kFactor    float64
+	glicko2Tau float64
+}
+
+func NewRatingEngine(ratingConfig config.RatingConfig) services.RatingEngine {
+	return &RatingEngineImpl{
+		system: ratingConfig.System,
+		initial: services.Rating{
+			Rating:     ratingConfig.InitialRating,
+			Deviation:  ratingConfig.InitialDeviation,
+			Volatility: ratingConfig.InitialVolatility,
+		},
+		kFactor:    ratingConfig.EloKFactor,
+		glicko2Tau: ratingConfig.Glicko2Tau,
+	}
+}
+
+// System implements services.RatingEngine.
+func (r *RatingEngineImpl) System() string {
+	return r.system
+}
+
+// Initial implements services.RatingEngine.
+func (r *RatingEngineImpl) Initial() services.Rating {
+	return r.initial
+}
+
+// Rate implements services.RatingEngine.
+func (r *RatingEngineImpl) Rate(teams []services.RatedTeam) [][]services.Rating {
+	composites := make([]services.Rating, len(teams))
+	for i, team := range teams {
+		composites[i] = compositeRating(team.Players)
+	}
+
+	ratings := make([][]services.Rating, len(teams))
+	for i, team := range teams {
+		pairings := make([]pairing, 0, len(teams)-1)
+		for j, opponent := range teams {
+			if j != i {
+				pairings = append(pairings, pairing{opponent: composites[j], score: placementScore(team.Placement, opponent.Placement)})
+			}
+		}
+
+		ratings[i] = make([]services.Rating, len(team.Players))
+		for j, player := range team.Players {
+			if r.system == models.RatingSystemGlicko2 {
+				ratings[i][j] = r.rateGlicko2(player, pairings)
+			} else {
+				ratings[i][j] = r.rateElo(player, pairings)
+			}
+		}
+	}
+
+	return ratings
+}
+
+// rateElo averages the Elo update of every pairing, so a free-for-all moves
+// the rating as much as a 1v1.
+func (r *RatingEngineImpl) rateElo(player services.Rating, pairings []pairing) services.Rating {
+	if len(pairings) == 0 {
+		return player
+	}
+
+	var surprise float64
+	for _, p := range pairings {
+		expected := 1 / (1 + math.Pow(10, (p.opponent.Rating-player.Rating)/400))
+		surprise += p.score - expected
+	}
+
+	player.Rating += r.kFactor * surprise / float64(len(pairings))
+	return player
+}
+
+// rateGlicko2 follows the steps of Glickman's "Example of the Glicko-2
+// system". The deviation never grows above the one of new players.
+func (r *RatingEngineImpl) rateGlicko2(player services.Rating, pairings []pairing) services.Rating {
+	if len(pairings) == 0 {
+		return player
+	}
+
+	mu := (player.Rating - r.initial.Rating) / glicko2Scale
+	phi := player.Deviation / glicko2Scale
+
+	var inverseVariance, improvement float64
+	for _, p := range pairings {
+		opponentMu := (p.opponent.Rating - r.initial.Rating) / glicko2Scale
+		opponentPhi := p.opponent.Deviation / glicko2Scale
+
+		g := 1 / math.Sqrt(1+3*opponentPhi*opponentPhi/(math.Pi*math.Pi))
+		expected := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+
+		inverseVariance += g * g * expected * (1 - expected)
+		improvement += g * (p.score - expected)
+	}
+
+	variance := 1 / inverseVariance
+	volatility := r.glicko2Volatility(phi, variance, variance*improvement, player.Volatility)
+
+	preRatingPhi := math.Sqrt(phi*phi + volatility*volatility)
+	newPhi := 1 / math.Sqrt(1/(preRatingPhi*preRatingPhi)+1/variance)
+	newMu := mu + newPhi*newPhi*improvement
+
+	return services.Rating{
+		Rating:     glicko2Scale*newMu + r.initial.Rating,
+		Deviation:  min(glicko2Scale*newPhi, r.initial.Deviation),
+		Volatility: volatility,
+	}
+}
+
+// glicko2Volatility finds the new volatility with the Illinois algorithm.
+func (r *RatingEngineImpl) glicko2Volatility(phi float64, variance float64, delta float64, volatility float64) float64 {
+	tau := r.glicko2Tau
+	a := math.Log(volatility * volatility)
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + variance + ex
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	lower := a
+	var upper float64
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		upper = a - k*tau
+	}
+
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > glicko2Convergence {
+		c := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fC := f(c)
+
+		if fC*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+
+		upper, fUpper = c, fC
+	}
+
+	return math.Exp(lower / 2)
+}
+
+// compositeRating is a team seen as one player, its deviation is the root
+// mean square of the deviations of the players.
+func compositeRating(players []services.Rating) services.Rating {
+	var composite services.Rating
+	for _, player := range players {
+		composite.Rating += player.Rating
+		composite.Deviation += player.Deviation * player.Deviation
+		composite.Volatility += player.Volatility
+	}
+
+	n := float64(len(players))
+	composite.Rating /= n
+	composite.Deviation = math.Sqrt(composite.Deviation / n)
+	composite.Volatility /= n
+
+	return composite
+}
+
+func placementScore(placement int, opponentPlacement int) float64 {
+	switch {
+	case placement < opponentPlacement:
+		return 1
+	case placement == opponentPlacement:
+		return 0.5
+	default:
+		return 0
+	}
+}
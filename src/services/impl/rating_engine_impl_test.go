@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRatingConfig = config.RatingConfig{
+	System:            models.RatingSystemElo,
+	InitialRating:     1500,
+	InitialDeviation:  350,
+	InitialVolatility: 0.06,
+	EloKFactor:        32,
+	Glicko2Tau:        0.5,
+}
+
+func TestRatingEngineImpl_Elo(t *testing.T) {
+	engine := NewRatingEngine(testRatingConfig)
+	initial := engine.Initial()
+
+	t.Run("Elo_OneVersusOne", func(t *testing.T) {
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 1, Players: []services.Rating{initial}},
+			{Placement: 2, Players: []services.Rating{initial}},
+		})
+
+		assert.InDelta(t, 1516, ratings[0][0].Rating, 0.001)
+		assert.InDelta(t, 1484, ratings[1][0].Rating, 0.001)
+		assert.Equal(t, initial.Deviation, ratings[0][0].Deviation, "Elo keeps the deviation")
+	})
+
+	t.Run("Elo_Draw", func(t *testing.T) {
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 1, Players: []services.Rating{initial}},
+			{Placement: 1, Players: []services.Rating{initial}},
+		})
+
+		assert.InDelta(t, 1500, ratings[0][0].Rating, 0.001)
+		assert.InDelta(t, 1500, ratings[1][0].Rating, 0.001)
+	})
+
+	t.Run("Elo_FreeForAll", func(t *testing.T) {
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 2, Players: []services.Rating{initial}},
+			{Placement: 1, Players: []services.Rating{initial}},
+			{Placement: 3, Players: []services.Rating{initial}},
+		})
+
+		assert.InDelta(t, 1500, ratings[0][0].Rating, 0.001, "Beat one player and lost to one")
+		assert.InDelta(t, 1516, ratings[1][0].Rating, 0.001)
+		assert.InDelta(t, 1484, ratings[2][0].Rating, 0.001)
+	})
+
+	t.Run("Elo_Teams", func(t *testing.T) {
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 1, Players: []services.Rating{{Rating: 1600}, {Rating: 1400}}},
+			{Placement: 2, Players: []services.Rating{{Rating: 1500}, {Rating: 1500}}},
+		})
+
+		// Both winners face a 1500 composite, the weaker one was less expected to win
+		assert.InDelta(t, 1611.52, ratings[0][0].Rating, 0.01)
+		assert.InDelta(t, 1420.48, ratings[0][1].Rating, 0.01)
+		assert.InDelta(t, 1484, ratings[1][0].Rating, 0.001)
+	})
+}
+
+func TestRatingEngineImpl_Glicko2(t *testing.T) {
+	glicko2Config := testRatingConfig
+	glicko2Config.System = models.RatingSystemGlicko2
+	engine := NewRatingEngine(glicko2Config)
+
+	require.Equal(t, models.RatingSystemGlicko2, engine.System())
+
+	t.Run("Glicko2_GlickmanExample", func(t *testing.T) {
+		// Beats the 1400 player and loses to the 1550 and 1700 players
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 2, Players: []services.Rating{{Rating: 1500, Deviation: 200, Volatility: 0.06}}},
+			{Placement: 3, Players: []services.Rating{{Rating: 1400, Deviation: 30, Volatility: 0.06}}},
+			{Placement: 1, Players: []services.Rating{{Rating: 1550, Deviation: 100, Volatility: 0.06}}},
+			{Placement: 1, Players: []services.Rating{{Rating: 1700, Deviation: 300, Volatility: 0.06}}},
+		})
+
+		assert.InDelta(t, 1464.06, ratings[0][0].Rating, 0.01)
+		assert.InDelta(t, 151.52, ratings[0][0].Deviation, 0.01)
+		assert.InDelta(t, 0.05999, ratings[0][0].Volatility, 0.00001)
+	})
+
+	t.Run("Glicko2_DeviationCapped", func(t *testing.T) {
+		initial := engine.Initial()
+
+		ratings := engine.Rate([]services.RatedTeam{
+			{Placement: 1, Players: []services.Rating{initial}},
+			{Placement: 2, Players: []services.Rating{initial}},
+		})
+
+		assert.Greater(t, ratings[0][0].Rating, initial.Rating)
+		assert.Less(t, ratings[1][0].Rating, initial.Rating)
+		assert.LessOrEqual(t, ratings[0][0].Deviation, initial.Deviation)
+	})
+}
@@ -0,0 +1,421 @@
+package impl
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// Role names travel in the access tokens, keep them simple.
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// builtInRoles are created at startup with these permissions when missing.
+// Admin always gets the whole catalog.
+var builtInRoles = []models.Role{
+	{Name: models.AdminRole, Description: "Full access"},
+	{Name: models.UserRole, Description: "Player, can only manage their own account and profiles"},
+	{Name: models.GameServerRole, Description: "Dedicated game server", Permissions: []models.RolePermission{{Permission: models.PermissionAchievementsAward}, {Permission: models.PermissionPlayersProgress}, {Permission: models.PermissionMatchesSubmit}}},
+}
+
+type RoleServiceImpl struct {
+	RoleRepository  repository.RoleRepository
+	UserRepository  repository.UserRepository
+	PermissionStore auth.PermissionStore
+	RevocationStore auth.RevocationStore
+	Validate        *validator.Validate
+}
+
+// GetPermissions implements services.RoleService.
+func (r *RoleServiceImpl) GetPermissions() []response.PermissionResponse {
+	permissions := make([]response.PermissionResponse, 0, len(models.Permissions))
+	for _, permission := range models.Permissions {
+		permissions = append(permissions, response.PermissionResponse{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return permissions
+}
+
+// GetAllRoles implements services.RoleService.
+func (r *RoleServiceImpl) GetAllRoles() ([]response.RoleResponse, error) {
+	roles, err := r.RoleRepository.GetAllRoles()
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.GetAllRoles] Failed to get roles")
+		return nil, err
+	}
+
+	roleResponses := make([]response.RoleResponse, 0, len(roles))
+	for i := range roles {
+		roleResponses = append(roleResponses, toRoleResponse(&roles[i]))
+	}
+
+	return roleResponses, nil
+}
+
+// GetRole implements services.RoleService.
+func (r *RoleServiceImpl) GetRole(roleID uint) (*response.RoleResponse, error) {
+	if roleID == 0 {
+		return nil, helpers.ErrInvalidRoleID
+	}
+
+	role, err := r.RoleRepository.GetRole(roleID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorRoleNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.GetRole] Failed to get role")
+		}
+		return nil, err
+	}
+
+	roleResponse := toRoleResponse(role)
+	return &roleResponse, nil
+}
+
+// CreateRole implements services.RoleService.
+func (r *RoleServiceImpl) CreateRole(createRequest request.CreateRoleRequest) (*response.RoleResponse, error) {
+	err := r.Validate.Struct(createRequest)
+	if err != nil || !roleNamePattern.MatchString(createRequest.Name) {
+		logrus.WithError(err).Error("[RoleServiceImpl.CreateRole] Failed to validate role request")
+		return nil, helpers.ErrRoleDataValidation
+	}
+
+	rolePermissions, err := toRolePermissions(createRequest.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.RoleRepository.FindByName(createRequest.Name)
+	if err == nil {
+		return nil, helpers.ErrRoleAlreadyExists
+	}
+
+	if !errors.Is(err, helpers.ErrorRoleNotFound) {
+		logrus.WithError(err).Error("[RoleServiceImpl.CreateRole] Failed to find role")
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        createRequest.Name,
+		Description: createRequest.Description,
+		Permissions: rolePermissions,
+	}
+
+	err = r.RoleRepository.CreateRole(role)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.CreateRole] Failed to create role")
+		return nil, err
+	}
+
+	r.PermissionStore.Invalidate()
+	logrus.WithField("role", role.Name).Info("[RoleServiceImpl.CreateRole] Role created")
+
+	roleResponse := toRoleResponse(role)
+	return &roleResponse, nil
+}
+
+// UpdateRole implements services.RoleService.
+func (r *RoleServiceImpl) UpdateRole(roleID uint, updateRequest request.UpdateRoleRequest) (*response.RoleResponse, error) {
+	if roleID == 0 {
+		return nil, helpers.ErrInvalidRoleID
+	}
+
+	err := r.Validate.Struct(updateRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.UpdateRole] Failed to validate role request")
+		return nil, helpers.ErrRoleDataValidation
+	}
+
+	rolePermissions, err := toRolePermissions(updateRequest.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := r.RoleRepository.GetRole(roleID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorRoleNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.UpdateRole] Failed to get role")
+		}
+		return nil, err
+	}
+
+	// Admins can't lock themselves out of a permission
+	if role.Name == models.AdminRole && len(rolePermissions) != len(models.Permissions) {
+		return nil, helpers.ErrBuiltInRole
+	}
+
+	// New accounts get the default role, CheckDefaultRole only runs at startup
+	if role.Name == config.DefaultRole() && len(rolePermissions) > 0 {
+		return nil, helpers.ErrPrivilegedDefaultRole
+	}
+
+	role.Description = updateRequest.Description
+	role.Permissions = rolePermissions
+
+	err = r.RoleRepository.UpdateRole(role)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.UpdateRole] Failed to update role")
+		return nil, err
+	}
+
+	r.PermissionStore.Invalidate()
+	logrus.WithField("role", role.Name).Info("[RoleServiceImpl.UpdateRole] Role updated")
+
+	roleResponse := toRoleResponse(role)
+	return &roleResponse, nil
+}
+
+// DeleteRole implements services.RoleService.
+// Built-in roles and roles still assigned to users can't be deleted.
+func (r *RoleServiceImpl) DeleteRole(roleID uint) error {
+	if roleID == 0 {
+		return helpers.ErrInvalidRoleID
+	}
+
+	role, err := r.RoleRepository.GetRole(roleID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorRoleNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.DeleteRole] Failed to get role")
+		}
+		return err
+	}
+
+	if isBuiltInRole(role.Name) {
+		return helpers.ErrBuiltInRole
+	}
+
+	userCount, err := r.UserRepository.CountUsersByRole(role.Name)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.DeleteRole] Failed to count users with the role")
+		return err
+	}
+
+	if userCount > 0 {
+		return helpers.ErrRoleInUse
+	}
+
+	err = r.RoleRepository.DeleteRole(roleID)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.DeleteRole] Failed to delete role")
+		return err
+	}
+
+	r.PermissionStore.Invalidate()
+	logrus.WithField("role", role.Name).Info("[RoleServiceImpl.DeleteRole] Role deleted")
+
+	return nil
+}
+
+// AssignRole implements services.RoleService.
+// The access tokens of the user are revoked so the new role applies on the
+// next refresh instead of when they expire.
+func (r *RoleServiceImpl) AssignRole(userID uint, assignRequest request.AssignRoleRequest) error {
+	if userID == 0 {
+		return helpers.ErrInvalidUserID
+	}
+
+	err := r.Validate.Struct(assignRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to validate assign role request")
+		return helpers.ErrRoleDataValidation
+	}
+
+	_, err = r.RoleRepository.FindByName(assignRequest.Role)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorRoleNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to find role")
+		}
+		return err
+	}
+
+	user, err := r.UserRepository.GetUser(userID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorUserNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to get user")
+		}
+		return err
+	}
+
+	if user.Role == assignRequest.Role {
+		return nil
+	}
+
+	if user.Role == models.AdminRole {
+		adminCount, err := r.UserRepository.CountUsersByRole(models.AdminRole)
+		if err != nil {
+			logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to count admins")
+			return err
+		}
+
+		if adminCount <= 1 {
+			return helpers.ErrLastAdmin
+		}
+	}
+
+	err = r.UserRepository.UpdateUser(userID, &models.User{Role: assignRequest.Role})
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to update user role")
+		return err
+	}
+
+	err = r.RevocationStore.RevokeAllForUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[RoleServiceImpl.AssignRole] Failed to revoke access tokens")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{"userID": userID, "from": user.Role, "to": assignRequest.Role}).Info("[RoleServiceImpl.AssignRole] Role changed")
+
+	return nil
+}
+
+// GetUserPermissions implements services.RoleService.
+func (r *RoleServiceImpl) GetUserPermissions(userID uint) ([]string, error) {
+	if userID == 0 {
+		return nil, helpers.ErrInvalidUserID
+	}
+
+	user, err := r.UserRepository.GetUser(userID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorUserNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.GetUserPermissions] Failed to get user")
+		}
+		return nil, err
+	}
+
+	// A role deleted meanwhile grants nothing, like in the auth middleware
+	permissions, _ := r.PermissionStore.Permissions(user.Role)
+	return permissions, nil
+}
+
+// CheckDefaultRole implements services.RoleService.
+// New accounts get the default role, so it must exist and grant no permission.
+func (r *RoleServiceImpl) CheckDefaultRole(name string) error {
+	role, err := r.RoleRepository.FindByName(name)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorRoleNotFound) {
+			return helpers.ErrInvalidDefaultRole
+		}
+		logrus.WithError(err).Error("[RoleServiceImpl.CheckDefaultRole] Failed to find role")
+		return err
+	}
+
+	if len(role.Permissions) > 0 {
+		return helpers.ErrPrivilegedDefaultRole
+	}
+
+	return nil
+}
+
+// SeedRoles implements services.RoleService.
+// Missing built-in roles are created, existing ones keep the permissions set
+// by the admins except admin, which gets the permissions added to the catalog.
+func (r *RoleServiceImpl) SeedRoles() error {
+	for _, builtInRole := range builtInRoles {
+		role, err := r.RoleRepository.FindByName(builtInRole.Name)
+		if err != nil && !errors.Is(err, helpers.ErrorRoleNotFound) {
+			logrus.WithError(err).Error("[RoleServiceImpl.SeedRoles] Failed to find role")
+			return err
+		}
+
+		if role == nil {
+			role = &models.Role{
+				Name:        builtInRole.Name,
+				Description: builtInRole.Description,
+				Permissions: append([]models.RolePermission{}, builtInRole.Permissions...),
+			}
+			if role.Name == models.AdminRole {
+				role.Permissions = allRolePermissions()
+			}
+
+			err = r.RoleRepository.CreateRole(role)
+			if err != nil {
+				logrus.WithError(err).Error("[RoleServiceImpl.SeedRoles] Failed to create role")
+				return err
+			}
+
+			logrus.WithField("role", role.Name).Info("[RoleServiceImpl.SeedRoles] Role created")
+			continue
+		}
+
+		if role.Name == models.AdminRole && len(role.Permissions) != len(models.Permissions) {
+			role.Permissions = allRolePermissions()
+
+			err = r.RoleRepository.UpdateRole(role)
+			if err != nil {
+				logrus.WithError(err).Error("[RoleServiceImpl.SeedRoles] Failed to update admin permissions")
+				return err
+			}
+		}
+	}
+
+	r.PermissionStore.Invalidate()
+	return nil
+}
+
+func toRoleResponse(role *models.Role) response.RoleResponse {
+	return response.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+	}
+}
+
+// toRolePermissions checks the permissions against the catalog, dropping repeated ones.
+func toRolePermissions(permissions []string) ([]models.RolePermission, error) {
+	rolePermissions := make([]models.RolePermission, 0, len(permissions))
+	seen := map[string]bool{}
+
+	for _, permission := range permissions {
+		if !models.IsPermission(permission) {
+			return nil, helpers.ErrUnknownPermission
+		}
+
+		if !seen[permission] {
+			seen[permission] = true
+			rolePermissions = append(rolePermissions, models.RolePermission{Permission: permission})
+		}
+	}
+
+	return rolePermissions, nil
+}
+
+func allRolePermissions() []models.RolePermission {
+	rolePermissions := make([]models.RolePermission, 0, len(models.Permissions))
+	for _, permission := range models.Permissions {
+		rolePermissions = append(rolePermissions, models.RolePermission{Permission: permission.Name})
+	}
+
+	return rolePermissions
+}
+
+func isBuiltInRole(name string) bool {
+	for _, builtInRole := range builtInRoles {
+		if builtInRole.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewRoleServiceImpl(roleRepository repository.RoleRepository, userRepository repository.UserRepository, permissionStore auth.PermissionStore, revocationStore auth.RevocationStore, validate *validator.Validate) services.RoleService {
+	return &RoleServiceImpl{
+		RoleRepository:  roleRepository,
+		UserRepository:  userRepository,
+		PermissionStore: permissionStore,
+		RevocationStore: revocationStore,
+		Validate:        validate,
+	}
+}
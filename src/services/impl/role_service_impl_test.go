@@ -0,0 +1,410 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRoleServiceImpl_CreateRole(t *testing.T) {
+	t.Run("CreateRole_Success", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Test data
+		createRequest := request.CreateRoleRequest{
+			Name:        "moderator",
+			Description: "Community moderator",
+			Permissions: []string{models.PermissionPlayersModerate, models.PermissionPlayersModerate},
+		}
+
+		mockRoleRepo.On("FindByName", "moderator").Return(nil, helpers.ErrorRoleNotFound)
+		mockRoleRepo.On("CreateRole", mock.MatchedBy(func(role *models.Role) bool {
+			return role.Name == "moderator" && len(role.Permissions) == 1
+		})).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		role, err := roleService.CreateRole(createRequest)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.PermissionPlayersModerate}, role.Permissions)
+		mockPermissionStore.AssertExpectations(t)
+	})
+
+	t.Run("CreateRole_InvalidName", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		// Execution
+		_, err := roleService.CreateRole(request.CreateRoleRequest{Name: "Mod Team"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrRoleDataValidation, err)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+
+	t.Run("CreateRole_UnknownPermission", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		// Execution
+		_, err := roleService.CreateRole(request.CreateRoleRequest{Name: "moderator", Permissions: []string{"players:ban"}})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrUnknownPermission, err)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+
+	t.Run("CreateRole_AlreadyExists", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("FindByName", "moderator").Return(&models.Role{Name: "moderator"}, nil)
+
+		// Execution
+		_, err := roleService.CreateRole(request.CreateRoleRequest{Name: "moderator"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrRoleAlreadyExists, err)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+}
+
+func TestRoleServiceImpl_UpdateRole(t *testing.T) {
+	t.Run("UpdateRole_Success", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Test data
+		role := &models.Role{Model: gorm.Model{ID: 4}, Name: "moderator"}
+
+		mockRoleRepo.On("GetRole", uint(4)).Return(role, nil)
+		mockRoleRepo.On("UpdateRole", role).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		updated, err := roleService.UpdateRole(4, request.UpdateRoleRequest{
+			Description: "Moderators",
+			Permissions: []string{models.PermissionPlayersModerate, models.PermissionUsersRead},
+		})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, "Moderators", updated.Description)
+		assert.Equal(t, []string{models.PermissionPlayersModerate, models.PermissionUsersRead}, updated.Permissions)
+		mockPermissionStore.AssertExpectations(t)
+	})
+
+	t.Run("UpdateRole_AdminLosesPermission", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("GetRole", uint(1)).Return(&models.Role{Model: gorm.Model{ID: 1}, Name: models.AdminRole}, nil)
+
+		// Execution
+		_, err := roleService.UpdateRole(1, request.UpdateRoleRequest{Permissions: []string{models.PermissionUsersRead}})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrBuiltInRole, err)
+		mockRoleRepo.AssertNotCalled(t, "UpdateRole", mock.Anything)
+	})
+
+	t.Run("UpdateRole_DefaultRoleGainsPermission", func(t *testing.T) {
+		t.Setenv("DEFAULT_ROLE", models.UserRole)
+
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Test data
+		role := &models.Role{Model: gorm.Model{ID: 2}, Name: models.UserRole}
+
+		mockRoleRepo.On("GetRole", uint(2)).Return(role, nil)
+		mockRoleRepo.On("UpdateRole", role).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		_, privilegedErr := roleService.UpdateRole(2, request.UpdateRoleRequest{Permissions: []string{models.PermissionUsersRead}})
+		_, describedErr := roleService.UpdateRole(2, request.UpdateRoleRequest{Description: "Players"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrPrivilegedDefaultRole, privilegedErr)
+		assert.NoError(t, describedErr, "Expected the default role to keep accepting updates without permissions")
+		mockRoleRepo.AssertNumberOfCalls(t, "UpdateRole", 1)
+	})
+
+	t.Run("UpdateRole_NotFound", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("GetRole", uint(9)).Return(nil, helpers.ErrorRoleNotFound)
+
+		// Execution
+		_, err := roleService.UpdateRole(9, request.UpdateRoleRequest{})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorRoleNotFound, err)
+	})
+}
+
+func TestRoleServiceImpl_DeleteRole(t *testing.T) {
+	t.Run("DeleteRole_Success", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("GetRole", uint(4)).Return(&models.Role{Model: gorm.Model{ID: 4}, Name: "moderator"}, nil)
+		mockUserRepo.On("CountUsersByRole", "moderator").Return(int64(0), nil)
+		mockRoleRepo.On("DeleteRole", uint(4)).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		err := roleService.DeleteRole(4)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+		mockPermissionStore.AssertExpectations(t)
+	})
+
+	t.Run("DeleteRole_InUse", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("GetRole", uint(4)).Return(&models.Role{Model: gorm.Model{ID: 4}, Name: "moderator"}, nil)
+		mockUserRepo.On("CountUsersByRole", "moderator").Return(int64(3), nil)
+
+		// Execution
+		err := roleService.DeleteRole(4)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrRoleInUse, err)
+		mockRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+	})
+
+	t.Run("DeleteRole_BuiltIn", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("GetRole", uint(2)).Return(&models.Role{Model: gorm.Model{ID: 2}, Name: models.UserRole}, nil)
+
+		// Execution
+		err := roleService.DeleteRole(2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrBuiltInRole, err)
+		mockRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+	})
+
+	t.Run("DeleteRole_InvalidID", func(t *testing.T) {
+		// Mocks
+		roleService := NewRoleServiceImpl(new(mocks.RoleRepository), new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		// Execution
+		err := roleService.DeleteRole(0)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidRoleID, err)
+	})
+}
+
+func TestRoleServiceImpl_SeedRoles(t *testing.T) {
+	t.Run("SeedRoles_EmptyDatabase", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Test data
+		created := map[string][]string{}
+
+		mockRoleRepo.On("FindByName", mock.Anything).Return(nil, helpers.ErrorRoleNotFound)
+		mockRoleRepo.On("CreateRole", mock.MatchedBy(func(role *models.Role) bool {
+			created[role.Name] = role.PermissionNames()
+			return true
+		})).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		err := roleService.SeedRoles()
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Len(t, created[models.AdminRole], len(models.Permissions))
+		assert.Empty(t, created[models.UserRole])
+		assert.Equal(t, []string{models.PermissionAchievementsAward, models.PermissionPlayersProgress, models.PermissionMatchesSubmit}, created[models.GameServerRole])
+	})
+
+	t.Run("SeedRoles_AdminGetsNewPermissions", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Test data
+		admin := &models.Role{Name: models.AdminRole, Permissions: []models.RolePermission{{Permission: models.PermissionUsersRead}}}
+		gameServer := &models.Role{Name: models.GameServerRole}
+
+		mockRoleRepo.On("FindByName", models.AdminRole).Return(admin, nil)
+		mockRoleRepo.On("FindByName", models.UserRole).Return(&models.Role{Name: models.UserRole}, nil)
+		mockRoleRepo.On("FindByName", models.GameServerRole).Return(gameServer, nil)
+		mockRoleRepo.On("UpdateRole", admin).Return(nil)
+		mockPermissionStore.On("Invalidate").Return()
+
+		// Execution
+		err := roleService.SeedRoles()
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Len(t, admin.Permissions, len(models.Permissions))
+		assert.Empty(t, gameServer.Permissions, "Permissions set by the admins are kept")
+		mockRoleRepo.AssertNumberOfCalls(t, "UpdateRole", 1)
+		mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+}
+
+func TestRoleServiceImpl_AssignRole(t *testing.T) {
+	t.Run("AssignRole_Success", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockRevocationStore := new(mocks.MockRevocationStore)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, new(mocks.MockPermissionStore), mockRevocationStore, validator.New())
+
+		mockRoleRepo.On("FindByName", "moderator").Return(&models.Role{Name: "moderator"}, nil)
+		mockUserRepo.On("GetUser", uint(7)).Return(&models.User{Model: gorm.Model{ID: 7}, Role: models.UserRole}, nil)
+		mockUserRepo.On("UpdateUser", uint(7), &models.User{Role: "moderator"}).Return(nil)
+		mockRevocationStore.On("RevokeAllForUser", uint(7)).Return(nil)
+
+		// Execution
+		err := roleService.AssignRole(7, request.AssignRoleRequest{Role: "moderator"})
+
+		// Assertions
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockRevocationStore.AssertExpectations(t)
+	})
+
+	t.Run("AssignRole_UnknownRole", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("FindByName", "superuser").Return(nil, helpers.ErrorRoleNotFound)
+
+		// Execution
+		err := roleService.AssignRole(7, request.AssignRoleRequest{Role: "superuser"})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorRoleNotFound, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AssignRole_LastAdmin", func(t *testing.T) {
+		// Mocks
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		roleService := NewRoleServiceImpl(mockRoleRepo, mockUserRepo, new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		mockRoleRepo.On("FindByName", models.UserRole).Return(&models.Role{Name: models.UserRole}, nil)
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Role: models.AdminRole}, nil)
+		mockUserRepo.On("CountUsersByRole", models.AdminRole).Return(int64(1), nil)
+
+		// Execution
+		err := roleService.AssignRole(1, request.AssignRoleRequest{Role: models.UserRole})
+
+		// Assertions
+		assert.Equal(t, helpers.ErrLastAdmin, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleServiceImpl_GetUserPermissions(t *testing.T) {
+	t.Run("GetUserPermissions_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPermissionStore := new(mocks.MockPermissionStore)
+		roleService := NewRoleServiceImpl(new(mocks.RoleRepository), mockUserRepo, mockPermissionStore, new(mocks.MockRevocationStore), validator.New())
+
+		// Expectations
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Role: models.AdminRole}, nil)
+		mockPermissionStore.On("Permissions", models.AdminRole).Return([]string{models.PermissionRolesManage}, true)
+
+		// Execution
+		permissions, err := roleService.GetUserPermissions(1)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.PermissionRolesManage}, permissions)
+	})
+
+	t.Run("GetUserPermissions_UserNotFound", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		roleService := NewRoleServiceImpl(new(mocks.RoleRepository), mockUserRepo, new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+		// Expectations
+		mockUserRepo.On("GetUser", uint(9)).Return(nil, helpers.ErrorUserNotFound)
+
+		// Execution
+		_, err := roleService.GetUserPermissions(9)
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrorUserNotFound)
+	})
+}
+
+func TestRoleServiceImpl_CheckDefaultRole(t *testing.T) {
+	testCases := map[string]struct {
+		role *models.Role
+		err  error
+		want error
+	}{
+		"Unprivileged": {&models.Role{Name: models.UserRole}, nil, nil},
+		"Unknown":      {nil, helpers.ErrorRoleNotFound, helpers.ErrInvalidDefaultRole},
+		"Privileged":   {&models.Role{Name: models.AdminRole, Permissions: []models.RolePermission{{Permission: models.PermissionUsersWrite}}}, nil, helpers.ErrPrivilegedDefaultRole},
+	}
+
+	for name, tc := range testCases {
+		t.Run("CheckDefaultRole_"+name, func(t *testing.T) {
+			// Mocks
+			mockRoleRepo := new(mocks.RoleRepository)
+			roleService := NewRoleServiceImpl(mockRoleRepo, new(mocks.UserRepository), new(mocks.MockPermissionStore), new(mocks.MockRevocationStore), validator.New())
+
+			mockRoleRepo.On("FindByName", "default").Return(tc.role, tc.err)
+
+			// Execution
+			err := roleService.CheckDefaultRole("default")
+
+			// Assertions
+			assert.Equal(t, tc.want, err)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type SeasonServiceImpl struct {
+	SeasonRepository repository.SeasonRepository
+	LeaderboardCache services.LeaderboardCache
+	Validate         *validator.Validate
+}
+
+// StartSeason implements services.SeasonService.
+// The season points go back to zero, lifetime points, level and experience are kept.
+func (s *SeasonServiceImpl) StartSeason(seasonRequest request.StartSeasonRequest) (*response.SeasonResponse, error) {
+	err := s.Validate.Struct(seasonRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[SeasonServiceImpl.StartSeason] Failed to validate season data")
+		return nil, helpers.ErrSeasonDataValidation
+	}
+
+	season := models.Season{
+		Name:      seasonRequest.Name,
+		StartedAt: time.Now(),
+	}
+
+	err = s.SeasonRepository.StartSeason(&season)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorSeasonAlreadyExists) || errors.Is(err, helpers.ErrorSeasonAlreadyEnded) {
+			return nil, err
+		}
+
+		logrus.WithError(err).Error("[SeasonServiceImpl.StartSeason] Failed to start season")
+		return nil, helpers.ErrRepository
+	}
+
+	// The season points board is stale after the reset
+	s.LeaderboardCache.Invalidate()
+
+	logrus.WithField("season", season.Name).Info("[SeasonServiceImpl.StartSeason] Season started")
+
+	seasonResponse := toSeasonResponse(&season)
+	return &seasonResponse, nil
+}
+
+// GetCurrentSeason implements services.SeasonService.
+func (s *SeasonServiceImpl) GetCurrentSeason() (*response.SeasonResponse, error) {
+	season, err := s.SeasonRepository.GetCurrentSeason()
+	if err != nil {
+		return nil, err
+	}
+
+	seasonResponse := toSeasonResponse(season)
+	return &seasonResponse, nil
+}
+
+// GetAllSeasons implements services.SeasonService.
+func (s *SeasonServiceImpl) GetAllSeasons() ([]response.SeasonResponse, error) {
+	seasons, err := s.SeasonRepository.GetAllSeasons()
+	if err != nil {
+		logrus.WithError(err).Error("[SeasonServiceImpl.GetAllSeasons] Failed to get seasons")
+		return nil, helpers.ErrRepository
+	}
+
+	seasonsResponse := make([]response.SeasonResponse, len(seasons))
+	for i := range seasons {
+		seasonsResponse[i] = toSeasonResponse(&seasons[i])
+	}
+
+	return seasonsResponse, nil
+}
+
+// GetStandings implements services.SeasonService.
+func (s *SeasonServiceImpl) GetStandings(seasonID uint, page int, pageSize int) ([]response.SeasonStandingResponse, error) {
+	if seasonID == 0 {
+		return nil, helpers.ErrInvalidSeasonID
+	}
+
+	if page <= 0 || pageSize <= 0 {
+		return nil, helpers.ErrInvalidPagination
+	}
+
+	_, err := s.SeasonRepository.GetSeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings, err := s.SeasonRepository.GetStandings(seasonID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		logrus.WithError(err).Error("[SeasonServiceImpl.GetStandings] Failed to get standings")
+		return nil, helpers.ErrRepository
+	}
+
+	standingsResponse := make([]response.SeasonStandingResponse, len(standings))
+	for i, standing := range standings {
+		standingsResponse[i] = response.SeasonStandingResponse{
+			Rank:       standing.Rank,
+			PlayerID:   standing.PlayerProfileID,
+			Nickname:   standing.Nickname,
+			Points:     standing.Points,
+			Level:      standing.Level,
+			Experience: standing.Experience,
+		}
+	}
+
+	return standingsResponse, nil
+}
+
+func toSeasonResponse(season *models.Season) response.SeasonResponse {
+	return response.SeasonResponse{
+		ID:        season.ID,
+		Name:      season.Name,
+		StartedAt: season.StartedAt,
+		EndedAt:   season.EndedAt,
+	}
+}
+
+func NewSeasonServiceImpl(seasonRepository repository.SeasonRepository, leaderboardCache services.LeaderboardCache, validate *validator.Validate) services.SeasonService {
+	return &SeasonServiceImpl{
+		SeasonRepository: seasonRepository,
+		LeaderboardCache: leaderboardCache,
+		Validate:         validate,
+	}
+}
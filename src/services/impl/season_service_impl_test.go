@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSeasonServiceImpl_StartSeason(t *testing.T) {
+	t.Run("StartSeason_Success", func(t *testing.T) {
+		// Mocks
+		mockSeasonRepo := new(mocks.SeasonRepository)
+		mockCache := new(mocks.MockLeaderboardCache)
+		seasonService := NewSeasonServiceImpl(mockSeasonRepo, mockCache, validator.New())
+
+		// Expectations
+		mockSeasonRepo.On("StartSeason", mock.MatchedBy(func(season *models.Season) bool {
+			return season.Name == "Season 2" && !season.StartedAt.IsZero()
+		})).Return(nil)
+		mockCache.On("Invalidate").Return()
+
+		// Execution
+		season, err := seasonService.StartSeason(request.StartSeasonRequest{Name: "Season 2"})
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, "Season 2", season.Name)
+		assert.Nil(t, season.EndedAt)
+		mockSeasonRepo.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("StartSeason_ValidationError", func(t *testing.T) {
+		// Mocks
+		mockSeasonRepo := new(mocks.SeasonRepository)
+		seasonService := NewSeasonServiceImpl(mockSeasonRepo, new(mocks.MockLeaderboardCache), validator.New())
+
+		// Execution
+		season, err := seasonService.StartSeason(request.StartSeasonRequest{Name: "S"})
+
+		// Assertions
+		assert.Nil(t, season)
+		assert.Equal(t, helpers.ErrSeasonDataValidation, err)
+		mockSeasonRepo.AssertNotCalled(t, "StartSeason", mock.Anything)
+	})
+
+	testCases := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "StartSeason_AlreadyExists", repoErr: helpers.ErrorSeasonAlreadyExists, expectedErr: helpers.ErrorSeasonAlreadyExists},
+		{name: "StartSeason_AlreadyEnded", repoErr: helpers.ErrorSeasonAlreadyEnded, expectedErr: helpers.ErrorSeasonAlreadyEnded},
+		{name: "StartSeason_RepositoryError", repoErr: assert.AnError, expectedErr: helpers.ErrRepository},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSeasonRepo := new(mocks.SeasonRepository)
+			mockCache := new(mocks.MockLeaderboardCache)
+			seasonService := NewSeasonServiceImpl(mockSeasonRepo, mockCache, validator.New())
+
+			mockSeasonRepo.On("StartSeason", mock.Anything).Return(tc.repoErr)
+
+			season, err := seasonService.StartSeason(request.StartSeasonRequest{Name: "Season 2"})
+
+			assert.Nil(t, season)
+			assert.Equal(t, tc.expectedErr, err)
+			mockCache.AssertNotCalled(t, "Invalidate")
+		})
+	}
+}
+
+func TestSeasonServiceImpl_GetCurrentSeason(t *testing.T) {
+	// Mocks
+	mockSeasonRepo := new(mocks.SeasonRepository)
+	seasonService := NewSeasonServiceImpl(mockSeasonRepo, new(mocks.MockLeaderboardCache), validator.New())
+
+	// Expectations
+	mockSeasonRepo.On("GetCurrentSeason").Return(nil, helpers.ErrorSeasonNotFound)
+
+	// Execution
+	season, err := seasonService.GetCurrentSeason()
+
+	// Assertions
+	assert.Nil(t, season)
+	assert.Equal(t, helpers.ErrorSeasonNotFound, err)
+}
+
+func TestSeasonServiceImpl_GetAllSeasons(t *testing.T) {
+	// Mocks
+	mockSeasonRepo := new(mocks.SeasonRepository)
+	seasonService := NewSeasonServiceImpl(mockSeasonRepo, new(mocks.MockLeaderboardCache), validator.New())
+
+	// Test data
+	endedAt := time.Now()
+	seasons := []models.Season{
+		{Model: gorm.Model{ID: 2}, Name: "Season 2", StartedAt: endedAt},
+		{Model: gorm.Model{ID: 1}, Name: "Season 1", StartedAt: endedAt.Add(-time.Hour), EndedAt: &endedAt},
+	}
+
+	// Expectations
+	mockSeasonRepo.On("GetAllSeasons").Return(seasons, nil)
+
+	// Execution
+	seasonsResponse, err := seasonService.GetAllSeasons()
+
+	// Assertions
+	require.NoError(t, err)
+	require.Len(t, seasonsResponse, 2)
+	assert.Equal(t, uint(2), seasonsResponse[0].ID)
+	assert.Equal(t, &endedAt, seasonsResponse[1].EndedAt)
+}
+
+func TestSeasonServiceImpl_GetStandings(t *testing.T) {
+	t.Run("GetStandings_Success", func(t *testing.T) {
+		// Mocks
+		mockSeasonRepo := new(mocks.SeasonRepository)
+		seasonService := NewSeasonServiceImpl(mockSeasonRepo, new(mocks.MockLeaderboardCache), validator.New())
+
+		// Test data
+		standings := []models.SeasonStanding{
+			{SeasonID: 1, PlayerProfileID: 4, Nickname: "noob", Rank: 11, Points: 90},
+		}
+
+		// Expectations
+		mockSeasonRepo.On("GetSeason", uint(1)).Return(&models.Season{Model: gorm.Model{ID: 1}}, nil)
+		mockSeasonRepo.On("GetStandings", uint(1), 10, 10).Return(standings, nil)
+
+		// Execution
+		standingsResponse, err := seasonService.GetStandings(1, 2, 10)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, standingsResponse, 1)
+		assert.Equal(t, 11, standingsResponse[0].Rank)
+		assert.Equal(t, uint(4), standingsResponse[0].PlayerID)
+		mockSeasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetStandings_SeasonNotFound", func(t *testing.T) {
+		// Mocks
+		mockSeasonRepo := new(mocks.SeasonRepository)
+		seasonService := NewSeasonServiceImpl(mockSeasonRepo, new(mocks.MockLeaderboardCache), validator.New())
+
+		// Expectations
+		mockSeasonRepo.On("GetSeason", uint(1)).Return(nil, helpers.ErrorSeasonNotFound)
+
+		// Execution
+		standingsResponse, err := seasonService.GetStandings(1, 1, 10)
+
+		// Assertions
+		assert.Nil(t, standingsResponse)
+		assert.Equal(t, helpers.ErrorSeasonNotFound, err)
+	})
+
+	t.Run("GetStandings_InvalidPagination", func(t *testing.T) {
+		seasonService := NewSeasonServiceImpl(new(mocks.SeasonRepository), new(mocks.MockLeaderboardCache), validator.New())
+
+		standingsResponse, err := seasonService.GetStandings(1, 1, 0)
+
+		assert.Nil(t, standingsResponse)
+		assert.Equal(t, helpers.ErrInvalidPagination, err)
+	})
+}
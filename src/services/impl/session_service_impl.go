@@ -0,0 +1,96 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionServiceImpl struct {
+	SessionRepository      repository.SessionRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	SessionStore           auth.SessionStore
+	AuthConfig             config.AuthConfig
+}
+
+// GetSessions implements services.SessionService.
+// A session not refreshed for longer than the refresh token TTL can't be
+// resumed anymore, so it isn't listed.
+func (s *SessionServiceImpl) GetSessions(userID uint, currentSessionID uint) ([]response.SessionResponse, error) {
+	sessions, err := s.SessionRepository.GetActiveSessions(userID, time.Now().Add(-s.AuthConfig.RefreshTokenTTL))
+	if err != nil {
+		logrus.WithError(err).Error("[SessionServiceImpl.GetSessions] Failed to get sessions")
+		return nil, err
+	}
+
+	sessionResponses := make([]response.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		sessionResponses = append(sessionResponses, toSessionResponse(&sessions[i], currentSessionID))
+	}
+
+	return sessionResponses, nil
+}
+
+// EndSession implements services.SessionService.
+// Sessions of other users are reported as not found.
+func (s *SessionServiceImpl) EndSession(userID uint, sessionID uint) error {
+	if sessionID == 0 {
+		return helpers.ErrInvalidSessionID
+	}
+
+	session, err := s.SessionRepository.GetSession(sessionID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorSessionNotFound) {
+			logrus.WithError(err).Error("[SessionServiceImpl.EndSession] Failed to get session")
+		}
+		return err
+	}
+
+	if session.UserID != userID || session.EndedAt != nil {
+		return helpers.ErrorSessionNotFound
+	}
+
+	err = s.RefreshTokenRepository.RevokeFamily(session.FamilyID)
+	if err != nil {
+		logrus.WithError(err).Error("[SessionServiceImpl.EndSession] Failed to revoke refresh token family")
+		return err
+	}
+
+	err = s.SessionStore.EndSession(session.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[SessionServiceImpl.EndSession] Failed to end session")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{"userID": userID, "sessionID": sessionID}).Info("[SessionServiceImpl.EndSession] Session ended")
+	return nil
+}
+
+func toSessionResponse(session *models.Session, currentSessionID uint) response.SessionResponse {
+	return response.SessionResponse{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		Current:    session.ID == currentSessionID,
+	}
+}
+
+func NewSessionServiceImpl(sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, sessionStore auth.SessionStore, authConfig config.AuthConfig) services.SessionService {
+	return &SessionServiceImpl{
+		SessionRepository:      sessionRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		SessionStore:           sessionStore,
+		AuthConfig:             authConfig,
+	}
+}
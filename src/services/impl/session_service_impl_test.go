@@ -0,0 +1,137 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSessionServiceImpl_GetSessions(t *testing.T) {
+	t.Run("GetSessions_Success", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, new(mocks.RefreshTokenRepository), new(mocks.MockSessionStore), testAuthConfig)
+
+		// Test data
+		mockSessionRepo.On("GetActiveSessions", uint(1), mock.MatchedBy(func(seenSince time.Time) bool {
+			return time.Since(seenSince) >= testAuthConfig.RefreshTokenTTL
+		})).Return([]models.Session{
+			{Model: gorm.Model{ID: 2}, UserID: 1, DeviceName: "Living room PS5", IP: "203.0.113.7"},
+			{Model: gorm.Model{ID: 3}, UserID: 1, UserAgent: "Mozilla/5.0"},
+		}, nil)
+
+		// Execution
+		sessions, err := sessionService.GetSessions(1, 3)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "Living room PS5", sessions[0].DeviceName)
+		assert.False(t, sessions[0].Current)
+		assert.True(t, sessions[1].Current, "Expected the session of the request to be marked as current")
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetSessions_RepositoryError", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, new(mocks.RefreshTokenRepository), new(mocks.MockSessionStore), testAuthConfig)
+
+		mockSessionRepo.On("GetActiveSessions", uint(1), mock.Anything).Return(nil, assert.AnError)
+
+		// Execution
+		sessions, err := sessionService.GetSessions(1, 0)
+
+		// Assertions
+		assert.Equal(t, assert.AnError, err)
+		assert.Nil(t, sessions)
+	})
+}
+
+func TestSessionServiceImpl_EndSession(t *testing.T) {
+	t.Run("EndSession_Success", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionStore := new(mocks.MockSessionStore)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, mockRefreshTokenRepo, mockSessionStore, testAuthConfig)
+
+		// Test data
+		mockSessionRepo.On("GetSession", uint(2)).Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 1, FamilyID: "family"}, nil)
+		mockRefreshTokenRepo.On("RevokeFamily", "family").Return(nil)
+		mockSessionStore.On("EndSession", uint(2)).Return(nil)
+
+		// Execution
+		err := sessionService.EndSession(1, 2)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockRefreshTokenRepo.AssertExpectations(t)
+		mockSessionStore.AssertExpectations(t)
+	})
+
+	t.Run("EndSession_OtherUser", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		mockRefreshTokenRepo := new(mocks.RefreshTokenRepository)
+		mockSessionStore := new(mocks.MockSessionStore)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, mockRefreshTokenRepo, mockSessionStore, testAuthConfig)
+
+		mockSessionRepo.On("GetSession", uint(2)).Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 5, FamilyID: "family"}, nil)
+
+		// Execution
+		err := sessionService.EndSession(1, 2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorSessionNotFound, err)
+		mockRefreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+		mockSessionStore.AssertNotCalled(t, "EndSession", mock.Anything)
+	})
+
+	t.Run("EndSession_AlreadyEnded", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, new(mocks.RefreshTokenRepository), new(mocks.MockSessionStore), testAuthConfig)
+
+		endedAt := time.Now()
+		mockSessionRepo.On("GetSession", uint(2)).Return(&models.Session{Model: gorm.Model{ID: 2}, UserID: 1, EndedAt: &endedAt}, nil)
+
+		// Execution
+		err := sessionService.EndSession(1, 2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorSessionNotFound, err)
+	})
+
+	t.Run("EndSession_NotFound", func(t *testing.T) {
+		// Mocks
+		mockSessionRepo := new(mocks.SessionRepository)
+		sessionService := NewSessionServiceImpl(mockSessionRepo, new(mocks.RefreshTokenRepository), new(mocks.MockSessionStore), testAuthConfig)
+
+		mockSessionRepo.On("GetSession", uint(2)).Return(nil, helpers.ErrorSessionNotFound)
+
+		// Execution
+		err := sessionService.EndSession(1, 2)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrorSessionNotFound, err)
+	})
+
+	t.Run("EndSession_InvalidID", func(t *testing.T) {
+		// Mocks
+		sessionService := NewSessionServiceImpl(new(mocks.SessionRepository), new(mocks.RefreshTokenRepository), new(mocks.MockSessionStore), testAuthConfig)
+
+		// Execution
+		err := sessionService.EndSession(1, 0)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidSessionID, err)
+	})
+}
@@ -0,0 +1,288 @@
+package impl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/repository"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// Number of recovery codes issued when two factor authentication is confirmed.
+const recoveryCodeCount = 10
+
+// Steps of clock drift accepted in each direction.
+const totpSkew = 1
+
+type TwoFactorServiceImpl struct {
+	UserRepository      repository.UserRepository
+	TwoFactorRepository repository.TwoFactorRepository
+	PasswordHasher      services.PasswordHasher
+	LoginLimiter        auth.LoginLimiter
+	Validate            *validator.Validate
+	AuthConfig          config.AuthConfig
+}
+
+// Enroll implements services.TwoFactorService.
+// It creates a new secret that isn't enforced until it is confirmed with a code.
+func (t *TwoFactorServiceImpl) Enroll(userID uint) (*response.TwoFactorEnrollmentResponse, error) {
+	user, err := t.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Enroll] Failed to get user")
+		return nil, err
+	}
+
+	twoFactor, err := t.TwoFactorRepository.GetTwoFactor(userID)
+	if err != nil && !errors.Is(err, helpers.ErrorTwoFactorNotFound) {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Enroll] Failed to get two factor")
+		return nil, err
+	}
+
+	if twoFactor != nil && twoFactor.ConfirmedAt != nil {
+		return nil, helpers.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Enroll] Failed to generate secret")
+		return nil, err
+	}
+
+	err = t.TwoFactorRepository.SaveTwoFactor(&models.TwoFactor{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Enroll] Failed to save two factor")
+		return nil, err
+	}
+
+	return &response.TwoFactorEnrollmentResponse{
+		Secret: secret,
+		URI:    helpers.TOTPURI(t.AuthConfig.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm implements services.TwoFactorService.
+// The first valid code enables two factor authentication and returns the
+// recovery codes, the only time they are shown.
+func (t *TwoFactorServiceImpl) Confirm(userID uint, codeRequest request.TwoFactorCodeRequest, client request.ClientInfo) (*response.RecoveryCodesResponse, error) {
+	err := t.Validate.Struct(codeRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to validate code request")
+		return nil, helpers.ErrTwoFactorDataValidation
+	}
+
+	user, err := t.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to get user")
+		return nil, err
+	}
+
+	err = t.checkAttemptLimit(user.Email, client.IP)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := t.TwoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorTwoFactorNotFound) {
+			return nil, helpers.ErrTwoFactorNotEnabled
+		}
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to get two factor")
+		return nil, err
+	}
+
+	if twoFactor.ConfirmedAt != nil {
+		return nil, helpers.ErrTwoFactorAlreadyEnabled
+	}
+
+	err = t.verifyTOTP(twoFactor, codeRequest.Code)
+	if err != nil {
+		t.recordFailedAttempt(err, user.Email, client.IP)
+		return nil, err
+	}
+
+	recoveryCodes := make([]string, recoveryCodeCount)
+	codeHashes := make([]string, recoveryCodeCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i], err = helpers.GenerateRecoveryCode()
+		if err != nil {
+			logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to generate recovery code")
+			return nil, err
+		}
+		codeHashes[i] = helpers.HashToken(recoveryCodes[i])
+	}
+
+	err = t.TwoFactorRepository.ReplaceRecoveryCodes(userID, codeHashes)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to store recovery codes")
+		return nil, err
+	}
+
+	err = t.TwoFactorRepository.ConfirmTwoFactor(userID, time.Now())
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Confirm] Failed to confirm two factor")
+		return nil, err
+	}
+
+	return &response.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// Disable implements services.TwoFactorService.
+// A stolen access token isn't enough, the current password is required too.
+func (t *TwoFactorServiceImpl) Disable(userID uint, disableRequest request.DisableTwoFactorRequest, client request.ClientInfo) error {
+	err := t.Validate.Struct(disableRequest)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Disable] Failed to validate disable request")
+		return helpers.ErrTwoFactorDataValidation
+	}
+
+	user, err := t.UserRepository.GetUser(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Disable] Failed to get user")
+		return err
+	}
+
+	err = t.checkAttemptLimit(user.Email, client.IP)
+	if err != nil {
+		return err
+	}
+
+	err = t.PasswordHasher.ComparePassword(user.PassWord, disableRequest.Password)
+	if err != nil {
+		logrus.WithError(err).Warn("[TwoFactorServiceImpl.Disable] Current password does not match")
+		t.recordFailedAttempt(helpers.ErrInvalidCurrentPassword, user.Email, client.IP)
+		return helpers.ErrInvalidCurrentPassword
+	}
+
+	err = t.VerifyCode(userID, disableRequest.Code)
+	if err != nil {
+		t.recordFailedAttempt(err, user.Email, client.IP)
+		return err
+	}
+
+	err = t.TwoFactorRepository.DeleteTwoFactor(userID)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.Disable] Failed to delete two factor")
+		return err
+	}
+
+	return nil
+}
+
+// IsEnabled implements services.TwoFactorService.
+func (t *TwoFactorServiceImpl) IsEnabled(userID uint) (bool, error) {
+	twoFactor, err := t.TwoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorTwoFactorNotFound) {
+			return false, nil
+		}
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.IsEnabled] Failed to get two factor")
+		return false, err
+	}
+
+	return twoFactor.ConfirmedAt != nil, nil
+}
+
+// VerifyCode implements services.TwoFactorService.
+// The code can be a TOTP code or one of the recovery codes, both single use.
+func (t *TwoFactorServiceImpl) VerifyCode(userID uint, code string) error {
+	twoFactor, err := t.TwoFactorRepository.GetTwoFactor(userID)
+	if err != nil {
+		if errors.Is(err, helpers.ErrorTwoFactorNotFound) {
+			return helpers.ErrTwoFactorNotEnabled
+		}
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.VerifyCode] Failed to get two factor")
+		return err
+	}
+
+	if twoFactor.ConfirmedAt == nil {
+		return helpers.ErrTwoFactorNotEnabled
+	}
+
+	if len(code) == helpers.TOTPDigits {
+		return t.verifyTOTP(twoFactor, code)
+	}
+
+	used, err := t.TwoFactorRepository.UseRecoveryCode(userID, helpers.HashToken(helpers.NormalizeRecoveryCode(code)))
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.VerifyCode] Failed to use recovery code")
+		return err
+	}
+
+	if !used {
+		return helpers.ErrInvalidTwoFactorCode
+	}
+
+	logrus.WithField("userID", userID).Warn("[TwoFactorServiceImpl.VerifyCode] Recovery code used")
+
+	return nil
+}
+
+// checkAttemptLimit refuses the attempt while the account or the client IP is backing off.
+func (t *TwoFactorServiceImpl) checkAttemptLimit(email string, clientIP string) error {
+	retryAfter, err := t.LoginLimiter.Check(email, clientIP)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.checkAttemptLimit] Failed to check login attempts")
+		return err
+	}
+
+	if retryAfter > 0 {
+		logrus.WithField("retryAfter", retryAfter).Warn("[TwoFactorServiceImpl.checkAttemptLimit] Two factor attempt throttled")
+		return &helpers.RetryAfterError{Err: helpers.ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// recordFailedAttempt counts wrong codes and passwords, other errors aren't the client's guess.
+func (t *TwoFactorServiceImpl) recordFailedAttempt(err error, email string, clientIP string) {
+	if !errors.Is(err, helpers.ErrInvalidTwoFactorCode) && !errors.Is(err, helpers.ErrInvalidCurrentPassword) {
+		return
+	}
+
+	recordErr := t.LoginLimiter.RecordFailure(email, clientIP)
+	if recordErr != nil {
+		logrus.WithError(recordErr).Error("[TwoFactorServiceImpl.recordFailedAttempt] Failed to record login failure")
+	}
+}
+
+func (t *TwoFactorServiceImpl) verifyTOTP(twoFactor *models.TwoFactor, code string) error {
+	step, ok := helpers.ValidateTOTP(twoFactor.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return helpers.ErrInvalidTwoFactorCode
+	}
+
+	// A code can't be replayed, even inside its validity window
+	updated, err := t.TwoFactorRepository.UpdateLastUsedStep(twoFactor.UserID, step)
+	if err != nil {
+		logrus.WithError(err).Error("[TwoFactorServiceImpl.verifyTOTP] Failed to update last used step")
+		return err
+	}
+
+	if !updated {
+		return helpers.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func NewTwoFactorServiceImpl(userRepository repository.UserRepository, twoFactorRepository repository.TwoFactorRepository, passwordHasher services.PasswordHasher, loginLimiter auth.LoginLimiter, validate *validator.Validate, authConfig config.AuthConfig) services.TwoFactorService {
+	return &TwoFactorServiceImpl{
+		UserRepository:      userRepository,
+		TwoFactorRepository: twoFactorRepository,
+		PasswordHasher:      passwordHasher,
+		LoginLimiter:        loginLimiter,
+		Validate:            validate,
+		AuthConfig:          authConfig,
+	}
+}
@@ -0,0 +1,356 @@
+package impl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/helpers"
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/dieg0code/player-profile/src/testutils/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testTwoFactorConfig = config.AuthConfig{
+	TOTPIssuer: "Player Profile",
+}
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func currentTOTPCode(t *testing.T) string {
+	code, err := helpers.TOTPCode(testTOTPSecret, helpers.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorServiceImpl_Enroll(t *testing.T) {
+	t.Run("Enroll_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		// Test data
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}, nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(nil, helpers.ErrorTwoFactorNotFound)
+
+		var saved *models.TwoFactor
+		mockTwoFactorRepo.On("SaveTwoFactor", mock.MatchedBy(func(twoFactor *models.TwoFactor) bool {
+			saved = twoFactor
+			return twoFactor.UserID == 1 && twoFactor.ConfirmedAt == nil
+		})).Return(nil)
+
+		// Execution
+		enrollment, err := twoFactorService.Enroll(1)
+
+		// Assertions
+		require.NoError(t, err)
+		assert.Equal(t, saved.Secret, enrollment.Secret)
+		assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/"))
+		assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Enroll_AlreadyEnabled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		confirmedAt := time.Now()
+		mockUserRepo.On("GetUser", uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}, nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil)
+
+		// Execution
+		_, err := twoFactorService.Enroll(1)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrTwoFactorAlreadyEnabled, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "SaveTwoFactor", mock.Anything)
+	})
+}
+
+func TestTwoFactorServiceImpl_Confirm(t *testing.T) {
+	client := request.ClientInfo{IP: "10.0.0.1"}
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com"}
+
+	t.Run("Confirm_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		// Test data
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret}, nil)
+		mockTwoFactorRepo.On("UpdateLastUsedStep", uint(1), mock.Anything).Return(true, nil)
+
+		var codeHashes []string
+		mockTwoFactorRepo.On("ReplaceRecoveryCodes", uint(1), mock.MatchedBy(func(hashes []string) bool {
+			codeHashes = hashes
+			return len(hashes) == recoveryCodeCount
+		})).Return(nil)
+		mockTwoFactorRepo.On("ConfirmTwoFactor", uint(1), mock.Anything).Return(nil)
+
+		// Execution
+		recoveryCodes, err := twoFactorService.Confirm(1, request.TwoFactorCodeRequest{Code: currentTOTPCode(t)}, client)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Len(t, recoveryCodes.RecoveryCodes, recoveryCodeCount)
+		for i, code := range recoveryCodes.RecoveryCodes {
+			assert.Equal(t, helpers.HashToken(code), codeHashes[i], "Expected only the hash of the code to be stored")
+		}
+		mockTwoFactorRepo.AssertExpectations(t)
+		mockLoginLimiter.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Confirm_WrongCode", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockLoginLimiter.On("RecordFailure", user.Email, client.IP).Return(nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret}, nil)
+
+		// Execution
+		_, err := twoFactorService.Confirm(1, request.TwoFactorCodeRequest{Code: "abcdef"}, client)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidTwoFactorCode, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "ConfirmTwoFactor", mock.Anything, mock.Anything)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("Confirm_Throttled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Minute, nil)
+
+		// Execution
+		_, err := twoFactorService.Confirm(1, request.TwoFactorCodeRequest{Code: currentTOTPCode(t)}, client)
+
+		// Assertions
+		assert.ErrorIs(t, err, helpers.ErrTooManyLoginAttempts)
+		mockTwoFactorRepo.AssertNotCalled(t, "GetTwoFactor", mock.Anything)
+	})
+
+	t.Run("Confirm_NotEnrolled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, nil, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(nil, helpers.ErrorTwoFactorNotFound)
+
+		// Execution
+		_, err := twoFactorService.Confirm(1, request.TwoFactorCodeRequest{Code: "123456"}, client)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrTwoFactorNotEnabled, err)
+	})
+}
+
+func TestTwoFactorServiceImpl_VerifyCode(t *testing.T) {
+	confirmedAt := time.Now().Add(-time.Hour)
+	twoFactor := &models.TwoFactor{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}
+
+	t.Run("VerifyCode_TOTP", func(t *testing.T) {
+		// Mocks
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(new(mocks.UserRepository), mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		step := helpers.TOTPStep(time.Now())
+		code, err := helpers.TOTPCode(testTOTPSecret, step)
+		require.NoError(t, err)
+
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(twoFactor, nil)
+		mockTwoFactorRepo.On("UpdateLastUsedStep", uint(1), step).Return(true, nil)
+
+		// Execution
+		err = twoFactorService.VerifyCode(1, code)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("VerifyCode_ReplayedTOTP", func(t *testing.T) {
+		// Mocks
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(new(mocks.UserRepository), mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(twoFactor, nil)
+		mockTwoFactorRepo.On("UpdateLastUsedStep", uint(1), mock.Anything).Return(false, nil)
+
+		// Execution
+		err := twoFactorService.VerifyCode(1, currentTOTPCode(t))
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidTwoFactorCode, err)
+	})
+
+	t.Run("VerifyCode_RecoveryCode", func(t *testing.T) {
+		// Mocks
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(new(mocks.UserRepository), mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(twoFactor, nil)
+		mockTwoFactorRepo.On("UseRecoveryCode", uint(1), helpers.HashToken("abcde-fghij")).Return(true, nil)
+
+		// Execution
+		err := twoFactorService.VerifyCode(1, "ABCDE FGHIJ")
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("VerifyCode_UsedRecoveryCode", func(t *testing.T) {
+		// Mocks
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(new(mocks.UserRepository), mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(twoFactor, nil)
+		mockTwoFactorRepo.On("UseRecoveryCode", uint(1), helpers.HashToken("abcde-fghij")).Return(false, nil)
+
+		// Execution
+		err := twoFactorService.VerifyCode(1, "abcde-fghij")
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidTwoFactorCode, err)
+	})
+
+	t.Run("VerifyCode_NotConfirmed", func(t *testing.T) {
+		// Mocks
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		twoFactorService := NewTwoFactorServiceImpl(new(mocks.UserRepository), mockTwoFactorRepo, nil, nil, validator.New(), testTwoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret}, nil)
+
+		// Execution
+		err := twoFactorService.VerifyCode(1, currentTOTPCode(t))
+
+		// Assertions
+		assert.Equal(t, helpers.ErrTwoFactorNotEnabled, err)
+	})
+}
+
+func TestTwoFactorServiceImpl_Disable(t *testing.T) {
+	client := request.ClientInfo{IP: "10.0.0.1"}
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "test@test.com", PassWord: "hashed"}
+	confirmedAt := time.Now()
+
+	t.Run("Disable_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, mockPasswordHasher, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockPasswordHasher.On("ComparePassword", user.PassWord, "password").Return(nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil)
+		mockTwoFactorRepo.On("UpdateLastUsedStep", uint(1), mock.Anything).Return(true, nil)
+		mockTwoFactorRepo.On("DeleteTwoFactor", uint(1)).Return(nil)
+
+		// Execution
+		err := twoFactorService.Disable(1, request.DisableTwoFactorRequest{Password: "password", Code: currentTOTPCode(t)}, client)
+
+		// Assertions
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+		mockPasswordHasher.AssertExpectations(t)
+	})
+
+	t.Run("Disable_WrongCode", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, mockPasswordHasher, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockLoginLimiter.On("RecordFailure", user.Email, client.IP).Return(nil)
+		mockPasswordHasher.On("ComparePassword", user.PassWord, "password").Return(nil)
+		mockTwoFactorRepo.On("GetTwoFactor", uint(1)).Return(&models.TwoFactor{UserID: 1, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil)
+		mockTwoFactorRepo.On("UseRecoveryCode", uint(1), mock.Anything).Return(false, nil)
+
+		// Execution
+		err := twoFactorService.Disable(1, request.DisableTwoFactorRequest{Password: "password", Code: "wrong-code"}, client)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidTwoFactorCode, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "DeleteTwoFactor", mock.Anything)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("Disable_WrongPassword", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, mockPasswordHasher, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Duration(0), nil)
+		mockLoginLimiter.On("RecordFailure", user.Email, client.IP).Return(nil)
+		mockPasswordHasher.On("ComparePassword", user.PassWord, "wrong").Return(assert.AnError)
+
+		// Execution
+		err := twoFactorService.Disable(1, request.DisableTwoFactorRequest{Password: "wrong", Code: currentTOTPCode(t)}, client)
+
+		// Assertions
+		assert.Equal(t, helpers.ErrInvalidCurrentPassword, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "GetTwoFactor", mock.Anything)
+		mockTwoFactorRepo.AssertNotCalled(t, "DeleteTwoFactor", mock.Anything)
+		mockLoginLimiter.AssertExpectations(t)
+	})
+
+	t.Run("Disable_Throttled", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockTwoFactorRepo := new(mocks.TwoFactorRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockLoginLimiter := new(mocks.MockLoginLimiter)
+		twoFactorService := NewTwoFactorServiceImpl(mockUserRepo, mockTwoFactorRepo, mockPasswordHasher, mockLoginLimiter, validator.New(), testTwoFactorConfig)
+
+		mockUserRepo.On("GetUser", uint(1)).Return(user, nil)
+		mockLoginLimiter.On("Check", user.Email, client.IP).Return(time.Minute, nil)
+
+		// Execution
+		err := twoFactorService.Disable(1, request.DisableTwoFactorRequest{Password: "password", Code: currentTOTPCode(t)}, client)
+
+		// Assertions
+		var retryAfterErr *helpers.RetryAfterError
+		require.ErrorAs(t, err, &retryAfterErr)
+		assert.Equal(t, time.Minute, retryAfterErr.RetryAfter)
+		mockPasswordHasher.AssertNotCalled(t, "ComparePassword", mock.Anything, mock.Anything)
+		mockTwoFactorRepo.AssertNotCalled(t, "DeleteTwoFactor", mock.Anything)
+	})
+}
@@ -2,8 +2,9 @@ package impl
 
 import (
 	"errors"
-	"os"
+	"time"
 
+	"github.com/dieg0code/player-profile/src/config"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/dieg0code/player-profile/src/helpers"
@@ -15,9 +16,10 @@ import (
 )
 
 type UserServiceImpl struct {
-	UserRepository repository.UserRepository
-	Validate       *validator.Validate
-	PasswordHasher services.PasswordHasher
+	UserRepository           repository.UserRepository
+	Validate                 *validator.Validate
+	PasswordHasher           services.PasswordHasher
+	EmailVerificationService services.EmailVerificationService
 }
 
 // Create implements services.UserService.
@@ -40,7 +42,7 @@ func (u *UserServiceImpl) Create(user request.CreateUserRequest) error {
 		PassWord: string(hashedPassword),
 		Email:    user.Email,
 		Age:      user.Age,
-		Role:     os.Getenv("DEFAULT_ROLE"),
+		Role:     config.DefaultRole(),
 	}
 
 	err = u.UserRepository.CreateUser(&userModel)
@@ -49,17 +51,44 @@ func (u *UserServiceImpl) Create(user request.CreateUserRequest) error {
 		return errors.New("email already exists")
 	}
 
+	// The account already exists at this point, the user can ask for another email
+	err = u.EmailVerificationService.SendVerificationEmail(userModel.ID)
+	if err != nil {
+		logrus.WithError(err).Error("[UserServiceImpl.Create] Failed to send verification email")
+	}
+
 	return nil
 }
 
 // Delete implements services.UserService.
+// The last admin can't be deleted, like it can't be demoted.
 func (u *UserServiceImpl) Delete(userID uint) error {
 
 	if userID == 0 {
 		return helpers.ErrInvalidUserID
 	}
 
-	err := u.UserRepository.DeleteUser(userID)
+	user, err := u.UserRepository.GetUser(userID)
+	if err != nil {
+		if !errors.Is(err, helpers.ErrorUserNotFound) {
+			logrus.WithError(err).Error("[UserServiceImpl.Delete] Failed to get user")
+		}
+		return err
+	}
+
+	if user.Role == models.AdminRole {
+		adminCount, err := u.UserRepository.CountUsersByRole(models.AdminRole)
+		if err != nil {
+			logrus.WithError(err).Error("[UserServiceImpl.Delete] Failed to count admins")
+			return err
+		}
+
+		if adminCount <= 1 {
+			return helpers.ErrLastAdmin
+		}
+	}
+
+	err = u.UserRepository.DeleteUser(userID)
 	if err != nil {
 		logrus.WithError(err).Error("[UserServiceImpl.Delete] Failed to delete user")
 		return err
@@ -69,18 +98,31 @@ func (u *UserServiceImpl) Delete(userID uint) error {
 }
 
 // GetAll implements services.UserService.
-func (u *UserServiceImpl) GetAll(page int, pageSize int) ([]response.UserResponse, error) {
-
-	if page <= 0 || pageSize <= 0 {
-		return nil, helpers.ErrInvalidPagination
+func (u *UserServiceImpl) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.UserResponse, *response.Pagination, error) {
+	page, err := listPage(pageRequest)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	offset := (page - 1) * pageSize
-
-	users, err := u.UserRepository.GetAllUsers(offset, pageSize)
+	users, err := u.UserRepository.GetAllUsers(page, listQuery)
 	if err != nil {
+		if errors.Is(err, helpers.ErrInvalidListQuery) {
+			return nil, nil, err
+		}
+
 		logrus.WithError(err).Error("[UserServiceImpl.GetAll] Failed to get all users")
-		return nil, err
+		return nil, nil, err
+	}
+
+	users, pagination := pageItems(users, page, listQuery, func(user models.User) uint { return user.ID })
+
+	if pageRequest.Total {
+		total, err := u.UserRepository.CountUsers(listQuery)
+		if err != nil {
+			logrus.WithError(err).Error("[UserServiceImpl.GetAll] Failed to count users")
+			return nil, nil, helpers.ErrRepository
+		}
+		pagination.Total = &total
 	}
 
 	var userResponses []response.UserResponse
@@ -95,13 +137,13 @@ func (u *UserServiceImpl) GetAll(page int, pageSize int) ([]response.UserRespons
 		err = u.Validate.Struct(userResponse)
 		if err != nil {
 			logrus.WithError(err).Error("[UserServiceImpl.GetAll] Failed to validate user data")
-			return nil, helpers.ErrUserDataValidation
+			return nil, nil, helpers.ErrUserDataValidation
 		}
 
 		userResponses = append(userResponses, userResponse)
 	}
 
-	return userResponses, nil
+	return userResponses, pagination, nil
 }
 
 // GetByID implements services.UserService.
@@ -152,23 +194,97 @@ func (u *UserServiceImpl) Update(userID uint, user request.UpdateUserRequest) er
 		return err
 	}
 
-	userData.UserName = user.UserName
-	userData.Email = user.Email
-	userData.Age = user.Age
+	// A new email isn't verified, it's cleared before the email changes so a
+	// failed update can't leave the new address verified
+	emailChanged := userData.Email != user.Email
+	if emailChanged {
+		err = u.UserRepository.ClearEmailVerification(userID)
+		if err != nil {
+			logrus.WithError(err).Error("[UserServiceImpl.Update] Failed to clear email verification")
+			return err
+		}
+	}
 
-	err = u.UserRepository.UpdateUser(userID, userData)
+	// Only the editable columns are written, the role and password read above
+	// may be stale already
+	err = u.UserRepository.UpdateUser(userID, &models.User{
+		UserName: user.UserName,
+		Email:    user.Email,
+		Age:      user.Age,
+	})
 	if err != nil {
 		logrus.WithError(err).Error("[UserServiceImpl.Update] Failed to update user")
 		return err
 	}
 
+	if emailChanged {
+		// The email already changed at this point, the user can ask for another email
+		err = u.EmailVerificationService.SendVerificationEmail(userID)
+		if err != nil {
+			logrus.WithError(err).Error("[UserServiceImpl.Update] Failed to send verification email")
+		}
+	}
+
 	return nil
 }
 
-func NewUserServiceImpl(userRepository repository.UserRepository, validate *validator.Validate, passwordHasher services.PasswordHasher) services.UserService {
+// BootstrapAdmin implements services.UserService.
+// It creates the first admin, with its email already verified, and refuses to
+// run once any admin exists.
+func (u *UserServiceImpl) BootstrapAdmin(admin request.CreateUserRequest) (*response.UserResponse, error) {
+	err := u.Validate.Struct(admin)
+	if err != nil {
+		logrus.WithError(err).Error("[UserServiceImpl.BootstrapAdmin] Failed to validate user data")
+		return nil, helpers.ErrUserDataValidation
+	}
+
+	adminCount, err := u.UserRepository.CountUsersByRole(models.AdminRole)
+	if err != nil {
+		logrus.WithError(err).Error("[UserServiceImpl.BootstrapAdmin] Failed to count admins")
+		return nil, err
+	}
+
+	if adminCount > 0 {
+		return nil, helpers.ErrAdminAlreadyExists
+	}
+
+	hashedPassword, err := u.PasswordHasher.HashPassword(admin.Password)
+	if err != nil {
+		logrus.WithError(err).Error("[UserServiceImpl.BootstrapAdmin] Failed to hash password")
+		return nil, errors.New("failed to hash password")
+	}
+
+	verifiedAt := time.Now()
+	userModel := models.User{
+		UserName:        admin.UserName,
+		PassWord:        string(hashedPassword),
+		Email:           admin.Email,
+		Age:             admin.Age,
+		Role:            models.AdminRole,
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	err = u.UserRepository.CreateUser(&userModel)
+	if err != nil {
+		logrus.WithError(err).Error("[UserServiceImpl.BootstrapAdmin] Failed to create user")
+		return nil, errors.New("email already exists")
+	}
+
+	logrus.WithField("userID", userModel.ID).Info("[UserServiceImpl.BootstrapAdmin] Admin created")
+
+	return &response.UserResponse{
+		ID:       userModel.ID,
+		UserName: userModel.UserName,
+		Email:    userModel.Email,
+		Age:      userModel.Age,
+	}, nil
+}
+
+func NewUserServiceImpl(userRepository repository.UserRepository, validate *validator.Validate, passwordHasher services.PasswordHasher, emailVerificationService services.EmailVerificationService) services.UserService {
 	return &UserServiceImpl{
-		UserRepository: userRepository,
-		Validate:       validate,
-		PasswordHasher: passwordHasher,
+		UserRepository:           userRepository,
+		Validate:                 validate,
+		PasswordHasher:           passwordHasher,
+		EmailVerificationService: emailVerificationService,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
@@ -22,7 +23,8 @@ func TestUserServiceImpl_Create(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		mockEmailVerificationService := new(mocks.MockEmailVerificationService)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, mockEmailVerificationService)
 
 		testUser := request.CreateUserRequest{
 			UserName: "test",
@@ -42,19 +44,48 @@ func TestUserServiceImpl_Create(t *testing.T) {
 			Role:     os.Getenv("DEFAULT_ROLE"),
 		}).Return(nil)
 
+		mockEmailVerificationService.On("SendVerificationEmail", uint(0)).Return(nil)
+
 		err := userService.Create(testUser)
 
 		require.NoError(t, err)
 		mockUserRepo.AssertExpectations(t)
+		mockEmailVerificationService.AssertExpectations(t)
 
 	})
 
+	t.Run("CreateUser_VerificationEmailError", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockValidator := validator.New()
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		mockEmailVerificationService := new(mocks.MockEmailVerificationService)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, mockEmailVerificationService)
+
+		testUser := request.CreateUserRequest{
+			UserName: "test",
+			Password: "12345678",
+			Email:    "test@test.com",
+			Age:      18,
+		}
+
+		mockPasswordHasher.On("HashPassword", testUser.Password).Return("hashed", nil)
+		mockUserRepo.On("CreateUser", mock.Anything).Return(nil)
+		mockEmailVerificationService.On("SendVerificationEmail", uint(0)).Return(helpers.ErrSendEmail)
+
+		err := userService.Create(testUser)
+
+		// The account is created anyway, the email can be sent again later
+		require.NoError(t, err)
+		mockEmailVerificationService.AssertExpectations(t)
+	})
+
 	t.Run("CreateUser_Error", func(t *testing.T) {
 		// Mocks
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		testUser := request.CreateUserRequest{
 			UserName: "t",
@@ -73,7 +104,7 @@ func TestUserServiceImpl_Create(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		testUser := request.CreateUserRequest{
 			UserName: "test",
@@ -94,7 +125,7 @@ func TestUserServiceImpl_Create(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		testUser := request.CreateUserRequest{
 			UserName: "test",
@@ -124,7 +155,7 @@ func TestUserServiceImpl_Create(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		invalidUser := request.CreateUserRequest{
 			UserName: "test",
@@ -145,10 +176,11 @@ func TestUserServiceImpl_Delete(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 
+		mockUserRepo.On("GetUser", userID).Return(&models.User{Model: gorm.Model{ID: userID}, Role: "user"}, nil)
 		mockUserRepo.On("DeleteUser", userID).Return(nil)
 
 		err := userService.Delete(userID)
@@ -161,10 +193,11 @@ func TestUserServiceImpl_Delete(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 
+		mockUserRepo.On("GetUser", userID).Return(&models.User{Model: gorm.Model{ID: userID}, Role: "user"}, nil)
 		mockUserRepo.On("DeleteUser", userID).Return(errors.New("repository error"))
 
 		err := userService.Delete(userID)
@@ -173,11 +206,45 @@ func TestUserServiceImpl_Delete(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("DeleteUser_LastAdmin", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockValidator := validator.New()
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
+
+		userID := uint(1)
+
+		mockUserRepo.On("GetUser", userID).Return(&models.User{Model: gorm.Model{ID: userID}, Role: models.AdminRole}, nil)
+		mockUserRepo.On("CountUsersByRole", models.AdminRole).Return(int64(1), nil)
+
+		err := userService.Delete(userID)
+
+		require.ErrorIs(t, err, helpers.ErrLastAdmin)
+		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "DeleteUser", userID)
+	})
+
+	t.Run("DeleteUser_OneOfSeveralAdmins", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockValidator := validator.New()
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
+
+		userID := uint(1)
+
+		mockUserRepo.On("GetUser", userID).Return(&models.User{Model: gorm.Model{ID: userID}, Role: models.AdminRole}, nil)
+		mockUserRepo.On("CountUsersByRole", models.AdminRole).Return(int64(2), nil)
+		mockUserRepo.On("DeleteUser", userID).Return(nil)
+
+		err := userService.Delete(userID)
+
+		require.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
 	t.Run("DeleteUser_ValidationError", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		err := userService.Delete(0)
 
@@ -192,7 +259,7 @@ func TestUserServiceImpl_GetById(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 		user := &models.User{
@@ -217,7 +284,7 @@ func TestUserServiceImpl_GetById(t *testing.T) {
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 		mockUserRepo.On("GetUser", userID).Return(nil, errors.New("repository error"))
@@ -235,7 +302,7 @@ func TestUserServiceImpl_GetById(t *testing.T) {
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 
@@ -254,7 +321,7 @@ func TestUserServiceImpl_GetById(t *testing.T) {
 		mockValidator := validator.New()
 		mockPasswordHasher := new(mocks.MockPasswordHasher)
 
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, mockPasswordHasher, nil)
 
 		userID := uint(1)
 		mockUser := &models.User{
@@ -278,7 +345,7 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 	t.Run("GetAll_Success", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		// mock data
 		user1 := models.User{
@@ -299,7 +366,7 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 		var usersMock []models.User
 		usersMock = append(usersMock, user1, user2)
 
-		mockUserRepo.On("GetAllUsers", 0, 10).Return(usersMock, nil)
+		mockUserRepo.On("GetAllUsers", models.Page{Limit: 11}, models.ListQuery{}).Return(usersMock, nil)
 		var responseMock []response.UserResponse
 		for _, user := range usersMock {
 			responseMock = append(responseMock, response.UserResponse{
@@ -310,7 +377,7 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 			})
 		}
 
-		users, err := userService.GetAll(1, 10)
+		users, _, err := userService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		require.NoError(t, err)
 		require.Equal(t, responseMock, users)
@@ -321,11 +388,11 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 	t.Run("GetAll_Error", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
-		mockUserRepo.On("GetAllUsers", 0, 10).Return([]models.User{}, helpers.ErrRepository)
+		mockUserRepo.On("GetAllUsers", models.Page{Limit: 11}, models.ListQuery{}).Return([]models.User{}, helpers.ErrRepository)
 
-		users, err := userService.GetAll(1, 10)
+		users, _, err := userService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		require.Error(t, err)
 		require.Nil(t, users)
@@ -336,11 +403,11 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 	t.Run("GetAll_Empty", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
-		mockUserRepo.On("GetAllUsers", 0, 10).Return([]models.User{}, nil)
+		mockUserRepo.On("GetAllUsers", models.Page{Limit: 11}, models.ListQuery{}).Return([]models.User{}, nil)
 
-		users, err := userService.GetAll(1, 10)
+		users, _, err := userService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		require.NoError(t, err)
 		require.Nil(t, users)
@@ -351,9 +418,9 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 	t.Run("GetAll_InvalidPagination", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
-		users, err := userService.GetAll(0, 0)
+		users, _, err := userService.GetAll(request.PageRequest{Page: 0, PageSize: 0}, models.ListQuery{})
 
 		require.Error(t, err)
 		require.Nil(t, users)
@@ -364,7 +431,7 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 	t.Run("GetAll_ValidationError", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		user1 := models.User{
 			Model:    gorm.Model{ID: 1},
@@ -380,9 +447,9 @@ func TestUserServiceImpl_GetAll(t *testing.T) {
 		var usersMock []models.User
 		usersMock = append(usersMock, user1, user2)
 
-		mockUserRepo.On("GetAllUsers", 0, 10).Return(usersMock, nil)
+		mockUserRepo.On("GetAllUsers", models.Page{Limit: 11}, models.ListQuery{}).Return(usersMock, nil)
 
-		users, err := userService.GetAll(1, 10)
+		users, _, err := userService.GetAll(request.PageRequest{Page: 1, PageSize: 10}, models.ListQuery{})
 
 		require.Error(t, err)
 		require.Nil(t, users)
@@ -394,24 +461,26 @@ func TestUserServiceImpl_Update(t *testing.T) {
 	t.Run("UpdateUser_Success", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		userID := uint(1)
 		updateRequest := request.UpdateUserRequest{
 			UserName: "updatedName",
-			Email:    "update@test.com",
+			Email:    "original@test.com",
 			Age:      25,
 		}
 
 		existingUser := &models.User{
 			Model:    gorm.Model{ID: userID},
 			UserName: "originalName",
+			PassWord: "stale-hash",
 			Email:    "original@test.com",
 			Age:      20,
+			Role:     models.AdminRole,
 		}
 
+		// The role and password aren't written back
 		updatedUser := &models.User{
-			Model:    gorm.Model{ID: userID},
 			UserName: updateRequest.UserName,
 			Email:    updateRequest.Email,
 			Age:      updateRequest.Age,
@@ -424,12 +493,48 @@ func TestUserServiceImpl_Update(t *testing.T) {
 
 		require.NoError(t, err)
 		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "ClearEmailVerification", userID)
+	})
+
+	t.Run("UpdateUser_EmailChanged", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockEmailVerificationService := new(mocks.MockEmailVerificationService)
+		userService := NewUserServiceImpl(mockUserRepo, validator.New(), nil, mockEmailVerificationService)
+
+		userID := uint(1)
+		verifiedAt := time.Now()
+		updateRequest := request.UpdateUserRequest{
+			UserName: "originalName",
+			Email:    "new@test.com",
+			Age:      20,
+		}
+
+		existingUser := &models.User{
+			Model:           gorm.Model{ID: userID},
+			UserName:        "originalName",
+			Email:           "original@test.com",
+			Age:             20,
+			EmailVerifiedAt: &verifiedAt,
+		}
+
+		mockUserRepo.On("GetUser", userID).Return(existingUser, nil)
+		mockUserRepo.On("ClearEmailVerification", userID).Return(nil)
+		mockUserRepo.On("UpdateUser", userID, mock.MatchedBy(func(user *models.User) bool {
+			return user.Email == "new@test.com" && user.EmailVerifiedAt == nil
+		})).Return(nil)
+		mockEmailVerificationService.On("SendVerificationEmail", userID).Return(nil)
+
+		err := userService.Update(userID, updateRequest)
+
+		require.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockEmailVerificationService.AssertExpectations(t)
 	})
 
 	t.Run("UpdateUser_GetUserError", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		userID := uint(1)
 		updateRequest := request.UpdateUserRequest{}
@@ -446,7 +551,7 @@ func TestUserServiceImpl_Update(t *testing.T) {
 	t.Run("UpdateUser_ValidationError", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		userID := uint(1)
 		updateRequest := request.UpdateUserRequest{
@@ -466,7 +571,7 @@ func TestUserServiceImpl_Update(t *testing.T) {
 	t.Run("UpdateUser_UpdateUserError", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockValidator := validator.New()
-		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil)
+		userService := NewUserServiceImpl(mockUserRepo, mockValidator, nil, nil)
 
 		userID := uint(1)
 		updateRequest := request.UpdateUserRequest{
@@ -483,6 +588,7 @@ func TestUserServiceImpl_Update(t *testing.T) {
 		}
 
 		mockUserRepo.On("GetUser", userID).Return(existingUser, nil)
+		mockUserRepo.On("ClearEmailVerification", userID).Return(nil)
 		mockUserRepo.On("UpdateUser", mock.AnythingOfType("uint"), mock.AnythingOfType("*models.User")).Return(errors.New("update error"))
 
 		err := userService.Update(userID, updateRequest)
@@ -492,3 +598,62 @@ func TestUserServiceImpl_Update(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserServiceImpl_BootstrapAdmin(t *testing.T) {
+	adminRequest := request.CreateUserRequest{
+		UserName: "root",
+		Password: "12345678",
+		Email:    "admin@test.com",
+		Age:      30,
+	}
+
+	t.Run("BootstrapAdmin_Success", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, validator.New(), mockPasswordHasher, nil)
+
+		mockUserRepo.On("CountUsersByRole", models.AdminRole).Return(int64(0), nil)
+		mockPasswordHasher.On("HashPassword", adminRequest.Password).Return("hashed", nil)
+		mockUserRepo.On("CreateUser", mock.MatchedBy(func(user *models.User) bool {
+			return user.Role == models.AdminRole && user.EmailVerifiedAt != nil && user.PassWord == "hashed"
+		})).Return(nil)
+
+		// Execution
+		admin, err := userService.BootstrapAdmin(adminRequest)
+
+		// Assertions
+		require.NoError(t, err)
+		require.Equal(t, "admin@test.com", admin.Email)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("BootstrapAdmin_AdminExists", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		mockPasswordHasher := new(mocks.MockPasswordHasher)
+		userService := NewUserServiceImpl(mockUserRepo, validator.New(), mockPasswordHasher, nil)
+
+		mockUserRepo.On("CountUsersByRole", models.AdminRole).Return(int64(1), nil)
+
+		// Execution
+		_, err := userService.BootstrapAdmin(adminRequest)
+
+		// Assertions
+		require.Equal(t, helpers.ErrAdminAlreadyExists, err)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+	})
+
+	t.Run("BootstrapAdmin_ValidationError", func(t *testing.T) {
+		// Mocks
+		mockUserRepo := new(mocks.UserRepository)
+		userService := NewUserServiceImpl(mockUserRepo, validator.New(), nil, nil)
+
+		// Execution
+		_, err := userService.BootstrapAdmin(request.CreateUserRequest{Email: "admin@test.com"})
+
+		// Assertions
+		require.Equal(t, helpers.ErrUserDataValidation, err)
+		mockUserRepo.AssertNotCalled(t, "CountUsersByRole", mock.Anything)
+	})
+}
@@ -0,0 +1,65 @@
+package impl
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/dieg0code/player-profile/src/services"
+	"github.com/sirupsen/logrus"
+)
+
+// XPCurveImpl keeps the total experience of every level, index 0 is level 1.
+type XPCurveImpl struct {
+	totals []int
+}
+
+// NewXPCurve builds the curve up to the max level. Experience is stored in
+// 32 bit columns, so steep curves stop at the last level that fits.
+func NewXPCurve(curveConfig config.XPCurveConfig) services.XPCurve {
+	totals := []int{0}
+
+	for level := 1; level < curveConfig.MaxLevel; level++ {
+		var total float64
+
+		switch curveConfig.Type {
+		case config.XPCurveTable:
+			total = float64(curveConfig.Table[level-1])
+		case config.XPCurveExponential:
+			total = float64(totals[level-1]) + math.Round(float64(curveConfig.Base)*math.Pow(curveConfig.Factor, float64(level-1)))
+		default:
+			total = float64(curveConfig.Base * level)
+		}
+
+		if total > math.MaxInt32 {
+			logrus.WithField("maxLevel", level).Warn("[NewXPCurve] Curve too steep, max level lowered")
+			break
+		}
+
+		totals = append(totals, int(total))
+	}
+
+	return &XPCurveImpl{totals: totals}
+}
+
+// ExperienceForLevel implements services.XPCurve.
+func (x *XPCurveImpl) ExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+
+	return x.totals[min(level, len(x.totals))-1]
+}
+
+// LevelForExperience implements services.XPCurve.
+func (x *XPCurveImpl) LevelForExperience(experience int) int {
+	// Index of the first level that needs more experience
+	return sort.Search(len(x.totals), func(i int) bool {
+		return x.totals[i] > experience
+	})
+}
+
+// MaxLevel implements services.XPCurve.
+func (x *XPCurveImpl) MaxLevel() int {
+	return len(x.totals)
+}
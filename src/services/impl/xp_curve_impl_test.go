@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"testing"
+
+	"github.com/dieg0code/player-profile/src/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXPCurveImpl(t *testing.T) {
+	t.Run("Linear", func(t *testing.T) {
+		curve := NewXPCurve(config.XPCurveConfig{Type: config.XPCurveLinear, Base: 100, MaxLevel: 10})
+
+		assert.Equal(t, 0, curve.ExperienceForLevel(1))
+		assert.Equal(t, 100, curve.ExperienceForLevel(2))
+		assert.Equal(t, 900, curve.ExperienceForLevel(10))
+		assert.Equal(t, 900, curve.ExperienceForLevel(11), "Levels above the max cost the same as the max")
+		assert.Equal(t, 1, curve.LevelForExperience(99))
+		assert.Equal(t, 2, curve.LevelForExperience(100))
+		assert.Equal(t, 10, curve.LevelForExperience(5000))
+		assert.Equal(t, 10, curve.MaxLevel())
+	})
+
+	t.Run("Exponential", func(t *testing.T) {
+		curve := NewXPCurve(config.XPCurveConfig{Type: config.XPCurveExponential, Base: 100, Factor: 2, MaxLevel: 5})
+
+		// Each level costs twice the previous one: 100, 200, 400, 800
+		assert.Equal(t, 100, curve.ExperienceForLevel(2))
+		assert.Equal(t, 300, curve.ExperienceForLevel(3))
+		assert.Equal(t, 1500, curve.ExperienceForLevel(5))
+		assert.Equal(t, 3, curve.LevelForExperience(699))
+		assert.Equal(t, 4, curve.LevelForExperience(700))
+	})
+
+	t.Run("Exponential_TooSteep", func(t *testing.T) {
+		curve := NewXPCurve(config.XPCurveConfig{Type: config.XPCurveExponential, Base: 1000, Factor: 10, MaxLevel: 100})
+
+		assert.Less(t, curve.MaxLevel(), 100, "The max level stops before overflowing")
+		assert.Positive(t, curve.ExperienceForLevel(curve.MaxLevel()))
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		curve := NewXPCurve(config.XPCurveConfig{Type: config.XPCurveTable, Table: []int{50, 150, 400}, MaxLevel: 4})
+
+		assert.Equal(t, 50, curve.ExperienceForLevel(2))
+		assert.Equal(t, 400, curve.ExperienceForLevel(4))
+		assert.Equal(t, 3, curve.LevelForExperience(399))
+		assert.Equal(t, 4, curve.LevelForExperience(400))
+		assert.Equal(t, 4, curve.MaxLevel())
+	})
+}
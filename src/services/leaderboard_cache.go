@@ -0,0 +1,13 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/models"
+
+// LeaderboardCache keeps the top players of every leaderboard in memory.
+type LeaderboardCache interface {
+	// Top returns the first Size players ranked by the metric, reloaded once
+	// they are older than the TTL.
+	Top(metric string) ([]models.PlayerProfile, error)
+	Size() int
+	// Invalidate makes the next lookups reload the boards.
+	Invalidate()
+}
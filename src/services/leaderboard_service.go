@@ -0,0 +1,9 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/data/response"
+
+type LeaderboardService interface {
+	GetLeaderboard(metric string, ranking string, page int, pageSize int) (*response.LeaderboardResponse, error)
+	// GetPlayerNeighbourhood returns the player with the neighbours ranked right above and below.
+	GetPlayerNeighbourhood(metric string, ranking string, playerProfileID uint, neighbours int) (*response.LeaderboardResponse, error)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type LevelingService interface {
+	// GrantExperience adds experience to the player and levels them up following the XP curve.
+	GrantExperience(playerProfileID uint, grant request.GrantExperienceRequest) (*response.ExperienceGrantResponse, error)
+}
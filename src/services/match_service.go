@@ -0,0 +1,12 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type MatchService interface {
+	// SubmitMatch saves the result of a match and rates its players.
+	SubmitMatch(match request.SubmitMatchRequest) (*response.MatchResponse, error)
+	GetPlayerMatches(playerProfileID uint, page int, pageSize int) ([]response.PlayerMatchResponse, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type OIDCService interface {
+	Providers() []string
+	// Authorize starts a login with the provider, linkUserID is the signed in
+	// user linking the identity or zero for a plain login.
+	Authorize(provider string, linkUserID uint) (*response.OIDCAuthorizationResponse, error)
+	Callback(provider string, state string, code string, client request.ClientInfo) (*response.LoginResponse, error)
+	GetIdentities(userID uint) ([]response.UserIdentityResponse, error)
+	Unlink(userID uint, provider string) error
+}
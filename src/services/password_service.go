@@ -0,0 +1,9 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/data/request"
+
+type PasswordService interface {
+	ForgotPassword(forgotRequest request.ForgotPasswordRequest) error
+	ResetPassword(resetRequest request.ResetPasswordRequest) error
+	ChangePassword(userID uint, requireCurrentPassword bool, changeRequest request.ChangePasswordRequest) error
+}
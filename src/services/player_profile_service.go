@@ -3,13 +3,16 @@ package services
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 )
 
 type PlayerProfileService interface {
 	Create(playerProfile request.CreatePlayerProfileRequest) error
 	GetByID(playerProfileID uint) (*response.PlayerProfileResponse, error)
 	Update(playerProfileID uint, playerProfile request.UpdatePlayerProfileRequest) error
+	UpdateProgress(playerProfileID uint, progress request.UpdatePlayerProgressRequest) error
 	Delete(playerProfileID uint) error
-	GetAll(page int, pageSize int) ([]response.PlayerProfileResponse, error)
-	GetPlayerWithAchievements(playerProfileID uint) (*response.PlayerWithAchievements, error)
+	// GetAll hides the players who blocked a player of the viewer, zero for API keys.
+	GetAll(pageRequest request.PageRequest, listQuery models.ListQuery, viewerUserID uint) ([]response.PlayerProfileResponse, *response.Pagination, error)
+	GetPlayerWithAchievements(playerProfileID uint, page int, pageSize int, sortOrder string) (*response.PlayerWithAchievements, error)
 }
@@ -0,0 +1,24 @@
+package services
+
+// Rating is the skill of a player. Elo only moves the rating, Glicko-2 also
+// tracks how certain the rating is.
+type Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// RatedTeam is a team of a match. Placement 1 won, teams can share a placement.
+type RatedTeam struct {
+	Placement int
+	Players   []Rating
+}
+
+// RatingEngine rates the players of a match from the placements of their teams.
+type RatingEngine interface {
+	System() string
+	// Initial is the rating of players without rated matches.
+	Initial() Rating
+	// Rate returns the new ratings, indexed like the teams and their players.
+	Rate(teams []RatedTeam) [][]Rating
+}
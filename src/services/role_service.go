@@ -0,0 +1,20 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type RoleService interface {
+	GetPermissions() []response.PermissionResponse
+	GetAllRoles() ([]response.RoleResponse, error)
+	GetRole(roleID uint) (*response.RoleResponse, error)
+	CreateRole(createRequest request.CreateRoleRequest) (*response.RoleResponse, error)
+	UpdateRole(roleID uint, updateRequest request.UpdateRoleRequest) (*response.RoleResponse, error)
+	DeleteRole(roleID uint) error
+	AssignRole(userID uint, assignRequest request.AssignRoleRequest) error
+	// GetUserPermissions returns the permissions granted by the role of the user.
+	GetUserPermissions(userID uint) ([]string, error)
+	CheckDefaultRole(name string) error
+	SeedRoles() error
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type SeasonService interface {
+	StartSeason(season request.StartSeasonRequest) (*response.SeasonResponse, error)
+	GetCurrentSeason() (*response.SeasonResponse, error)
+	GetAllSeasons() ([]response.SeasonResponse, error)
+	GetStandings(seasonID uint, page int, pageSize int) ([]response.SeasonStandingResponse, error)
+}
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/dieg0code/player-profile/src/data/response"
+
+type SessionService interface {
+	// GetSessions lists the active sessions of the user, currentSessionID marks
+	// the one making the request.
+	GetSessions(userID uint, currentSessionID uint) ([]response.SessionResponse, error)
+	EndSession(userID uint, sessionID uint) error
+}
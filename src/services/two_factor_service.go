@@ -0,0 +1,16 @@
+package services
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+)
+
+type TwoFactorService interface {
+	Enroll(userID uint) (*response.TwoFactorEnrollmentResponse, error)
+	// Confirm and Disable count the wrong codes and passwords like failed
+	// logins, they are refused while the account or the client is backing off.
+	Confirm(userID uint, codeRequest request.TwoFactorCodeRequest, client request.ClientInfo) (*response.RecoveryCodesResponse, error)
+	Disable(userID uint, disableRequest request.DisableTwoFactorRequest, client request.ClientInfo) error
+	IsEnabled(userID uint) (bool, error)
+	VerifyCode(userID uint, code string) error
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 )
 
 type UserService interface {
@@ -10,5 +11,6 @@ type UserService interface {
 	GetByID(userID uint) (*response.UserResponse, error)
 	Update(userID uint, user request.UpdateUserRequest) error
 	Delete(userID uint) error
-	GetAll(page int, pageSize int) ([]response.UserResponse, error)
+	GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.UserResponse, *response.Pagination, error)
+	BootstrapAdmin(admin request.CreateUserRequest) (*response.UserResponse, error)
 }
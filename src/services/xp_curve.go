@@ -0,0 +1,10 @@
+package services
+
+// XPCurve maps the total experience of a player to their level.
+type XPCurve interface {
+	// ExperienceForLevel returns the total experience needed to reach the
+	// level, levels above the max level cost the same as the max level.
+	ExperienceForLevel(level int) int
+	LevelForExperience(experience int) int
+	MaxLevel() int
+}
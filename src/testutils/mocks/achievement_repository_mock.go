@@ -22,11 +22,16 @@ func (_m *AchievementRepository) GetAchievement(achievementID uint) (*models.Ach
 	return achievement, args.Error(1)
 }
 
-func (_m *AchievementRepository) GetAllAchievements(offset int, pageSize int) ([]models.Achievement, error) {
-	ret := _m.Called(offset, pageSize)
+func (_m *AchievementRepository) GetAllAchievements(page models.Page, listQuery models.ListQuery) ([]models.Achievement, error) {
+	ret := _m.Called(page, listQuery)
 	return ret.Get(0).([]models.Achievement), ret.Error(1)
 }
 
+func (_m *AchievementRepository) CountAchievements(listQuery models.ListQuery) (int64, error) {
+	ret := _m.Called(listQuery)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
 func (_m *AchievementRepository) UpdateAchievement(achievementID uint, achievement *models.Achievement) error {
 	ret := _m.Called(achievementID, achievement)
 	return ret.Error(0)
@@ -42,10 +47,23 @@ func (_m *AchievementRepository) CheckAchievementExists(achievementID uint) (boo
 	return args.Bool(0), args.Error(1)
 }
 
-func (_m *AchievementRepository) GetAchievementWithPlayers(achievementID uint) (*models.Achievement, error) {
-	args := _m.Called(achievementID)
+func (_m *AchievementRepository) GetAchievementPlayers(achievementID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error) {
+	args := _m.Called(achievementID, offset, pageSize, sortOrder)
 
-	achievement, _ := args.Get(0).(*models.Achievement)
+	playerAchievements, _ := args.Get(0).([]models.PlayerProfileAchievement)
 
-	return achievement, args.Error(1)
+	return playerAchievements, args.Error(1)
+}
+
+func (_m *AchievementRepository) AwardAchievement(playerProfileID uint, achievementID uint) (*models.PlayerProfileAchievement, bool, error) {
+	args := _m.Called(playerProfileID, achievementID)
+
+	playerAchievement, _ := args.Get(0).(*models.PlayerProfileAchievement)
+
+	return playerAchievement, args.Bool(1), args.Error(2)
+}
+
+func (_m *AchievementRepository) RevokeAchievement(playerProfileID uint, achievementID uint) error {
+	args := _m.Called(playerProfileID, achievementID)
+	return args.Error(0)
 }
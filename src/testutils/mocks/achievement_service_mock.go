@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,16 +23,34 @@ func (_m *MockAchievementService) GetByID(achievementID uint) (*response.Achieve
 	ret := _m.Called(achievementID)
 	return ret.Get(0).(*response.AchievementResponse), ret.Error(1)
 }
-func (_m *MockAchievementService) GetAll(page int, pageSize int) ([]response.AchievementResponse, error) {
-	ret := _m.Called(page, pageSize)
-	return ret.Get(0).([]response.AchievementResponse), ret.Error(1)
+func (_m *MockAchievementService) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.AchievementResponse, *response.Pagination, error) {
+	ret := _m.Called(pageRequest, listQuery)
+	items, _ := ret.Get(0).([]response.AchievementResponse)
+	pagination, _ := ret.Get(1).(*response.Pagination)
+	return items, pagination, ret.Error(2)
 }
 func (_m *MockAchievementService) Update(achievementID uint, achievement request.UpdateAchievementRequest) error {
 	ret := _m.Called(achievementID, achievement)
 	return ret.Error(0)
 }
 
-func (_m *MockAchievementService) GetAchievementWithPlayers(achievementID uint) (*response.AchievementWithPlayers, error) {
-	ret := _m.Called(achievementID)
-	return ret.Get(0).(*response.AchievementWithPlayers), ret.Error(1)
+func (_m *MockAchievementService) GetAchievementWithPlayers(achievementID uint, page int, pageSize int, sortOrder string) (*response.AchievementWithPlayers, error) {
+	ret := _m.Called(achievementID, page, pageSize, sortOrder)
+
+	achievement, _ := ret.Get(0).(*response.AchievementWithPlayers)
+
+	return achievement, ret.Error(1)
+}
+
+func (_m *MockAchievementService) AwardToPlayer(playerProfileID uint, achievementID uint) (*response.PlayerAchievementResponse, error) {
+	ret := _m.Called(playerProfileID, achievementID)
+
+	playerAchievement, _ := ret.Get(0).(*response.PlayerAchievementResponse)
+
+	return playerAchievement, ret.Error(1)
+}
+
+func (_m *MockAchievementService) RevokeFromPlayer(playerProfileID uint, achievementID uint) error {
+	ret := _m.Called(playerProfileID, achievementID)
+	return ret.Error(0)
 }
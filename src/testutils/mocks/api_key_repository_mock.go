@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+func (_m *APIKeyRepository) CreateAPIKey(apiKey *models.APIKey) error {
+	ret := _m.Called(apiKey)
+	return ret.Error(0)
+}
+
+func (_m *APIKeyRepository) GetAPIKey(apiKeyID uint) (*models.APIKey, error) {
+	args := _m.Called(apiKeyID)
+
+	apiKey, _ := args.Get(0).(*models.APIKey)
+
+	return apiKey, args.Error(1)
+}
+
+func (_m *APIKeyRepository) GetAllAPIKeys() ([]models.APIKey, error) {
+	args := _m.Called()
+
+	apiKeys, _ := args.Get(0).([]models.APIKey)
+
+	return apiKeys, args.Error(1)
+}
+
+func (_m *APIKeyRepository) FindByPrefix(prefix string) (*models.APIKey, error) {
+	args := _m.Called(prefix)
+
+	apiKey, _ := args.Get(0).(*models.APIKey)
+
+	return apiKey, args.Error(1)
+}
+
+func (_m *APIKeyRepository) RevokeAPIKey(apiKeyID uint) error {
+	ret := _m.Called(apiKeyID)
+	return ret.Error(0)
+}
+
+func (_m *APIKeyRepository) UpdateLastUsed(apiKeyID uint, lastUsedAt time.Time) error {
+	ret := _m.Called(apiKeyID, lastUsedAt)
+	return ret.Error(0)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (_m *MockAPIKeyService) CreateAPIKey(createdBy uint, createRequest request.CreateAPIKeyRequest) (*response.CreatedAPIKeyResponse, error) {
+	ret := _m.Called(createdBy, createRequest)
+
+	created, _ := ret.Get(0).(*response.CreatedAPIKeyResponse)
+
+	return created, ret.Error(1)
+}
+
+func (_m *MockAPIKeyService) GetAllAPIKeys() ([]response.APIKeyResponse, error) {
+	ret := _m.Called()
+
+	apiKeys, _ := ret.Get(0).([]response.APIKeyResponse)
+
+	return apiKeys, ret.Error(1)
+}
+
+func (_m *MockAPIKeyService) RevokeAPIKey(apiKeyID uint) error {
+	ret := _m.Called(apiKeyID)
+	return ret.Error(0)
+}
+
+func (_m *MockAPIKeyService) Authenticate(key string) (*response.APIKeyResponse, error) {
+	ret := _m.Called(key)
+
+	apiKey, _ := ret.Get(0).(*response.APIKeyResponse)
+
+	return apiKey, ret.Error(1)
+}
@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
 	"github.com/stretchr/testify/mock"
@@ -10,7 +13,51 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (_m *MockAuthService) Login(loginRequest request.LoginRequest) (*response.LoginResponse, error) {
-	ret := _m.Called(loginRequest)
+func (_m *MockAuthService) Login(loginRequest request.LoginRequest, client request.ClientInfo) (*response.LoginResponse, error) {
+	ret := _m.Called(loginRequest, client)
 	return ret.Get(0).(*response.LoginResponse), ret.Error(1)
 }
+
+func (_m *MockAuthService) VerifyTwoFactor(verifyRequest request.TwoFactorLoginRequest, client request.ClientInfo) (*response.LoginResponse, error) {
+	ret := _m.Called(verifyRequest, client)
+
+	loginResponse, _ := ret.Get(0).(*response.LoginResponse)
+
+	return loginResponse, ret.Error(1)
+}
+
+func (_m *MockAuthService) LoginWithIdentity(userID uint, client request.ClientInfo) (*response.LoginResponse, error) {
+	ret := _m.Called(userID, client)
+
+	loginResponse, _ := ret.Get(0).(*response.LoginResponse)
+
+	return loginResponse, ret.Error(1)
+}
+
+func (_m *MockAuthService) Refresh(refreshRequest request.RefreshTokenRequest, client request.ClientInfo) (*response.LoginResponse, error) {
+	ret := _m.Called(refreshRequest, client)
+
+	refreshResponse, _ := ret.Get(0).(*response.LoginResponse)
+
+	return refreshResponse, ret.Error(1)
+}
+
+func (_m *MockAuthService) Logout(userID uint, sessionID uint, tokenID string, expiresAt time.Time, logoutRequest request.LogoutRequest) error {
+	ret := _m.Called(userID, sessionID, tokenID, expiresAt, logoutRequest)
+	return ret.Error(0)
+}
+
+func (_m *MockAuthService) LogoutAll(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
+func (_m *MockAuthService) UnlockAccount(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
+func (_m *MockAuthService) JWKS() auth.JWKS {
+	ret := _m.Called()
+	return ret.Get(0).(auth.JWKS)
+}
@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/auth"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,11 +12,22 @@ type MockAuthUtils struct {
 	mock.Mock
 }
 
-func (_m *MockAuthUtils) GenerateToken(userID uint, role string) (string, error) {
-	ret := _m.Called(userID, role)
+func (_m *MockAuthUtils) GenerateToken(userID uint, role string, sessionID uint) (string, error) {
+	ret := _m.Called(userID, role, sessionID)
+	return ret.String(0), ret.Error(1)
+}
+
+func (_m *MockAuthUtils) GenerateImpersonationToken(userID uint, role string, actorID uint, ttl time.Duration) (string, error) {
+	ret := _m.Called(userID, role, actorID, ttl)
 	return ret.String(0), ret.Error(1)
 }
+
 func (_m *MockAuthUtils) ParseToken(tokenString string) (*jwt.Token, error) {
 	ret := _m.Called(tokenString)
 	return ret.Get(0).(*jwt.Token), ret.Error(1)
 }
+
+func (_m *MockAuthUtils) JWKS() auth.JWKS {
+	ret := _m.Called()
+	return ret.Get(0).(auth.JWKS)
+}
@@ -15,3 +15,8 @@ func (_m *MockPasswordHasher) ComparePassword(hashedPassword string, password st
 	args := _m.Called(hashedPassword, password)
 	return args.Error(0)
 }
+
+func (_m *MockPasswordHasher) NeedsRehash(hashedPassword string) bool {
+	args := _m.Called(hashedPassword)
+	return args.Bool(0)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailVerificationService struct {
+	mock.Mock
+}
+
+func (_m *MockEmailVerificationService) SendVerificationEmail(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
+func (_m *MockEmailVerificationService) VerifyEmail(token string) error {
+	ret := _m.Called(token)
+	return ret.Error(0)
+}
+
+func (_m *MockEmailVerificationService) ResendVerificationEmail(resendRequest request.ResendVerificationRequest) error {
+	ret := _m.Called(resendRequest)
+	return ret.Error(0)
+}
@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type FriendshipRepository struct {
+	mock.Mock
+}
+
+func (_m *FriendshipRepository) CreateFriendRequest(friendRequest *models.FriendRequest) error {
+	ret := _m.Called(friendRequest)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) GetFriendRequest(friendRequestID uint) (*models.FriendRequest, error) {
+	ret := _m.Called(friendRequestID)
+
+	friendRequest, _ := ret.Get(0).(*models.FriendRequest)
+
+	return friendRequest, ret.Error(1)
+}
+
+func (_m *FriendshipRepository) GetFriendRequests(playerProfileID uint, incoming bool, page models.Page) ([]models.FriendRequest, error) {
+	ret := _m.Called(playerProfileID, incoming, page)
+
+	friendRequests, _ := ret.Get(0).([]models.FriendRequest)
+
+	return friendRequests, ret.Error(1)
+}
+
+func (_m *FriendshipRepository) CountFriendRequests(playerProfileID uint, incoming bool) (int64, error) {
+	ret := _m.Called(playerProfileID, incoming)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *FriendshipRepository) AcceptFriendRequest(friendRequest *models.FriendRequest) error {
+	ret := _m.Called(friendRequest)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) DeleteFriendRequest(friendRequestID uint) error {
+	ret := _m.Called(friendRequestID)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) DeleteFriendship(playerProfileID uint, friendID uint) error {
+	ret := _m.Called(playerProfileID, friendID)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) GetFriends(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	ret := _m.Called(playerProfileID, page)
+
+	friends, _ := ret.Get(0).([]models.PlayerProfile)
+
+	return friends, ret.Error(1)
+}
+
+func (_m *FriendshipRepository) CountFriends(playerProfileID uint) (int64, error) {
+	ret := _m.Called(playerProfileID)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *FriendshipRepository) GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	ret := _m.Called(playerProfileID, otherPlayerProfileID, page)
+
+	friends, _ := ret.Get(0).([]models.PlayerProfile)
+
+	return friends, ret.Error(1)
+}
+
+func (_m *FriendshipRepository) CountMutualFriends(playerProfileID uint, otherPlayerProfileID uint) (int64, error) {
+	ret := _m.Called(playerProfileID, otherPlayerProfileID)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *FriendshipRepository) BlockPlayer(block *models.PlayerBlock) error {
+	ret := _m.Called(block)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) UnblockPlayer(blockerID uint, blockedID uint) error {
+	ret := _m.Called(blockerID, blockedID)
+	return ret.Error(0)
+}
+
+func (_m *FriendshipRepository) GetBlockedPlayers(playerProfileID uint, page models.Page) ([]models.PlayerProfile, error) {
+	ret := _m.Called(playerProfileID, page)
+
+	players, _ := ret.Get(0).([]models.PlayerProfile)
+
+	return players, ret.Error(1)
+}
+
+func (_m *FriendshipRepository) CountBlockedPlayers(playerProfileID uint) (int64, error) {
+	ret := _m.Called(playerProfileID)
+	return ret.Get(0).(int64), ret.Error(1)
+}
@@ -0,0 +1,85 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFriendshipService struct {
+	mock.Mock
+}
+
+func (_m *MockFriendshipService) SendFriendRequest(playerProfileID uint, friendRequest request.SendFriendRequestRequest) (*response.FriendRequestResponse, error) {
+	ret := _m.Called(playerProfileID, friendRequest)
+
+	friendRequestResponse, _ := ret.Get(0).(*response.FriendRequestResponse)
+
+	return friendRequestResponse, ret.Error(1)
+}
+
+func (_m *MockFriendshipService) GetFriendRequests(playerProfileID uint, direction string, pageRequest request.PageRequest) ([]response.FriendRequestResponse, *response.Pagination, error) {
+	ret := _m.Called(playerProfileID, direction, pageRequest)
+
+	friendRequests, _ := ret.Get(0).([]response.FriendRequestResponse)
+	pagination, _ := ret.Get(1).(*response.Pagination)
+
+	return friendRequests, pagination, ret.Error(2)
+}
+
+func (_m *MockFriendshipService) AcceptFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	ret := _m.Called(playerProfileID, friendRequestID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) DeclineFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	ret := _m.Called(playerProfileID, friendRequestID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) CancelFriendRequest(playerProfileID uint, friendRequestID uint) error {
+	ret := _m.Called(playerProfileID, friendRequestID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) Unfriend(playerProfileID uint, friendID uint) error {
+	ret := _m.Called(playerProfileID, friendID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) GetFriends(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	ret := _m.Called(playerProfileID, pageRequest)
+
+	friends, _ := ret.Get(0).([]response.PlayerProfileResponse)
+	pagination, _ := ret.Get(1).(*response.Pagination)
+
+	return friends, pagination, ret.Error(2)
+}
+
+func (_m *MockFriendshipService) GetMutualFriends(playerProfileID uint, otherPlayerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	ret := _m.Called(playerProfileID, otherPlayerProfileID, pageRequest)
+
+	friends, _ := ret.Get(0).([]response.PlayerProfileResponse)
+	pagination, _ := ret.Get(1).(*response.Pagination)
+
+	return friends, pagination, ret.Error(2)
+}
+
+func (_m *MockFriendshipService) BlockPlayer(playerProfileID uint, blockedID uint) error {
+	ret := _m.Called(playerProfileID, blockedID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) UnblockPlayer(playerProfileID uint, blockedID uint) error {
+	ret := _m.Called(playerProfileID, blockedID)
+	return ret.Error(0)
+}
+
+func (_m *MockFriendshipService) GetBlockedPlayers(playerProfileID uint, pageRequest request.PageRequest) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	ret := _m.Called(playerProfileID, pageRequest)
+
+	players, _ := ret.Get(0).([]response.PlayerProfileResponse)
+	pagination, _ := ret.Get(1).(*response.Pagination)
+
+	return players, pagination, ret.Error(2)
+}
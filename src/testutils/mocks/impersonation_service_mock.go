@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImpersonationService struct {
+	mock.Mock
+}
+
+func (_m *MockImpersonationService) Impersonate(actorID uint, userID uint) (*response.ImpersonationResponse, error) {
+	ret := _m.Called(actorID, userID)
+
+	impersonation, _ := ret.Get(0).(*response.ImpersonationResponse)
+
+	return impersonation, ret.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLeaderboardCache struct {
+	mock.Mock
+}
+
+func (_m *MockLeaderboardCache) Top(metric string) ([]models.PlayerProfile, error) {
+	ret := _m.Called(metric)
+
+	players, _ := ret.Get(0).([]models.PlayerProfile)
+
+	return players, ret.Error(1)
+}
+
+func (_m *MockLeaderboardCache) Size() int {
+	ret := _m.Called()
+	return ret.Int(0)
+}
+
+func (_m *MockLeaderboardCache) Invalidate() {
+	_m.Called()
+}
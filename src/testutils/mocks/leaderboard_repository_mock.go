@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type LeaderboardRepository struct {
+	mock.Mock
+}
+
+func (_m *LeaderboardRepository) GetRankedPlayers(metric string, offset int, limit int) ([]models.PlayerProfile, error) {
+	ret := _m.Called(metric, offset, limit)
+
+	players, _ := ret.Get(0).([]models.PlayerProfile)
+
+	return players, ret.Error(1)
+}
+
+func (_m *LeaderboardRepository) CountPlayersAbove(metric string, value int, distinct bool) (int64, error) {
+	ret := _m.Called(metric, value, distinct)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *LeaderboardRepository) GetPlayerPosition(metric string, playerProfile *models.PlayerProfile) (int64, error) {
+	ret := _m.Called(metric, playerProfile)
+	return ret.Get(0).(int64), ret.Error(1)
+}
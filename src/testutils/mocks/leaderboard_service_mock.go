@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLeaderboardService struct {
+	mock.Mock
+}
+
+func (_m *MockLeaderboardService) GetLeaderboard(metric string, ranking string, page int, pageSize int) (*response.LeaderboardResponse, error) {
+	ret := _m.Called(metric, ranking, page, pageSize)
+
+	leaderboard, _ := ret.Get(0).(*response.LeaderboardResponse)
+
+	return leaderboard, ret.Error(1)
+}
+
+func (_m *MockLeaderboardService) GetPlayerNeighbourhood(metric string, ranking string, playerProfileID uint, neighbours int) (*response.LeaderboardResponse, error) {
+	ret := _m.Called(metric, ranking, playerProfileID, neighbours)
+
+	leaderboard, _ := ret.Get(0).(*response.LeaderboardResponse)
+
+	return leaderboard, ret.Error(1)
+}
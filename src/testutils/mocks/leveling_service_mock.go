@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLevelingService struct {
+	mock.Mock
+}
+
+func (_m *MockLevelingService) GrantExperience(playerProfileID uint, grant request.GrantExperienceRequest) (*response.ExperienceGrantResponse, error) {
+	ret := _m.Called(playerProfileID, grant)
+
+	experienceGrant, _ := ret.Get(0).(*response.ExperienceGrantResponse)
+
+	return experienceGrant, ret.Error(1)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+func (_m *LoginAttemptRepository) GetLoginAttempt(key string) (*models.LoginAttempt, error) {
+	args := _m.Called(key)
+
+	loginAttempt, _ := args.Get(0).(*models.LoginAttempt)
+
+	return loginAttempt, args.Error(1)
+}
+
+func (_m *LoginAttemptRepository) RecordFailure(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	args := _m.Called(key, now, window)
+
+	loginAttempt, _ := args.Get(0).(*models.LoginAttempt)
+
+	return loginAttempt, args.Error(1)
+}
+
+func (_m *LoginAttemptRepository) LockUntil(key string, lockedUntil time.Time) error {
+	ret := _m.Called(key, lockedUntil)
+	return ret.Error(0)
+}
+
+func (_m *LoginAttemptRepository) DeleteLoginAttempt(key string) error {
+	ret := _m.Called(key)
+	return ret.Error(0)
+}
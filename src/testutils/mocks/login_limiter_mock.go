@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginLimiter struct {
+	mock.Mock
+}
+
+func (_m *MockLoginLimiter) Check(email string, clientIP string) (time.Duration, error) {
+	ret := _m.Called(email, clientIP)
+	return ret.Get(0).(time.Duration), ret.Error(1)
+}
+
+func (_m *MockLoginLimiter) RecordFailure(email string, clientIP string) error {
+	ret := _m.Called(email, clientIP)
+	return ret.Error(0)
+}
+
+func (_m *MockLoginLimiter) RecordSuccess(email string) error {
+	ret := _m.Called(email)
+	return ret.Error(0)
+}
+
+func (_m *MockLoginLimiter) Unlock(email string) error {
+	ret := _m.Called(email)
+	return ret.Error(0)
+}
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/mailer"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (_m *MockMailer) Send(message mailer.Message) error {
+	ret := _m.Called(message)
+	return ret.Error(0)
+}
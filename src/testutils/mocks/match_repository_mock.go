@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MatchRepository struct {
+	mock.Mock
+}
+
+func (_m *MatchRepository) GetPlayerRatings(playerProfileIDs []uint) ([]models.PlayerRating, error) {
+	ret := _m.Called(playerProfileIDs)
+
+	ratings, _ := ret.Get(0).([]models.PlayerRating)
+
+	return ratings, ret.Error(1)
+}
+
+func (_m *MatchRepository) CreateMatch(match *models.Match, ratings []models.PlayerRating) error {
+	ret := _m.Called(match, ratings)
+	return ret.Error(0)
+}
+
+func (_m *MatchRepository) GetPlayerMatches(playerProfileID uint, offset int, pageSize int) ([]models.MatchParticipant, error) {
+	ret := _m.Called(playerProfileID, offset, pageSize)
+
+	participants, _ := ret.Get(0).([]models.MatchParticipant)
+
+	return participants, ret.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMatchService struct {
+	mock.Mock
+}
+
+func (_m *MockMatchService) SubmitMatch(match request.SubmitMatchRequest) (*response.MatchResponse, error) {
+	ret := _m.Called(match)
+
+	matchResponse, _ := ret.Get(0).(*response.MatchResponse)
+
+	return matchResponse, ret.Error(1)
+}
+
+func (_m *MockMatchService) GetPlayerMatches(playerProfileID uint, page int, pageSize int) ([]response.PlayerMatchResponse, error) {
+	ret := _m.Called(playerProfileID, page, pageSize)
+
+	matches, _ := ret.Get(0).([]response.PlayerMatchResponse)
+
+	return matches, ret.Error(1)
+}
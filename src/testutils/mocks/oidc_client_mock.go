@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/auth"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCClient struct {
+	mock.Mock
+}
+
+func (_m *MockOIDCClient) Providers() []string {
+	ret := _m.Called()
+	return ret.Get(0).([]string)
+}
+
+func (_m *MockOIDCClient) AuthCodeURL(provider string, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(provider, state, nonce, codeChallenge)
+	return ret.String(0), ret.Error(1)
+}
+
+func (_m *MockOIDCClient) Exchange(provider string, code string, codeVerifier string) (*auth.OIDCClaims, error) {
+	ret := _m.Called(provider, code, codeVerifier)
+
+	claims, _ := ret.Get(0).(*auth.OIDCClaims)
+
+	return claims, ret.Error(1)
+}
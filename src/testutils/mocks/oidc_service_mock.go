@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCService struct {
+	mock.Mock
+}
+
+func (_m *MockOIDCService) Providers() []string {
+	ret := _m.Called()
+	return ret.Get(0).([]string)
+}
+
+func (_m *MockOIDCService) Authorize(provider string, linkUserID uint) (*response.OIDCAuthorizationResponse, error) {
+	ret := _m.Called(provider, linkUserID)
+
+	authorizationResponse, _ := ret.Get(0).(*response.OIDCAuthorizationResponse)
+
+	return authorizationResponse, ret.Error(1)
+}
+
+func (_m *MockOIDCService) Callback(provider string, state string, code string, client request.ClientInfo) (*response.LoginResponse, error) {
+	ret := _m.Called(provider, state, code, client)
+
+	loginResponse, _ := ret.Get(0).(*response.LoginResponse)
+
+	return loginResponse, ret.Error(1)
+}
+
+func (_m *MockOIDCService) GetIdentities(userID uint) ([]response.UserIdentityResponse, error) {
+	ret := _m.Called(userID)
+
+	identities, _ := ret.Get(0).([]response.UserIdentityResponse)
+
+	return identities, ret.Error(1)
+}
+
+func (_m *MockOIDCService) Unlink(userID uint, provider string) error {
+	ret := _m.Called(userID, provider)
+	return ret.Error(0)
+}
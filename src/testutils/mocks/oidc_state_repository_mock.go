@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type OIDCStateRepository struct {
+	mock.Mock
+}
+
+func (_m *OIDCStateRepository) CreateOIDCState(oidcState *models.OIDCState) error {
+	ret := _m.Called(oidcState)
+	return ret.Error(0)
+}
+
+func (_m *OIDCStateRepository) FindByStateHash(stateHash string) (*models.OIDCState, error) {
+	args := _m.Called(stateHash)
+
+	oidcState, _ := args.Get(0).(*models.OIDCState)
+
+	return oidcState, args.Error(1)
+}
+
+func (_m *OIDCStateRepository) MarkAsUsed(oidcStateID uint) (bool, error) {
+	args := _m.Called(oidcStateID)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordService struct {
+	mock.Mock
+}
+
+func (_m *MockPasswordService) ForgotPassword(forgotRequest request.ForgotPasswordRequest) error {
+	ret := _m.Called(forgotRequest)
+	return ret.Error(0)
+}
+
+func (_m *MockPasswordService) ResetPassword(resetRequest request.ResetPasswordRequest) error {
+	ret := _m.Called(resetRequest)
+	return ret.Error(0)
+}
+
+func (_m *MockPasswordService) ChangePassword(userID uint, requireCurrentPassword bool, changeRequest request.ChangePasswordRequest) error {
+	ret := _m.Called(userID, requireCurrentPassword, changeRequest)
+	return ret.Error(0)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPermissionStore struct {
+	mock.Mock
+}
+
+func (_m *MockPermissionStore) Permissions(role string) ([]string, bool) {
+	ret := _m.Called(role)
+
+	permissions, _ := ret.Get(0).([]string)
+
+	return permissions, ret.Bool(1)
+}
+
+func (_m *MockPermissionStore) Invalidate() {
+	_m.Called()
+}
@@ -9,8 +9,8 @@ type PlayerProfileRepository struct {
 	mock.Mock
 }
 
-func (_m *PlayerProfileRepository) CreatePlayerProfile(playerProfile *models.PlayerProfile) error {
-	ret := _m.Called(playerProfile)
+func (_m *PlayerProfileRepository) CreatePlayerProfileWithinLimit(playerProfile *models.PlayerProfile, maxProfiles int) error {
+	ret := _m.Called(playerProfile, maxProfiles)
 	return ret.Error(0)
 }
 
@@ -22,16 +22,31 @@ func (_m *PlayerProfileRepository) GetPlayerProfile(playerProfileID uint) (*mode
 	return player, args.Error(1)
 }
 
-func (_m *PlayerProfileRepository) GetAllPlayerProfiles(offset int, pageSize int) ([]models.PlayerProfile, error) {
-	ret := _m.Called(offset, pageSize)
+func (_m *PlayerProfileRepository) GetAllPlayerProfiles(page models.Page, listQuery models.ListQuery, viewerUserID uint) ([]models.PlayerProfile, error) {
+	ret := _m.Called(page, listQuery, viewerUserID)
 	return ret.Get(0).([]models.PlayerProfile), ret.Error(1)
 }
 
+func (_m *PlayerProfileRepository) CountPlayerProfiles(listQuery models.ListQuery, viewerUserID uint) (int64, error) {
+	ret := _m.Called(listQuery, viewerUserID)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
 func (_m *PlayerProfileRepository) UpdatePlayerProfile(playerProfileID uint, playerProfile *models.PlayerProfile) error {
 	ret := _m.Called(playerProfileID, playerProfile)
 	return ret.Error(0)
 }
 
+func (_m *PlayerProfileRepository) UpdatePlayerProgress(playerProfileID uint, playerProfile *models.PlayerProfile) error {
+	ret := _m.Called(playerProfileID, playerProfile)
+	return ret.Error(0)
+}
+
+func (_m *PlayerProfileRepository) UpdatePlayerExperience(playerProfile *models.PlayerProfile, level int, experience int) (bool, error) {
+	ret := _m.Called(playerProfile, level, experience)
+	return ret.Bool(0), ret.Error(1)
+}
+
 func (_m *PlayerProfileRepository) DeletePlayerProfile(playerProfileID uint) error {
 	ret := _m.Called(playerProfileID)
 	return ret.Error(0)
@@ -42,10 +57,10 @@ func (_m *PlayerProfileRepository) CheckPlayerProfileExists(playerProfileID uint
 	return args.Bool(0), args.Error(1)
 }
 
-func (_m *PlayerProfileRepository) GetPlayerWithAchievements(playerProfileID uint) (*models.PlayerProfile, error) {
-	args := _m.Called(playerProfileID)
+func (_m *PlayerProfileRepository) GetPlayerAchievements(playerProfileID uint, offset int, pageSize int, sortOrder string) ([]models.PlayerProfileAchievement, error) {
+	args := _m.Called(playerProfileID, offset, pageSize, sortOrder)
 
-	player, _ := args.Get(0).(*models.PlayerProfile)
+	playerAchievements, _ := args.Get(0).([]models.PlayerProfileAchievement)
 
-	return player, args.Error(1)
+	return playerAchievements, args.Error(1)
 }
@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,16 +23,25 @@ func (_m *MockPlayerProfileService) Update(playerProfileID uint, playerProfile r
 	args := _m.Called(playerProfileID, playerProfile)
 	return args.Error(0)
 }
+func (_m *MockPlayerProfileService) UpdateProgress(playerProfileID uint, progress request.UpdatePlayerProgressRequest) error {
+	args := _m.Called(playerProfileID, progress)
+	return args.Error(0)
+}
 func (_m *MockPlayerProfileService) Delete(playerProfileID uint) error {
 	args := _m.Called(playerProfileID)
 	return args.Error(0)
 }
-func (_m *MockPlayerProfileService) GetAll(page int, pageSize int) ([]response.PlayerProfileResponse, error) {
-	args := _m.Called(page, pageSize)
-	return args.Get(0).([]response.PlayerProfileResponse), args.Error(1)
+func (_m *MockPlayerProfileService) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery, viewerUserID uint) ([]response.PlayerProfileResponse, *response.Pagination, error) {
+	args := _m.Called(pageRequest, listQuery, viewerUserID)
+	items, _ := args.Get(0).([]response.PlayerProfileResponse)
+	pagination, _ := args.Get(1).(*response.Pagination)
+	return items, pagination, args.Error(2)
 }
 
-func (_m *MockPlayerProfileService) GetPlayerWithAchievements(playerProfileID uint) (*response.PlayerWithAchievements, error) {
-	args := _m.Called(playerProfileID)
-	return args.Get(0).(*response.PlayerWithAchievements), args.Error(1)
+func (_m *MockPlayerProfileService) GetPlayerWithAchievements(playerProfileID uint, page int, pageSize int, sortOrder string) (*response.PlayerWithAchievements, error) {
+	args := _m.Called(playerProfileID, page, pageSize, sortOrder)
+
+	player, _ := args.Get(0).(*response.PlayerWithAchievements)
+
+	return player, args.Error(1)
 }
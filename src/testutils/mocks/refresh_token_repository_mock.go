@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (_m *RefreshTokenRepository) CreateRefreshToken(refreshToken *models.RefreshToken) error {
+	ret := _m.Called(refreshToken)
+	return ret.Error(0)
+}
+
+func (_m *RefreshTokenRepository) FindByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	args := _m.Called(tokenHash)
+
+	refreshToken, _ := args.Get(0).(*models.RefreshToken)
+
+	return refreshToken, args.Error(1)
+}
+
+func (_m *RefreshTokenRepository) MarkAsUsed(refreshTokenID uint) (bool, error) {
+	ret := _m.Called(refreshTokenID)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	ret := _m.Called(familyID)
+	return ret.Error(0)
+}
+
+func (_m *RefreshTokenRepository) RevokeAllForUser(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
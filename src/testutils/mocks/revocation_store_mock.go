@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRevocationStore struct {
+	mock.Mock
+}
+
+func (_m *MockRevocationStore) RevokeToken(tokenID string, userID uint, expiresAt time.Time) error {
+	ret := _m.Called(tokenID, userID, expiresAt)
+	return ret.Error(0)
+}
+
+func (_m *MockRevocationStore) RevokeAllForUser(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
+func (_m *MockRevocationStore) IsRevoked(tokenID string, userID uint, issuedAt time.Time) bool {
+	ret := _m.Called(tokenID, userID, issuedAt)
+	return ret.Bool(0)
+}
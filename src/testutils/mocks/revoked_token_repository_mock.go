@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type RevokedTokenRepository struct {
+	mock.Mock
+}
+
+func (_m *RevokedTokenRepository) CreateRevokedToken(revokedToken *models.RevokedToken) error {
+	ret := _m.Called(revokedToken)
+	return ret.Error(0)
+}
+
+func (_m *RevokedTokenRepository) GetActiveRevokedTokens(now time.Time) ([]models.RevokedToken, error) {
+	args := _m.Called(now)
+
+	revokedTokens, _ := args.Get(0).([]models.RevokedToken)
+
+	return revokedTokens, args.Error(1)
+}
+
+func (_m *RevokedTokenRepository) DeleteExpiredRevokedTokens(now time.Time) error {
+	ret := _m.Called(now)
+	return ret.Error(0)
+}
+
+func (_m *RevokedTokenRepository) SaveUserRevocation(userRevocation *models.UserRevocation) error {
+	ret := _m.Called(userRevocation)
+	return ret.Error(0)
+}
+
+func (_m *RevokedTokenRepository) GetAllUserRevocations() ([]models.UserRevocation, error) {
+	args := _m.Called()
+
+	userRevocations, _ := args.Get(0).([]models.UserRevocation)
+
+	return userRevocations, args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRepository struct {
+	mock.Mock
+}
+
+func (_m *RoleRepository) CreateRole(role *models.Role) error {
+	ret := _m.Called(role)
+	return ret.Error(0)
+}
+
+func (_m *RoleRepository) GetRole(roleID uint) (*models.Role, error) {
+	args := _m.Called(roleID)
+
+	role, _ := args.Get(0).(*models.Role)
+
+	return role, args.Error(1)
+}
+
+func (_m *RoleRepository) FindByName(name string) (*models.Role, error) {
+	args := _m.Called(name)
+
+	role, _ := args.Get(0).(*models.Role)
+
+	return role, args.Error(1)
+}
+
+func (_m *RoleRepository) GetAllRoles() ([]models.Role, error) {
+	args := _m.Called()
+
+	roles, _ := args.Get(0).([]models.Role)
+
+	return roles, args.Error(1)
+}
+
+func (_m *RoleRepository) UpdateRole(role *models.Role) error {
+	ret := _m.Called(role)
+	return ret.Error(0)
+}
+
+func (_m *RoleRepository) DeleteRole(roleID uint) error {
+	ret := _m.Called(roleID)
+	return ret.Error(0)
+}
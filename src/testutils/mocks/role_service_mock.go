@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRoleService struct {
+	mock.Mock
+}
+
+func (_m *MockRoleService) GetPermissions() []response.PermissionResponse {
+	ret := _m.Called()
+
+	permissions, _ := ret.Get(0).([]response.PermissionResponse)
+
+	return permissions
+}
+
+func (_m *MockRoleService) GetAllRoles() ([]response.RoleResponse, error) {
+	ret := _m.Called()
+
+	roles, _ := ret.Get(0).([]response.RoleResponse)
+
+	return roles, ret.Error(1)
+}
+
+func (_m *MockRoleService) GetRole(roleID uint) (*response.RoleResponse, error) {
+	ret := _m.Called(roleID)
+
+	role, _ := ret.Get(0).(*response.RoleResponse)
+
+	return role, ret.Error(1)
+}
+
+func (_m *MockRoleService) CreateRole(createRequest request.CreateRoleRequest) (*response.RoleResponse, error) {
+	ret := _m.Called(createRequest)
+
+	role, _ := ret.Get(0).(*response.RoleResponse)
+
+	return role, ret.Error(1)
+}
+
+func (_m *MockRoleService) UpdateRole(roleID uint, updateRequest request.UpdateRoleRequest) (*response.RoleResponse, error) {
+	ret := _m.Called(roleID, updateRequest)
+
+	role, _ := ret.Get(0).(*response.RoleResponse)
+
+	return role, ret.Error(1)
+}
+
+func (_m *MockRoleService) DeleteRole(roleID uint) error {
+	ret := _m.Called(roleID)
+	return ret.Error(0)
+}
+
+func (_m *MockRoleService) AssignRole(userID uint, assignRequest request.AssignRoleRequest) error {
+	ret := _m.Called(userID, assignRequest)
+	return ret.Error(0)
+}
+
+func (_m *MockRoleService) GetUserPermissions(userID uint) ([]string, error) {
+	ret := _m.Called(userID)
+	permissions, _ := ret.Get(0).([]string)
+	return permissions, ret.Error(1)
+}
+
+func (_m *MockRoleService) CheckDefaultRole(name string) error {
+	ret := _m.Called(name)
+	return ret.Error(0)
+}
+
+func (_m *MockRoleService) SeedRoles() error {
+	ret := _m.Called()
+	return ret.Error(0)
+}
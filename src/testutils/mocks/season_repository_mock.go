@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type SeasonRepository struct {
+	mock.Mock
+}
+
+func (_m *SeasonRepository) GetCurrentSeason() (*models.Season, error) {
+	ret := _m.Called()
+
+	season, _ := ret.Get(0).(*models.Season)
+
+	return season, ret.Error(1)
+}
+
+func (_m *SeasonRepository) GetSeason(seasonID uint) (*models.Season, error) {
+	ret := _m.Called(seasonID)
+
+	season, _ := ret.Get(0).(*models.Season)
+
+	return season, ret.Error(1)
+}
+
+func (_m *SeasonRepository) GetAllSeasons() ([]models.Season, error) {
+	ret := _m.Called()
+
+	seasons, _ := ret.Get(0).([]models.Season)
+
+	return seasons, ret.Error(1)
+}
+
+func (_m *SeasonRepository) StartSeason(season *models.Season) error {
+	ret := _m.Called(season)
+	return ret.Error(0)
+}
+
+func (_m *SeasonRepository) GetStandings(seasonID uint, offset int, pageSize int) ([]models.SeasonStanding, error) {
+	ret := _m.Called(seasonID, offset, pageSize)
+
+	standings, _ := ret.Get(0).([]models.SeasonStanding)
+
+	return standings, ret.Error(1)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSeasonService struct {
+	mock.Mock
+}
+
+func (_m *MockSeasonService) StartSeason(season request.StartSeasonRequest) (*response.SeasonResponse, error) {
+	ret := _m.Called(season)
+
+	seasonResponse, _ := ret.Get(0).(*response.SeasonResponse)
+
+	return seasonResponse, ret.Error(1)
+}
+
+func (_m *MockSeasonService) GetCurrentSeason() (*response.SeasonResponse, error) {
+	ret := _m.Called()
+
+	seasonResponse, _ := ret.Get(0).(*response.SeasonResponse)
+
+	return seasonResponse, ret.Error(1)
+}
+
+func (_m *MockSeasonService) GetAllSeasons() ([]response.SeasonResponse, error) {
+	ret := _m.Called()
+
+	seasons, _ := ret.Get(0).([]response.SeasonResponse)
+
+	return seasons, ret.Error(1)
+}
+
+func (_m *MockSeasonService) GetStandings(seasonID uint, page int, pageSize int) ([]response.SeasonStandingResponse, error) {
+	ret := _m.Called(seasonID, page, pageSize)
+
+	standings, _ := ret.Get(0).([]response.SeasonStandingResponse)
+
+	return standings, ret.Error(1)
+}
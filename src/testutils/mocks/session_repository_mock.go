@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionRepository struct {
+	mock.Mock
+}
+
+func (_m *SessionRepository) CreateSession(session *models.Session) error {
+	ret := _m.Called(session)
+	return ret.Error(0)
+}
+
+func (_m *SessionRepository) GetSession(sessionID uint) (*models.Session, error) {
+	args := _m.Called(sessionID)
+
+	session, _ := args.Get(0).(*models.Session)
+
+	return session, args.Error(1)
+}
+
+func (_m *SessionRepository) FindByFamilyID(familyID string) (*models.Session, error) {
+	args := _m.Called(familyID)
+
+	session, _ := args.Get(0).(*models.Session)
+
+	return session, args.Error(1)
+}
+
+func (_m *SessionRepository) GetActiveSessions(userID uint, seenSince time.Time) ([]models.Session, error) {
+	args := _m.Called(userID, seenSince)
+
+	sessions, _ := args.Get(0).([]models.Session)
+
+	return sessions, args.Error(1)
+}
+
+func (_m *SessionRepository) UpdateLastSeen(sessionID uint, ip string, lastSeenAt time.Time) error {
+	ret := _m.Called(sessionID, ip, lastSeenAt)
+	return ret.Error(0)
+}
+
+func (_m *SessionRepository) EndSession(sessionID uint, endedAt time.Time) (bool, error) {
+	ret := _m.Called(sessionID, endedAt)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *SessionRepository) EndAllForUser(userID uint, endedAt time.Time) error {
+	ret := _m.Called(userID, endedAt)
+	return ret.Error(0)
+}
+
+func (_m *SessionRepository) GetEndedSessions(endedSince time.Time) ([]models.Session, error) {
+	args := _m.Called(endedSince)
+
+	sessions, _ := args.Get(0).([]models.Session)
+
+	return sessions, args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (_m *MockSessionService) GetSessions(userID uint, currentSessionID uint) ([]response.SessionResponse, error) {
+	ret := _m.Called(userID, currentSessionID)
+
+	sessions, _ := ret.Get(0).([]response.SessionResponse)
+
+	return sessions, ret.Error(1)
+}
+
+func (_m *MockSessionService) EndSession(userID uint, sessionID uint) error {
+	ret := _m.Called(userID, sessionID)
+	return ret.Error(0)
+}
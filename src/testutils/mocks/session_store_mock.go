@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionStore struct {
+	mock.Mock
+}
+
+func (_m *MockSessionStore) EndSession(sessionID uint) error {
+	ret := _m.Called(sessionID)
+	return ret.Error(0)
+}
+
+func (_m *MockSessionStore) IsEnded(sessionID uint) bool {
+	ret := _m.Called(sessionID)
+	return ret.Bool(0)
+}
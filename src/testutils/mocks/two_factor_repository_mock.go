@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type TwoFactorRepository struct {
+	mock.Mock
+}
+
+func (_m *TwoFactorRepository) SaveTwoFactor(twoFactor *models.TwoFactor) error {
+	ret := _m.Called(twoFactor)
+	return ret.Error(0)
+}
+
+func (_m *TwoFactorRepository) GetTwoFactor(userID uint) (*models.TwoFactor, error) {
+	args := _m.Called(userID)
+
+	twoFactor, _ := args.Get(0).(*models.TwoFactor)
+
+	return twoFactor, args.Error(1)
+}
+
+func (_m *TwoFactorRepository) ConfirmTwoFactor(userID uint, confirmedAt time.Time) error {
+	ret := _m.Called(userID, confirmedAt)
+	return ret.Error(0)
+}
+
+func (_m *TwoFactorRepository) UpdateLastUsedStep(userID uint, step int64) (bool, error) {
+	ret := _m.Called(userID, step)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *TwoFactorRepository) DeleteTwoFactor(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
+func (_m *TwoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	ret := _m.Called(userID, codeHashes)
+	return ret.Error(0)
+}
+
+func (_m *TwoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	ret := _m.Called(userID, codeHash)
+	return ret.Bool(0), ret.Error(1)
+}
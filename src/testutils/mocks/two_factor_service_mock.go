@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/data/request"
+	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+func (_m *MockTwoFactorService) Enroll(userID uint) (*response.TwoFactorEnrollmentResponse, error) {
+	ret := _m.Called(userID)
+
+	enrollment, _ := ret.Get(0).(*response.TwoFactorEnrollmentResponse)
+
+	return enrollment, ret.Error(1)
+}
+
+func (_m *MockTwoFactorService) Confirm(userID uint, codeRequest request.TwoFactorCodeRequest, client request.ClientInfo) (*response.RecoveryCodesResponse, error) {
+	ret := _m.Called(userID, codeRequest, client)
+
+	recoveryCodes, _ := ret.Get(0).(*response.RecoveryCodesResponse)
+
+	return recoveryCodes, ret.Error(1)
+}
+
+func (_m *MockTwoFactorService) Disable(userID uint, disableRequest request.DisableTwoFactorRequest, client request.ClientInfo) error {
+	ret := _m.Called(userID, disableRequest, client)
+	return ret.Error(0)
+}
+
+func (_m *MockTwoFactorService) IsEnabled(userID uint) (bool, error) {
+	ret := _m.Called(userID)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *MockTwoFactorService) VerifyCode(userID uint, code string) error {
+	ret := _m.Called(userID, code)
+	return ret.Error(0)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserIdentityRepository struct {
+	mock.Mock
+}
+
+func (_m *UserIdentityRepository) CreateUserIdentity(userIdentity *models.UserIdentity) error {
+	ret := _m.Called(userIdentity)
+	return ret.Error(0)
+}
+
+func (_m *UserIdentityRepository) CreateUserWithIdentity(user *models.User, userIdentity *models.UserIdentity) error {
+	ret := _m.Called(user, userIdentity)
+	return ret.Error(0)
+}
+
+func (_m *UserIdentityRepository) FindByProviderSubject(provider string, subject string) (*models.UserIdentity, error) {
+	args := _m.Called(provider, subject)
+
+	userIdentity, _ := args.Get(0).(*models.UserIdentity)
+
+	return userIdentity, args.Error(1)
+}
+
+func (_m *UserIdentityRepository) GetUserIdentities(userID uint) ([]models.UserIdentity, error) {
+	args := _m.Called(userID)
+
+	userIdentities, _ := args.Get(0).([]models.UserIdentity)
+
+	return userIdentities, args.Error(1)
+}
+
+func (_m *UserIdentityRepository) DeleteUserIdentity(userID uint, provider string) error {
+	ret := _m.Called(userID, provider)
+	return ret.Error(0)
+}
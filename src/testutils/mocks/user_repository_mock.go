@@ -22,16 +22,26 @@ func (_m *UserRepository) GetUser(userID uint) (*models.User, error) {
 	return user, args.Error(1)
 }
 
-func (_m *UserRepository) GetAllUsers(offset int, pageSize int) ([]models.User, error) {
-	ret := _m.Called(offset, pageSize)
+func (_m *UserRepository) GetAllUsers(page models.Page, listQuery models.ListQuery) ([]models.User, error) {
+	ret := _m.Called(page, listQuery)
 	return ret.Get(0).([]models.User), ret.Error(1)
 }
 
+func (_m *UserRepository) CountUsers(listQuery models.ListQuery) (int64, error) {
+	ret := _m.Called(listQuery)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
 func (_m *UserRepository) UpdateUser(userID uint, user *models.User) error {
 	ret := _m.Called(userID, user)
 	return ret.Error(0)
 }
 
+func (_m *UserRepository) ClearEmailVerification(userID uint) error {
+	ret := _m.Called(userID)
+	return ret.Error(0)
+}
+
 func (_m *UserRepository) DeleteUser(userID uint) error {
 	ret := _m.Called(userID)
 	return ret.Error(0)
@@ -44,3 +54,8 @@ func (_m *UserRepository) FindByEmail(email string) (*models.User, error) {
 
 	return user, args.Error(1)
 }
+
+func (_m *UserRepository) CountUsersByRole(role string) (int64, error) {
+	ret := _m.Called(role)
+	return ret.Get(0).(int64), ret.Error(1)
+}
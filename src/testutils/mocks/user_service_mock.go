@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/dieg0code/player-profile/src/data/request"
 	"github.com/dieg0code/player-profile/src/data/response"
+	"github.com/dieg0code/player-profile/src/models"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,9 +16,11 @@ func (_m *MockUserService) Create(req request.CreateUserRequest) error {
 	return args.Error(0)
 }
 
-func (_m *MockUserService) GetAll(page int, pageSize int) ([]response.UserResponse, error) {
-	args := _m.Called(page, pageSize)
-	return args.Get(0).([]response.UserResponse), args.Error(1)
+func (_m *MockUserService) GetAll(pageRequest request.PageRequest, listQuery models.ListQuery) ([]response.UserResponse, *response.Pagination, error) {
+	args := _m.Called(pageRequest, listQuery)
+	items, _ := args.Get(0).([]response.UserResponse)
+	pagination, _ := args.Get(1).(*response.Pagination)
+	return items, pagination, args.Error(2)
 }
 
 func (_m *MockUserService) GetByID(userID uint) (*response.UserResponse, error) {
@@ -34,3 +37,11 @@ func (_m *MockUserService) Delete(userID uint) error {
 	args := _m.Called(userID)
 	return args.Error(0)
 }
+
+func (_m *MockUserService) BootstrapAdmin(admin request.CreateUserRequest) (*response.UserResponse, error) {
+	args := _m.Called(admin)
+
+	user, _ := args.Get(0).(*response.UserResponse)
+
+	return user, args.Error(1)
+}
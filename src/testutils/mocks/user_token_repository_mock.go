@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"github.com/dieg0code/player-profile/src/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserTokenRepository struct {
+	mock.Mock
+}
+
+func (_m *UserTokenRepository) CreateUserToken(userToken *models.UserToken) error {
+	ret := _m.Called(userToken)
+	return ret.Error(0)
+}
+
+func (_m *UserTokenRepository) FindByTokenHash(purpose string, tokenHash string) (*models.UserToken, error) {
+	args := _m.Called(purpose, tokenHash)
+
+	userToken, _ := args.Get(0).(*models.UserToken)
+
+	return userToken, args.Error(1)
+}
+
+func (_m *UserTokenRepository) MarkAsUsed(userTokenID uint) (bool, error) {
+	ret := _m.Called(userTokenID)
+	return ret.Bool(0), ret.Error(1)
+}
+
+func (_m *UserTokenRepository) DeleteUserTokens(userID uint, purpose string) error {
+	ret := _m.Called(userID, purpose)
+	return ret.Error(0)
+}
+
+func (_m *UserTokenRepository) GetLatestUserToken(userID uint, purpose string) (*models.UserToken, error) {
+	args := _m.Called(userID, purpose)
+
+	userToken, _ := args.Get(0).(*models.UserToken)
+
+	return userToken, args.Error(1)
+}
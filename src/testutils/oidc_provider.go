@@ -0,0 +1,195 @@
+package testutils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCUser is the account the stand-in provider signs in.
+type OIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type oidcAuthorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	user          OIDCUser
+}
+
+// OIDCProvider is a local OpenID Connect provider for tests. It publishes a
+// discovery document and its keys, and issues RS256 ID tokens through the
+// authorization code flow with PKCE.
+type OIDCProvider struct {
+	Server   *httptest.Server
+	ClientID string
+	KeyID    string
+	Key      *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]oidcAuthorization
+}
+
+// NewOIDCProvider starts the provider, close it with Close.
+func NewOIDCProvider(clientID string) *OIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("failed to generate provider key")
+	}
+
+	provider := &OIDCProvider{
+		ClientID: clientID,
+		KeyID:    "test-key",
+		Key:      key,
+		codes:    map[string]oidcAuthorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/jwks", provider.jwks)
+	mux.HandleFunc("/token", provider.token)
+	provider.Server = httptest.NewServer(mux)
+
+	return provider
+}
+
+// Issuer is the base URL of the provider.
+func (p *OIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+func (p *OIDCProvider) Close() {
+	p.Server.Close()
+}
+
+// Authorize plays the user signing in at the provider: it reads the
+// authorization URL built by the client and returns the code and state the
+// provider would send back to the redirect URL.
+func (p *OIDCProvider) Authorize(authorizationURL string, user OIDCUser) (code string, state string) {
+	parsedURL, err := url.Parse(authorizationURL)
+	if err != nil {
+		panic("invalid authorization url")
+	}
+	query := parsedURL.Query()
+
+	codeBytes := make([]byte, 16)
+	_, err = rand.Read(codeBytes)
+	if err != nil {
+		panic("failed to generate code")
+	}
+	code = base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	p.mu.Lock()
+	p.codes[code] = oidcAuthorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		user:          user,
+	}
+	p.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+// SignIDToken signs claims with the provider key.
+func (p *OIDCProvider) SignIDToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.KeyID
+
+	signed, err := token.SignedString(p.Key)
+	if err != nil {
+		panic("failed to sign id token")
+	}
+
+	return signed
+}
+
+func (p *OIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.Issuer(),
+		"authorization_endpoint": p.Issuer() + "/authorize",
+		"token_endpoint":         p.Issuer() + "/token",
+		"jwks_uri":               p.Issuer() + "/jwks",
+	})
+}
+
+func (p *OIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": p.KeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.Key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.Key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *OIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	// Codes are single use
+	p.mu.Lock()
+	authorization, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !ok || authorization.clientID != r.PostForm.Get("client_id") || authorization.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(hash[:]) != authorization.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+		return
+	}
+
+	now := time.Now()
+	idToken := p.SignIDToken(jwt.MapClaims{
+		"iss":            p.Issuer(),
+		"sub":            authorization.user.Subject,
+		"aud":            p.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          authorization.nonce,
+		"email":          authorization.user.Email,
+		"email_verified": authorization.user.EmailVerified,
+		"name":           authorization.user.Name,
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}